@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/gameap/gameap/internal/daemon/fakedaemon"
+)
+
+const certFilePerm = 0o600
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:31717", "Listen address")
+	root := flag.String("root", "", "Directory serving as the node filesystem, temporary by default")
+	login := flag.String("login", "", "Daemon login, any login is accepted if empty")
+	password := flag.String("password", "", "Daemon password")
+	certsDir := flag.String("certs", ".", "Directory to write server.crt, client.crt and client.key to")
+
+	flag.Parse()
+
+	opts := []fakedaemon.Option{fakedaemon.WithAddress(*addr)}
+	if *root != "" {
+		opts = append(opts, fakedaemon.WithRoot(*root))
+	}
+	if *login != "" {
+		opts = append(opts, fakedaemon.WithCredentials(*login, *password))
+	}
+
+	server, err := fakedaemon.New(opts...)
+	if err != nil {
+		slog.Error("Failed to start fake daemon", "error", err)
+		os.Exit(1)
+	}
+
+	if err = writeCertificates(*certsDir, server); err != nil {
+		_ = server.Close()
+		slog.Error("Failed to write certificates", "error", err)
+		os.Exit(1)
+	}
+
+	slog.Info("Fake daemon started",
+		"host", server.Host(),
+		"port", server.Port(),
+		"root", server.Root(),
+		"certs", *certsDir,
+	)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+
+	slog.Info("Stopping ...")
+
+	if err = server.Close(); err != nil {
+		slog.Error("Failed to stop fake daemon", "error", err)
+	}
+}
+
+func writeCertificates(dir string, server *fakedaemon.Server) error {
+	for name, data := range map[string][]byte{
+		"server.crt": server.ServerCertificate(),
+		"client.crt": server.ClientCertificate(),
+		"client.key": server.ClientPrivateKey(),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, certFilePerm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
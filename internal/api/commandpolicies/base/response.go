@@ -0,0 +1,45 @@
+package base
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type CommandPolicyResponse struct {
+	ID          uint       `json:"id"`
+	ServerID    *uint      `json:"server_id"`
+	Role        *string    `json:"role"`
+	Target      string     `json:"target"`
+	Action      string     `json:"action"`
+	PatternType string     `json:"pattern_type"`
+	Pattern     string     `json:"pattern"`
+	Description string     `json:"description"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+func NewCommandPolicyResponse(policy *domain.CommandPolicy) CommandPolicyResponse {
+	return CommandPolicyResponse{
+		ID:          policy.ID,
+		ServerID:    policy.ServerID,
+		Role:        policy.Role,
+		Target:      string(policy.Target),
+		Action:      string(policy.Action),
+		PatternType: string(policy.PatternType),
+		Pattern:     policy.Pattern,
+		Description: policy.Description,
+		CreatedAt:   policy.CreatedAt,
+		UpdatedAt:   policy.UpdatedAt,
+	}
+}
+
+func NewCommandPoliciesResponse(policies []domain.CommandPolicy) []CommandPolicyResponse {
+	response := make([]CommandPolicyResponse, 0, len(policies))
+
+	for i := range policies {
+		response = append(response, NewCommandPolicyResponse(&policies[i]))
+	}
+
+	return response
+}
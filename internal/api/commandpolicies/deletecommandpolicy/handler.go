@@ -0,0 +1,62 @@
+package deletecommandpolicy
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+var ErrCommandPolicyNotFound = api.NewNotFoundError("command policy not found")
+
+type Handler struct {
+	repo      repositories.CommandPolicyRepository
+	responder base.Responder
+}
+
+func NewHandler(repo repositories.CommandPolicyRepository, responder base.Responder) *Handler {
+	return &Handler{
+		repo:      repo,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := api.NewInputReader(r).ReadUint("id")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid command policy id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	policies, err := h.repo.Find(ctx, &filters.FindCommandPolicy{
+		IDs: []uint{id},
+	}, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find command policy"))
+
+		return
+	}
+
+	if len(policies) == 0 {
+		h.responder.WriteError(ctx, rw, ErrCommandPolicyNotFound)
+
+		return
+	}
+
+	if err = h.repo.Delete(ctx, id); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to delete command policy"))
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
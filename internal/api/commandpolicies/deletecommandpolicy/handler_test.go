@@ -0,0 +1,81 @@
+package deletecommandpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name          string
+		policyID      string
+		wantStatus    int
+		wantError     string
+		wantRemaining int
+	}{
+		{
+			name:          "successful_deletion",
+			policyID:      "1",
+			wantStatus:    http.StatusNoContent,
+			wantRemaining: 0,
+		},
+		{
+			name:          "policy_not_found",
+			policyID:      "999",
+			wantStatus:    http.StatusNotFound,
+			wantError:     "command policy not found",
+			wantRemaining: 1,
+		},
+		{
+			name:          "invalid_id",
+			policyID:      "abc",
+			wantStatus:    http.StatusBadRequest,
+			wantError:     "invalid command policy id",
+			wantRemaining: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			repo := inmemory.NewCommandPolicyRepository()
+			require.NoError(t, repo.Save(ctx, &domain.CommandPolicy{
+				Target:      domain.CommandPolicyTargetAll,
+				Action:      domain.CommandPolicyActionDeny,
+				PatternType: domain.CommandPolicyPatternTypeGlob,
+				Pattern:     "quit",
+			}))
+
+			handler := NewHandler(repo, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/command_policies/"+tt.policyID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.policyID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response["error"], tt.wantError)
+			}
+
+			policies, err := repo.Find(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, policies, tt.wantRemaining)
+		})
+	}
+}
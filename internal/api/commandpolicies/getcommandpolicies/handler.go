@@ -0,0 +1,59 @@
+package getcommandpolicies
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	cpbase "github.com/gameap/gameap/internal/api/commandpolicies/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	repo      repositories.CommandPolicyRepository
+	responder base.Responder
+}
+
+func NewHandler(repo repositories.CommandPolicyRepository, responder base.Responder) *Handler {
+	return &Handler{
+		repo:      repo,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	in, err := readInput(r)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to read input"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	var filter *filters.FindCommandPolicy
+	if len(in.ServerIDs) > 0 {
+		filter = &filters.FindCommandPolicy{
+			AppliedToServerIDs: in.ServerIDs,
+		}
+	}
+
+	policies, err := h.repo.Find(ctx, filter, []filters.Sorting{
+		{
+			Field:     "id",
+			Direction: filters.SortDirectionAsc,
+		},
+	}, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find command policies"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, cpbase.NewCommandPoliciesResponse(policies))
+}
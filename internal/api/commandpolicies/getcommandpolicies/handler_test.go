@@ -0,0 +1,103 @@
+package getcommandpolicies
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cpbase "github.com/gameap/gameap/internal/api/commandpolicies/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantIDs    []uint
+		wantError  string
+	}{
+		{
+			name:       "all_policies",
+			wantStatus: http.StatusOK,
+			wantIDs:    []uint{1, 2, 3},
+		},
+		{
+			name:       "policies_applied_to_server",
+			query:      "?server_id=1",
+			wantStatus: http.StatusOK,
+			wantIDs:    []uint{1, 2},
+		},
+		{
+			name:       "invalid_server_id",
+			query:      "?server_id=abc",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid server_id value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := inmemory.NewCommandPolicyRepository()
+
+			for _, policy := range []*domain.CommandPolicy{
+				{
+					Target:      domain.CommandPolicyTargetAll,
+					Action:      domain.CommandPolicyActionDeny,
+					PatternType: domain.CommandPolicyPatternTypeGlob,
+					Pattern:     "quit",
+				},
+				{
+					ServerID:    lo.ToPtr(uint(1)),
+					Target:      domain.CommandPolicyTargetRcon,
+					Action:      domain.CommandPolicyActionDeny,
+					PatternType: domain.CommandPolicyPatternTypeGlob,
+					Pattern:     "rcon_password *",
+				},
+				{
+					ServerID:    lo.ToPtr(uint(2)),
+					Role:        lo.ToPtr("moderator"),
+					Target:      domain.CommandPolicyTargetRcon,
+					Action:      domain.CommandPolicyActionAllow,
+					PatternType: domain.CommandPolicyPatternTypeRegex,
+					Pattern:     "^say ",
+				},
+			} {
+				require.NoError(t, repo.Save(ctx, policy))
+			}
+
+			handler := NewHandler(repo, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/command_policies"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response["error"], tt.wantError)
+
+				return
+			}
+
+			var response []cpbase.CommandPolicyResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			ids := lo.Map(response, func(p cpbase.CommandPolicyResponse, _ int) uint {
+				return p.ID
+			})
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package getcommandpolicies
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type input struct {
+	// ServerIDs selects policies applied to the servers, including policies for all servers.
+	ServerIDs []uint
+}
+
+func readInput(r *http.Request) (*input, error) {
+	serverIDs, err := api.NewQueryReader(r).ReadUintList("server_id")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid server_id value")
+	}
+
+	return &input{
+		ServerIDs: serverIDs,
+	}, nil
+}
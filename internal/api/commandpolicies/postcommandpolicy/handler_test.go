@@ -0,0 +1,169 @@
+package postcommandpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cpbase "github.com/gameap/gameap/internal/api/commandpolicies/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		requestBody string
+		wantStatus  int
+		wantError   string
+		wantPolicy  *cpbase.CommandPolicyResponse
+	}{
+		{
+			name: "policy_for_all_servers",
+			requestBody: `{
+				"target": "rcon",
+				"action": "deny",
+				"pattern": "rcon_password *",
+				"description": "Changing the RCON password"
+			}`,
+			wantStatus: http.StatusCreated,
+			wantPolicy: &cpbase.CommandPolicyResponse{
+				ID:          1,
+				Target:      "rcon",
+				Action:      "deny",
+				PatternType: "glob",
+				Pattern:     "rcon_password *",
+				Description: "Changing the RCON password",
+			},
+		},
+		{
+			name: "policy_for_server_and_role",
+			requestBody: `{
+				"server_id": 1,
+				"role": "moderator",
+				"target": "all",
+				"action": "allow",
+				"pattern_type": "regex",
+				"pattern": "^(say|kick) "
+			}`,
+			wantStatus: http.StatusCreated,
+			wantPolicy: &cpbase.CommandPolicyResponse{
+				ID:          1,
+				ServerID:    lo.ToPtr(uint(1)),
+				Role:        lo.ToPtr("moderator"),
+				Target:      "all",
+				Action:      "allow",
+				PatternType: "regex",
+				Pattern:     "^(say|kick) ",
+			},
+		},
+		{
+			name:        "invalid_target",
+			requestBody: `{"target": "chat", "action": "deny", "pattern": "quit"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "target must be one of: rcon, console, all",
+		},
+		{
+			name:        "invalid_action",
+			requestBody: `{"target": "rcon", "action": "block", "pattern": "quit"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "action must be one of: allow, deny",
+		},
+		{
+			name:        "invalid_pattern_type",
+			requestBody: `{"target": "rcon", "action": "deny", "pattern_type": "exact", "pattern": "quit"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "pattern_type must be one of: glob, regex",
+		},
+		{
+			name:        "missing_pattern",
+			requestBody: `{"target": "rcon", "action": "deny"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "pattern is required",
+		},
+		{
+			name:        "pattern_too_long",
+			requestBody: `{"target": "rcon", "action": "deny", "pattern": "` + strings.Repeat("a", 1025) + `"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "pattern must not exceed 1024 characters",
+		},
+		{
+			name:        "invalid_regex",
+			requestBody: `{"target": "rcon", "action": "deny", "pattern_type": "regex", "pattern": "("}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "pattern is not a valid regular expression",
+		},
+		{
+			name:        "empty_role",
+			requestBody: `{"role": "", "target": "rcon", "action": "deny", "pattern": "quit"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "role must not be empty",
+		},
+		{
+			name:        "server_not_found",
+			requestBody: `{"server_id": 999, "target": "rcon", "action": "deny", "pattern": "quit"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "server not found",
+		},
+		{
+			name:        "invalid_json",
+			requestBody: `{"target": }`,
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "invalid request body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := inmemory.NewCommandPolicyRepository()
+			serverRepo := inmemory.NewServerRepository()
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{ID: 1, Name: "Test Server"}))
+
+			handler := NewHandler(repo, serverRepo, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/command_policies", strings.NewReader(tt.requestBody))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response["error"], tt.wantError)
+
+				policies, err := repo.Find(ctx, nil, nil, nil)
+				require.NoError(t, err)
+				assert.Empty(t, policies)
+
+				return
+			}
+
+			var response cpbase.CommandPolicyResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantPolicy.ID, response.ID)
+			assert.Equal(t, tt.wantPolicy.ServerID, response.ServerID)
+			assert.Equal(t, tt.wantPolicy.Role, response.Role)
+			assert.Equal(t, tt.wantPolicy.Target, response.Target)
+			assert.Equal(t, tt.wantPolicy.Action, response.Action)
+			assert.Equal(t, tt.wantPolicy.PatternType, response.PatternType)
+			assert.Equal(t, tt.wantPolicy.Pattern, response.Pattern)
+			assert.Equal(t, tt.wantPolicy.Description, response.Description)
+			assert.NotNil(t, response.CreatedAt)
+
+			policies, err := repo.Find(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, policies, 1)
+			assert.Equal(t, domain.CommandPolicyPatternType(tt.wantPolicy.PatternType), policies[0].PatternType)
+		})
+	}
+}
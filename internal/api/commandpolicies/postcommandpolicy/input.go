@@ -0,0 +1,107 @@
+package postcommandpolicy
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/samber/lo"
+)
+
+const (
+	maxPatternLength     = 1024
+	maxRoleLength        = 255
+	maxDescriptionLength = 255
+)
+
+var (
+	ErrInvalidTarget        = api.NewValidationError("target must be one of: rcon, console, all")
+	ErrInvalidAction        = api.NewValidationError("action must be one of: allow, deny")
+	ErrInvalidPatternType   = api.NewValidationError("pattern_type must be one of: glob, regex")
+	ErrPatternIsRequired    = api.NewValidationError("pattern is required")
+	ErrPatternIsTooLong     = api.NewValidationError("pattern must not exceed 1024 characters")
+	ErrInvalidPattern       = api.NewValidationError("pattern is not a valid regular expression")
+	ErrRoleIsEmpty          = api.NewValidationError("role must not be empty")
+	ErrRoleIsTooLong        = api.NewValidationError("role must not exceed 255 characters")
+	ErrDescriptionIsTooLong = api.NewValidationError("description must not exceed 255 characters")
+	ErrInvalidServerID      = api.NewValidationError("server_id must be positive")
+)
+
+type commandPolicyInput struct {
+	// ServerID is empty for policies applied to all servers.
+	ServerID *uint `json:"server_id"`
+
+	// Role is empty for policies applied to all users.
+	Role *string `json:"role"`
+
+	Target      domain.CommandPolicyTarget      `json:"target"`
+	Action      domain.CommandPolicyAction      `json:"action"`
+	PatternType domain.CommandPolicyPatternType `json:"pattern_type"`
+	Pattern     string                          `json:"pattern"`
+	Description string                          `json:"description"`
+}
+
+func (in *commandPolicyInput) Validate() error {
+	if in.ServerID != nil && *in.ServerID == 0 {
+		return ErrInvalidServerID
+	}
+
+	if in.Role != nil {
+		if *in.Role == "" {
+			return ErrRoleIsEmpty
+		}
+
+		if len(*in.Role) > maxRoleLength {
+			return ErrRoleIsTooLong
+		}
+	}
+
+	if !in.Target.IsValid() {
+		return ErrInvalidTarget
+	}
+
+	if !in.Action.IsValid() {
+		return ErrInvalidAction
+	}
+
+	if in.PatternType == "" {
+		in.PatternType = domain.CommandPolicyPatternTypeGlob
+	}
+
+	if !in.PatternType.IsValid() {
+		return ErrInvalidPatternType
+	}
+
+	if in.Pattern == "" {
+		return ErrPatternIsRequired
+	}
+
+	if len(in.Pattern) > maxPatternLength {
+		return ErrPatternIsTooLong
+	}
+
+	if len(in.Description) > maxDescriptionLength {
+		return ErrDescriptionIsTooLong
+	}
+
+	policy := domain.CommandPolicy{PatternType: in.PatternType, Pattern: in.Pattern}
+	if _, err := policy.Compile(); err != nil {
+		return ErrInvalidPattern
+	}
+
+	return nil
+}
+
+func (in *commandPolicyInput) ToDomain(now time.Time) *domain.CommandPolicy {
+	return &domain.CommandPolicy{
+		ServerID:    in.ServerID,
+		Role:        in.Role,
+		Target:      in.Target,
+		Action:      in.Action,
+		PatternType: in.PatternType,
+		Pattern:     in.Pattern,
+		Description: in.Description,
+		CreatedAt:   lo.ToPtr(now),
+		UpdatedAt:   lo.ToPtr(now),
+	}
+}
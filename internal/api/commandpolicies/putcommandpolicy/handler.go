@@ -0,0 +1,114 @@
+package putcommandpolicy
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	cpbase "github.com/gameap/gameap/internal/api/commandpolicies/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrCommandPolicyNotFound = api.NewNotFoundError("command policy not found")
+	ErrServerNotFound        = api.NewValidationError("server not found")
+)
+
+type Handler struct {
+	repo       repositories.CommandPolicyRepository
+	serverRepo repositories.ServerRepository
+	responder  base.Responder
+}
+
+func NewHandler(
+	repo repositories.CommandPolicyRepository,
+	serverRepo repositories.ServerRepository,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		repo:       repo,
+		serverRepo: serverRepo,
+		responder:  responder,
+	}
+}
+
+//nolint:funlen
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := api.NewInputReader(r).ReadUint("id")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid command policy id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	input := &commandPolicyInput{}
+	if err = json.NewDecoder(r.Body).Decode(input); err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "validation failed"))
+
+		return
+	}
+
+	policies, err := h.repo.Find(ctx, &filters.FindCommandPolicy{
+		IDs: []uint{id},
+	}, nil, &filters.Pagination{
+		Limit:  1,
+		Offset: 0,
+	})
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find command policy"))
+
+		return
+	}
+
+	if len(policies) == 0 {
+		h.responder.WriteError(ctx, rw, ErrCommandPolicyNotFound)
+
+		return
+	}
+
+	if input.ServerID != nil {
+		exists, err := h.serverRepo.Exists(ctx, &filters.FindServer{
+			IDs: []uint{*input.ServerID},
+		})
+		if err != nil {
+			h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to check server existence"))
+
+			return
+		}
+
+		if !exists {
+			h.responder.WriteError(ctx, rw, errors.WithMessage(ErrServerNotFound, "validation failed"))
+
+			return
+		}
+	}
+
+	policy := &policies[0]
+	input.Apply(policy, time.Now())
+
+	if err = h.repo.Save(ctx, policy); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to update command policy"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, cpbase.NewCommandPolicyResponse(policy))
+}
@@ -0,0 +1,140 @@
+package putcommandpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cpbase "github.com/gameap/gameap/internal/api/commandpolicies/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		policyID    string
+		requestBody string
+		wantStatus  int
+		wantError   string
+	}{
+		{
+			name:     "successful_update",
+			policyID: "1",
+			requestBody: `{
+				"server_id": 1,
+				"target": "console",
+				"action": "allow",
+				"pattern_type": "regex",
+				"pattern": "^say ",
+				"description": "Only chat"
+			}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "policy_not_found",
+			policyID:    "999",
+			requestBody: `{"target": "rcon", "action": "deny", "pattern": "quit"}`,
+			wantStatus:  http.StatusNotFound,
+			wantError:   "command policy not found",
+		},
+		{
+			name:        "invalid_id",
+			policyID:    "abc",
+			requestBody: `{"target": "rcon", "action": "deny", "pattern": "quit"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantError:   "invalid command policy id",
+		},
+		{
+			name:        "invalid_target",
+			policyID:    "1",
+			requestBody: `{"target": "chat", "action": "deny", "pattern": "quit"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "target must be one of: rcon, console, all",
+		},
+		{
+			name:        "invalid_regex",
+			policyID:    "1",
+			requestBody: `{"target": "rcon", "action": "deny", "pattern_type": "regex", "pattern": "[a-"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "pattern is not a valid regular expression",
+		},
+		{
+			name:        "server_not_found",
+			policyID:    "1",
+			requestBody: `{"server_id": 999, "target": "rcon", "action": "deny", "pattern": "quit"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantError:   "server not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			repo := inmemory.NewCommandPolicyRepository()
+			require.NoError(t, repo.Save(ctx, &domain.CommandPolicy{
+				Target:      domain.CommandPolicyTargetRcon,
+				Action:      domain.CommandPolicyActionDeny,
+				PatternType: domain.CommandPolicyPatternTypeGlob,
+				Pattern:     "quit",
+				CreatedAt:   &createdAt,
+				UpdatedAt:   &createdAt,
+			}))
+
+			serverRepo := inmemory.NewServerRepository()
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{ID: 1, Name: "Test Server"}))
+
+			handler := NewHandler(repo, serverRepo, api.NewResponder())
+
+			req := httptest.NewRequest(
+				http.MethodPut, "/api/command_policies/"+tt.policyID, strings.NewReader(tt.requestBody),
+			)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.policyID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+
+			policies, err := repo.Find(ctx, &filters.FindCommandPolicy{IDs: []uint{1}}, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, policies, 1)
+			policy := policies[0]
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Contains(t, response["error"], tt.wantError)
+				assert.Equal(t, "quit", policy.Pattern)
+
+				return
+			}
+
+			var response cpbase.CommandPolicyResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, uint(1), response.ID)
+			assert.Equal(t, "^say ", response.Pattern)
+
+			assert.Equal(t, lo.ToPtr(uint(1)), policy.ServerID)
+			assert.Equal(t, domain.CommandPolicyTargetConsole, policy.Target)
+			assert.Equal(t, domain.CommandPolicyActionAllow, policy.Action)
+			assert.Equal(t, domain.CommandPolicyPatternTypeRegex, policy.PatternType)
+			assert.Equal(t, "^say ", policy.Pattern)
+			assert.Equal(t, "Only chat", policy.Description)
+			assert.Equal(t, createdAt, *policy.CreatedAt)
+			assert.True(t, policy.UpdatedAt.After(createdAt))
+		})
+	}
+}
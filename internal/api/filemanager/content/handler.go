@@ -12,6 +12,7 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/nodehealth"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
@@ -24,6 +25,7 @@ type fileService interface {
 type Handler struct {
 	serverFinder   *serversbase.ServerFinder
 	abilityChecker *serversbase.AbilityChecker
+	nodeState      *serversbase.NodeStateChecker
 	nodeRepo       repositories.NodeRepository
 	daemonFiles    fileService
 	responder      base.Responder
@@ -32,6 +34,7 @@ type Handler struct {
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	nodeRepo repositories.NodeRepository,
+	nodeHealth *nodehealth.Checker,
 	rbac base.RBAC,
 	daemonFiles fileService,
 	responder base.Responder,
@@ -39,6 +42,7 @@ func NewHandler(
 	return &Handler{
 		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
 		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
 		nodeRepo:       nodeRepo,
 		daemonFiles:    daemonFiles,
 		responder:      responder,
@@ -89,6 +93,12 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
 	// Read disk parameter
 	disk := r.URL.Query().Get("disk")
 	if disk == "" {
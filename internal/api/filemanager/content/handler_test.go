@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	apitesting "github.com/gameap/gameap/internal/api/testing"
 	"github.com/gameap/gameap/internal/daemon"
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/rbac"
@@ -878,7 +879,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
 			fileService := tt.setupFileService()
-			handler := NewHandler(serverRepo, nodeRepo, rbacService, fileService, responder)
+			handler := NewHandler(serverRepo, nodeRepo, apitesting.NewNodeHealthChecker(t), rbacService, fileService, responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, nodeRepo, rbacRepo)
@@ -925,6 +926,36 @@ func TestHandler_ServeHTTP(t *testing.T) {
 	}
 }
 
+func TestHandler_NodeOffline(t *testing.T) {
+	serverRepo := apitesting.NewServerRepository(t, testUser1.ID, apitesting.NewServer("cstrike"))
+	nodeRepo := inmemory.NewNodeRepository()
+	require.NoError(t, nodeRepo.Save(context.Background(), &testNode))
+	rbacRepo := inmemory.NewRBACRepository()
+	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+	allowUserFilesAbility(t, rbacRepo, testUser1.ID, 1)
+
+	readDirCalled := false
+	fileService := &mockFileService{
+		readDirFunc: func(_ context.Context, _ *domain.Node, _ string) ([]*daemon.FileInfo, error) {
+			readDirCalled = true
+
+			return []*daemon.FileInfo{}, nil
+		},
+	}
+	nodeHealth := apitesting.NewNodeHealthChecker(t, apitesting.OfflineNodeHealth(testNode.ID))
+	handler := NewHandler(serverRepo, nodeRepo, nodeHealth, rbacService, fileService, api.NewResponder())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/file-manager/1/content", nil)
+	req = req.WithContext(apitesting.ContextWithUser(&testUser1))
+	req = mux.SetURLVars(req, map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.False(t, readDirCalled, "the node is not called while it is offline")
+}
+
 func TestParseFilename(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1064,7 +1095,7 @@ func TestHandler_FullPathConstruction(t *testing.T) {
 				},
 			}
 
-			handler := NewHandler(serverRepo, nodeRepo, rbacService, fileService, responder)
+			handler := NewHandler(serverRepo, nodeRepo, apitesting.NewNodeHealthChecker(t), rbacService, fileService, responder)
 
 			now := time.Now()
 			server := &domain.Server{
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	apitesting "github.com/gameap/gameap/internal/api/testing"
 	"github.com/gameap/gameap/internal/daemon"
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/rbac"
@@ -1218,7 +1219,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
 			fileService := tt.setupFileService()
-			handler := NewHandler(serverRepo, nodeRepo, rbacService, fileService, responder)
+			handler := NewHandler(serverRepo, nodeRepo, apitesting.NewNodeHealthChecker(t), rbacService, fileService, responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, nodeRepo, rbacRepo)
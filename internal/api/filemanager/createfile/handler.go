@@ -14,6 +14,7 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/nodehealth"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
@@ -27,6 +28,7 @@ type fileService interface {
 type Handler struct {
 	serverFinder   *serversbase.ServerFinder
 	abilityChecker *serversbase.AbilityChecker
+	nodeState      *serversbase.NodeStateChecker
 	nodeRepo       repositories.NodeRepository
 	daemonFiles    fileService
 	responder      base.Responder
@@ -35,6 +37,7 @@ type Handler struct {
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	nodeRepo repositories.NodeRepository,
+	nodeHealth *nodehealth.Checker,
 	rbac base.RBAC,
 	daemonFiles fileService,
 	responder base.Responder,
@@ -42,6 +45,7 @@ func NewHandler(
 	return &Handler{
 		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
 		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
 		nodeRepo:       nodeRepo,
 		daemonFiles:    daemonFiles,
 		responder:      responder,
@@ -92,6 +96,12 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
 	var req createFileRequest
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -0,0 +1,28 @@
+package base
+
+import (
+	"net/http"
+
+	rconbase "github.com/gameap/gameap/internal/api/servers/rcon/base"
+	"github.com/gameap/gameap/internal/services/gameadmins"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// WrapServiceError sets the HTTP status of the game admins service errors.
+// Errors of the RCON executor are mapped with rconbase.WrapExecutorError.
+func WrapServiceError(err error) error {
+	switch {
+	case errors.Is(err, gameadmins.ErrAdminsNotSupported):
+		return api.WrapHTTPError(err, http.StatusNotImplemented)
+	case errors.Is(err, gameadmins.ErrInvalidSteamID),
+		errors.Is(err, gameadmins.ErrInvalidFlags),
+		errors.Is(err, gameadmins.ErrInvalidImmunity),
+		errors.Is(err, gameadmins.ErrImmunityNotSupported):
+		return api.WrapHTTPError(err, http.StatusUnprocessableEntity)
+	case errors.Is(err, gameadmins.ErrAdminNotFound):
+		return api.WrapHTTPError(err, http.StatusNotFound)
+	default:
+		return rconbase.WrapExecutorError(err)
+	}
+}
@@ -0,0 +1,47 @@
+package base
+
+import (
+	"github.com/gameap/gameap/internal/services/gameadmins"
+)
+
+type AdminResponse struct {
+	SteamID  string `json:"steam_id"`
+	Flags    string `json:"flags"`
+	Immunity int    `json:"immunity"`
+}
+
+type AdminListResponse struct {
+	Format string          `json:"format"`
+	Path   string          `json:"path"`
+	Admins []AdminResponse `json:"admins"`
+}
+
+func NewAdminListResponse(list *gameadmins.AdminList) AdminListResponse {
+	admins := make([]AdminResponse, 0, len(list.Admins))
+
+	for _, admin := range list.Admins {
+		admins = append(admins, AdminResponse{
+			SteamID:  admin.SteamID,
+			Flags:    admin.Flags,
+			Immunity: admin.Immunity,
+		})
+	}
+
+	return AdminListResponse{
+		Format: string(list.Format),
+		Path:   list.Path,
+		Admins: admins,
+	}
+}
+
+type ChangeResponse struct {
+	Reloaded bool   `json:"reloaded"`
+	Output   string `json:"output"`
+}
+
+func NewChangeResponse(result *gameadmins.ChangeResult) ChangeResponse {
+	return ChangeResponse{
+		Reloaded: result.Reloaded,
+		Output:   result.Output,
+	}
+}
@@ -0,0 +1,133 @@
+package deleteadmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/gameadmins"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+const testUsersIniPath = "/srv/gameap/servers/cs/cstrike/addons/amxmodx/configs/users.ini"
+
+const testUsersIni = `; Users configuration
+"STEAM_0:0:123456" "" "abcdefghijklmnopqrstu" "ce"
+`
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		steamID        string
+		online         bool
+		ctx            context.Context
+		allowAbility   bool
+		expectedStatus int
+		wantError      string
+		wantCommands   []string
+	}{
+		{
+			name:           "user not authenticated",
+			steamID:        "STEAM_0:0:123456",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "user does not have players ability",
+			steamID:        "STEAM_0:0:123456",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "invalid steam id",
+			steamID:        "STEAM_0:0:abc",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "invalid steam id",
+		},
+		{
+			name:           "admin not found",
+			steamID:        "STEAM_0:1:7",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "admin not found",
+		},
+		{
+			name:           "remove admin and reload",
+			steamID:        "STEAM_0:0:123456",
+			online:         true,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantCommands:   []string{"amx_reloadadmins"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apitesting.NewServer("cstrike")
+			server.Dir = "servers/cs"
+			server.ProcessActive = tt.online
+			serverRepo := apitesting.NewServerRepository(t, testUser1.ID, server)
+			executor := &apitesting.Executor{}
+			node := apitesting.NewFakeNode(t, "/srv/gameap")
+			require.NoError(t, node.Daemon.WriteFile(testUsersIniPath, []byte(testUsersIni)))
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			service := gameadmins.NewService(node.Repo, executor, node.Files)
+			handler := NewHandler(serverRepo, service, apitesting.NewNodeHealthChecker(t), rbacService, api.NewResponder())
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/servers/1/admins/"+tt.steamID, nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1", "steam_id": tt.steamID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.wantCommands, executor.Commands)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, true, response["reloaded"])
+			data, err := node.Daemon.ReadFile(testUsersIniPath)
+			require.NoError(t, err)
+			assert.Equal(t, "; Users configuration\n", string(data))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package getadmins
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	adminsbase "github.com/gameap/gameap/internal/api/gameadmins/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/gameadmins"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	nodeState      *serversbase.NodeStateChecker
+	admins         *gameadmins.Service
+	responder      base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	admins *gameadmins.Service,
+	nodeHealth *nodehealth.Checker,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
+		admins:         admins,
+		responder:      responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	serverID, err := api.NewInputReader(r).ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.abilityChecker.CheckOrError(
+		ctx, session.User.ID, server.ID, []domain.AbilityName{domain.AbilityNameGameServerRconPlayers},
+	); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	admins, err := h.admins.Admins(ctx, server)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(
+			adminsbase.WrapServiceError(err),
+			"failed to get admins",
+		))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, adminsbase.NewAdminListResponse(admins))
+}
@@ -0,0 +1,138 @@
+package getadmins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/gameadmins"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+const testUsersIniPath = "/srv/gameap/servers/cs/cstrike/addons/amxmodx/configs/users.ini"
+
+const testUsersIni = `; Users configuration
+"STEAM_0:0:123456" "" "abcdefghijklmnopqrstu" "ce"
+`
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		gameID         string
+		ctx            context.Context
+		allowAbility   bool
+		nodeOffline    bool
+		expectedStatus int
+		wantError      string
+		wantBody       map[string]any
+	}{
+		{
+			name:           "user not authenticated",
+			gameID:         "cstrike",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "user does not have players ability",
+			gameID:         "cstrike",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "node offline",
+			gameID:         "cstrike",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			nodeOffline:    true,
+			expectedStatus: http.StatusServiceUnavailable,
+			wantError:      "Service Unavailable",
+		},
+		{
+			name:           "game not supported",
+			gameID:         "minecraft",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotImplemented,
+			wantError:      "Not Implemented",
+		},
+		{
+			name:           "amx mod x admins",
+			gameID:         "cstrike",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantBody: map[string]any{
+				"format": "amxmodx",
+				"path":   "cstrike/addons/amxmodx/configs/users.ini",
+				"admins": []any{
+					map[string]any{"steam_id": "STEAM_0:0:123456", "flags": "abcdefghijklmnopqrstu", "immunity": float64(0)},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apitesting.NewServer(tt.gameID)
+			server.Dir = "servers/cs"
+			server.ProcessActive = false
+			serverRepo := apitesting.NewServerRepository(t, testUser1.ID, server)
+			node := apitesting.NewFakeNode(t, "/srv/gameap")
+			require.NoError(t, node.Daemon.WriteFile(testUsersIniPath, []byte(testUsersIni)))
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			service := gameadmins.NewService(node.Repo, &apitesting.Executor{}, node.Files)
+			nodeHealth := apitesting.NewNodeHealthChecker(t)
+			if tt.nodeOffline {
+				nodeHealth = apitesting.NewNodeHealthChecker(t, apitesting.OfflineNodeHealth(server.DSID))
+			}
+			handler := NewHandler(serverRepo, service, nodeHealth, rbacService, api.NewResponder())
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/1/admins", nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1"})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantBody, response)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package putadmin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	adminsbase "github.com/gameap/gameap/internal/api/gameadmins/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/gameadmins"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	nodeState      *serversbase.NodeStateChecker
+	admins         *gameadmins.Service
+	responder      base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	admins *gameadmins.Service,
+	nodeHealth *nodehealth.Checker,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
+		admins:         admins,
+		responder:      responder,
+	}
+}
+
+//nolint:funlen
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	serverID, err := api.NewInputReader(r).ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	steamID, _ := api.NewInputReader(r).ReadString("steam_id")
+	if !players.IsValidSteamID(steamID) {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			gameadmins.ErrInvalidSteamID,
+			http.StatusUnprocessableEntity,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.abilityChecker.CheckOrError(
+		ctx, session.User.ID, server.ID, []domain.AbilityName{domain.AbilityNameGameServerRconPlayers},
+	); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	input := &adminInput{}
+	if err = json.NewDecoder(r.Body).Decode(input); err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "validation failed"))
+
+		return
+	}
+
+	result, err := h.admins.Save(ctx, server, input.ToAdmin(steamID))
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(
+			adminsbase.WrapServiceError(err),
+			"failed to save admin",
+		))
+
+		return
+	}
+
+	if result.Created {
+		rw.WriteHeader(http.StatusCreated)
+	}
+
+	h.responder.Write(ctx, rw, adminsbase.NewChangeResponse(result))
+}
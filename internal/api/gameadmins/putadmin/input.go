@@ -0,0 +1,39 @@
+package putadmin
+
+import (
+	"github.com/gameap/gameap/internal/services/gameadmins"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/flexible"
+)
+
+var (
+	ErrFlagsAreRequired = api.NewValidationError("flags are required")
+	ErrInvalidImmunity  = api.NewValidationError("immunity must be between 0 and 99")
+)
+
+type adminInput struct {
+	Flags string `json:"flags"`
+
+	// Immunity is the SourceMod immunity level, AMX Mod X uses the a flag instead.
+	Immunity flexible.Int `json:"immunity"`
+}
+
+func (in *adminInput) Validate() error {
+	if in.Flags == "" {
+		return ErrFlagsAreRequired
+	}
+
+	if in.Immunity.Int() < 0 || in.Immunity.Int() > gameadmins.MaxImmunity {
+		return ErrInvalidImmunity
+	}
+
+	return nil
+}
+
+func (in *adminInput) ToAdmin(steamID string) gameadmins.Admin {
+	return gameadmins.Admin{
+		SteamID:  steamID,
+		Flags:    in.Flags,
+		Immunity: in.Immunity.Int(),
+	}
+}
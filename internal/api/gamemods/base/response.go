@@ -23,11 +23,26 @@ type GameModResponse struct {
 	ChmapCmd                *string           `json:"chmap_cmd"`
 	SendmsgCmd              *string           `json:"sendmsg_cmd"`
 	PasswdCmd               *string           `json:"passwd_cmd"`
+	QueryProtocol           *string           `json:"query_protocol"`
+	RconProtocol            *string           `json:"rcon_protocol"`
+	PlayerManager           *string           `json:"player_manager"`
+	QueryPortOffset         *int              `json:"query_port_offset"`
+	RconPortOffset          *int              `json:"rcon_port_offset"`
 }
 
 type gameModFastRcon struct {
-	Info    string `json:"info"`
-	Command string `json:"command"`
+	Info    string                 `json:"info"`
+	Command string                 `json:"command"`
+	Params  []gameModFastRconParam `json:"params,omitempty"`
+}
+
+type gameModFastRconParam struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Label   string   `json:"label,omitempty"`
+	Options []string `json:"options,omitempty"`
+	Min     *int     `json:"min,omitempty"`
+	Max     *int     `json:"max,omitempty"`
 }
 
 type gameModVar struct {
@@ -67,6 +82,11 @@ func NewGameModResponseFromGameMod(gm *domain.GameMod) GameModResponse {
 		ChmapCmd:                gm.ChmapCmd,
 		SendmsgCmd:              gm.SendmsgCmd,
 		PasswdCmd:               gm.PasswdCmd,
+		QueryProtocol:           gm.QueryProtocol,
+		RconProtocol:            gm.RconProtocol,
+		PlayerManager:           gm.PlayerManager,
+		QueryPortOffset:         gm.QueryPortOffset,
+		RconPortOffset:          gm.RconPortOffset,
 	}
 }
 
@@ -77,6 +97,28 @@ func gameModFastRconFromDomain(fastRcon domain.GameModFastRconList) []gameModFas
 		result = append(result, gameModFastRcon{
 			Info:    fr.Info,
 			Command: fr.Command,
+			Params:  gameModFastRconParamsFromDomain(fr.Params),
+		})
+	}
+
+	return result
+}
+
+func gameModFastRconParamsFromDomain(params []domain.GameModFastRconParam) []gameModFastRconParam {
+	if len(params) == 0 {
+		return nil
+	}
+
+	result := make([]gameModFastRconParam, 0, len(params))
+
+	for _, p := range params {
+		result = append(result, gameModFastRconParam{
+			Name:    p.Name,
+			Type:    string(p.Type),
+			Label:   p.Label,
+			Options: p.Options,
+			Min:     p.Min,
+			Max:     p.Max,
 		})
 	}
 
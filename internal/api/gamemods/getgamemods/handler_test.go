@@ -118,7 +118,12 @@ func TestGameMods(t *testing.T) {
 					"srestart_cmd": "restart",
 					"chmap_cmd": "changelevel",
 					"sendmsg_cmd": "say",
-					"passwd_cmd": "rcon_password"
+					"passwd_cmd": "rcon_password",
+					"query_protocol": null,
+					"rcon_protocol": null,
+					"player_manager": null,
+					"query_port_offset": null,
+					"rcon_port_offset": null
 				},
 				{
 					"id": 1,
@@ -150,7 +155,12 @@ func TestGameMods(t *testing.T) {
 					"srestart_cmd": "restart",
 					"chmap_cmd": "changelevel",
 					"sendmsg_cmd": "say",
-					"passwd_cmd": "password"
+					"passwd_cmd": "password",
+					"query_protocol": null,
+					"rcon_protocol": null,
+					"player_manager": null,
+					"query_port_offset": null,
+					"rcon_port_offset": null
 				}
 			]`,
 		},
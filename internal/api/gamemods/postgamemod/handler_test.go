@@ -166,6 +166,86 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			expectedStatus: http.StatusUnprocessableEntity,
 			wantError:      "passwd command must not exceed 200 characters",
 		},
+		{
+			name: "unsupported query protocol",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"query_protocol": "unknown"
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "query protocol is not supported",
+		},
+		{
+			name: "unsupported rcon protocol",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"rcon_protocol": "unknown"
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "rcon protocol is not supported",
+		},
+		{
+			name: "unsupported player manager",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"player_manager": "unknown"
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "player manager is not supported",
+		},
+		{
+			name: "query port offset out of range",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"query_port_offset": 70000
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "query port offset must be between -65535 and 65535",
+		},
+		{
+			name: "fast rcon param with unsupported type",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"fast_rcon": [{"info": "Exec", "command": "exec {file}", "params": [{"name": "file", "type": "file"}]}]
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "fast rcon param type is not supported",
+		},
+		{
+			name: "fast rcon enum param without options",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"fast_rcon": [{"info": "Map", "command": "changelevel {map}", "params": [{"name": "map", "type": "enum"}]}]
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "fast rcon enum param must have options",
+		},
+		{
+			name: "fast rcon param min exceeds max",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"fast_rcon": [{"info": "Slots", "command": "maxplayers {slots}", "params": [{"name": "slots", "type": "int", "min": 10, "max": 1}]}]
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "fast rcon param min must not exceed max",
+		},
+		{
+			name: "fast rcon param without placeholder",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"fast_rcon": [{"info": "Map", "command": "changelevel", "params": [{"name": "map", "type": "string"}]}]
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "fast rcon command must contain {map} placeholder",
+		},
 		{
 			name: "complete game mod with all fields",
 			requestBody: `{
@@ -185,7 +265,12 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				"srestart_cmd": "restart",
 				"chmap_cmd": "changelevel {map}",
 				"sendmsg_cmd": "say \"{msg}\"",
-				"passwd_cmd": "password {password}"
+				"passwd_cmd": "password {password}",
+				"query_protocol": "source",
+				"rcon_protocol": "goldsource",
+				"player_manager": "goldsrc",
+				"query_port_offset": 0,
+				"rcon_port_offset": 0
 			}`,
 			expectedStatus: http.StatusOK,
 		},
@@ -263,6 +348,13 @@ func TestHandler_GameModPersistence(t *testing.T) {
 		"name":      "Half-Life Default",
 		"fast_rcon": []map[string]any{
 			{"info": "Status", "command": "status"},
+			{
+				"info":    "Change map",
+				"command": "changelevel {map}",
+				"params": []map[string]any{
+					{"name": "map", "type": "enum", "label": "Map", "options": []string{"crossfire", "datacore"}},
+				},
+			},
 		},
 		"vars": []map[string]any{
 			{"var": "default_map", "default": "crossfire", "info": "Default Map"},
@@ -306,6 +398,18 @@ func TestHandler_GameModPersistence(t *testing.T) {
 			Info:    "Status",
 			Command: "status",
 		},
+		{
+			Info:    "Change map",
+			Command: "changelevel {map}",
+			Params: []domain.GameModFastRconParam{
+				{
+					Name:    "map",
+					Type:    domain.GameModFastRconParamTypeEnum,
+					Label:   "Map",
+					Options: []string{"crossfire", "datacore"},
+				},
+			},
+		},
 	}, gameMod.FastRcon)
 	assert.Equal(t, domain.GameModVarList{
 		{
@@ -303,6 +303,114 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			wantStatus: http.StatusUnprocessableEntity,
 			wantError:  "passwd command must not exceed 200 characters",
 		},
+		{
+			name:      "unsupported query protocol",
+			gameModID: "1",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"query_protocol": "unknown"
+			}`,
+			setupRepo: func(repo *inmemory.GameModRepository) {
+				_ = repo.Save(context.Background(), &domain.GameMod{
+					ID:       1,
+					GameCode: "valve",
+					Name:     "Default",
+				})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "query protocol is not supported",
+		},
+		{
+			name:      "unsupported rcon protocol",
+			gameModID: "1",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"rcon_protocol": "unknown"
+			}`,
+			setupRepo: func(repo *inmemory.GameModRepository) {
+				_ = repo.Save(context.Background(), &domain.GameMod{
+					ID:       1,
+					GameCode: "valve",
+					Name:     "Default",
+				})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "rcon protocol is not supported",
+		},
+		{
+			name:      "unsupported player manager",
+			gameModID: "1",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"player_manager": "unknown"
+			}`,
+			setupRepo: func(repo *inmemory.GameModRepository) {
+				_ = repo.Save(context.Background(), &domain.GameMod{
+					ID:       1,
+					GameCode: "valve",
+					Name:     "Default",
+				})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "player manager is not supported",
+		},
+		{
+			name:      "query port offset out of range",
+			gameModID: "1",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"query_port_offset": -70000
+			}`,
+			setupRepo: func(repo *inmemory.GameModRepository) {
+				_ = repo.Save(context.Background(), &domain.GameMod{
+					ID:       1,
+					GameCode: "valve",
+					Name:     "Default",
+				})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "query port offset must be between -65535 and 65535",
+		},
+		{
+			name:      "fast rcon param with invalid name",
+			gameModID: "1",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"fast_rcon": [{"info": "Map", "command": "changelevel {map name}", "params": [{"name": "map name", "type": "string"}]}]
+			}`,
+			setupRepo: func(repo *inmemory.GameModRepository) {
+				_ = repo.Save(context.Background(), &domain.GameMod{
+					ID:       1,
+					GameCode: "valve",
+					Name:     "Default",
+				})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "fast rcon param name must contain only latin letters, digits and underscores",
+		},
+		{
+			name:      "fast rcon duplicated param",
+			gameModID: "1",
+			requestBody: `{
+				"game_code": "valve",
+				"name": "Default",
+				"fast_rcon": [{"info": "Map", "command": "changelevel {map}", "params": [{"name": "map", "type": "string"}, {"name": "map", "type": "string"}]}]
+			}`,
+			setupRepo: func(repo *inmemory.GameModRepository) {
+				_ = repo.Save(context.Background(), &domain.GameMod{
+					ID:       1,
+					GameCode: "valve",
+					Name:     "Default",
+				})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "fast rcon param map is duplicated",
+		},
 		{
 			name:      "update game mod with all fields",
 			gameModID: "1",
@@ -465,6 +573,11 @@ func TestHandler_GameModUpdatePersistence(t *testing.T) {
 		"chmap_cmd":                 "changelevel {map}",
 		"sendmsg_cmd":               "say \"{msg}\"",
 		"passwd_cmd":                "password {password}",
+		"query_protocol":            "source",
+		"rcon_protocol":             "goldsource",
+		"player_manager":            "goldsrc",
+		"query_port_offset":         0,
+		"rcon_port_offset":          10,
 	}
 
 	body, err := json.Marshal(updateData)
@@ -531,6 +644,11 @@ func TestHandler_GameModUpdatePersistence(t *testing.T) {
 	assert.Equal(t, "changelevel {map}", lo.FromPtr(gameMod.ChmapCmd))
 	assert.Equal(t, "say \"{msg}\"", lo.FromPtr(gameMod.SendmsgCmd))
 	assert.Equal(t, "password {password}", lo.FromPtr(gameMod.PasswdCmd))
+	assert.Equal(t, lo.ToPtr("source"), gameMod.QueryProtocol)
+	assert.Equal(t, lo.ToPtr("goldsource"), gameMod.RconProtocol)
+	assert.Equal(t, lo.ToPtr("goldsrc"), gameMod.PlayerManager)
+	assert.Equal(t, lo.ToPtr(0), gameMod.QueryPortOffset)
+	assert.Equal(t, lo.ToPtr(10), gameMod.RconPortOffset)
 }
 
 func TestHandler_EmptyGameModID(t *testing.T) {
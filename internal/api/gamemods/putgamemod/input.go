@@ -2,10 +2,15 @@ package putgamemod
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/flexible"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/gameap/gameap/pkg/quercon/rcon"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
 	"github.com/pkg/errors"
 )
 
@@ -14,8 +19,11 @@ const (
 	maxGameCodeLength       = 255
 	maxShellCmdLength       = 1000
 	maxGameConsoleCmdLength = 200
+	maxPortOffset           = 65535
 )
 
+var fastRconParamNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
 var (
 	ErrGameModNameIsRequired = api.NewValidationError("game mod name is required")
 	ErrGameCodeIsRequired    = api.NewValidationError("game code is required")
@@ -48,6 +56,15 @@ var (
 	ErrPasswdCmdTooLong = api.NewValidationError(
 		fmt.Sprintf("passwd command must not exceed %d characters", maxGameConsoleCmdLength),
 	)
+	ErrQueryProtocolUnsupported = api.NewValidationError("query protocol is not supported")
+	ErrRconProtocolUnsupported  = api.NewValidationError("rcon protocol is not supported")
+	ErrPlayerManagerUnsupported = api.NewValidationError("player manager is not supported")
+	ErrQueryPortOffsetInvalid   = api.NewValidationError(
+		fmt.Sprintf("query port offset must be between %d and %d", -maxPortOffset, maxPortOffset),
+	)
+	ErrRconPortOffsetInvalid = api.NewValidationError(
+		fmt.Sprintf("rcon port offset must be between %d and %d", -maxPortOffset, maxPortOffset),
+	)
 )
 
 type updateGameModInput struct {
@@ -68,6 +85,11 @@ type updateGameModInput struct {
 	ChmapCmd                *string         `json:"chmap_cmd,omitempty"`
 	SendmsgCmd              *string         `json:"sendmsg_cmd,omitempty"`
 	PasswdCmd               *string         `json:"passwd_cmd,omitempty"`
+	QueryProtocol           *string         `json:"query_protocol,omitempty"`
+	RconProtocol            *string         `json:"rcon_protocol,omitempty"`
+	PlayerManager           *string         `json:"player_manager,omitempty"`
+	QueryPortOffset         *int            `json:"query_port_offset,omitempty"`
+	RconPortOffset          *int            `json:"rcon_port_offset,omitempty"`
 }
 
 func (g *updateGameModInput) Validate() error {
@@ -123,6 +145,29 @@ func (g *updateGameModInput) Validate() error {
 		return ErrPasswdCmdTooLong
 	}
 
+	if g.QueryProtocol != nil && *g.QueryProtocol != "" &&
+		!query.IsProtocolSupported(query.Protocol(*g.QueryProtocol)) {
+		return ErrQueryProtocolUnsupported
+	}
+
+	if g.RconProtocol != nil && *g.RconProtocol != "" &&
+		!rcon.IsProtocolSupported(rcon.Protocol(*g.RconProtocol)) {
+		return ErrRconProtocolUnsupported
+	}
+
+	if g.PlayerManager != nil && *g.PlayerManager != "" &&
+		!players.IsPlayerManagerSupported(*g.PlayerManager) {
+		return ErrPlayerManagerUnsupported
+	}
+
+	if g.QueryPortOffset != nil && (*g.QueryPortOffset < -maxPortOffset || *g.QueryPortOffset > maxPortOffset) {
+		return ErrQueryPortOffsetInvalid
+	}
+
+	if g.RconPortOffset != nil && (*g.RconPortOffset < -maxPortOffset || *g.RconPortOffset > maxPortOffset) {
+		return ErrRconPortOffsetInvalid
+	}
+
 	for i := range g.FastRcon {
 		if err := g.FastRcon[i].Validate(); err != nil {
 			return errors.WithMessagef(err, "game mod input FastRcon[%d]", i)
@@ -154,6 +199,11 @@ func (g *updateGameModInput) Apply(gameMod *domain.GameMod) {
 	gameMod.ChmapCmd = g.ChmapCmd
 	gameMod.SendmsgCmd = g.SendmsgCmd
 	gameMod.PasswdCmd = g.PasswdCmd
+	gameMod.QueryProtocol = g.QueryProtocol
+	gameMod.RconProtocol = g.RconProtocol
+	gameMod.PlayerManager = g.PlayerManager
+	gameMod.QueryPortOffset = g.QueryPortOffset
+	gameMod.RconPortOffset = g.RconPortOffset
 
 	fastRconList := make(domain.GameModFastRconList, 0, len(g.FastRcon))
 	for _, fr := range g.FastRcon {
@@ -169,8 +219,9 @@ func (g *updateGameModInput) Apply(gameMod *domain.GameMod) {
 }
 
 type fastRconInput struct {
-	Info    string `json:"info"`
-	Command string `json:"command"`
+	Info    string               `json:"info"`
+	Command string               `json:"command"`
+	Params  []fastRconParamInput `json:"params,omitempty"`
 }
 
 func (f *fastRconInput) Validate() error {
@@ -182,13 +233,87 @@ func (f *fastRconInput) Validate() error {
 		return api.NewValidationError("fast rcon command is required")
 	}
 
+	names := make(map[string]struct{}, len(f.Params))
+
+	for i := range f.Params {
+		if err := f.Params[i].Validate(); err != nil {
+			return errors.WithMessagef(err, "params[%d]", i)
+		}
+
+		if _, exists := names[f.Params[i].Name]; exists {
+			return api.NewValidationError(
+				fmt.Sprintf("fast rcon param %s is duplicated", f.Params[i].Name),
+			)
+		}
+
+		names[f.Params[i].Name] = struct{}{}
+
+		if !strings.Contains(f.Command, "{"+f.Params[i].Name+"}") {
+			return api.NewValidationError(
+				fmt.Sprintf("fast rcon command must contain {%s} placeholder", f.Params[i].Name),
+			)
+		}
+	}
+
 	return nil
 }
 
 func (f *fastRconInput) ToDomain() domain.GameModFastRcon {
+	var params []domain.GameModFastRconParam
+	for _, param := range f.Params {
+		params = append(params, param.ToDomain())
+	}
+
 	return domain.GameModFastRcon{
 		Info:    f.Info,
 		Command: f.Command,
+		Params:  params,
+	}
+}
+
+type fastRconParamInput struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Label   string   `json:"label,omitempty"`
+	Options []string `json:"options,omitempty"`
+	Min     *int     `json:"min,omitempty"`
+	Max     *int     `json:"max,omitempty"`
+}
+
+func (p *fastRconParamInput) Validate() error {
+	if p.Name == "" {
+		return api.NewValidationError("fast rcon param name is required")
+	}
+
+	if !fastRconParamNameRegexp.MatchString(p.Name) {
+		return api.NewValidationError(
+			"fast rcon param name must contain only latin letters, digits and underscores",
+		)
+	}
+
+	if !domain.GameModFastRconParamType(p.Type).IsValid() {
+		return api.NewValidationError("fast rcon param type is not supported")
+	}
+
+	if domain.GameModFastRconParamType(p.Type) == domain.GameModFastRconParamTypeEnum && len(p.Options) == 0 {
+		return api.NewValidationError("fast rcon enum param must have options")
+	}
+
+	if p.Min != nil && p.Max != nil && *p.Min > *p.Max {
+		return api.NewValidationError("fast rcon param min must not exceed max")
+	}
+
+	return nil
+}
+
+func (p *fastRconParamInput) ToDomain() domain.GameModFastRconParam {
+	return domain.GameModFastRconParam{
+		Name:    p.Name,
+		Type:    domain.GameModFastRconParamType(p.Type),
+		Label:   p.Label,
+		Options: p.Options,
+		Min:     p.Min,
+		Max:     p.Max,
 	}
 }
 
@@ -14,6 +14,11 @@ type gameResponse struct {
 	SteamAppIDWindows       *uint   `json:"steam_app_id_windows"`
 	RemoteRepositoryWindows *string `json:"remote_repository_windows"`
 	LocalRepositoryWindows  *string `json:"local_repository_windows"`
+	QueryProtocol           *string `json:"query_protocol"`
+	RconProtocol            *string `json:"rcon_protocol"`
+	PlayerManager           *string `json:"player_manager"`
+	QueryPortOffset         *int    `json:"query_port_offset"`
+	RconPortOffset          *int    `json:"rcon_port_offset"`
 	Enabled                 bool    `json:"enabled"`
 }
 
@@ -30,6 +35,11 @@ func newGameResponseFromGame(g *domain.Game) gameResponse {
 		SteamAppIDWindows:       g.SteamAppIDWindows,
 		RemoteRepositoryWindows: g.RemoteRepositoryWindows,
 		LocalRepositoryWindows:  g.LocalRepositoryWindows,
+		QueryProtocol:           g.QueryProtocol,
+		RconProtocol:            g.RconProtocol,
+		PlayerManager:           g.PlayerManager,
+		QueryPortOffset:         g.QueryPortOffset,
+		RconPortOffset:          g.RconPortOffset,
 		Enabled:                 g.Enabled == 1,
 	}
 }
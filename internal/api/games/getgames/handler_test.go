@@ -36,6 +36,10 @@ func TestGames(t *testing.T) {
 					RemoteRepositoryWindows: lo.ToPtr("http://example.com/windows"),
 					LocalRepositoryLinux:    lo.ToPtr("/var/repo/linux"),
 					LocalRepositoryWindows:  lo.ToPtr("C:\\repo\\windows"),
+					QueryProtocol:           lo.ToPtr("source"),
+					RconProtocol:            lo.ToPtr("goldsource"),
+					PlayerManager:           lo.ToPtr("goldsrc"),
+					RconPortOffset:          lo.ToPtr(1),
 					Enabled:                 1,
 				},
 			},
@@ -52,6 +56,11 @@ func TestGames(t *testing.T) {
 					"remote_repository_windows": "http://example.com/windows",
 					"local_repository_linux": "/var/repo/linux",
 					"local_repository_windows": "C:\\repo\\windows",
+					"query_protocol": "source",
+					"rcon_protocol": "goldsource",
+					"player_manager": "goldsrc",
+					"query_port_offset": null,
+					"rcon_port_offset": 1,
 					"enabled": 1
 				}
 			]`,
@@ -14,6 +14,11 @@ type gameResponse struct {
 	RemoteRepositoryWindows *string `json:"remote_repository_windows"`
 	LocalRepositoryLinux    *string `json:"local_repository_linux"`
 	LocalRepositoryWindows  *string `json:"local_repository_windows"`
+	QueryProtocol           *string `json:"query_protocol"`
+	RconProtocol            *string `json:"rcon_protocol"`
+	PlayerManager           *string `json:"player_manager"`
+	QueryPortOffset         *int    `json:"query_port_offset"`
+	RconPortOffset          *int    `json:"rcon_port_offset"`
 	Enabled                 int     `json:"enabled"`
 }
 
@@ -40,6 +45,11 @@ func newGameResponseFromGame(g *domain.Game) gameResponse {
 		RemoteRepositoryWindows: g.RemoteRepositoryWindows,
 		LocalRepositoryLinux:    g.LocalRepositoryLinux,
 		LocalRepositoryWindows:  g.LocalRepositoryWindows,
+		QueryProtocol:           g.QueryProtocol,
+		RconProtocol:            g.RconProtocol,
+		PlayerManager:           g.PlayerManager,
+		QueryPortOffset:         g.QueryPortOffset,
+		RconPortOffset:          g.RconPortOffset,
 		Enabled:                 g.Enabled,
 	}
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/pkg/api"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -149,6 +150,54 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			expectedStatus: http.StatusUnprocessableEntity,
 			wantError:      "local repository must not exceed 128 characters",
 		},
+		{
+			name: "unsupported query protocol",
+			requestBody: `{
+				"code": "cs16",
+				"name": "Counter-Strike 1.6",
+				"engine": "GoldSource",
+				"query_protocol": "unknown",
+				"enabled": 1
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "query protocol is not supported",
+		},
+		{
+			name: "unsupported rcon protocol",
+			requestBody: `{
+				"code": "cs16",
+				"name": "Counter-Strike 1.6",
+				"engine": "GoldSource",
+				"rcon_protocol": "unknown",
+				"enabled": 1
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "rcon protocol is not supported",
+		},
+		{
+			name: "unsupported player manager",
+			requestBody: `{
+				"code": "cs16",
+				"name": "Counter-Strike 1.6",
+				"engine": "GoldSource",
+				"player_manager": "unknown",
+				"enabled": 1
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "player manager is not supported",
+		},
+		{
+			name: "rcon port offset out of range",
+			requestBody: `{
+				"code": "cs16",
+				"name": "Counter-Strike 1.6",
+				"engine": "GoldSource",
+				"rcon_port_offset": 70000,
+				"enabled": 1
+			}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "rcon port offset must be between -65535 and 65535",
+		},
 		{
 			name: "complete game with all optional fields",
 			requestBody: `{
@@ -250,6 +299,10 @@ func TestHandler_GamePersistence(t *testing.T) {
 		"remote_repository_windows": "https://example.com/hl2/windows",
 		"local_repository_linux":    "/local/hl2/linux",
 		"local_repository_windows":  "C:\\local\\hl2\\windows",
+		"query_protocol":            "source",
+		"rcon_protocol":             "source",
+		"player_manager":            "source",
+		"rcon_port_offset":          1,
 		"enabled":                   1,
 	}
 
@@ -289,6 +342,11 @@ func TestHandler_GamePersistence(t *testing.T) {
 	assert.Equal(t, "/local/hl2/linux", *game.LocalRepositoryLinux)
 	require.NotNil(t, game.LocalRepositoryWindows)
 	assert.Equal(t, "C:\\local\\hl2\\windows", *game.LocalRepositoryWindows)
+	assert.Equal(t, lo.ToPtr("source"), game.QueryProtocol)
+	assert.Equal(t, lo.ToPtr("source"), game.RconProtocol)
+	assert.Equal(t, lo.ToPtr("source"), game.PlayerManager)
+	assert.Nil(t, game.QueryPortOffset)
+	assert.Equal(t, lo.ToPtr(1), game.RconPortOffset)
 	assert.Equal(t, 1, game.Enabled)
 }
 
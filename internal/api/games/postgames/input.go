@@ -6,6 +6,9 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/flexible"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/gameap/gameap/pkg/quercon/rcon"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
 	"github.com/gameap/gameap/pkg/validation"
 	"github.com/samber/lo"
 )
@@ -19,6 +22,7 @@ const (
 	maxEngineVersionLength = 128
 	maxConfigLength        = 128
 	maxRepositoryLength    = 128
+	maxPortOffset          = 65535
 )
 
 var (
@@ -56,6 +60,15 @@ var (
 	ErrLocalRepositoryTooLong = api.NewValidationError(
 		fmt.Sprintf("local repository must not exceed %d characters", maxRepositoryLength),
 	)
+	ErrQueryProtocolUnsupported = api.NewValidationError("query protocol is not supported")
+	ErrRconProtocolUnsupported  = api.NewValidationError("rcon protocol is not supported")
+	ErrPlayerManagerUnsupported = api.NewValidationError("player manager is not supported")
+	ErrQueryPortOffsetInvalid   = api.NewValidationError(
+		fmt.Sprintf("query port offset must be between %d and %d", -maxPortOffset, maxPortOffset),
+	)
+	ErrRconPortOffsetInvalid = api.NewValidationError(
+		fmt.Sprintf("rcon port offset must be between %d and %d", -maxPortOffset, maxPortOffset),
+	)
 )
 
 type createGameInput struct {
@@ -71,6 +84,11 @@ type createGameInput struct {
 	RemoteRepositoryWindows *string        `json:"remote_repository_windows,omitempty"` // maxlen=128
 	LocalRepositoryLinux    *string        `json:"local_repository_linux,omitempty"`    // maxlen=128
 	LocalRepositoryWindows  *string        `json:"local_repository_windows,omitempty"`  // maxlen=128
+	QueryProtocol           *string        `json:"query_protocol,omitempty"`            // query.Protocol
+	RconProtocol            *string        `json:"rcon_protocol,omitempty"`             // rcon.Protocol
+	PlayerManager           *string        `json:"player_manager,omitempty"`            // players manager name
+	QueryPortOffset         *int           `json:"query_port_offset,omitempty"`         // -65535..65535
+	RconPortOffset          *int           `json:"rcon_port_offset,omitempty"`          // -65535..65535
 	Enabled                 int            `json:"enabled"`                             //
 }
 
@@ -133,6 +151,29 @@ func (g *createGameInput) Validate() error {
 		return ErrLocalRepositoryTooLong
 	}
 
+	if g.QueryProtocol != nil && *g.QueryProtocol != "" &&
+		!query.IsProtocolSupported(query.Protocol(*g.QueryProtocol)) {
+		return ErrQueryProtocolUnsupported
+	}
+
+	if g.RconProtocol != nil && *g.RconProtocol != "" &&
+		!rcon.IsProtocolSupported(rcon.Protocol(*g.RconProtocol)) {
+		return ErrRconProtocolUnsupported
+	}
+
+	if g.PlayerManager != nil && *g.PlayerManager != "" &&
+		!players.IsPlayerManagerSupported(*g.PlayerManager) {
+		return ErrPlayerManagerUnsupported
+	}
+
+	if g.QueryPortOffset != nil && (*g.QueryPortOffset < -maxPortOffset || *g.QueryPortOffset > maxPortOffset) {
+		return ErrQueryPortOffsetInvalid
+	}
+
+	if g.RconPortOffset != nil && (*g.RconPortOffset < -maxPortOffset || *g.RconPortOffset > maxPortOffset) {
+		return ErrRconPortOffsetInvalid
+	}
+
 	return nil
 }
 
@@ -149,6 +190,11 @@ func (g *createGameInput) ToDomain() *domain.Game {
 		RemoteRepositoryWindows: g.RemoteRepositoryWindows,
 		LocalRepositoryLinux:    g.LocalRepositoryLinux,
 		LocalRepositoryWindows:  g.LocalRepositoryWindows,
+		QueryProtocol:           g.QueryProtocol,
+		RconProtocol:            g.RconProtocol,
+		PlayerManager:           g.PlayerManager,
+		QueryPortOffset:         g.QueryPortOffset,
+		RconPortOffset:          g.RconPortOffset,
 		Enabled:                 g.Enabled,
 	}
 }
@@ -238,6 +238,86 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			wantStatus: http.StatusUnprocessableEntity,
 			wantError:  "local repository must not exceed 128 characters",
 		},
+		{
+			name:     "unsupported query protocol",
+			gameCode: "cs16",
+			requestBody: `{
+				"name": "Counter-Strike 1.6",
+				"engine": "GoldSource",
+				"query_protocol": "unknown",
+				"enabled": 1
+			}`,
+			setupRepo: func(repo *inmemory.GameRepository) {
+				_ = repo.Save(context.Background(), &domain.Game{
+					Code:    "cs16",
+					Name:    "Counter-Strike 1.6",
+					Engine:  "GoldSource",
+					Enabled: 1,
+				})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "query protocol is not supported",
+		},
+		{
+			name:     "unsupported rcon protocol",
+			gameCode: "cs16",
+			requestBody: `{
+				"name": "Counter-Strike 1.6",
+				"engine": "GoldSource",
+				"rcon_protocol": "unknown",
+				"enabled": 1
+			}`,
+			setupRepo: func(repo *inmemory.GameRepository) {
+				_ = repo.Save(context.Background(), &domain.Game{
+					Code:    "cs16",
+					Name:    "Counter-Strike 1.6",
+					Engine:  "GoldSource",
+					Enabled: 1,
+				})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "rcon protocol is not supported",
+		},
+		{
+			name:     "unsupported player manager",
+			gameCode: "cs16",
+			requestBody: `{
+				"name": "Counter-Strike 1.6",
+				"engine": "GoldSource",
+				"player_manager": "unknown",
+				"enabled": 1
+			}`,
+			setupRepo: func(repo *inmemory.GameRepository) {
+				_ = repo.Save(context.Background(), &domain.Game{
+					Code:    "cs16",
+					Name:    "Counter-Strike 1.6",
+					Engine:  "GoldSource",
+					Enabled: 1,
+				})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "player manager is not supported",
+		},
+		{
+			name:     "rcon port offset out of range",
+			gameCode: "cs16",
+			requestBody: `{
+				"name": "Counter-Strike 1.6",
+				"engine": "GoldSource",
+				"rcon_port_offset": 70000,
+				"enabled": 1
+			}`,
+			setupRepo: func(repo *inmemory.GameRepository) {
+				_ = repo.Save(context.Background(), &domain.Game{
+					Code:    "cs16",
+					Name:    "Counter-Strike 1.6",
+					Engine:  "GoldSource",
+					Enabled: 1,
+				})
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  "rcon port offset must be between -65535 and 65535",
+		},
 		{
 			name:     "update game with all optional fields",
 			gameCode: "cs16",
@@ -352,6 +432,11 @@ func TestHandler_GameUpdatePersistence(t *testing.T) {
 		"remote_repository_windows": lo.ToPtr("C:\\local\\hl2\\windows\\updated"),
 		"local_repository_linux":    lo.ToPtr("C:\\local\\hl2\\windows\\updated"),
 		"local_repository_windows":  lo.ToPtr("C:\\local\\hl2\\windows\\updated"),
+		"query_protocol":            "source",
+		"rcon_protocol":             "source",
+		"player_manager":            "source",
+		"query_port_offset":         1,
+		"rcon_port_offset":          -1,
 		"enabled":                   0,
 	}
 
@@ -390,6 +475,11 @@ func TestHandler_GameUpdatePersistence(t *testing.T) {
 	assert.Equal(t, lo.ToPtr("C:\\local\\hl2\\windows\\updated"), game.LocalRepositoryLinux)
 	require.NotNil(t, game.LocalRepositoryWindows)
 	assert.Equal(t, lo.ToPtr("C:\\local\\hl2\\windows\\updated"), game.LocalRepositoryWindows)
+	assert.Equal(t, lo.ToPtr("source"), game.QueryProtocol)
+	assert.Equal(t, lo.ToPtr("source"), game.RconProtocol)
+	assert.Equal(t, lo.ToPtr("source"), game.PlayerManager)
+	assert.Equal(t, lo.ToPtr(1), game.QueryPortOffset)
+	assert.Equal(t, lo.ToPtr(-1), game.RconPortOffset)
 	assert.Equal(t, 0, game.Enabled)
 }
 
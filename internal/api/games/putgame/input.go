@@ -6,6 +6,9 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/flexible"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/gameap/gameap/pkg/quercon/rcon"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
 	"github.com/samber/lo"
 )
 
@@ -16,6 +19,7 @@ const (
 	maxEngineVersionLength = 128
 	maxConfigLength        = 128
 	maxRepositoryLength    = 128
+	maxPortOffset          = 65535
 )
 
 var (
@@ -42,6 +46,15 @@ var (
 	ErrLocalRepositoryTooLong = api.NewValidationError(
 		fmt.Sprintf("local repository must not exceed %d characters", maxRepositoryLength),
 	)
+	ErrQueryProtocolUnsupported = api.NewValidationError("query protocol is not supported")
+	ErrRconProtocolUnsupported  = api.NewValidationError("rcon protocol is not supported")
+	ErrPlayerManagerUnsupported = api.NewValidationError("player manager is not supported")
+	ErrQueryPortOffsetInvalid   = api.NewValidationError(
+		fmt.Sprintf("query port offset must be between %d and %d", -maxPortOffset, maxPortOffset),
+	)
+	ErrRconPortOffsetInvalid = api.NewValidationError(
+		fmt.Sprintf("rcon port offset must be between %d and %d", -maxPortOffset, maxPortOffset),
+	)
 )
 
 type updateGameInput struct {
@@ -55,6 +68,11 @@ type updateGameInput struct {
 	RemoteRepositoryWindows *string        `json:"remote_repository_windows,omitempty"` // maxlen=128
 	LocalRepositoryLinux    *string        `json:"local_repository_linux,omitempty"`    // maxlen=128
 	LocalRepositoryWindows  *string        `json:"local_repository_windows,omitempty"`  // maxlen=128
+	QueryProtocol           *string        `json:"query_protocol,omitempty"`            // query.Protocol
+	RconProtocol            *string        `json:"rcon_protocol,omitempty"`             // rcon.Protocol
+	PlayerManager           *string        `json:"player_manager,omitempty"`            // players manager name
+	QueryPortOffset         *int           `json:"query_port_offset,omitempty"`         // -65535..65535
+	RconPortOffset          *int           `json:"rcon_port_offset,omitempty"`          // -65535..65535
 	Enabled                 int            `json:"enabled"`                             //
 }
 
@@ -104,6 +122,29 @@ func (g *updateGameInput) Validate() error {
 		return ErrLocalRepositoryTooLong
 	}
 
+	if g.QueryProtocol != nil && *g.QueryProtocol != "" &&
+		!query.IsProtocolSupported(query.Protocol(*g.QueryProtocol)) {
+		return ErrQueryProtocolUnsupported
+	}
+
+	if g.RconProtocol != nil && *g.RconProtocol != "" &&
+		!rcon.IsProtocolSupported(rcon.Protocol(*g.RconProtocol)) {
+		return ErrRconProtocolUnsupported
+	}
+
+	if g.PlayerManager != nil && *g.PlayerManager != "" &&
+		!players.IsPlayerManagerSupported(*g.PlayerManager) {
+		return ErrPlayerManagerUnsupported
+	}
+
+	if g.QueryPortOffset != nil && (*g.QueryPortOffset < -maxPortOffset || *g.QueryPortOffset > maxPortOffset) {
+		return ErrQueryPortOffsetInvalid
+	}
+
+	if g.RconPortOffset != nil && (*g.RconPortOffset < -maxPortOffset || *g.RconPortOffset > maxPortOffset) {
+		return ErrRconPortOffsetInvalid
+	}
+
 	return nil
 }
 
@@ -118,5 +159,10 @@ func (g *updateGameInput) Apply(game *domain.Game) {
 	game.RemoteRepositoryWindows = g.RemoteRepositoryWindows
 	game.LocalRepositoryLinux = g.LocalRepositoryLinux
 	game.LocalRepositoryWindows = g.LocalRepositoryWindows
+	game.QueryProtocol = g.QueryProtocol
+	game.RconProtocol = g.RconProtocol
+	game.PlayerManager = g.PlayerManager
+	game.QueryPortOffset = g.QueryPortOffset
+	game.RconPortOffset = g.RconPortOffset
 	game.Enabled = g.Enabled
 }
@@ -0,0 +1,42 @@
+package base
+
+import (
+	"net/http"
+
+	rconbase "github.com/gameap/gameap/internal/api/servers/rcon/base"
+	"github.com/gameap/gameap/internal/services/minecraftaccess"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+var ErrListNotFound = errors.New("list not found")
+
+// ReadList reads the list name from the request path.
+func ReadList(r *http.Request) (minecraftaccess.List, error) {
+	name, _ := api.NewInputReader(r).ReadString("list")
+
+	list := minecraftaccess.List(name)
+	if !list.IsValid() {
+		return "", api.WrapHTTPError(ErrListNotFound, http.StatusNotFound)
+	}
+
+	return list, nil
+}
+
+// WrapServiceError sets the HTTP status of the Minecraft access service errors.
+// Errors of the RCON executor are mapped with rconbase.WrapExecutorError.
+func WrapServiceError(err error) error {
+	switch {
+	case errors.Is(err, minecraftaccess.ErrNotMinecraftServer):
+		return api.WrapHTTPError(err, http.StatusNotImplemented)
+	case errors.Is(err, minecraftaccess.ErrInvalidPlayerName),
+		errors.Is(err, minecraftaccess.ErrProfileNotFound):
+		return api.WrapHTTPError(err, http.StatusUnprocessableEntity)
+	case errors.Is(err, minecraftaccess.ErrEntryExists):
+		return api.WrapHTTPError(err, http.StatusConflict)
+	case errors.Is(err, minecraftaccess.ErrEntryNotFound):
+		return api.WrapHTTPError(err, http.StatusNotFound)
+	default:
+		return rconbase.WrapExecutorError(err)
+	}
+}
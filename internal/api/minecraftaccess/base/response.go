@@ -0,0 +1,39 @@
+package base
+
+import (
+	"github.com/gameap/gameap/internal/services/minecraftaccess"
+)
+
+type EntryResponse struct {
+	UUID                string `json:"uuid"`
+	Name                string `json:"name"`
+	Level               int    `json:"level,omitempty"`
+	BypassesPlayerLimit bool   `json:"bypasses_player_limit,omitempty"`
+}
+
+func NewEntriesResponse(entries []minecraftaccess.Entry) []EntryResponse {
+	response := make([]EntryResponse, 0, len(entries))
+
+	for _, entry := range entries {
+		response = append(response, EntryResponse{
+			UUID:                entry.UUID,
+			Name:                entry.Name,
+			Level:               entry.Level,
+			BypassesPlayerLimit: entry.BypassesPlayerLimit,
+		})
+	}
+
+	return response
+}
+
+type ChangeResponse struct {
+	Method string `json:"method"`
+	Output string `json:"output"`
+}
+
+func NewChangeResponse(result *minecraftaccess.ChangeResult) ChangeResponse {
+	return ChangeResponse{
+		Method: string(result.Method),
+		Output: result.Output,
+	}
+}
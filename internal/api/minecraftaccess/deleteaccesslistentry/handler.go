@@ -0,0 +1,115 @@
+package deleteaccesslistentry
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	minecraftbase "github.com/gameap/gameap/internal/api/minecraftaccess/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/minecraftaccess"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	nodeState      *serversbase.NodeStateChecker
+	access         *minecraftaccess.Service
+	responder      base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	access *minecraftaccess.Service,
+	nodeHealth *nodehealth.Checker,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
+		access:         access,
+		responder:      responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	serverID, err := api.NewInputReader(r).ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	list, err := minecraftbase.ReadList(r)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	name, _ := api.NewInputReader(r).ReadString("name")
+	if !players.IsValidMinecraftName(name) {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			minecraftaccess.ErrInvalidPlayerName,
+			http.StatusUnprocessableEntity,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.abilityChecker.CheckOrError(
+		ctx, session.User.ID, server.ID, []domain.AbilityName{domain.AbilityNameGameServerRconPlayers},
+	); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	result, err := h.access.Remove(ctx, server, list, name)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(
+			minecraftbase.WrapServiceError(err),
+			"failed to remove player from the list",
+		))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, minecraftbase.NewChangeResponse(result))
+}
@@ -0,0 +1,185 @@
+package deleteaccesslistentry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/minecraftaccess"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+const testWhitelistPath = "/srv/gameap/servers/mc/whitelist.json"
+
+const testWhitelist = `[{"uuid": "069a79f4-44e9-4726-a5be-fca90e38aaf5", "name": "Notch"}]`
+
+func newService(t *testing.T, executor *apitesting.Executor, node *apitesting.FakeNode) *minecraftaccess.Service {
+	t.Helper()
+
+	gameRepo := inmemory.NewGameRepository()
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "minecraft",
+		Name:   "Minecraft",
+		Engine: "Minecraft",
+	}))
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "cstrike",
+		Name:   "Counter-Strike",
+		Engine: "GoldSource",
+	}))
+
+	return minecraftaccess.NewService(
+		node.Repo,
+		serversbase.NewGameFinder(gameRepo, inmemory.NewGameModRepository()),
+		executor,
+		node.Files,
+		minecraftaccess.NewOfflineProfileResolver(),
+	)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		list           string
+		player         string
+		online         bool
+		ctx            context.Context
+		allowAbility   bool
+		expectedStatus int
+		wantError      string
+		wantMethod     string
+		wantCommands   []string
+	}{
+		{
+			name:           "user not authenticated",
+			list:           "whitelist",
+			player:         "Notch",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "user does not have players ability",
+			list:           "whitelist",
+			player:         "Notch",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "unknown list",
+			list:           "banned-ips",
+			player:         "Notch",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "list not found",
+		},
+		{
+			name:           "invalid name",
+			list:           "whitelist",
+			player:         "Notch-1",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "invalid minecraft player name",
+		},
+		{
+			name:           "player not in list",
+			list:           "whitelist",
+			player:         "Dinnerbone",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "player is not in the list",
+		},
+		{
+			name:           "remove operator over rcon",
+			list:           "ops",
+			player:         "Notch",
+			online:         true,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantMethod:     "rcon",
+			wantCommands:   []string{"deop Notch"},
+		},
+		{
+			name:           "remove from whitelist file",
+			list:           "whitelist",
+			player:         "Notch",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantMethod:     "file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apitesting.NewServer("minecraft")
+			server.Dir = "servers/mc"
+			server.ProcessActive = tt.online
+			serverRepo := apitesting.NewServerRepository(t, testUser1.ID, server)
+			executor := &apitesting.Executor{Output: "done"}
+			node := apitesting.NewFakeNode(t, "/srv/gameap")
+			require.NoError(t, node.Daemon.WriteFile(testWhitelistPath, []byte(testWhitelist)))
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			service := newService(t, executor, node)
+			handler := NewHandler(serverRepo, service, apitesting.NewNodeHealthChecker(t), rbacService, api.NewResponder())
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/servers/1/minecraft/"+tt.list+"/"+tt.player, nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1", "list": tt.list, "name": tt.player})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.wantCommands, executor.Commands)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantMethod, response["method"])
+
+			if tt.wantMethod == "file" {
+				data, err := node.Daemon.ReadFile(testWhitelistPath)
+				require.NoError(t, err)
+				assert.JSONEq(t, "[]", string(data))
+			}
+		})
+	}
+}
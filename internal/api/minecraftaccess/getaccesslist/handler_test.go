@@ -0,0 +1,172 @@
+package getaccesslist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/minecraftaccess"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+const testWhitelistPath = "/srv/gameap/servers/mc/whitelist.json"
+
+const testWhitelist = `[{"uuid": "069a79f4-44e9-4726-a5be-fca90e38aaf5", "name": "Notch"}]`
+
+func newService(t *testing.T, executor *apitesting.Executor, node *apitesting.FakeNode) *minecraftaccess.Service {
+	t.Helper()
+
+	gameRepo := inmemory.NewGameRepository()
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "minecraft",
+		Name:   "Minecraft",
+		Engine: "Minecraft",
+	}))
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "cstrike",
+		Name:   "Counter-Strike",
+		Engine: "GoldSource",
+	}))
+
+	return minecraftaccess.NewService(
+		node.Repo,
+		serversbase.NewGameFinder(gameRepo, inmemory.NewGameModRepository()),
+		executor,
+		node.Files,
+		minecraftaccess.NewOfflineProfileResolver(),
+	)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		list           string
+		gameID         string
+		ctx            context.Context
+		allowAbility   bool
+		nodeOffline    bool
+		expectedStatus int
+		wantError      string
+		wantEntries    []map[string]any
+	}{
+		{
+			name:           "user not authenticated",
+			list:           "whitelist",
+			gameID:         "minecraft",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "user does not have players ability",
+			list:           "whitelist",
+			gameID:         "minecraft",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "node offline",
+			list:           "whitelist",
+			gameID:         "minecraft",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			nodeOffline:    true,
+			expectedStatus: http.StatusServiceUnavailable,
+			wantError:      "Service Unavailable",
+		},
+		{
+			name:           "unknown list",
+			list:           "banned-players",
+			gameID:         "minecraft",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "list not found",
+		},
+		{
+			name:           "not minecraft server",
+			list:           "whitelist",
+			gameID:         "cstrike",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotImplemented,
+			wantError:      "Not Implemented",
+		},
+		{
+			name:           "whitelist entries",
+			list:           "whitelist",
+			gameID:         "minecraft",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantEntries: []map[string]any{
+				{"uuid": "069a79f4-44e9-4726-a5be-fca90e38aaf5", "name": "Notch"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apitesting.NewServer(tt.gameID)
+			server.Dir = "servers/mc"
+			server.ProcessActive = false
+			serverRepo := apitesting.NewServerRepository(t, testUser1.ID, server)
+			node := apitesting.NewFakeNode(t, "/srv/gameap")
+			require.NoError(t, node.Daemon.WriteFile(testWhitelistPath, []byte(testWhitelist)))
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			service := newService(t, &apitesting.Executor{Output: "done"}, node)
+			nodeHealth := apitesting.NewNodeHealthChecker(t)
+			if tt.nodeOffline {
+				nodeHealth = apitesting.NewNodeHealthChecker(t, apitesting.OfflineNodeHealth(server.DSID))
+			}
+			handler := NewHandler(serverRepo, service, nodeHealth, rbacService, api.NewResponder())
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/1/minecraft/"+tt.list, nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1", "list": tt.list})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var entries []map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+			assert.Equal(t, tt.wantEntries, entries)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package postaccesslistentry
+
+import (
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+)
+
+var (
+	ErrNameIsRequired = api.NewValidationError("name is required")
+	ErrInvalidName    = api.NewValidationError(
+		"name must be 1 to 16 characters long and contain only letters, digits and underscores",
+	)
+)
+
+type entryInput struct {
+	Name string `json:"name"`
+}
+
+func (in *entryInput) Validate() error {
+	if in.Name == "" {
+		return ErrNameIsRequired
+	}
+
+	if !players.IsValidMinecraftName(in.Name) {
+		return ErrInvalidName
+	}
+
+	return nil
+}
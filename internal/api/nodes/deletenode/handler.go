@@ -16,20 +16,27 @@ var (
 	ErrNodeHasServers = errors.New("cannot delete node with existing game servers")
 )
 
+type daemonPools interface {
+	Invalidate(nodeID uint)
+}
+
 type Handler struct {
 	nodesRepo   repositories.NodeRepository
 	serversRepo repositories.ServerRepository
+	daemonPools daemonPools
 	responder   base.Responder
 }
 
 func NewHandler(
 	nodesRepo repositories.NodeRepository,
 	serversRepo repositories.ServerRepository,
+	daemonPools daemonPools,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
 		nodesRepo:   nodesRepo,
 		serversRepo: serversRepo,
+		daemonPools: daemonPools,
 		responder:   responder,
 	}
 }
@@ -105,5 +112,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.daemonPools.Invalidate(nodeID)
+
 	rw.WriteHeader(http.StatusNoContent)
 }
@@ -25,6 +25,14 @@ var testUser1 = domain.User{
 	Email: "admin@example.com",
 }
 
+type mockDaemonPools struct {
+	invalidated []uint
+}
+
+func (m *mockDaemonPools) Invalidate(nodeID uint) {
+	m.invalidated = append(m.invalidated, nodeID)
+}
+
 func TestHandler_ServeHTTP(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -155,7 +163,8 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			nodesRepo := inmemory.NewNodeRepository()
 			serversRepo := inmemory.NewServerRepository()
 			responder := api.NewResponder()
-			handler := NewHandler(nodesRepo, serversRepo, responder)
+			daemonPools := &mockDaemonPools{}
+			handler := NewHandler(nodesRepo, serversRepo, daemonPools, responder)
 
 			if tt.setupRepos != nil {
 				tt.setupRepos(nodesRepo, serversRepo)
@@ -186,6 +195,9 @@ func TestHandler_ServeHTTP(t *testing.T) {
 
 			if tt.expectedStatus == http.StatusNoContent {
 				assert.Empty(t, w.Body.String())
+				assert.Equal(t, []uint{1}, daemonPools.invalidated)
+			} else {
+				assert.Empty(t, daemonPools.invalidated)
 			}
 		})
 	}
@@ -195,7 +207,7 @@ func TestHandler_NodeActuallySoftDeleted(t *testing.T) {
 	nodesRepo := inmemory.NewNodeRepository()
 	serversRepo := inmemory.NewServerRepository()
 	responder := api.NewResponder()
-	handler := NewHandler(nodesRepo, serversRepo, responder)
+	handler := NewHandler(nodesRepo, serversRepo, &mockDaemonPools{}, responder)
 
 	now := time.Now()
 	node := &domain.Node{
@@ -241,10 +253,13 @@ func TestHandler_NewHandler(t *testing.T) {
 	serversRepo := inmemory.NewServerRepository()
 	responder := api.NewResponder()
 
-	handler := NewHandler(nodesRepo, serversRepo, responder)
+	daemonPools := &mockDaemonPools{}
+
+	handler := NewHandler(nodesRepo, serversRepo, daemonPools, responder)
 
 	require.NotNil(t, handler)
 	assert.Equal(t, nodesRepo, handler.nodesRepo)
 	assert.Equal(t, serversRepo, handler.serversRepo)
+	assert.Equal(t, daemonPools, handler.daemonPools)
 	assert.Equal(t, responder, handler.responder)
 }
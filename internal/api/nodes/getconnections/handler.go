@@ -0,0 +1,64 @@
+package getconnections
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type breakerStatsProvider interface {
+	Stats() []daemon.BreakerStats
+}
+
+type poolStatsProvider interface {
+	Stats() []daemon.PoolStats
+}
+
+type Handler struct {
+	nodeRepo  repositories.NodeRepository
+	breakers  breakerStatsProvider
+	pools     poolStatsProvider
+	responder base.Responder
+}
+
+func NewHandler(
+	nodeRepo repositories.NodeRepository,
+	breakers breakerStatsProvider,
+	pools poolStatsProvider,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		nodeRepo:  nodeRepo,
+		breakers:  breakers,
+		pools:     pools,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	nodes, err := h.nodeRepo.FindAll(ctx, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find nodes"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newConnectionsResponse(nodes, h.breakers.Stats(), h.pools.Stats()))
+}
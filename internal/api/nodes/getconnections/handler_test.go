@@ -0,0 +1,179 @@
+package getconnections
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/daemon/binnapi"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDial = errors.New("dial tcp 127.0.0.2:31717: connection refused")
+
+var testUser = domain.User{
+	ID:    1,
+	Login: "admin",
+	Email: "admin@example.com",
+}
+
+type mockPoolStats []daemon.PoolStats
+
+func (m mockPoolStats) Stats() []daemon.PoolStats {
+	return m
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name             string
+		setupAuth        func() context.Context
+		setupBreakers    func(*daemon.Breakers)
+		pools            mockPoolStats
+		expectedStatus   int
+		wantError        string
+		validateResponse func(t *testing.T, resp []nodeConnectionsResponse)
+	}{
+		{
+			name: "nodes without connections",
+			setupAuth: func() context.Context {
+				return auth.ContextWithSession(context.Background(), &auth.Session{
+					Login: "admin",
+					Email: "admin@example.com",
+					User:  &testUser,
+				})
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, resp []nodeConnectionsResponse) {
+				t.Helper()
+
+				require.Len(t, resp, 2)
+				for _, node := range resp {
+					assert.Equal(t, "closed", node.Breaker.State)
+					assert.Zero(t, node.Breaker.ConsecutiveFailures)
+					assert.Nil(t, node.Breaker.OpenedAt)
+					assert.Empty(t, node.Pools)
+				}
+			},
+		},
+		{
+			name: "degraded node",
+			setupAuth: func() context.Context {
+				return auth.ContextWithSession(context.Background(), &auth.Session{
+					Login: "admin",
+					Email: "admin@example.com",
+					User:  &testUser,
+				})
+			},
+			setupBreakers: func(breakers *daemon.Breakers) {
+				breakers.Get(1).Success()
+				for range 3 {
+					breakers.Get(2).Failure(errDial)
+				}
+			},
+			pools: mockPoolStats{
+				{
+					NodeID:            1,
+					Mode:              binnapi.ModeCMD,
+					TotalResources:    2,
+					AcquiredResources: 1,
+					IdleResources:     1,
+					MaxResources:      3,
+					AcquireCount:      10,
+					AcquireDuration:   1500 * time.Millisecond,
+				},
+				{NodeID: 1, Mode: binnapi.ModeStatus, MaxResources: 3},
+				{NodeID: 2, Mode: binnapi.ModeStatus, MaxResources: 3, EmptyAcquireCount: 3},
+				{NodeID: 99, Mode: binnapi.ModeStatus, MaxResources: 3},
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, resp []nodeConnectionsResponse) {
+				t.Helper()
+
+				require.Len(t, resp, 2)
+
+				assert.Equal(t, uint(1), resp[0].NodeID)
+				assert.Equal(t, "Node 1", resp[0].NodeName)
+				assert.Equal(t, "closed", resp[0].Breaker.State)
+				require.Len(t, resp[0].Pools, 2)
+				assert.Equal(t, poolResponse{
+					Mode:                "commands",
+					TotalConnections:    2,
+					AcquiredConnections: 1,
+					IdleConnections:     1,
+					MaxConnections:      3,
+					AcquireCount:        10,
+					AcquireDuration:     1500,
+				}, resp[0].Pools[0])
+				assert.Equal(t, "status", resp[0].Pools[1].Mode)
+
+				assert.Equal(t, uint(2), resp[1].NodeID)
+				assert.Equal(t, "open", resp[1].Breaker.State)
+				assert.Equal(t, 3, resp[1].Breaker.ConsecutiveFailures)
+				assert.Equal(t, errDial.Error(), resp[1].Breaker.LastError)
+				assert.NotNil(t, resp[1].Breaker.OpenedAt)
+				require.Len(t, resp[1].Pools, 1)
+				assert.Equal(t, int64(3), resp[1].Pools[0].EmptyAcquireCount)
+			},
+		},
+		{
+			name: "user not authenticated",
+			setupAuth: func() context.Context {
+				return context.Background()
+			},
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeRepo := inmemory.NewNodeRepository()
+			for _, node := range []*domain.Node{
+				{ID: 1, Enabled: true, Name: "Node 1", GdaemonHost: "127.0.0.1"},
+				{ID: 2, Enabled: true, Name: "Node 2", GdaemonHost: "127.0.0.2"},
+			} {
+				require.NoError(t, nodeRepo.Save(context.Background(), node))
+			}
+
+			breakers := daemon.NewBreakers(3, time.Minute)
+			if tt.setupBreakers != nil {
+				tt.setupBreakers(breakers)
+			}
+
+			handler := NewHandler(nodeRepo, breakers, tt.pools, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/dedicated_servers/connections", nil)
+			req = req.WithContext(tt.setupAuth())
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var resp []nodeConnectionsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			tt.validateResponse(t, resp)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package getconnections
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/daemon/binnapi"
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type nodeConnectionsResponse struct {
+	NodeID   uint            `json:"node_id"`
+	NodeName string          `json:"node_name"`
+	Breaker  breakerResponse `json:"breaker"`
+	Pools    []poolResponse  `json:"pools"`
+}
+
+type breakerResponse struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	OpenedAt            *time.Time `json:"opened_at"`
+}
+
+type poolResponse struct {
+	Mode                    string `json:"mode"`
+	TotalConnections        int32  `json:"total_connections"`
+	AcquiredConnections     int32  `json:"acquired_connections"`
+	IdleConnections         int32  `json:"idle_connections"`
+	ConstructingConnections int32  `json:"constructing_connections"`
+	MaxConnections          int32  `json:"max_connections"`
+	AcquireCount            int64  `json:"acquire_count"`
+	AcquireDuration         int64  `json:"acquire_duration"` // milliseconds
+	EmptyAcquireCount       int64  `json:"empty_acquire_count"`
+	CanceledAcquireCount    int64  `json:"canceled_acquire_count"`
+}
+
+func newConnectionsResponse(
+	nodes []domain.Node,
+	breakers []daemon.BreakerStats,
+	pools []daemon.PoolStats,
+) []nodeConnectionsResponse {
+	breakersByNode := make(map[uint]daemon.BreakerStats, len(breakers))
+	for _, b := range breakers {
+		breakersByNode[b.NodeID] = b
+	}
+
+	poolsByNode := make(map[uint][]poolResponse, len(nodes))
+	for _, p := range pools {
+		poolsByNode[p.NodeID] = append(poolsByNode[p.NodeID], newPoolResponse(p))
+	}
+
+	response := make([]nodeConnectionsResponse, 0, len(nodes))
+	for _, node := range nodes {
+		nodePools := poolsByNode[node.ID]
+		if nodePools == nil {
+			nodePools = []poolResponse{}
+		}
+
+		response = append(response, nodeConnectionsResponse{
+			NodeID:   node.ID,
+			NodeName: node.Name,
+			Breaker:  newBreakerResponse(breakersByNode[node.ID]),
+			Pools:    nodePools,
+		})
+	}
+
+	return response
+}
+
+func newBreakerResponse(stats daemon.BreakerStats) breakerResponse {
+	state := stats.State
+	if state == "" {
+		// No connection attempts to the node were made yet
+		state = daemon.BreakerStateClosed
+	}
+
+	return breakerResponse{
+		State:               string(state),
+		ConsecutiveFailures: stats.ConsecutiveFailures,
+		LastError:           stats.LastError,
+		OpenedAt:            stats.OpenedAt,
+	}
+}
+
+func newPoolResponse(stats daemon.PoolStats) poolResponse {
+	return poolResponse{
+		Mode:                    modeName(stats.Mode),
+		TotalConnections:        stats.TotalResources,
+		AcquiredConnections:     stats.AcquiredResources,
+		IdleConnections:         stats.IdleResources,
+		ConstructingConnections: stats.ConstructingResources,
+		MaxConnections:          stats.MaxResources,
+		AcquireCount:            stats.AcquireCount,
+		AcquireDuration:         stats.AcquireDuration.Milliseconds(),
+		EmptyAcquireCount:       stats.EmptyAcquireCount,
+		CanceledAcquireCount:    stats.CanceledAcquireCount,
+	}
+}
+
+func modeName(mode binnapi.Mode) string {
+	switch mode {
+	case binnapi.ModeCMD:
+		return "commands"
+	case binnapi.ModeFiles:
+		return "files"
+	case binnapi.ModeStatus:
+		return "status"
+	default:
+		return "unknown"
+	}
+}
@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/daemon/fakedaemon"
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/files"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
@@ -309,6 +311,51 @@ func TestHandler_ServeHTTP(t *testing.T) {
 	}
 }
 
+func TestHandler_FakeDaemon(t *testing.T) {
+	server, err := fakedaemon.New(fakedaemon.WithVersion("3.2.0", "2025-03-01"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, server.Close())
+	}()
+
+	ctx := auth.ContextWithSession(context.Background(), &auth.Session{
+		Login: "admin",
+		Email: "admin@example.com",
+		User:  &testUser,
+	})
+
+	nodeRepo := inmemory.NewNodeRepository()
+	certRepo := inmemory.NewClientCertificateRepository()
+	fileManager := files.NewInMemoryFileManager()
+
+	node := &domain.Node{ID: 1, Enabled: true, Name: "fake-node", GdaemonAPIKey: "test-api-key"}
+	require.NoError(t, server.ConfigureNode(ctx, node, certRepo, fileManager))
+	require.NoError(t, nodeRepo.Save(ctx, node))
+
+	pools := daemon.NewPoolRegistry(daemon.NewBreakers(0, 0), 0)
+	defer func() {
+		_ = pools.Close()
+	}()
+
+	handler := NewHandler(nodeRepo, daemon.NewStatusService(certRepo, fileManager, pools), api.NewResponder())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/dedicated_servers/1/daemon", nil)
+	req = req.WithContext(ctx)
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp daemonStatusResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "fake-node", resp.Name)
+	assert.Equal(t, "3.2.0", resp.Version.Version)
+	assert.Equal(t, "2025-03-01", resp.Version.CompileDate)
+	assert.Equal(t, "0", resp.BaseInfo.OnlineServersCount)
+}
+
 func TestHandler_NewHandler(t *testing.T) {
 	nodeRepo := inmemory.NewNodeRepository()
 	mockStatus := &mockDaemonStatusService{}
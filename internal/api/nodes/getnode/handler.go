@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/pkg/api"
@@ -12,17 +13,20 @@ import (
 )
 
 type Handler struct {
-	nodesRepo repositories.NodeRepository
-	responder base.Responder
+	nodesRepo  repositories.NodeRepository
+	healthRepo repositories.NodeHealthRepository
+	responder  base.Responder
 }
 
 func NewHandler(
 	nodesRepo repositories.NodeRepository,
+	healthRepo repositories.NodeHealthRepository,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
-		nodesRepo: nodesRepo,
-		responder: responder,
+		nodesRepo:  nodesRepo,
+		healthRepo: healthRepo,
+		responder:  responder,
 	}
 }
 
@@ -70,5 +74,17 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.responder.Write(ctx, rw, newNodeResponseFromNode(&nodes[0]))
+	healths, err := h.healthRepo.Find(ctx, &filters.FindNodeHealth{NodeIDs: []uint{nodeID}}, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find node health"))
+
+		return
+	}
+
+	var health *domain.NodeHealth
+	if len(healths) > 0 {
+		health = &healths[0]
+	}
+
+	h.responder.Write(ctx, rw, newNodeResponseFromNode(&nodes[0], health))
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/gorilla/mux"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -119,7 +120,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			nodesRepo := inmemory.NewNodeRepository()
 			responder := api.NewResponder()
-			handler := NewHandler(nodesRepo, responder)
+			handler := NewHandler(nodesRepo, inmemory.NewNodeHealthRepository(), responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(nodesRepo)
@@ -164,7 +165,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 func TestHandler_NodeResponseFields(t *testing.T) {
 	nodesRepo := inmemory.NewNodeRepository()
 	responder := api.NewResponder()
-	handler := NewHandler(nodesRepo, responder)
+	handler := NewHandler(nodesRepo, inmemory.NewNodeHealthRepository(), responder)
 
 	now := time.Now()
 	provider := "AWS"
@@ -310,7 +311,7 @@ func TestHandler_NewHandler(t *testing.T) {
 	nodesRepo := inmemory.NewNodeRepository()
 	responder := api.NewResponder()
 
-	handler := NewHandler(nodesRepo, responder)
+	handler := NewHandler(nodesRepo, inmemory.NewNodeHealthRepository(), responder)
 
 	require.NotNil(t, handler)
 	assert.Equal(t, nodesRepo, handler.nodesRepo)
@@ -348,7 +349,7 @@ func TestNewNodeResponseFromNode(t *testing.T) {
 		UpdatedAt:           &now,
 	}
 
-	response := newNodeResponseFromNode(node)
+	response := newNodeResponseFromNode(node, nil)
 
 	assert.Equal(t, uint(1), response.ID)
 	assert.True(t, response.Enabled)
@@ -395,7 +396,7 @@ func TestNewNodeResponseFromNode_EmptyIP(t *testing.T) {
 		PreferInstallMethod: "auto",
 	}
 
-	response := newNodeResponseFromNode(node)
+	response := newNodeResponseFromNode(node, nil)
 
 	assert.Equal(t, uint(1), response.ID)
 	assert.Empty(t, response.IPs)
@@ -422,7 +423,7 @@ func TestNewNodeResponseFromNode_NullableFields(t *testing.T) {
 		UpdatedAt:           &now,
 	}
 
-	response := newNodeResponseFromNode(node)
+	response := newNodeResponseFromNode(node, nil)
 
 	assert.Equal(t, uint(1), response.ID)
 	assert.False(t, response.Enabled)
@@ -450,3 +451,58 @@ func TestNewNodeResponseFromNode_NullableFields(t *testing.T) {
 	assert.Nil(t, response.ScriptDelete)
 	assert.Nil(t, response.DeletedAt)
 }
+
+func TestNewNodeResponseFromNode_Health(t *testing.T) {
+	lastSeenAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	node := &domain.Node{ID: 1, Name: "test-node"}
+
+	tests := []struct {
+		name        string
+		health      *domain.NodeHealth
+		wantOnline  *bool
+		wantLatency *uint
+	}{
+		{
+			name:   "unchecked_node",
+			health: nil,
+		},
+		{
+			name: "online_node",
+			health: &domain.NodeHealth{
+				NodeID:     1,
+				Online:     true,
+				Latency:    25,
+				LastSeenAt: &lastSeenAt,
+				CheckedAt:  lastSeenAt,
+			},
+			wantOnline:  lo.ToPtr(true),
+			wantLatency: lo.ToPtr(uint(25)),
+		},
+		{
+			name: "offline_node",
+			health: &domain.NodeHealth{
+				NodeID:     1,
+				Online:     false,
+				Error:      "connection refused",
+				LastSeenAt: &lastSeenAt,
+				CheckedAt:  lastSeenAt.Add(time.Minute),
+			},
+			wantOnline: lo.ToPtr(false),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := newNodeResponseFromNode(node, tt.health)
+
+			assert.Equal(t, tt.wantOnline, response.Online)
+			assert.Equal(t, tt.wantLatency, response.Latency)
+
+			if tt.health == nil {
+				assert.Nil(t, response.LastSeenAt)
+			} else {
+				assert.Equal(t, tt.health.LastSeenAt, response.LastSeenAt)
+			}
+		})
+	}
+}
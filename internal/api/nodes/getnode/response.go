@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/samber/lo"
 )
 
 type nodeResponse struct {
@@ -43,10 +44,15 @@ type nodeResponse struct {
 	CreatedAt           *time.Time `json:"created_at"`
 	UpdatedAt           *time.Time `json:"updated_at"`
 	DeletedAt           *time.Time `json:"deleted_at"`
+
+	// Online is nil when the node hasn't been checked yet.
+	Online     *bool      `json:"online"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+	Latency    *uint      `json:"latency"`
 }
 
-func newNodeResponseFromNode(n *domain.Node) nodeResponse {
-	return nodeResponse{
+func newNodeResponseFromNode(n *domain.Node, health *domain.NodeHealth) nodeResponse {
+	response := nodeResponse{
 		ID:                  n.ID,
 		Enabled:             n.Enabled,
 		Name:                n.Name,
@@ -84,4 +90,15 @@ func newNodeResponseFromNode(n *domain.Node) nodeResponse {
 		UpdatedAt:           n.UpdatedAt,
 		DeletedAt:           n.DeletedAt,
 	}
+
+	if health != nil {
+		response.Online = lo.ToPtr(health.Online)
+		response.LastSeenAt = health.LastSeenAt
+
+		if health.Online {
+			response.Latency = lo.ToPtr(health.Latency)
+		}
+	}
+
+	return response
 }
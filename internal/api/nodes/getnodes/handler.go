@@ -11,17 +11,20 @@ import (
 )
 
 type Handler struct {
-	nodesRepo repositories.NodeRepository
-	responder base.Responder
+	nodesRepo  repositories.NodeRepository
+	healthRepo repositories.NodeHealthRepository
+	responder  base.Responder
 }
 
 func NewHandler(
 	nodesRepo repositories.NodeRepository,
+	healthRepo repositories.NodeHealthRepository,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
-		nodesRepo: nodesRepo,
-		responder: responder,
+		nodesRepo:  nodesRepo,
+		healthRepo: healthRepo,
+		responder:  responder,
 	}
 }
 
@@ -45,7 +48,14 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	nodesResponse := newNodesResponseFromNodes(nodes)
+	healths, err := h.healthRepo.Find(ctx, nil, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find nodes health"))
+
+		return
+	}
+
+	nodesResponse := newNodesResponseFromNodes(nodes, healths)
 
 	h.responder.Write(ctx, rw, nodesResponse)
 }
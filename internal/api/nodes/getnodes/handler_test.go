@@ -109,7 +109,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			nodesRepo := inmemory.NewNodeRepository()
 			responder := api.NewResponder()
-			handler := NewHandler(nodesRepo, responder)
+			handler := NewHandler(nodesRepo, inmemory.NewNodeHealthRepository(), responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(nodesRepo)
@@ -158,7 +158,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 func TestHandler_NodesResponseFields(t *testing.T) {
 	nodesRepo := inmemory.NewNodeRepository()
 	responder := api.NewResponder()
-	handler := NewHandler(nodesRepo, responder)
+	handler := NewHandler(nodesRepo, inmemory.NewNodeHealthRepository(), responder)
 
 	now := time.Now()
 	provider := "AWS"
@@ -238,7 +238,7 @@ func TestNewNodesResponseFromNodes(t *testing.T) {
 		},
 	}
 
-	response := newNodesResponseFromNodes(nodes)
+	response := newNodesResponseFromNodes(nodes, nil)
 
 	require.Len(t, response, 2)
 
@@ -275,7 +275,7 @@ func TestNewNodeResponseFromNode(t *testing.T) {
 		UpdatedAt:   &now,
 	}
 
-	response := newNodeResponseFromNode(node)
+	response := newNodeResponseFromNode(node, nil)
 
 	assert.Equal(t, uint(1), response.ID)
 	assert.True(t, response.Enabled)
@@ -301,8 +301,71 @@ func TestNewNodeResponseFromNode_EmptyIP(t *testing.T) {
 		GdaemonPort: 31717,
 	}
 
-	response := newNodeResponseFromNode(node)
+	response := newNodeResponseFromNode(node, nil)
 
 	assert.Equal(t, uint(1), response.ID)
 	assert.Empty(t, response.IP)
 }
+
+func TestHandler_NodesHealth(t *testing.T) {
+	ctx := context.Background()
+	nodesRepo := inmemory.NewNodeRepository()
+	healthRepo := inmemory.NewNodeHealthRepository()
+	handler := NewHandler(nodesRepo, healthRepo, api.NewResponder())
+
+	for _, id := range []uint{1, 2, 3} {
+		require.NoError(t, nodesRepo.Save(ctx, &domain.Node{
+			ID:       id,
+			Enabled:  true,
+			Name:     "node",
+			OS:       "linux",
+			Location: "Montenegro",
+		}))
+	}
+
+	lastSeenAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, healthRepo.Save(ctx, &domain.NodeHealth{
+		NodeID:     1,
+		Online:     true,
+		Latency:    12,
+		LastSeenAt: &lastSeenAt,
+		CheckedAt:  lastSeenAt,
+	}))
+	require.NoError(t, healthRepo.Save(ctx, &domain.NodeHealth{
+		NodeID:     2,
+		Online:     false,
+		Latency:    0,
+		Error:      "connection refused",
+		LastSeenAt: &lastSeenAt,
+		CheckedAt:  lastSeenAt.Add(time.Minute),
+	}))
+
+	session := &auth.Session{Login: "admin", Email: "admin@example.com", User: &testUser1}
+	req := httptest.NewRequest(http.MethodGet, "/api/dedicated_servers", nil)
+	req = req.WithContext(auth.ContextWithSession(ctx, session))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var nodes []nodeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &nodes))
+	require.Len(t, nodes, 3)
+
+	require.NotNil(t, nodes[0].Online)
+	assert.True(t, *nodes[0].Online)
+	require.NotNil(t, nodes[0].Latency)
+	assert.Equal(t, uint(12), *nodes[0].Latency)
+	require.NotNil(t, nodes[0].LastSeenAt)
+	assert.True(t, lastSeenAt.Equal(*nodes[0].LastSeenAt))
+
+	require.NotNil(t, nodes[1].Online)
+	assert.False(t, *nodes[1].Online)
+	assert.Nil(t, nodes[1].Latency)
+	require.NotNil(t, nodes[1].LastSeenAt)
+
+	assert.Nil(t, nodes[2].Online, "unchecked node has unknown state")
+	assert.Nil(t, nodes[2].LastSeenAt)
+	assert.Nil(t, nodes[2].Latency)
+}
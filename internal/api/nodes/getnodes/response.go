@@ -1,7 +1,10 @@
 package getnodes
 
 import (
+	"time"
+
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/samber/lo"
 )
 
 type nodeResponse struct {
@@ -12,20 +15,34 @@ type nodeResponse struct {
 	Location string   `json:"location"`
 	Provider *string  `json:"provider"`
 	IP       []string `json:"ip"`
+
+	// Online is nil when the node hasn't been checked yet.
+	Online     *bool      `json:"online"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+	Latency    *uint      `json:"latency"`
 }
 
-func newNodesResponseFromNodes(nodes []domain.Node) []nodeResponse {
+func newNodesResponseFromNodes(nodes []domain.Node, healths []domain.NodeHealth) []nodeResponse {
 	response := make([]nodeResponse, 0, len(nodes))
 
+	healthByNode := lo.SliceToMap(healths, func(health domain.NodeHealth) (uint, domain.NodeHealth) {
+		return health.NodeID, health
+	})
+
 	for _, n := range nodes {
-		response = append(response, newNodeResponseFromNode(&n))
+		var health *domain.NodeHealth
+		if h, ok := healthByNode[n.ID]; ok {
+			health = &h
+		}
+
+		response = append(response, newNodeResponseFromNode(&n, health))
 	}
 
 	return response
 }
 
-func newNodeResponseFromNode(n *domain.Node) nodeResponse {
-	return nodeResponse{
+func newNodeResponseFromNode(n *domain.Node, health *domain.NodeHealth) nodeResponse {
+	response := nodeResponse{
 		ID:       n.ID,
 		Enabled:  n.Enabled,
 		Name:     n.Name,
@@ -34,4 +51,15 @@ func newNodeResponseFromNode(n *domain.Node) nodeResponse {
 		Provider: n.Provider,
 		IP:       n.IPs,
 	}
+
+	if health != nil {
+		response.Online = lo.ToPtr(health.Online)
+		response.LastSeenAt = health.LastSeenAt
+
+		if health.Online {
+			response.Latency = lo.ToPtr(health.Latency)
+		}
+	}
+
+	return response
 }
@@ -0,0 +1,97 @@
+package getnodestats
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type Handler struct {
+	nodeRepo  repositories.NodeRepository
+	statRepo  repositories.NodeStatRepository
+	responder base.Responder
+}
+
+func NewHandler(
+	nodeRepo repositories.NodeRepository,
+	statRepo repositories.NodeStatRepository,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		nodeRepo:  nodeRepo,
+		statRepo:  statRepo,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	nodeID, err := api.NewInputReader(r).ReadUint("id")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid node id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	in, err := readInput(r, time.Now())
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to read input"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	nodes, err := h.nodeRepo.Find(ctx, &filters.FindNode{
+		IDs: []uint{nodeID},
+	}, nil, &filters.Pagination{
+		Limit: 1,
+	})
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find node"))
+
+		return
+	}
+
+	if len(nodes) == 0 {
+		h.responder.WriteError(ctx, rw, api.NewNotFoundError("node not found"))
+
+		return
+	}
+
+	stats, err := h.statRepo.Find(ctx, &filters.FindNodeStat{
+		NodeIDs:  []uint{nodeID},
+		TimeFrom: &in.From,
+		TimeTo:   &in.To,
+	}, []filters.Sorting{
+		{Field: "time", Direction: filters.SortDirectionAsc},
+	}, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find node stats"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newStatsResponse(in, stats))
+}
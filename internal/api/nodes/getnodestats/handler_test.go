@@ -0,0 +1,233 @@
+package getnodestats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/daemon/fakedaemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/nodestats"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser = domain.User{
+	ID:    1,
+	Login: "admin",
+	Email: "admin@example.com",
+}
+
+func setupRepos(t *testing.T, now time.Time) (*inmemory.NodeRepository, *inmemory.NodeStatRepository) {
+	t.Helper()
+
+	nodeRepo := inmemory.NewNodeRepository()
+	statRepo := inmemory.NewNodeStatRepository()
+
+	require.NoError(t, nodeRepo.Save(context.Background(), &domain.Node{
+		ID:       1,
+		Enabled:  true,
+		Name:     "Test Node",
+		OS:       domain.NodeOSLinux,
+		WorkPath: "/srv/gameap",
+	}))
+
+	stats := []domain.NodeStat{
+		{
+			NodeID:  1,
+			Time:    now.Add(-10 * time.Minute),
+			Load:    domain.NodeLoadAverage{0.5, 0.4, 0.3},
+			CPU:     12.5,
+			RAM:     domain.NodeStatUsage{Used: 1024, Total: 4096},
+			Disk:    domain.NodeStatUsage{Used: 2048, Total: 8192},
+			Network: domain.NodeStatTraffic{RX: 1000, TX: 500},
+			Ping:    15,
+		},
+		{
+			NodeID:  1,
+			Time:    now.Add(-5 * time.Minute),
+			Network: domain.NodeStatTraffic{RX: 31000, TX: 3500},
+		},
+		{
+			NodeID:  1,
+			Time:    now.Add(-48 * time.Hour),
+			Network: domain.NodeStatTraffic{RX: 1, TX: 1},
+		},
+		{
+			NodeID: 2,
+			Time:   now.Add(-5 * time.Minute),
+		},
+	}
+
+	for i := range stats {
+		require.NoError(t, statRepo.Save(context.Background(), &stats[i]))
+	}
+
+	return nodeRepo, statRepo
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		nodeID         string
+		query          string
+		ctx            context.Context
+		expectedStatus int
+		wantError      string
+		wantPoints     int
+	}{
+		{
+			name:           "user not authenticated",
+			nodeID:         "1",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "invalid node id",
+			nodeID:         "invalid",
+			ctx:            apitesting.ContextWithUser(&testUser),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid node id",
+		},
+		{
+			name:           "node not found",
+			nodeID:         "999",
+			ctx:            apitesting.ContextWithUser(&testUser),
+			expectedStatus: http.StatusNotFound,
+			wantError:      "node not found",
+		},
+		{
+			name:           "invalid time",
+			nodeID:         "1",
+			query:          "to=tomorrow",
+			ctx:            apitesting.ContextWithUser(&testUser),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid to value",
+		},
+		{
+			name:           "from after to",
+			nodeID:         "1",
+			query:          "from=" + now.Format(time.RFC3339) + "&to=" + now.Add(-time.Hour).Format(time.RFC3339),
+			ctx:            apitesting.ContextWithUser(&testUser),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "from must be before to",
+		},
+		{
+			name:           "default range is the last day",
+			nodeID:         "1",
+			ctx:            apitesting.ContextWithUser(&testUser),
+			expectedStatus: http.StatusOK,
+			wantPoints:     2,
+		},
+		{
+			name:           "custom range",
+			nodeID:         "1",
+			query:          "from=" + now.Add(-72*time.Hour).Format(time.RFC3339),
+			ctx:            apitesting.ContextWithUser(&testUser),
+			expectedStatus: http.StatusOK,
+			wantPoints:     3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeRepo, statRepo := setupRepos(t, now)
+			handler := NewHandler(nodeRepo, statRepo, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/nodes/"+tt.nodeID+"/stats?"+tt.query, nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.nodeID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response statsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Len(t, response.Points, tt.wantPoints)
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_Points(t *testing.T) {
+	now := time.Now()
+	nodeRepo, statRepo := setupRepos(t, now)
+	handler := NewHandler(nodeRepo, statRepo, api.NewResponder())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/nodes/1/stats", nil)
+	req = req.WithContext(apitesting.ContextWithUser(&testUser))
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response statsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Points, 2)
+
+	first := response.Points[0]
+	assert.Equal(t, [3]float64{0.5, 0.4, 0.3}, first.Load)
+	assert.InDelta(t, 12.5, first.CPUPercent, 0.001)
+	assert.Equal(t, usageResponse{Used: 1024, Total: 4096}, first.RAM)
+	assert.Equal(t, usageResponse{Used: 2048, Total: 8192}, first.Disk)
+	assert.Equal(t, uint(15), first.PingMS)
+	assert.Nil(t, first.Network.RXRate)
+	assert.Nil(t, first.Network.TXRate)
+
+	// 30000 and 3000 bytes in 5 minutes
+	second := response.Points[1]
+	require.NotNil(t, second.Network.RXRate)
+	require.NotNil(t, second.Network.TXRate)
+	assert.InDelta(t, 100, *second.Network.RXRate, 0.001)
+	assert.InDelta(t, 10, *second.Network.TXRate, 0.001)
+}
+
+func TestHandler_FakeDaemon(t *testing.T) {
+	node := apitesting.NewFakeNode(t, "/srv/gameap", fakedaemon.WithDefaultCommandResult(fakedaemon.CommandResult{
+		Output: "::loadavg\n0.52 0.58 0.59 1/467 12345\n::meminfo\nMemTotal: 2048 kB\nMemAvailable: 512 kB\n",
+	}))
+	statRepo := inmemory.NewNodeStatRepository()
+
+	require.NoError(t, nodestats.NewCollector(node.Repo, statRepo, node.Commands, 0).Collect(context.Background()))
+
+	handler := NewHandler(node.Repo, statRepo, api.NewResponder())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/nodes/1/stats", nil)
+	req = req.WithContext(apitesting.ContextWithUser(&testUser))
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response statsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Points, 1)
+	assert.Equal(t, [3]float64{0.52, 0.58, 0.59}, response.Points[0].Load)
+	assert.Equal(t, usageResponse{Used: 1536 * 1024, Total: 2048 * 1024}, response.Points[0].RAM)
+}
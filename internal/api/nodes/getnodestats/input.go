@@ -0,0 +1,47 @@
+package getnodestats
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+const defaultRange = 24 * time.Hour
+
+type input struct {
+	From time.Time
+	To   time.Time
+}
+
+func readInput(r *http.Request, now time.Time) (*input, error) {
+	queryReader := api.NewQueryReader(r)
+
+	result := &input{
+		From: now.Add(-defaultRange),
+		To:   now,
+	}
+
+	from, err := queryReader.ReadTime("from")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid from value")
+	}
+	if from != nil {
+		result.From = *from
+	}
+
+	to, err := queryReader.ReadTime("to")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid to value")
+	}
+	if to != nil {
+		result.To = *to
+	}
+
+	if !result.From.Before(result.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	return result, nil
+}
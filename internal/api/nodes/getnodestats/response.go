@@ -0,0 +1,94 @@
+package getnodestats
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type statsResponse struct {
+	From   time.Time       `json:"from"`
+	To     time.Time       `json:"to"`
+	Points []pointResponse `json:"points"`
+}
+
+type pointResponse struct {
+	Time       time.Time       `json:"time"`
+	Load       [3]float64      `json:"load"`
+	CPUPercent float64         `json:"cpu_percent"`
+	RAM        usageResponse   `json:"ram"`
+	Disk       usageResponse   `json:"disk"`
+	Network    networkResponse `json:"network"`
+	PingMS     uint            `json:"ping_ms"`
+}
+
+type usageResponse struct {
+	Used  uint64 `json:"used"`
+	Total uint64 `json:"total"`
+}
+
+type networkResponse struct {
+	RXBytes uint64 `json:"rx_bytes"`
+	TXBytes uint64 `json:"tx_bytes"`
+
+	// Bytes per second since the previous point, nil for the first point and after counters reset.
+	RXRate *float64 `json:"rx_rate"`
+	TXRate *float64 `json:"tx_rate"`
+}
+
+func newStatsResponse(in *input, stats []domain.NodeStat) statsResponse {
+	response := statsResponse{
+		From:   in.From,
+		To:     in.To,
+		Points: make([]pointResponse, 0, len(stats)),
+	}
+
+	for i, stat := range stats {
+		point := pointResponse{
+			Time:       stat.Time,
+			Load:       stat.Load,
+			CPUPercent: float64(stat.CPU),
+			RAM: usageResponse{
+				Used:  stat.RAM.Used,
+				Total: stat.RAM.Total,
+			},
+			Disk: usageResponse{
+				Used:  stat.Disk.Used,
+				Total: stat.Disk.Total,
+			},
+			Network: networkResponse{
+				RXBytes: stat.Network.RX,
+				TXBytes: stat.Network.TX,
+			},
+			PingMS: stat.Ping,
+		}
+
+		if i > 0 {
+			point.Network.RXRate, point.Network.TXRate = trafficRates(&stats[i-1], &stat)
+		}
+
+		response.Points = append(response.Points, point)
+	}
+
+	return response
+}
+
+func trafficRates(previous, current *domain.NodeStat) (*float64, *float64) {
+	seconds := current.Time.Sub(previous.Time).Seconds()
+	if seconds <= 0 {
+		return nil, nil
+	}
+
+	return rate(previous.Network.RX, current.Network.RX, seconds), rate(previous.Network.TX, current.Network.TX, seconds)
+}
+
+func rate(previous, current uint64, seconds float64) *float64 {
+	// Counters are reset after the node reboot
+	if current < previous {
+		return nil
+	}
+
+	value := float64(current-previous) / seconds
+
+	return &value
+}
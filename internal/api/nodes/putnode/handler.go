@@ -25,20 +25,27 @@ var (
 	ErrNodeNotFound            = errors.New("node not found")
 )
 
+type daemonPools interface {
+	Invalidate(nodeID uint)
+}
+
 type Handler struct {
 	repo        repositories.NodeRepository
 	fileManager files.FileManager
+	daemonPools daemonPools
 	responder   base.Responder
 }
 
 func NewHandler(
 	repo repositories.NodeRepository,
 	fileManager files.FileManager,
+	daemonPools daemonPools,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
 		repo:        repo,
 		fileManager: fileManager,
+		daemonPools: daemonPools,
 		responder:   responder,
 	}
 }
@@ -93,6 +100,9 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Connections to the daemon may use the old host, port or certificates
+	h.daemonPools.Invalidate(updatedNode.ID)
+
 	response := newNodeResponse(updatedNode)
 	h.responder.Write(ctx, rw, response)
 }
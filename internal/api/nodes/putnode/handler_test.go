@@ -42,6 +42,14 @@ xNCggoLtZL8pvX+CQATnEIEEhdvRyi3hD9/mYh94LMfPxjiQOzMuOYH+y9iPnx5b
 s1PL2QMvr5M=
 -----END CERTIFICATE-----`
 
+type mockDaemonPools struct {
+	invalidated []uint
+}
+
+func (m *mockDaemonPools) Invalidate(nodeID uint) {
+	m.invalidated = append(m.invalidated, nodeID)
+}
+
 func TestHandler_ServeHTTP(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -451,7 +459,8 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				tt.setupFileManager(fileManager)
 			}
 
-			handler := NewHandler(repo, fileManager, responder)
+			daemonPools := &mockDaemonPools{}
+			handler := NewHandler(repo, fileManager, daemonPools, responder)
 
 			body, err := json.Marshal(tt.input)
 			require.NoError(t, err)
@@ -469,6 +478,9 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				var response map[string]any
 				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
 				assert.Equal(t, "error", response["status"])
+				assert.Empty(t, daemonPools.invalidated)
+			} else {
+				assert.Equal(t, []uint{tt.nodeID}, daemonPools.invalidated)
 			}
 
 			if tt.validateResponse != nil {
@@ -518,7 +530,7 @@ func TestHandler_UpdatedAtTimestamp(t *testing.T) {
 		UpdatedAt:           &oldTime,
 	})
 
-	handler := NewHandler(repo, fileManager, responder)
+	handler := NewHandler(repo, fileManager, &mockDaemonPools{}, responder)
 
 	input := updateNodeInput{
 		Name: lo.ToPtr("Updated Name"),
@@ -576,7 +588,7 @@ func TestHandler_CertificateFileCleanup(t *testing.T) {
 		UpdatedAt:           &now,
 	})
 
-	handler := NewHandler(repo, fileManager, responder)
+	handler := NewHandler(repo, fileManager, &mockDaemonPools{}, responder)
 
 	input := updateNodeInput{
 		GdaemonServerCert: lo.ToPtr(validCertPEM),
@@ -0,0 +1,38 @@
+package base
+
+import (
+	"net/http"
+
+	rconbase "github.com/gameap/gameap/internal/api/servers/rcon/base"
+	"github.com/gameap/gameap/internal/services/playerbans"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/pkg/errors"
+)
+
+// WrapServiceError sets the HTTP status of the player bans service errors.
+// Errors of the RCON executor are mapped with rconbase.WrapExecutorError.
+func WrapServiceError(err error) error {
+	switch {
+	case errors.Is(err, playerbans.ErrBanNotFound):
+		return api.WrapHTTPError(err, http.StatusNotFound)
+	case errors.Is(err, playerbans.ErrBanNotActive):
+		return api.WrapHTTPError(err, http.StatusConflict)
+	case errors.Is(err, players.ErrPlayersManagementNotSupported):
+		return api.WrapHTTPError(
+			errors.WithMessage(err, "player management not supported for this game"),
+			http.StatusNotImplemented,
+		)
+	case errors.Is(err, players.ErrPlayerNameRequired),
+		errors.Is(err, players.ErrPlayerUniqIDRequired),
+		errors.Is(err, players.ErrInvalidPlayerName),
+		errors.Is(err, players.ErrInvalidPlayerUniqID):
+		return api.WrapHTTPError(err, http.StatusUnprocessableEntity)
+	case errors.Is(err, playerbans.ErrBanListPathRequired),
+		errors.Is(err, playerbans.ErrInvalidBanListPath),
+		errors.Is(err, playerbans.ErrUnsupportedBanListFormat):
+		return api.WrapHTTPError(err, http.StatusBadRequest)
+	default:
+		return rconbase.WrapExecutorError(err)
+	}
+}
@@ -0,0 +1,51 @@
+package base
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type BanResponse struct {
+	ID        uint       `json:"id"`
+	ServerID  uint       `json:"server_id"`
+	UniqID    string     `json:"uniq_id"`
+	Name      string     `json:"name"`
+	Reason    string     `json:"reason"`
+	Source    string     `json:"source"`
+	IssuerID  *uint      `json:"issuer_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	LiftedAt  *time.Time `json:"lifted_at"`
+	LiftedBy  *uint      `json:"lifted_by"`
+	Permanent bool       `json:"permanent"`
+	Active    bool       `json:"active"`
+}
+
+func NewBanResponse(ban *domain.PlayerBan, now time.Time) BanResponse {
+	return BanResponse{
+		ID:        ban.ID,
+		ServerID:  ban.ServerID,
+		UniqID:    ban.UniqID,
+		Name:      ban.Name,
+		Reason:    ban.Reason,
+		Source:    string(ban.Source),
+		IssuerID:  ban.IssuerID,
+		CreatedAt: ban.CreatedAt,
+		ExpiresAt: ban.ExpiresAt,
+		LiftedAt:  ban.LiftedAt,
+		LiftedBy:  ban.LiftedBy,
+		Permanent: ban.IsPermanent(),
+		Active:    ban.IsActive(now),
+	}
+}
+
+func NewBansResponse(bans []domain.PlayerBan, now time.Time) []BanResponse {
+	response := make([]BanResponse, 0, len(bans))
+
+	for i := range bans {
+		response = append(response, NewBanResponse(&bans[i], now))
+	}
+
+	return response
+}
@@ -0,0 +1,162 @@
+package deleteplayerban
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/playerbans"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func setupBanRepo(t *testing.T) *inmemory.PlayerBanRepository {
+	t.Helper()
+
+	now := time.Now()
+	banRepo := inmemory.NewPlayerBanRepository()
+
+	bans := []domain.PlayerBan{
+		{ServerID: 1, UniqID: "STEAM_0:1:1", Source: domain.PlayerBanSourcePanel, CreatedAt: now.Add(-time.Hour)},
+		{
+			ServerID:  1,
+			UniqID:    "STEAM_0:1:2",
+			Source:    domain.PlayerBanSourcePanel,
+			CreatedAt: now.Add(-time.Hour),
+			LiftedAt:  lo.ToPtr(now.Add(-time.Minute)),
+		},
+		{ServerID: 2, UniqID: "STEAM_0:1:3", Source: domain.PlayerBanSourcePanel, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	for i := range bans {
+		require.NoError(t, banRepo.Save(context.Background(), &bans[i]))
+	}
+
+	return banRepo
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		banID          string
+		ctx            context.Context
+		allowAbility   bool
+		expectedStatus int
+		wantError      string
+		wantCommands   []string
+	}{
+		{
+			name:           "user not authenticated",
+			banID:          "1",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "user does not have players ability",
+			banID:          "1",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "ban of another server",
+			banID:          "3",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "ban not found",
+		},
+		{
+			name:           "already lifted",
+			banID:          "2",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusConflict,
+			wantError:      "ban is not active",
+		},
+		{
+			name:           "lift ban",
+			banID:          "1",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNoContent,
+			wantCommands:   []string{"removeid STEAM_0:1:1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverRepo := apitesting.NewServerRepository(t, testUser1.ID, apitesting.NewServer("cstrike"))
+			banRepo := setupBanRepo(t)
+			executor := &apitesting.Executor{}
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			service := playerbans.NewService(banRepo, serverRepo, inmemory.NewNodeRepository(), newGameFinder(t), executor, nil, 0)
+			handler := NewHandler(serverRepo, service, apitesting.NewNodeHealthChecker(t), rbacService, api.NewResponder())
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/servers/1/bans/"+tt.banID, nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1", "id": tt.banID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.wantCommands, executor.Commands)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			bans, err := banRepo.Find(context.Background(), &filters.FindPlayerBan{IDs: []uint{1}}, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, bans, 1)
+			require.NotNil(t, bans[0].LiftedAt)
+			assert.Equal(t, lo.ToPtr(testUser1.ID), bans[0].LiftedBy)
+		})
+	}
+}
+
+func newGameFinder(t *testing.T) *serversbase.GameFinder {
+	t.Helper()
+
+	gameRepo := inmemory.NewGameRepository()
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "cstrike",
+		Name:   "Counter-Strike",
+		Engine: "GoldSource",
+	}))
+
+	return serversbase.NewGameFinder(gameRepo, inmemory.NewGameModRepository())
+}
@@ -0,0 +1,185 @@
+package getplayerbans
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	playerbansbase "github.com/gameap/gameap/internal/api/playerbans/base"
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/playerbans"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func setupRepos(t *testing.T, now time.Time) (*inmemory.ServerRepository, *inmemory.PlayerBanRepository) {
+	t.Helper()
+
+	serverRepo := inmemory.NewServerRepository()
+	banRepo := inmemory.NewPlayerBanRepository()
+
+	require.NoError(t, serverRepo.Save(context.Background(), &domain.Server{
+		ID:         1,
+		UUID:       uuid.New(),
+		UUIDShort:  "short1",
+		Enabled:    true,
+		Installed:  domain.ServerInstalledStatusInstalled,
+		Name:       "Test Server",
+		GameID:     "cstrike",
+		DSID:       1,
+		ServerIP:   "127.0.0.1",
+		ServerPort: 27015,
+	}))
+	serverRepo.AddUserServer(testUser1.ID, 1)
+
+	bans := []domain.PlayerBan{
+		{ServerID: 1, UniqID: "STEAM_0:1:1", CreatedAt: now.Add(-3 * time.Hour)},
+		{
+			ServerID:  1,
+			UniqID:    "STEAM_0:1:2",
+			CreatedAt: now.Add(-2 * time.Hour),
+			ExpiresAt: lo.ToPtr(now.Add(-time.Hour)),
+			LiftedAt:  lo.ToPtr(now.Add(-time.Hour)),
+		},
+		{ServerID: 1, UniqID: "STEAM_0:1:3", CreatedAt: now.Add(-time.Hour), ExpiresAt: lo.ToPtr(now.Add(time.Hour))},
+		{ServerID: 2, UniqID: "STEAM_0:1:4", CreatedAt: now.Add(-time.Hour)},
+	}
+
+	for i := range bans {
+		require.NoError(t, banRepo.Save(context.Background(), &bans[i]))
+	}
+
+	return serverRepo, banRepo
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name           string
+		serverID       string
+		query          string
+		ctx            context.Context
+		allowAbility   bool
+		expectedStatus int
+		wantError      string
+		wantUniqIDs    []string
+	}{
+		{
+			name:           "user not authenticated",
+			serverID:       "1",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "invalid server id",
+			serverID:       "invalid",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid server id",
+		},
+		{
+			name:           "server not found",
+			serverID:       "999",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "user does not have players ability",
+			serverID:       "1",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "invalid active value",
+			serverID:       "1",
+			query:          "active=maybe",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid active value",
+		},
+		{
+			name:           "all bans",
+			serverID:       "1",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantUniqIDs:    []string{"STEAM_0:1:3", "STEAM_0:1:2", "STEAM_0:1:1"},
+		},
+		{
+			name:           "active bans",
+			serverID:       "1",
+			query:          "active=1",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantUniqIDs:    []string{"STEAM_0:1:3", "STEAM_0:1:1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverRepo, banRepo := setupRepos(t, now)
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			service := playerbans.NewService(banRepo, serverRepo, inmemory.NewNodeRepository(), nil, nil, nil, 0)
+			handler := NewHandler(serverRepo, service, rbacService, api.NewResponder())
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/"+tt.serverID+"/bans?"+tt.query, nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response []playerbansbase.BanResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			uniqIDs := lo.Map(response, func(b playerbansbase.BanResponse, _ int) string { return b.UniqID })
+			assert.Equal(t, tt.wantUniqIDs, uniqIDs)
+
+			for _, ban := range response {
+				assert.Equal(t, ban.LiftedAt == nil, ban.Active)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package getplayerbans
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+type input struct {
+	// Active selects only bans which are not lifted and not expired.
+	Active bool
+}
+
+func readInput(r *http.Request) (*input, error) {
+	active, err := api.NewQueryReader(r).ReadString("active")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid active value")
+	}
+
+	result := &input{}
+
+	if active != "" {
+		result.Active, err = strconv.ParseBool(active)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid active value")
+		}
+	}
+
+	return result, nil
+}
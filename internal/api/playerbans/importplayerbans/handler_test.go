@@ -0,0 +1,177 @@
+package importplayerbans
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	playerbansbase "github.com/gameap/gameap/internal/api/playerbans/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/playerbans"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		ctx            context.Context
+		allowAbility   bool
+		nodeOffline    bool
+		expectedStatus int
+		wantError      string
+		wantImported   []string
+		wantSkipped    int
+	}{
+		{
+			name:           "user not authenticated",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "user does not have players ability",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "node offline",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			nodeOffline:    true,
+			expectedStatus: http.StatusServiceUnavailable,
+			wantError:      "Service Unavailable",
+		},
+		{
+			name:           "invalid path",
+			body:           `{"path":"../banned.cfg"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid ban list path",
+		},
+		{
+			name:           "unsupported format",
+			body:           `{"path":"cstrike/listip.txt"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "unsupported ban list format",
+		},
+		{
+			name:           "file not found",
+			body:           `{"path":"cstrike/other.cfg"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusInternalServerError,
+			wantError:      "Internal Server Error",
+		},
+		{
+			name:           "default ban list without body",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantImported:   []string{"STEAM_0:1:1", "STEAM_0:1:2"},
+		},
+		{
+			name:           "default ban list with empty path",
+			body:           `{"path":""}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantImported:   []string{"STEAM_0:1:1", "STEAM_0:1:2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apitesting.NewServer("cstrike")
+			server.Dir = "servers/test"
+			serverRepo := apitesting.NewServerRepository(t, testUser1.ID, server)
+			banRepo := inmemory.NewPlayerBanRepository()
+			node := apitesting.NewFakeNode(t, "/srv/gameap")
+			node.WriteFiles(t, map[string][]byte{
+				"/srv/gameap/servers/test/cstrike/banned.cfg": []byte("banid 0 STEAM_0:1:1\nbanid 0 STEAM_0:1:2\n"),
+			})
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			service := playerbans.NewService(
+				banRepo, serverRepo, node.Repo, newGameFinder(t), &apitesting.Executor{}, node.Files, 0,
+			)
+			nodeHealth := apitesting.NewNodeHealthChecker(t)
+			if tt.nodeOffline {
+				nodeHealth = apitesting.NewNodeHealthChecker(t, apitesting.OfflineNodeHealth(server.DSID))
+			}
+			handler := NewHandler(serverRepo, service, nodeHealth, rbacService, api.NewResponder())
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/servers/1/bans/import", strings.NewReader(tt.body))
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1"})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response importResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "cstrike/banned.cfg", response.Path)
+			assert.Equal(t, tt.wantSkipped, response.Skipped)
+
+			imported := lo.Map(response.Imported, func(b playerbansbase.BanResponse, _ int) string { return b.UniqID })
+			assert.Equal(t, tt.wantImported, imported)
+
+			for _, ban := range response.Imported {
+				assert.Equal(t, "import", ban.Source)
+				assert.True(t, ban.Permanent)
+			}
+		})
+	}
+}
+
+func newGameFinder(t *testing.T) *serversbase.GameFinder {
+	t.Helper()
+
+	gameRepo := inmemory.NewGameRepository()
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "cstrike",
+		Name:   "Counter-Strike",
+		Engine: "GoldSource",
+	}))
+
+	return serversbase.NewGameFinder(gameRepo, inmemory.NewGameModRepository())
+}
@@ -0,0 +1,23 @@
+package importplayerbans
+
+import (
+	"github.com/gameap/gameap/pkg/api"
+)
+
+const maxPathLength = 1024
+
+var ErrPathIsTooLong = api.NewValidationError("path must not exceed 1024 characters")
+
+type importInput struct {
+	// Path is the ban list file relative to the server directory.
+	// The default ban list of the game is imported when it's empty.
+	Path string `json:"path"`
+}
+
+func (in *importInput) Validate() error {
+	if len(in.Path) > maxPathLength {
+		return ErrPathIsTooLong
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package importplayerbans
+
+import (
+	"time"
+
+	playerbansbase "github.com/gameap/gameap/internal/api/playerbans/base"
+	"github.com/gameap/gameap/internal/services/playerbans"
+)
+
+type importResponse struct {
+	Path     string                       `json:"path"`
+	Imported []playerbansbase.BanResponse `json:"imported"`
+	Skipped  int                          `json:"skipped"`
+}
+
+func newImportResponse(result *playerbans.ImportResult, now time.Time) importResponse {
+	return importResponse{
+		Path:     result.Path,
+		Imported: playerbansbase.NewBansResponse(result.Imported, now),
+		Skipped:  result.Skipped,
+	}
+}
@@ -0,0 +1,209 @@
+package postplayerban
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	playerbansbase "github.com/gameap/gameap/internal/api/playerbans/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/playerbans"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverID       string
+		body           string
+		ctx            context.Context
+		allowAbility   bool
+		expectedStatus int
+		wantError      string
+		wantCommand    string
+		wantPermanent  bool
+	}{
+		{
+			name:           "user not authenticated",
+			serverID:       "1",
+			body:           `{"uniq_id":"STEAM_0:1:1"}`,
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "user does not have players ability",
+			serverID:       "1",
+			body:           `{"uniq_id":"STEAM_0:1:1"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "invalid body",
+			serverID:       "1",
+			body:           `{`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid request body",
+		},
+		{
+			name:           "player is required",
+			serverID:       "1",
+			body:           `{"reason":"cheater"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "uniq_id or name is required",
+		},
+		{
+			name:           "negative duration",
+			serverID:       "1",
+			body:           `{"uniq_id":"STEAM_0:1:1","duration":-5}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "duration must not be negative",
+		},
+		{
+			name:           "uniq_id with command separator",
+			serverID:       "1",
+			body:           `{"uniq_id":"STEAM_0:1:1; quit"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "uniq_id must be a SteamID, UUID or platform ID",
+		},
+		{
+			name:           "name with line break",
+			serverID:       "1",
+			body:           `{"name":"alice\nop alice"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "name must be a player name",
+		},
+		{
+			name:           "invalid uniq_id for the game",
+			serverID:       "1",
+			body:           `{"uniq_id":"Steam_76561198000000001"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "invalid player unique ID",
+		},
+		{
+			name:           "identifier required by the game is missing",
+			serverID:       "1",
+			body:           `{"name":"alice"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "player unique ID is required",
+		},
+		{
+			name:           "permanent ban",
+			serverID:       "1",
+			body:           `{"uniq_id":"STEAM_0:1:1","reason":"cheater"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusCreated,
+			wantCommand:    `banid 0 STEAM_0:1:1; kick STEAM_0:1:1 "cheater"`,
+			wantPermanent:  true,
+		},
+		{
+			name:           "temporary ban",
+			serverID:       "1",
+			body:           `{"uniq_id":"STEAM_0:1:1","duration":"30"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusCreated,
+			wantCommand:    "banid 30 STEAM_0:1:1 kick",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverRepo := apitesting.NewServerRepository(t, testUser1.ID, apitesting.NewServer("cstrike"))
+			banRepo := inmemory.NewPlayerBanRepository()
+			executor := &apitesting.Executor{}
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			service := playerbans.NewService(banRepo, serverRepo, inmemory.NewNodeRepository(), newGameFinder(t), executor, nil, 0)
+			handler := NewHandler(serverRepo, service, apitesting.NewNodeHealthChecker(t), rbacService, api.NewResponder())
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/servers/"+tt.serverID+"/bans", strings.NewReader(tt.body))
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			bans, err := banRepo.Find(context.Background(), nil, nil, nil)
+			require.NoError(t, err)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+				assert.Empty(t, bans)
+				assert.Empty(t, executor.Commands)
+
+				return
+			}
+
+			assert.Equal(t, []string{tt.wantCommand}, executor.Commands)
+
+			var response playerbansbase.BanResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "panel", response.Source)
+			assert.Equal(t, lo.ToPtr(testUser1.ID), response.IssuerID)
+			assert.Equal(t, tt.wantPermanent, response.Permanent)
+			assert.True(t, response.Active)
+
+			require.Len(t, bans, 1)
+			assert.Equal(t, response.ID, bans[0].ID)
+		})
+	}
+}
+
+func newGameFinder(t *testing.T) *serversbase.GameFinder {
+	t.Helper()
+
+	gameRepo := inmemory.NewGameRepository()
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "cstrike",
+		Name:   "Counter-Strike",
+		Engine: "GoldSource",
+	}))
+
+	return serversbase.NewGameFinder(gameRepo, inmemory.NewGameModRepository())
+}
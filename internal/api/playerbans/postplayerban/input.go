@@ -0,0 +1,93 @@
+package postplayerban
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/flexible"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/samber/lo"
+)
+
+const (
+	maxFieldLength  = 255
+	maxReasonLength = 1024
+)
+
+var (
+	ErrPlayerIsRequired = api.NewValidationError("uniq_id or name is required")
+	ErrUniqIDIsTooLong  = api.NewValidationError("uniq_id must not exceed 255 characters")
+	ErrInvalidUniqID    = api.NewValidationError("uniq_id must be a SteamID, UUID or platform ID")
+	ErrNameIsTooLong    = api.NewValidationError("name must not exceed 255 characters")
+	ErrInvalidName      = api.NewValidationError(
+		"name must be a player name of 1 to 16 letters, digits or underscores when uniq_id is not set",
+	)
+	ErrNameHasControlChars = api.NewValidationError("name must not contain control characters")
+	ErrReasonIsTooLong     = api.NewValidationError("reason must not exceed 1024 characters")
+	ErrInvalidDuration     = api.NewValidationError("duration must not be negative")
+)
+
+type banInput struct {
+	UniqID string `json:"uniq_id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+
+	// Duration is the ban duration in minutes, zero for a permanent ban.
+	Duration flexible.Int `json:"duration"`
+}
+
+func (in *banInput) Validate() error {
+	if in.UniqID == "" && in.Name == "" {
+		return ErrPlayerIsRequired
+	}
+
+	if len(in.UniqID) > maxFieldLength {
+		return ErrUniqIDIsTooLong
+	}
+
+	if in.UniqID != "" && !players.IsValidUniqID(in.UniqID) {
+		return ErrInvalidUniqID
+	}
+
+	if len(in.Name) > maxFieldLength {
+		return ErrNameIsTooLong
+	}
+
+	// Without the unique ID, the player is banned by name
+	if in.UniqID == "" && !players.IsValidMinecraftName(in.Name) {
+		return ErrInvalidName
+	}
+
+	if strings.IndexFunc(in.Name, unicode.IsControl) != -1 {
+		return ErrNameHasControlChars
+	}
+
+	if len(in.Reason) > maxReasonLength {
+		return ErrReasonIsTooLong
+	}
+
+	if in.Duration.Int() < 0 {
+		return ErrInvalidDuration
+	}
+
+	return nil
+}
+
+func (in *banInput) ToDomain(issuerID uint, now time.Time) *domain.PlayerBan {
+	ban := &domain.PlayerBan{
+		UniqID:   in.UniqID,
+		Name:     in.Name,
+		Reason:   in.Reason,
+		Source:   domain.PlayerBanSourcePanel,
+		IssuerID: &issuerID,
+	}
+
+	if minutes := in.Duration.Int(); minutes > 0 {
+		ban.ExpiresAt = lo.ToPtr(now.Add(time.Duration(minutes) * time.Minute))
+	}
+
+	return ban
+}
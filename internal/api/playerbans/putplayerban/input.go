@@ -0,0 +1,47 @@
+package putplayerban
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/flexible"
+	"github.com/samber/lo"
+)
+
+const maxReasonLength = 1024
+
+var (
+	ErrReasonIsTooLong = api.NewValidationError("reason must not exceed 1024 characters")
+	ErrInvalidDuration = api.NewValidationError("duration must not be negative")
+	ErrBanAlreadyEnded = api.NewValidationError("ban with the duration would already be expired, lift it instead")
+)
+
+type banInput struct {
+	Reason string `json:"reason"`
+
+	// Duration is the new ban duration in minutes counted from the ban creation,
+	// zero for a permanent ban.
+	Duration flexible.Int `json:"duration"`
+}
+
+func (in *banInput) Validate() error {
+	if len(in.Reason) > maxReasonLength {
+		return ErrReasonIsTooLong
+	}
+
+	if in.Duration.Int() < 0 {
+		return ErrInvalidDuration
+	}
+
+	return nil
+}
+
+// ExpiresAt returns the expiration time of the ban created at the given time.
+func (in *banInput) ExpiresAt(createdAt time.Time) *time.Time {
+	minutes := in.Duration.Int()
+	if minutes <= 0 {
+		return nil
+	}
+
+	return lo.ToPtr(createdAt.Add(time.Duration(minutes) * time.Minute))
+}
@@ -0,0 +1,63 @@
+package getplayers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/services/playersessions"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+// Handler looks up players on all servers by unique ID or name.
+type Handler struct {
+	sessions  *playersessions.Service
+	responder base.Responder
+}
+
+func NewHandler(
+	sessions *playersessions.Service,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		sessions:  sessions,
+		responder: responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	now := time.Now()
+
+	in, err := readInput(r, now)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to read input"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	sessions, err := h.sessions.Lookup(ctx, in.UniqIDs, in.Names, in.From, in.To)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find players"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newPlayersResponse(in, sessions, now))
+}
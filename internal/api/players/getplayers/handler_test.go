@@ -0,0 +1,173 @@
+package getplayers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/playersessions"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "admin",
+	Email: "admin@example.com",
+}
+
+func setupService(t *testing.T, now time.Time) *playersessions.Service {
+	t.Helper()
+
+	repo := inmemory.NewPlayerSessionRepository()
+
+	sessions := []domain.PlayerSession{
+		{
+			ServerID:   1,
+			UniqID:     "STEAM_1",
+			Name:       "alice",
+			JoinedAt:   now.Add(-3 * time.Hour),
+			LeftAt:     lo.ToPtr(now.Add(-2 * time.Hour)),
+			LastSeenAt: now.Add(-2 * time.Hour),
+		},
+		{
+			ServerID:   2,
+			UniqID:     "STEAM_1",
+			Name:       "alice, the second",
+			JoinedAt:   now.Add(-time.Hour),
+			LastSeenAt: now,
+		},
+		{
+			ServerID:   1,
+			Name:       "bob",
+			JoinedAt:   now.Add(-time.Hour),
+			LastSeenAt: now,
+		},
+		{
+			ServerID:   1,
+			UniqID:     "STEAM_1",
+			Name:       "alice",
+			JoinedAt:   now.Add(-60 * 24 * time.Hour),
+			LeftAt:     lo.ToPtr(now.Add(-60*24*time.Hour + time.Hour)),
+			LastSeenAt: now.Add(-60*24*time.Hour + time.Hour),
+		},
+	}
+
+	for i := range sessions {
+		require.NoError(t, repo.Save(context.Background(), &sessions[i]))
+	}
+
+	return playersessions.NewService(repo)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name           string
+		query          string
+		ctx            context.Context
+		expectedStatus int
+		wantError      string
+		wantNames      []string
+		wantServerIDs  []uint
+	}{
+		{
+			name:           "user not authenticated",
+			query:          "uniq_id=STEAM_1",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "no player specified",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "uniq_id or name is required",
+		},
+		{
+			name:           "invalid time",
+			query:          "uniq_id=STEAM_1&to=tomorrow",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid to value",
+		},
+		{
+			name:           "by unique id",
+			query:          "uniq_id=STEAM_1",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusOK,
+			wantNames:      []string{"alice", "alice, the second"},
+			wantServerIDs:  []uint{2, 1},
+		},
+		{
+			name:           "by name with comma",
+			query:          "name=alice%2C+the+second",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusOK,
+			wantNames:      []string{"alice", "alice, the second"},
+			wantServerIDs:  []uint{2, 1},
+		},
+		{
+			name:           "player without unique id",
+			query:          "name=bob",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusOK,
+			wantNames:      []string{"bob"},
+			wantServerIDs:  []uint{1},
+		},
+		{
+			name:           "unknown player",
+			query:          "uniq_id=STEAM_2",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(setupService(t, now), api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/players?"+tt.query, nil)
+			req = req.WithContext(tt.ctx)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response playersResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			if tt.wantNames == nil {
+				assert.Empty(t, response.Players)
+				assert.Empty(t, response.Sessions)
+
+				return
+			}
+
+			require.Len(t, response.Players, 1)
+			assert.Equal(t, tt.wantNames, response.Players[0].Names)
+			assert.Equal(t, tt.wantServerIDs, response.Players[0].ServerIDs)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package getplayers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const defaultRange = 30 * 24 * time.Hour
+
+type input struct {
+	UniqIDs []string
+	Names   []string
+	From    time.Time
+	To      time.Time
+}
+
+func readInput(r *http.Request, now time.Time) (*input, error) {
+	queryReader := api.NewQueryReader(r)
+
+	result := &input{
+		From: now.Add(-defaultRange),
+		To:   now,
+	}
+
+	uniqIDs, err := queryReader.ReadList("uniq_id")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid uniq_id value")
+	}
+	result.UniqIDs = lo.Compact(lo.Map(uniqIDs, func(id string, _ int) string {
+		return strings.TrimSpace(id)
+	}))
+
+	// Player names may contain commas, so only one name is accepted
+	name, err := queryReader.ReadString("name")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid name value")
+	}
+	if name != "" {
+		result.Names = []string{name}
+	}
+
+	if len(result.UniqIDs) == 0 && len(result.Names) == 0 {
+		return nil, errors.New("uniq_id or name is required")
+	}
+
+	from, err := queryReader.ReadTime("from")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid from value")
+	}
+	if from != nil {
+		result.From = *from
+	}
+
+	to, err := queryReader.ReadTime("to")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid to value")
+	}
+	if to != nil {
+		result.To = *to
+	}
+
+	if !result.From.Before(result.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	return result, nil
+}
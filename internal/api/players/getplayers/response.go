@@ -0,0 +1,78 @@
+package getplayers
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/playersessions"
+)
+
+type playersResponse struct {
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	Players  []playerResponse  `json:"players"`
+	Sessions []sessionResponse `json:"sessions"`
+}
+
+type playerResponse struct {
+	UniqID        string    `json:"uniq_id"`
+	Names         []string  `json:"names"`
+	ServerIDs     []uint    `json:"server_ids"`
+	SessionsCount int       `json:"sessions_count"`
+	Playtime      int64     `json:"playtime"`
+	FirstSeenAt   time.Time `json:"first_seen_at"`
+	LastSeenAt    time.Time `json:"last_seen_at"`
+	Online        bool      `json:"online"`
+}
+
+type sessionResponse struct {
+	ID         uint       `json:"id"`
+	ServerID   uint       `json:"server_id"`
+	UniqID     string     `json:"uniq_id"`
+	Name       string     `json:"name"`
+	JoinedAt   time.Time  `json:"joined_at"`
+	LeftAt     *time.Time `json:"left_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	Duration   int64      `json:"duration"`
+}
+
+func newPlayersResponse(in *input, sessions []domain.PlayerSession, now time.Time) playersResponse {
+	summaries := playersessions.Summarize(sessions, now)
+
+	response := playersResponse{
+		From:     in.From,
+		To:       in.To,
+		Players:  make([]playerResponse, 0, len(summaries)),
+		Sessions: make([]sessionResponse, 0, len(sessions)),
+	}
+
+	for _, summary := range summaries {
+		response.Players = append(response.Players, playerResponse{
+			UniqID:        summary.UniqID,
+			Names:         summary.Names,
+			ServerIDs:     summary.ServerIDs,
+			SessionsCount: summary.SessionsCount,
+			Playtime:      int64(summary.Playtime.Seconds()),
+			FirstSeenAt:   summary.FirstSeenAt,
+			LastSeenAt:    summary.LastSeenAt,
+			Online:        summary.Online,
+		})
+	}
+
+	for i := range sessions {
+		session := &sessions[i]
+
+		response.Sessions = append(response.Sessions, sessionResponse{
+			ID:         session.ID,
+			ServerID:   session.ServerID,
+			UniqID:     session.UniqID,
+			Name:       session.Name,
+			JoinedAt:   session.JoinedAt,
+			LeftAt:     session.LeftAt,
+			LastSeenAt: session.LastSeenAt,
+			Duration:   int64(session.Duration(now).Seconds()),
+		})
+	}
+
+	return response
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/gameap/gameap/internal/api/clientcertificates/deleteclientcertificates"
 	"github.com/gameap/gameap/internal/api/clientcertificates/getclientcertificates"
 	"github.com/gameap/gameap/internal/api/clientcertificates/postclientcertificates"
+	"github.com/gameap/gameap/internal/api/commandpolicies/deletecommandpolicy"
+	"github.com/gameap/gameap/internal/api/commandpolicies/getcommandpolicies"
+	"github.com/gameap/gameap/internal/api/commandpolicies/postcommandpolicy"
+	"github.com/gameap/gameap/internal/api/commandpolicies/putcommandpolicy"
 	"github.com/gameap/gameap/internal/api/daemon/createnode"
 	"github.com/gameap/gameap/internal/api/daemon/daemonsetup"
 	"github.com/gameap/gameap/internal/api/daemonapi/getinitdata"
@@ -40,6 +44,9 @@ import (
 	filemanagertree "github.com/gameap/gameap/internal/api/filemanager/tree"
 	filemanagerupdatefile "github.com/gameap/gameap/internal/api/filemanager/updatefile"
 	"github.com/gameap/gameap/internal/api/filemanager/upload"
+	"github.com/gameap/gameap/internal/api/gameadmins/deleteadmin"
+	"github.com/gameap/gameap/internal/api/gameadmins/getadmins"
+	"github.com/gameap/gameap/internal/api/gameadmins/putadmin"
 	"github.com/gameap/gameap/internal/api/gamemods/deletegamemod"
 	"github.com/gameap/gameap/internal/api/gamemods/getgamemod"
 	"github.com/gameap/gameap/internal/api/gamemods/getgamemods"
@@ -55,27 +62,44 @@ import (
 	"github.com/gameap/gameap/internal/api/games/upgradegames"
 	"github.com/gameap/gameap/internal/api/gethealth"
 	"github.com/gameap/gameap/internal/api/middlewares"
+	"github.com/gameap/gameap/internal/api/minecraftaccess/deleteaccesslistentry"
+	"github.com/gameap/gameap/internal/api/minecraftaccess/getaccesslist"
+	"github.com/gameap/gameap/internal/api/minecraftaccess/postaccesslistentry"
 	"github.com/gameap/gameap/internal/api/nodes/deletenode"
 	"github.com/gameap/gameap/internal/api/nodes/getbusyports"
 	"github.com/gameap/gameap/internal/api/nodes/getcertificateszip"
+	"github.com/gameap/gameap/internal/api/nodes/getconnections"
 	"github.com/gameap/gameap/internal/api/nodes/getdaemonstatus"
 	"github.com/gameap/gameap/internal/api/nodes/getiplist"
 	"github.com/gameap/gameap/internal/api/nodes/getlogszip"
 	"github.com/gameap/gameap/internal/api/nodes/getnode"
 	"github.com/gameap/gameap/internal/api/nodes/getnodes"
+	"github.com/gameap/gameap/internal/api/nodes/getnodestats"
 	nodesgetsummary "github.com/gameap/gameap/internal/api/nodes/getsummary"
 	"github.com/gameap/gameap/internal/api/nodes/nodesetup"
 	"github.com/gameap/gameap/internal/api/nodes/postnode"
 	"github.com/gameap/gameap/internal/api/nodes/putnode"
+	"github.com/gameap/gameap/internal/api/playerbans/deleteplayerban"
+	"github.com/gameap/gameap/internal/api/playerbans/getplayerbans"
+	"github.com/gameap/gameap/internal/api/playerbans/importplayerbans"
+	"github.com/gameap/gameap/internal/api/playerbans/postplayerban"
+	"github.com/gameap/gameap/internal/api/playerbans/putplayerban"
+	playersgetplayers "github.com/gameap/gameap/internal/api/players/getplayers"
 	"github.com/gameap/gameap/internal/api/profile/getprofile"
 	"github.com/gameap/gameap/internal/api/profile/putprofile"
+	"github.com/gameap/gameap/internal/api/servermaps/getmaplist"
+	"github.com/gameap/gameap/internal/api/servermaps/getmaps"
+	"github.com/gameap/gameap/internal/api/servermaps/postcurrentmap"
+	"github.com/gameap/gameap/internal/api/servermaps/putmaplist"
 	"github.com/gameap/gameap/internal/api/servers/deleteserver"
 	"github.com/gameap/gameap/internal/api/servers/getabilities"
 	"github.com/gameap/gameap/internal/api/servers/getconsole"
+	"github.com/gameap/gameap/internal/api/servers/getplayersessions"
 	"github.com/gameap/gameap/internal/api/servers/getquery"
 	"github.com/gameap/gameap/internal/api/servers/getserver"
 	"github.com/gameap/gameap/internal/api/servers/getserverabilities"
 	"github.com/gameap/gameap/internal/api/servers/getservers"
+	"github.com/gameap/gameap/internal/api/servers/getstats"
 	"github.com/gameap/gameap/internal/api/servers/getstatus"
 	"github.com/gameap/gameap/internal/api/servers/getsummary"
 	"github.com/gameap/gameap/internal/api/servers/postcommand"
@@ -86,7 +110,9 @@ import (
 	rcongetplayers "github.com/gameap/gameap/internal/api/servers/rcon/getplayers"
 	"github.com/gameap/gameap/internal/api/servers/rcon/getrconfeatures"
 	rconkickplayer "github.com/gameap/gameap/internal/api/servers/rcon/kickplayer"
+	rconpostaction "github.com/gameap/gameap/internal/api/servers/rcon/postaction"
 	rconpostcommand "github.com/gameap/gameap/internal/api/servers/rcon/postcommand"
+	rconsendmessage "github.com/gameap/gameap/internal/api/servers/rcon/sendmessage"
 	"github.com/gameap/gameap/internal/api/servers/searchservers"
 	"github.com/gameap/gameap/internal/api/serversettings/getserversettings"
 	"github.com/gameap/gameap/internal/api/serversettings/putserversettings"
@@ -118,7 +144,16 @@ import (
 	"github.com/gameap/gameap/internal/repositories"
 	"github.com/gameap/gameap/internal/repositories/base"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/gameadmins"
+	"github.com/gameap/gameap/internal/services/minecraftaccess"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/internal/services/playerbans"
+	"github.com/gameap/gameap/internal/services/playersessions"
 	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/gameap/gameap/internal/services/servermaps"
+	"github.com/gameap/gameap/internal/services/serverquery"
+	"github.com/gameap/gameap/internal/services/serverresources"
+	"github.com/gameap/gameap/internal/services/serverstats"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	webstatic "github.com/gameap/gameap/web/static"
@@ -147,11 +182,25 @@ type container interface {
 	ServerSettingRepository() repositories.ServerSettingRepository
 	NodeRepository() repositories.NodeRepository
 	ClientCertificateRepository() repositories.ClientCertificateRepository
+	NodeStatRepository() repositories.NodeStatRepository
+	CommandPolicyRepository() repositories.CommandPolicyRepository
+	NodeHealthRepository() repositories.NodeHealthRepository
 	RBAC() *rbac.RBAC
 	FileManager() files.FileManager
 	Cache() cache.Cache
 	CertificatesService() *certificates.Service
 	GlobalAPIService() *services.GlobalAPIService
+	ServerQueryStore() *serverquery.Store
+	ServerStatsService() *serverstats.Service
+	PlayerSessionsService() *playersessions.Service
+	PlayerBansService() *playerbans.Service
+	MinecraftAccessService() *minecraftaccess.Service
+	GameAdminsService() *gameadmins.Service
+	ServerMapsService() *servermaps.Service
+	ServerResourcesMonitor() *serverresources.Monitor
+	NodeHealthChecker() *nodehealth.Checker
+	DaemonBreakers() *daemon.Breakers
+	DaemonPools() *daemon.PoolRegistry
 	DaemonStatus() *daemon.StatusService
 	DaemonFiles() *daemon.FileService
 	DaemonCommands() *daemon.CommandService
@@ -322,6 +371,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: getservers.NewHandler(
 				c.ServerRepository(),
 				c.GameRepository(),
+				c.ServerQueryStore(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -369,6 +419,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: getserver.NewHandler(
 				c.ServerRepository(),
 				c.GameRepository(),
+				c.ServerQueryStore(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -417,6 +468,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Path:   "/api/servers/{server}/status",
 			Handler: getstatus.NewHandler(
 				c.ServerRepository(),
+				c.ServerResourcesMonitor(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -427,9 +479,254 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: getquery.NewHandler(
 				c.ServerRepository(),
 				c.GameRepository(),
+				c.GameModRepository(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/stats",
+			Handler: getstats.NewHandler(
+				c.ServerRepository(),
+				c.ServerStatsService(),
+				c.RBAC(),
+				c.Responder(),
+			),
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/players/sessions",
+			Handler: getplayersessions.NewHandler(
+				c.ServerRepository(),
+				c.PlayerSessionsService(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/bans",
+			Handler: getplayerbans.NewHandler(
+				c.ServerRepository(),
+				c.PlayerBansService(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/bans",
+			Handler: postplayerban.NewHandler(
+				c.ServerRepository(),
+				c.PlayerBansService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/bans/import",
+			Handler: importplayerbans.NewHandler(
+				c.ServerRepository(),
+				c.PlayerBansService(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.Responder(),
 			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodPut,
+			Path:   "/api/servers/{server}/bans/{id}",
+			Handler: putplayerban.NewHandler(
+				c.ServerRepository(),
+				c.PlayerBansService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/servers/{server}/bans/{id}",
+			Handler: deleteplayerban.NewHandler(
+				c.ServerRepository(),
+				c.PlayerBansService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/minecraft/{list}",
+			Handler: getaccesslist.NewHandler(
+				c.ServerRepository(),
+				c.MinecraftAccessService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/minecraft/{list}",
+			Handler: postaccesslistentry.NewHandler(
+				c.ServerRepository(),
+				c.MinecraftAccessService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/servers/{server}/minecraft/{list}/{name}",
+			Handler: deleteaccesslistentry.NewHandler(
+				c.ServerRepository(),
+				c.MinecraftAccessService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/admins",
+			Handler: getadmins.NewHandler(
+				c.ServerRepository(),
+				c.GameAdminsService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodPut,
+			Path:   "/api/servers/{server}/admins/{steam_id}",
+			Handler: putadmin.NewHandler(
+				c.ServerRepository(),
+				c.GameAdminsService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/servers/{server}/admins/{steam_id}",
+			Handler: deleteadmin.NewHandler(
+				c.ServerRepository(),
+				c.GameAdminsService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconPlayers,
+			},
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/maps",
+			Handler: getmaps.NewHandler(
+				c.ServerRepository(),
+				c.ServerMapsService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconConsole,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/maps/current",
+			Handler: postcurrentmap.NewHandler(
+				c.ServerRepository(),
+				c.GameRepository(),
+				c.GameModRepository(),
+				c.CommandPolicyRepository(),
+				c.ServerMapsService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconConsole,
+			},
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/servers/{server}/maps/{list}",
+			Handler: getmaplist.NewHandler(
+				c.ServerRepository(),
+				c.ServerMapsService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconConsole,
+			},
+		},
+		{
+			Method: http.MethodPut,
+			Path:   "/api/servers/{server}/maps/{list}",
+			Handler: putmaplist.NewHandler(
+				c.ServerRepository(),
+				c.ServerMapsService(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconConsole,
+			},
+		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/players",
+			Handler: playersgetplayers.NewHandler(
+				c.PlayerSessionsService(),
+				c.Responder(),
+			),
+			AdminOnly: true,
 		},
 		{
 			Method: http.MethodGet,
@@ -437,6 +734,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: getrconfeatures.NewHandler(
 				c.ServerRepository(),
 				c.GameRepository(),
+				c.GameModRepository(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -463,6 +761,25 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: rconpostcommand.NewHandler(
 				c.ServerRepository(),
 				c.GameRepository(),
+				c.GameModRepository(),
+				c.CommandPolicyRepository(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
+			CheckPATAbilities: []domain.PATAbility{
+				domain.PATAbilityServerRconConsole,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/rcon/actions/{action}",
+			Handler: rconpostaction.NewHandler(
+				c.ServerRepository(),
+				c.GameRepository(),
+				c.GameModRepository(),
+				c.CommandPolicyRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -476,6 +793,9 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: rcongetplayers.NewHandler(
 				c.ServerRepository(),
 				c.GameRepository(),
+				c.GameModRepository(),
+				c.PlayerSessionsService(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -484,9 +804,16 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			},
 		},
 		{
-			Method:  http.MethodPost,
-			Path:    "/api/servers/{server}/rcon/players/message",
-			Handler: &notImplementedHandler{},
+			Method: http.MethodPost,
+			Path:   "/api/servers/{server}/rcon/players/message",
+			Handler: rconsendmessage.NewHandler(
+				c.ServerRepository(),
+				c.GameRepository(),
+				c.GameModRepository(),
+				c.NodeHealthChecker(),
+				c.RBAC(),
+				c.Responder(),
+			),
 			CheckPATAbilities: []domain.PATAbility{
 				domain.PATAbilityServerRconConsole,
 			},
@@ -497,6 +824,8 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: rconkickplayer.NewHandler(
 				c.ServerRepository(),
 				c.GameRepository(),
+				c.GameModRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -510,6 +839,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: getconsole.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonCommands(),
 				c.DaemonFiles(),
@@ -525,6 +855,8 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: postconsole.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
+				c.CommandPolicyRepository(),
 				c.RBAC(),
 				c.DaemonCommands(),
 				c.DaemonFiles(),
@@ -551,6 +883,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: content.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -562,6 +895,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: filemanagertree.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -573,6 +907,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: filemanagerdelete.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -584,6 +919,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: upload.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -595,6 +931,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: filemanagerupdatefile.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -606,6 +943,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: filemanagerdownload.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -617,6 +955,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: filemanagerrename.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -628,6 +967,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: filemanagercreatedirectory.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -639,6 +979,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: filemanagercreatefile.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -650,6 +991,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: filemanagerstreamfile.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -661,6 +1003,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: filemanagerpaste.NewHandler(
 				c.ServerRepository(),
 				c.NodeRepository(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.DaemonFiles(),
 				c.Responder(),
@@ -756,6 +1099,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: postcommand.NewHandler(
 				c.ServerRepository(),
 				c.ServerControlService(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -769,6 +1113,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: postcommand.NewHandler(
 				c.ServerRepository(),
 				c.ServerControlService(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -782,6 +1127,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: postcommand.NewHandler(
 				c.ServerRepository(),
 				c.ServerControlService(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -795,6 +1141,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: postcommand.NewHandler(
 				c.ServerRepository(),
 				c.ServerControlService(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -808,6 +1155,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: postcommand.NewHandler(
 				c.ServerRepository(),
 				c.ServerControlService(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -821,6 +1169,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: postcommand.NewHandler(
 				c.ServerRepository(),
 				c.ServerControlService(),
+				c.NodeHealthChecker(),
 				c.RBAC(),
 				c.Responder(),
 			),
@@ -973,6 +1322,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Path:   "/api/dedicated_servers",
 			Handler: getnodes.NewHandler(
 				c.NodeRepository(),
+				c.NodeHealthRepository(),
 				c.Responder(),
 			),
 			AdminOnly: true,
@@ -993,6 +1343,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Path: "/api/nodes",
 			Handler: getnodes.NewHandler(
 				c.NodeRepository(),
+				c.NodeHealthRepository(),
 				c.Responder(),
 			),
 			AdminOnly: true,
@@ -1018,11 +1369,35 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			),
 			AdminOnly: true,
 		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/dedicated_servers/connections",
+			Handler: getconnections.NewHandler(
+				c.NodeRepository(),
+				c.DaemonBreakers(),
+				c.DaemonPools(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
+		{
+			Method: http.MethodGet,
+			// alias for /api/dedicated_servers/connections
+			Path: "/api/nodes/connections",
+			Handler: getconnections.NewHandler(
+				c.NodeRepository(),
+				c.DaemonBreakers(),
+				c.DaemonPools(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
 		{
 			Method: http.MethodGet,
 			Path:   "/api/dedicated_servers/{id}",
 			Handler: getnode.NewHandler(
 				c.NodeRepository(),
+				c.NodeHealthRepository(),
 				c.Responder(),
 			),
 			AdminOnly: true,
@@ -1033,6 +1408,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Path: "/api/nodes/{id}",
 			Handler: getnode.NewHandler(
 				c.NodeRepository(),
+				c.NodeHealthRepository(),
 				c.Responder(),
 			),
 			AdminOnly: true,
@@ -1043,6 +1419,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: putnode.NewHandler(
 				c.NodeRepository(),
 				c.FileManager(),
+				c.DaemonPools(),
 				c.Responder(),
 			),
 			AdminOnly: true,
@@ -1054,6 +1431,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: putnode.NewHandler(
 				c.NodeRepository(),
 				c.FileManager(),
+				c.DaemonPools(),
 				c.Responder(),
 			),
 			AdminOnly: true,
@@ -1064,6 +1442,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: deletenode.NewHandler(
 				c.NodeRepository(),
 				c.ServerRepository(),
+				c.DaemonPools(),
 				c.Responder(),
 			),
 			AdminOnly: true,
@@ -1075,6 +1454,7 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			Handler: deletenode.NewHandler(
 				c.NodeRepository(),
 				c.ServerRepository(),
+				c.DaemonPools(),
 				c.Responder(),
 			),
 			AdminOnly: true,
@@ -1138,6 +1518,27 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			),
 			AdminOnly: true,
 		},
+		{
+			Method: http.MethodGet,
+			Path:   "/api/dedicated_servers/{id}/stats",
+			Handler: getnodestats.NewHandler(
+				c.NodeRepository(),
+				c.NodeStatRepository(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
+		{
+			Method: http.MethodGet,
+			// alias for /api/dedicated_servers/{id}/stats
+			Path: "/api/nodes/{id}/stats",
+			Handler: getnodestats.NewHandler(
+				c.NodeRepository(),
+				c.NodeStatRepository(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
 		{
 			Method: http.MethodGet,
 			Path:   "/api/dedicated_servers/{id}/logs.zip",
@@ -1299,6 +1700,46 @@ func apiRoutes(c container, router *mux.Router) *mux.Router {
 			),
 			AdminOnly: true,
 		},
+
+		// Command Policies
+		{
+			Method: http.MethodGet,
+			Path:   "/api/command_policies",
+			Handler: getcommandpolicies.NewHandler(
+				c.CommandPolicyRepository(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/command_policies",
+			Handler: postcommandpolicy.NewHandler(
+				c.CommandPolicyRepository(),
+				c.ServerRepository(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
+		{
+			Method: http.MethodPut,
+			Path:   "/api/command_policies/{id}",
+			Handler: putcommandpolicy.NewHandler(
+				c.CommandPolicyRepository(),
+				c.ServerRepository(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
+		{
+			Method: http.MethodDelete,
+			Path:   "/api/command_policies/{id}",
+			Handler: deletecommandpolicy.NewHandler(
+				c.CommandPolicyRepository(),
+				c.Responder(),
+			),
+			AdminOnly: true,
+		},
 	}
 
 	authMiddleware := middlewares.NewAuthMiddleware(
@@ -1584,9 +2025,3 @@ func gdaemonAPIRoutes(c container, router *mux.Router) *mux.Router {
 
 	return router
 }
-
-type notImplementedHandler struct{}
-
-func (h *notImplementedHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
@@ -388,6 +388,18 @@ func TestRouterSecurity_UserAccess(t *testing.T) {
 			isAdmin:            true,
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name:               "regular_user_cannot_access_command_policies",
+			request:            "GET /api/command_policies",
+			isAdmin:            false,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "admin_can_access_command_policies",
+			request:            "GET /api/command_policies",
+			isAdmin:            true,
+			expectedStatusCode: http.StatusOK,
+		},
 	}
 
 	for _, test := range tests {
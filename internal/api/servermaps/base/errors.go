@@ -0,0 +1,36 @@
+package base
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/services/servermaps"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+var ErrListNotFound = errors.New("map list not found")
+
+// ReadList reads the map list name from the request path.
+func ReadList(r *http.Request) (servermaps.List, error) {
+	name, _ := api.NewInputReader(r).ReadString("list")
+
+	list := servermaps.List(name)
+	if !list.IsValid() {
+		return "", api.WrapHTTPError(ErrListNotFound, http.StatusNotFound)
+	}
+
+	return list, nil
+}
+
+// WrapServiceError sets the HTTP status of the server maps service errors.
+func WrapServiceError(err error) error {
+	switch {
+	case errors.Is(err, servermaps.ErrMapsNotSupported):
+		return api.WrapHTTPError(err, http.StatusNotImplemented)
+	case errors.Is(err, servermaps.ErrInvalidMapName),
+		errors.Is(err, servermaps.ErrMapNotInstalled):
+		return api.WrapHTTPError(err, http.StatusUnprocessableEntity)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,15 @@
+package base
+
+type MapListResponse struct {
+	Maps []string `json:"maps"`
+}
+
+func NewMapListResponse(maps []string) MapListResponse {
+	if maps == nil {
+		maps = []string{}
+	}
+
+	return MapListResponse{
+		Maps: maps,
+	}
+}
@@ -0,0 +1,160 @@
+package getmaplist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/servermaps"
+	"github.com/gameap/gameap/internal/services/serverquery"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+const (
+	testGameDir      = "/srv/gameap/servers/cs/cstrike"
+	testMapCyclePath = testGameDir + "/mapcycle.txt"
+)
+
+func setupGameRepo(t *testing.T) *inmemory.GameRepository {
+	t.Helper()
+
+	gameRepo := inmemory.NewGameRepository()
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "cstrike",
+		Name:   "Counter-Strike",
+		Engine: "GoldSource",
+	}))
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "minecraft",
+		Name:   "Minecraft",
+		Engine: "Minecraft",
+	}))
+
+	return gameRepo
+}
+
+func newService(t *testing.T, node *apitesting.FakeNode, store *serverquery.Store) *servermaps.Service {
+	t.Helper()
+
+	return servermaps.NewService(
+		node.Repo,
+		serversbase.NewGameFinder(setupGameRepo(t), inmemory.NewGameModRepository()),
+		node.Files,
+		store,
+	)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		list           string
+		ctx            context.Context
+		allowAbility   bool
+		expectedStatus int
+		wantError      string
+		wantMaps       []any
+	}{
+		{
+			name:           "user not authenticated",
+			list:           "mapcycle",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "user does not have console ability",
+			list:           "mapcycle",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "unknown list",
+			list:           "motd",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "map list not found",
+		},
+		{
+			name:           "map cycle",
+			list:           "mapcycle",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantMaps:       []any{"de_dust2", "de_inferno"},
+		},
+		{
+			name:           "missing map list",
+			list:           "maplist",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantMaps:       []any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apitesting.NewServer("cstrike")
+			server.GameModID = 1
+			server.Dir = "servers/cs"
+			serverRepo := apitesting.NewServerRepository(t, testUser1.ID, server)
+			node := apitesting.NewFakeNode(t, "/srv/gameap")
+			node.WriteFiles(t, map[string][]byte{
+				testGameDir + "/maps/de_dust2.bsp":   nil,
+				testGameDir + "/maps/de_inferno.bsp": nil,
+				testMapCyclePath:                     []byte("de_dust2\nde_inferno\n"),
+			})
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			service := newService(t, node, serverquery.NewStore(0))
+			handler := NewHandler(serverRepo, service, apitesting.NewNodeHealthChecker(t), rbacService, api.NewResponder())
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconConsole)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/1/maps/"+tt.list, nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1", "list": tt.list})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantMaps, response["maps"])
+		})
+	}
+}
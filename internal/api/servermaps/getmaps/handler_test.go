@@ -0,0 +1,171 @@
+package getmaps
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/servermaps"
+	"github.com/gameap/gameap/internal/services/serverquery"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+const (
+	testGameDir      = "/srv/gameap/servers/cs/cstrike"
+	testMapCyclePath = testGameDir + "/mapcycle.txt"
+)
+
+func setupGameRepo(t *testing.T) *inmemory.GameRepository {
+	t.Helper()
+
+	gameRepo := inmemory.NewGameRepository()
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "cstrike",
+		Name:   "Counter-Strike",
+		Engine: "GoldSource",
+	}))
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "minecraft",
+		Name:   "Minecraft",
+		Engine: "Minecraft",
+	}))
+
+	return gameRepo
+}
+
+func newService(t *testing.T, node *apitesting.FakeNode, store *serverquery.Store) *servermaps.Service {
+	t.Helper()
+
+	return servermaps.NewService(
+		node.Repo,
+		serversbase.NewGameFinder(setupGameRepo(t), inmemory.NewGameModRepository()),
+		node.Files,
+		store,
+	)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		gameID         string
+		ctx            context.Context
+		allowAbility   bool
+		nodeOffline    bool
+		expectedStatus int
+		wantError      string
+		wantBody       map[string]any
+	}{
+		{
+			name:           "user not authenticated",
+			gameID:         "cstrike",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "user does not have console ability",
+			gameID:         "cstrike",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "node offline",
+			gameID:         "cstrike",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			nodeOffline:    true,
+			expectedStatus: http.StatusServiceUnavailable,
+			wantError:      "Service Unavailable",
+		},
+		{
+			name:           "game not supported",
+			gameID:         "minecraft",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotImplemented,
+			wantError:      "Not Implemented",
+		},
+		{
+			name:           "installed maps",
+			gameID:         "cstrike",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantBody: map[string]any{
+				"current_map": "de_inferno",
+				"maps":        []any{"de_dust2", "de_inferno"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apitesting.NewServer(tt.gameID)
+			server.GameModID = 1
+			server.Dir = "servers/cs"
+			serverRepo := apitesting.NewServerRepository(t, testUser1.ID, server)
+			store := serverquery.NewStore(0)
+			store.Set(1, query.Result{Online: true, Map: "de_inferno"})
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			node := apitesting.NewFakeNode(t, "/srv/gameap")
+			node.WriteFiles(t, map[string][]byte{
+				testGameDir + "/maps/de_dust2.bsp":   nil,
+				testGameDir + "/maps/de_inferno.bsp": nil,
+			})
+			service := newService(t, node, store)
+			nodeHealth := apitesting.NewNodeHealthChecker(t)
+			if tt.nodeOffline {
+				nodeHealth = apitesting.NewNodeHealthChecker(t, apitesting.OfflineNodeHealth(server.DSID))
+			}
+			handler := NewHandler(serverRepo, service, nodeHealth, rbacService, api.NewResponder())
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconConsole)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/1/maps", nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1"})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantBody, response)
+		})
+	}
+}
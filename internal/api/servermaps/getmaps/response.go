@@ -0,0 +1,17 @@
+package getmaps
+
+import (
+	"github.com/gameap/gameap/internal/services/servermaps"
+)
+
+type mapsResponse struct {
+	CurrentMap string   `json:"current_map"`
+	Maps       []string `json:"maps"`
+}
+
+func newMapsResponse(result *servermaps.MapsResult) mapsResponse {
+	return mapsResponse{
+		CurrentMap: result.CurrentMap,
+		Maps:       result.Maps,
+	}
+}
@@ -0,0 +1,187 @@
+package postcurrentmap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	mapsbase "github.com/gameap/gameap/internal/api/servermaps/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	rconbase "github.com/gameap/gameap/internal/api/servers/rcon/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/internal/services/rconexec"
+	"github.com/gameap/gameap/internal/services/servermaps"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+var ErrMapNotInstalled = api.NewValidationError("map is not installed")
+
+type commandExecutor interface {
+	Execute(ctx context.Context, server *domain.Server, command string) (string, error)
+}
+
+// Handler changes the map of the server with the change map command template of the game mod.
+// Only installed maps are accepted, the rendered command is checked against command policies.
+type Handler struct {
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	nodeState      *serversbase.NodeStateChecker
+	policyChecker  *serversbase.CommandPolicyChecker
+	gameModRepo    repositories.GameModRepository
+	maps           *servermaps.Service
+	executor       commandExecutor
+	responder      base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	gameRepo repositories.GameRepository,
+	gameModRepo repositories.GameModRepository,
+	commandPolicyRepo repositories.CommandPolicyRepository,
+	maps *servermaps.Service,
+	nodeHealth *nodehealth.Checker,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
+		policyChecker:  serversbase.NewCommandPolicyChecker(commandPolicyRepo, rbac),
+		gameModRepo:    gameModRepo,
+		maps:           maps,
+		executor:       rconexec.NewExecutor(serversbase.NewGameFinder(gameRepo, gameModRepo)),
+		responder:      responder,
+	}
+}
+
+//nolint:funlen
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	serverID, err := api.NewInputReader(r).ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.abilityChecker.CheckOrError(
+		ctx, session.User.ID, server.ID, []domain.AbilityName{domain.AbilityNameGameServerRconConsole},
+	); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	input := &changeMapInput{}
+	if err = json.NewDecoder(r.Body).Decode(input); err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "validation failed"))
+
+		return
+	}
+
+	installed, err := h.maps.IsInstalled(ctx, server, input.Map)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(
+			mapsbase.WrapServiceError(err),
+			"failed to get installed maps",
+		))
+
+		return
+	}
+
+	if !installed {
+		h.responder.WriteError(ctx, rw, ErrMapNotInstalled)
+
+		return
+	}
+
+	command, err := h.renderCommand(ctx, server, input.Map)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.policyChecker.CheckOrError(
+		ctx, session.User.ID, server.ID, domain.CommandPolicyTargetRcon, command,
+	); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	output, err := h.executor.Execute(ctx, server, command)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(rconbase.WrapExecutorError(err), "failed to change map"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newChangeMapResponse(command, output))
+}
+
+func (h *Handler) renderCommand(ctx context.Context, server *domain.Server, mapName string) (string, error) {
+	gameMods, err := h.gameModRepo.Find(ctx, &filters.FindGameMod{IDs: []uint{server.GameModID}}, nil, nil)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to find game mod")
+	}
+
+	var gameMod *domain.GameMod
+	if len(gameMods) > 0 {
+		gameMod = &gameMods[0]
+	}
+
+	command, err := rconbase.RenderAction(gameMod, rconbase.ActionChangeMap, mapName)
+	if err != nil {
+		if errors.Is(err, rconbase.ErrActionNotAvailable) {
+			return "", api.WrapHTTPError(err, http.StatusNotImplemented)
+		}
+
+		return "", errors.WithMessage(err, "failed to render command")
+	}
+
+	return command, nil
+}
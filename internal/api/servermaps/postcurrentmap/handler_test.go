@@ -0,0 +1,238 @@
+package postcurrentmap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/servermaps"
+	"github.com/gameap/gameap/internal/services/serverquery"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+const (
+	testGameDir      = "/srv/gameap/servers/cs/cstrike"
+	testMapCyclePath = testGameDir + "/mapcycle.txt"
+)
+
+func setupGameRepo(t *testing.T) *inmemory.GameRepository {
+	t.Helper()
+
+	gameRepo := inmemory.NewGameRepository()
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "cstrike",
+		Name:   "Counter-Strike",
+		Engine: "GoldSource",
+	}))
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "minecraft",
+		Name:   "Minecraft",
+		Engine: "Minecraft",
+	}))
+
+	return gameRepo
+}
+
+func newService(t *testing.T, node *apitesting.FakeNode, store *serverquery.Store) *servermaps.Service {
+	t.Helper()
+
+	return servermaps.NewService(
+		node.Repo,
+		serversbase.NewGameFinder(setupGameRepo(t), inmemory.NewGameModRepository()),
+		node.Files,
+		store,
+	)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		gameID         string
+		chmapCmd       *string
+		denyPattern    string
+		body           string
+		ctx            context.Context
+		allowAbility   bool
+		expectedStatus int
+		wantError      string
+		wantCommand    string
+	}{
+		{
+			name:           "user not authenticated",
+			gameID:         "cstrike",
+			body:           `{"map": "de_dust2"}`,
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "user does not have console ability",
+			gameID:         "cstrike",
+			body:           `{"map": "de_dust2"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "invalid map name",
+			gameID:         "cstrike",
+			body:           `{"map": "de_dust2; quit"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "map name contains invalid characters",
+		},
+		{
+			name:           "map is not installed",
+			gameID:         "cstrike",
+			chmapCmd:       lo.ToPtr("changelevel {map}"),
+			body:           `{"map": "de_nuke"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "map is not installed",
+		},
+		{
+			name:           "game not supported",
+			gameID:         "minecraft",
+			body:           `{"map": "world"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotImplemented,
+			wantError:      "Not Implemented",
+		},
+		{
+			name:           "change map command is not set",
+			gameID:         "cstrike",
+			body:           `{"map": "de_dust2"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotImplemented,
+			wantError:      "Not Implemented",
+		},
+		{
+			name:           "denied by command policy",
+			gameID:         "cstrike",
+			chmapCmd:       lo.ToPtr("changelevel {map}"),
+			denyPattern:    "changelevel *",
+			body:           `{"map": "de_dust2"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusForbidden,
+			wantError:      `command "changelevel de_dust2" is denied by command policy #1`,
+		},
+		{
+			name:           "change map",
+			gameID:         "cstrike",
+			chmapCmd:       lo.ToPtr("changelevel {map}"),
+			body:           `{"map": "de_dust2"}`,
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantCommand:    "changelevel de_dust2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			server := apitesting.NewServer(tt.gameID)
+			server.GameModID = 1
+			server.Dir = "servers/cs"
+			serverRepo := apitesting.NewServerRepository(t, testUser1.ID, server)
+			gameModRepo := inmemory.NewGameModRepository()
+			policyRepo := inmemory.NewCommandPolicyRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			node := apitesting.NewFakeNode(t, "/srv/gameap")
+			node.WriteFiles(t, map[string][]byte{
+				testGameDir + "/maps/de_dust2.bsp":   nil,
+				testGameDir + "/maps/de_inferno.bsp": nil,
+			})
+			service := newService(t, node, serverquery.NewStore(0))
+			executor := &apitesting.Executor{Output: "ok"}
+
+			handler := NewHandler(
+				serverRepo,
+				setupGameRepo(t),
+				gameModRepo,
+				policyRepo,
+				service,
+				apitesting.NewNodeHealthChecker(t),
+				rbacService,
+				api.NewResponder(),
+			)
+			handler.executor = executor
+
+			require.NoError(t, gameModRepo.Save(ctx, &domain.GameMod{
+				ID:       1,
+				GameCode: tt.gameID,
+				Name:     "Default",
+				ChmapCmd: tt.chmapCmd,
+			}))
+
+			if tt.denyPattern != "" {
+				require.NoError(t, policyRepo.Save(ctx, &domain.CommandPolicy{
+					Target:      domain.CommandPolicyTargetAll,
+					Action:      domain.CommandPolicyActionDeny,
+					PatternType: domain.CommandPolicyPatternTypeGlob,
+					Pattern:     tt.denyPattern,
+				}))
+			}
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconConsole)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/servers/1/maps/current", strings.NewReader(tt.body))
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1"})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantCommand == "" {
+				assert.Empty(t, executor.Commands)
+			}
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			assert.Equal(t, []string{tt.wantCommand}, executor.Commands)
+
+			var response changeMapResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantCommand, response.Command)
+			assert.Equal(t, "ok", response.Output)
+		})
+	}
+}
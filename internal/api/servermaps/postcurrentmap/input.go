@@ -0,0 +1,27 @@
+package postcurrentmap
+
+import (
+	"github.com/gameap/gameap/internal/services/servermaps"
+	"github.com/gameap/gameap/pkg/api"
+)
+
+var (
+	ErrMapIsRequired  = api.NewValidationError("map is required")
+	ErrInvalidMapName = api.NewValidationError("map name contains invalid characters")
+)
+
+type changeMapInput struct {
+	Map string `json:"map"`
+}
+
+func (in *changeMapInput) Validate() error {
+	if in.Map == "" {
+		return ErrMapIsRequired
+	}
+
+	if !servermaps.IsValidMapName(in.Map) {
+		return ErrInvalidMapName
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package postcurrentmap
+
+type changeMapResponse struct {
+	Command string `json:"command"`
+	Output  string `json:"output"`
+}
+
+func newChangeMapResponse(command, output string) changeMapResponse {
+	return changeMapResponse{
+		Command: command,
+		Output:  output,
+	}
+}
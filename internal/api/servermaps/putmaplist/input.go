@@ -0,0 +1,28 @@
+package putmaplist
+
+import (
+	"github.com/gameap/gameap/pkg/api"
+)
+
+const maxMaps = 1000
+
+var (
+	ErrMapsAreRequired = api.NewValidationError("maps are required")
+	ErrTooManyMaps     = api.NewValidationError("maps must not contain more than 1000 maps")
+)
+
+type mapListInput struct {
+	Maps []string `json:"maps"`
+}
+
+func (in *mapListInput) Validate() error {
+	if in.Maps == nil {
+		return ErrMapsAreRequired
+	}
+
+	if len(in.Maps) > maxMaps {
+		return ErrTooManyMaps
+	}
+
+	return nil
+}
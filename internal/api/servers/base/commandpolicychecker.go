@@ -0,0 +1,81 @@
+package base
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// CommandPolicyChecker is responsible for checking RCON and console commands against command policies.
+type CommandPolicyChecker struct {
+	repo repositories.CommandPolicyRepository
+	rbac base.RBAC
+}
+
+func NewCommandPolicyChecker(repo repositories.CommandPolicyRepository, rbac base.RBAC) *CommandPolicyChecker {
+	return &CommandPolicyChecker{
+		repo: repo,
+		rbac: rbac,
+	}
+}
+
+// CheckOrError checks the command sent by the user to the server against the policies
+// of the server and of all servers. Admins are not restricted by policies.
+// It returns a forbidden error describing the matched policy when the command is rejected.
+func (c *CommandPolicyChecker) CheckOrError(
+	ctx context.Context,
+	userID uint,
+	serverID uint,
+	target domain.CommandPolicyTarget,
+	command string,
+) error {
+	isAdmin, err := c.rbac.Can(ctx, userID, []domain.AbilityName{domain.AbilityNameAdminRolesPermissions})
+	if err != nil {
+		return errors.WithMessage(err, "failed to check admin permissions")
+	}
+
+	if isAdmin {
+		return nil
+	}
+
+	policies, err := c.repo.Find(ctx, &filters.FindCommandPolicy{
+		AppliedToServerIDs: []uint{serverID},
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find command policies")
+	}
+
+	if len(policies) == 0 {
+		return nil
+	}
+
+	roles, err := c.rbac.GetRoles(ctx, userID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to get user roles")
+	}
+
+	applied := make([]domain.CommandPolicy, 0, len(policies))
+	for _, policy := range policies {
+		if policy.AppliesTo(target, roles) {
+			applied = append(applied, policy)
+		}
+	}
+
+	err = domain.CheckCommandPolicies(applied, command)
+	if err != nil {
+		violation := &domain.CommandPolicyViolation{}
+		if errors.As(err, &violation) {
+			return api.WrapHTTPError(violation, http.StatusForbidden)
+		}
+
+		return errors.WithMessage(err, "failed to check command policies")
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package base_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandPolicyChecker_CheckOrError(t *testing.T) {
+	ctx := context.Background()
+
+	rbacService, rbacRepo := setupRBAC(t)
+	policyRepo := inmemory.NewCommandPolicyRepository()
+
+	adminRole := createAdminRole(t, rbacRepo)
+	assignRoleToUser(t, rbacRepo, 1, adminRole)
+
+	moderatorRole := domain.Role{Name: "moderator"}
+	require.NoError(t, rbacRepo.SaveRole(ctx, &moderatorRole))
+	assignRoleToUser(t, rbacRepo, 3, moderatorRole)
+
+	policies := []*domain.CommandPolicy{
+		{
+			Target:      domain.CommandPolicyTargetAll,
+			Action:      domain.CommandPolicyActionDeny,
+			PatternType: domain.CommandPolicyPatternTypeGlob,
+			Pattern:     "quit",
+		},
+		{
+			ServerID:    lo.ToPtr(uint(10)),
+			Target:      domain.CommandPolicyTargetRcon,
+			Action:      domain.CommandPolicyActionDeny,
+			PatternType: domain.CommandPolicyPatternTypeGlob,
+			Pattern:     "rcon_password *",
+		},
+		{
+			Role:        lo.ToPtr("moderator"),
+			Target:      domain.CommandPolicyTargetRcon,
+			Action:      domain.CommandPolicyActionAllow,
+			PatternType: domain.CommandPolicyPatternTypeRegex,
+			Pattern:     `^(say|kick) `,
+		},
+	}
+	for _, policy := range policies {
+		require.NoError(t, policyRepo.Save(ctx, policy))
+	}
+
+	checker := serversbase.NewCommandPolicyChecker(policyRepo, rbacService)
+
+	tests := []struct {
+		name      string
+		userID    uint
+		serverID  uint
+		target    domain.CommandPolicyTarget
+		command   string
+		wantError string
+	}{
+		{
+			name:     "admin_is_not_restricted",
+			userID:   1,
+			serverID: 10,
+			target:   domain.CommandPolicyTargetRcon,
+			command:  "quit",
+		},
+		{
+			name:      "policy_for_all_servers",
+			userID:    2,
+			serverID:  20,
+			target:    domain.CommandPolicyTargetConsole,
+			command:   "quit",
+			wantError: `command "quit" is denied by command policy #1 (glob "quit")`,
+		},
+		{
+			name:      "policy_for_server",
+			userID:    2,
+			serverID:  10,
+			target:    domain.CommandPolicyTargetRcon,
+			command:   "rcon_password secret",
+			wantError: `command "rcon_password secret" is denied by command policy #2 (glob "rcon_password *")`,
+		},
+		{
+			name:     "policy_for_other_server",
+			userID:   2,
+			serverID: 20,
+			target:   domain.CommandPolicyTargetRcon,
+			command:  "rcon_password secret",
+		},
+		{
+			name:     "policy_for_other_target",
+			userID:   2,
+			serverID: 10,
+			target:   domain.CommandPolicyTargetConsole,
+			command:  "rcon_password secret",
+		},
+		{
+			name:     "role_policy_allows",
+			userID:   3,
+			serverID: 20,
+			target:   domain.CommandPolicyTargetRcon,
+			command:  "say hello",
+		},
+		{
+			name:      "role_policy_does_not_allow",
+			userID:    3,
+			serverID:  20,
+			target:    domain.CommandPolicyTargetRcon,
+			command:   "changelevel de_dust2",
+			wantError: `command "changelevel de_dust2" is not allowed by any command policy`,
+		},
+		{
+			name:     "role_policy_is_not_applied_to_other_users",
+			userID:   2,
+			serverID: 20,
+			target:   domain.CommandPolicyTargetRcon,
+			command:  "changelevel de_dust2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checker.CheckOrError(ctx, test.userID, test.serverID, test.target, test.command)
+
+			if test.wantError == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, test.wantError, err.Error())
+
+			var httpErr *api.WrappedError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusForbidden, httpErr.HTTPStatus())
+		})
+	}
+}
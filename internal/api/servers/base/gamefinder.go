@@ -0,0 +1,61 @@
+package base
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// GameFinder is responsible for finding games of servers.
+type GameFinder struct {
+	gameRepo    repositories.GameRepository
+	gameModRepo repositories.GameModRepository
+}
+
+func NewGameFinder(
+	gameRepo repositories.GameRepository,
+	gameModRepo repositories.GameModRepository,
+) *GameFinder {
+	return &GameFinder{
+		gameRepo:    gameRepo,
+		gameModRepo: gameModRepo,
+	}
+}
+
+// FindServerGame returns the game of the server with the protocol settings
+// of the server game mod applied.
+func (f *GameFinder) FindServerGame(ctx context.Context, server *domain.Server) (*domain.Game, error) {
+	games, err := f.gameRepo.Find(ctx, filters.FindGameByCodes(server.GameID), nil, nil)
+	if err != nil {
+		return nil, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to find game for server"),
+			http.StatusInternalServerError,
+		)
+	}
+	if len(games) == 0 {
+		return nil, api.WrapHTTPError(
+			errors.New("game for server not found"),
+			http.StatusInternalServerError,
+		)
+	}
+
+	game := &games[0]
+
+	gameMods, err := f.gameModRepo.Find(ctx, &filters.FindGameMod{
+		IDs: []uint{server.GameModID},
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find game mod for server")
+	}
+
+	if len(gameMods) > 0 {
+		game.ApplyGameMod(&gameMods[0])
+	}
+
+	return game, nil
+}
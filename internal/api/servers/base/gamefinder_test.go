@@ -0,0 +1,78 @@
+package base_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameFinder_FindServerGame(t *testing.T) {
+	ctx := context.Background()
+
+	gameRepo := inmemory.NewGameRepository()
+	gameModRepo := inmemory.NewGameModRepository()
+
+	require.NoError(t, gameRepo.Save(ctx, &domain.Game{
+		Code:            "rust",
+		Name:            "Rust",
+		Engine:          "unity",
+		RconProtocol:    lo.ToPtr("source"),
+		QueryPortOffset: lo.ToPtr(1),
+	}))
+	require.NoError(t, gameModRepo.Save(ctx, &domain.GameMod{
+		ID:             1,
+		GameCode:       "rust",
+		Name:           "Vanilla",
+		RconPortOffset: lo.ToPtr(10),
+	}))
+	require.NoError(t, gameModRepo.Save(ctx, &domain.GameMod{
+		ID:            2,
+		GameCode:      "rust",
+		Name:          "Oxide",
+		PlayerManager: lo.ToPtr("source"),
+		RconProtocol:  lo.ToPtr("telnet"),
+	}))
+
+	finder := serversbase.NewGameFinder(gameRepo, gameModRepo)
+
+	t.Run("game_mod_settings_applied", func(t *testing.T) {
+		game, err := finder.FindServerGame(ctx, &domain.Server{GameID: "rust", GameModID: 2})
+		require.NoError(t, err)
+
+		assert.Equal(t, lo.ToPtr("telnet"), game.RconProtocol)
+		assert.Equal(t, lo.ToPtr("source"), game.PlayerManager)
+		assert.Equal(t, lo.ToPtr(1), game.QueryPortOffset)
+		assert.Nil(t, game.RconPortOffset)
+	})
+
+	t.Run("game_settings_kept", func(t *testing.T) {
+		game, err := finder.FindServerGame(ctx, &domain.Server{GameID: "rust", GameModID: 1})
+		require.NoError(t, err)
+
+		assert.Equal(t, lo.ToPtr("source"), game.RconProtocol)
+		assert.Equal(t, lo.ToPtr(10), game.RconPortOffset)
+	})
+
+	t.Run("game_mod_not_found", func(t *testing.T) {
+		game, err := finder.FindServerGame(ctx, &domain.Server{GameID: "rust", GameModID: 99})
+		require.NoError(t, err)
+
+		assert.Equal(t, "rust", game.Code)
+	})
+
+	t.Run("game_not_found", func(t *testing.T) {
+		_, err := finder.FindServerGame(ctx, &domain.Server{GameID: "unknown", GameModID: 1})
+
+		var httpErr *api.WrappedError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusInternalServerError, httpErr.HTTPStatus())
+	})
+}
@@ -0,0 +1,42 @@
+package base
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+var ErrNodeOffline = errors.New("node offline")
+
+type nodeHealthProvider interface {
+	Health(ctx context.Context, nodeID uint) (*domain.NodeHealth, error)
+}
+
+// NodeStateChecker is responsible for rejecting server actions while the node of the server is offline.
+type NodeStateChecker struct {
+	health nodeHealthProvider
+}
+
+func NewNodeStateChecker(health nodeHealthProvider) *NodeStateChecker {
+	return &NodeStateChecker{
+		health: health,
+	}
+}
+
+// CheckOrError returns a service unavailable error when the last health check of the server node failed.
+// Nodes without a recent health check are considered online.
+func (c *NodeStateChecker) CheckOrError(ctx context.Context, server *domain.Server) error {
+	health, err := c.health.Health(ctx, server.DSID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to get node health")
+	}
+
+	if health != nil && !health.Online {
+		return api.WrapHTTPError(ErrNodeOffline, http.StatusServiceUnavailable)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package base_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeStateChecker_CheckOrError(t *testing.T) {
+	ctx := context.Background()
+
+	healthRepo := inmemory.NewNodeHealthRepository()
+	require.NoError(t, healthRepo.Save(ctx, &domain.NodeHealth{NodeID: 1, Online: true, CheckedAt: time.Now()}))
+	require.NoError(t, healthRepo.Save(ctx, &domain.NodeHealth{NodeID: 2, Online: false, CheckedAt: time.Now()}))
+	require.NoError(t, healthRepo.Save(ctx, &domain.NodeHealth{
+		NodeID:    3,
+		Online:    false,
+		CheckedAt: time.Now().Add(-time.Hour),
+	}))
+
+	checker := serversbase.NewNodeStateChecker(
+		nodehealth.NewChecker(inmemory.NewNodeRepository(), healthRepo, nil, time.Minute),
+	)
+
+	tests := []struct {
+		name    string
+		nodeID  uint
+		wantErr bool
+	}{
+		{name: "online_node", nodeID: 1},
+		{name: "offline_node", nodeID: 2, wantErr: true},
+		{name: "outdated_offline_state", nodeID: 3},
+		{name: "unchecked_node", nodeID: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checker.CheckOrError(ctx, &domain.Server{ID: 10, DSID: tt.nodeID})
+
+			if !tt.wantErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, "node offline", err.Error())
+
+			var httpErr *api.WrappedError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusServiceUnavailable, httpErr.HTTPStatus())
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	apitesting "github.com/gameap/gameap/internal/api/testing"
 	"github.com/gameap/gameap/internal/daemon"
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/rbac"
@@ -749,7 +750,15 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			responder := api.NewResponder()
 			mockFS := tt.setupMockFS()
 			mockDaemon := tt.setupMockDaemon()
-			handler := NewHandler(serverRepo, nodeRepo, rbacService, mockDaemon, mockFS, responder)
+			handler := NewHandler(
+				serverRepo,
+				nodeRepo,
+				apitesting.NewNodeHealthChecker(t),
+				rbacService,
+				mockDaemon,
+				mockFS,
+				responder,
+			)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, nodeRepo, rbacRepo)
@@ -800,7 +809,15 @@ func TestHandler_NewHandler(t *testing.T) {
 	mockDaemon := &mockDaemonCommands{}
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, nodeRepo, rbacService, mockDaemon, mockFS, responder)
+	handler := NewHandler(
+		serverRepo,
+		nodeRepo,
+		apitesting.NewNodeHealthChecker(t),
+		rbacService,
+		mockDaemon,
+		mockFS,
+		responder,
+	)
 
 	require.NotNil(t, handler)
 	assert.NotNil(t, handler.serverFinder)
@@ -0,0 +1,260 @@
+package getplayersessions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/playersessions"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func setupRepos(
+	t *testing.T,
+	now time.Time,
+) (*inmemory.ServerRepository, *inmemory.PlayerSessionRepository) {
+	t.Helper()
+
+	serverRepo := inmemory.NewServerRepository()
+	sessionRepo := inmemory.NewPlayerSessionRepository()
+
+	for _, id := range []uint{1, 2} {
+		require.NoError(t, serverRepo.Save(context.Background(), &domain.Server{
+			ID:         id,
+			UUID:       uuid.New(),
+			UUIDShort:  "short" + strconv.FormatUint(uint64(id), 10),
+			Enabled:    true,
+			Installed:  domain.ServerInstalledStatusInstalled,
+			Name:       "Test Server",
+			GameID:     "cstrike",
+			DSID:       1,
+			ServerIP:   "127.0.0.1",
+			ServerPort: 27015 + int(id),
+		}))
+		serverRepo.AddUserServer(testUser1.ID, id)
+	}
+
+	sessions := []domain.PlayerSession{
+		{
+			ServerID:   1,
+			UniqID:     "STEAM_1",
+			Name:       "alice",
+			JoinedAt:   now.Add(-3 * time.Hour),
+			LeftAt:     lo.ToPtr(now.Add(-time.Hour)),
+			LastSeenAt: now.Add(-time.Hour),
+		},
+		{
+			ServerID:   1,
+			Name:       "bob",
+			JoinedAt:   now.Add(-2 * time.Hour),
+			LastSeenAt: now,
+		},
+		{
+			ServerID:   1,
+			Name:       "old",
+			JoinedAt:   now.Add(-72 * time.Hour),
+			LeftAt:     lo.ToPtr(now.Add(-71 * time.Hour)),
+			LastSeenAt: now.Add(-71 * time.Hour),
+		},
+		{
+			ServerID:   2,
+			Name:       "carol",
+			JoinedAt:   now.Add(-time.Hour),
+			LastSeenAt: now,
+		},
+	}
+
+	for i := range sessions {
+		require.NoError(t, sessionRepo.Save(context.Background(), &sessions[i]))
+	}
+
+	return serverRepo, sessionRepo
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name           string
+		serverID       string
+		query          string
+		ctx            context.Context
+		allowAbility   bool
+		expectedStatus int
+		wantError      string
+		wantNames      []string
+		wantPeak       int
+		wantUnique     int
+	}{
+		{
+			name:           "user not authenticated",
+			serverID:       "1",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "invalid server id",
+			serverID:       "invalid",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid server id",
+		},
+		{
+			name:           "server not found",
+			serverID:       "999",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "user does not have players ability",
+			serverID:       "1",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name:           "invalid time",
+			serverID:       "1",
+			query:          "from=yesterday",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid from value",
+		},
+		{
+			name:     "too long range",
+			serverID: "1",
+			query: "from=" + now.Add(-60*24*time.Hour).Format(time.RFC3339) +
+				"&to=" + now.Format(time.RFC3339),
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "time range is too long",
+		},
+		{
+			name:           "default range is the last day",
+			serverID:       "1",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantNames:      []string{"alice", "bob"},
+			wantPeak:       2,
+			wantUnique:     2,
+		},
+		{
+			name:           "custom range",
+			serverID:       "1",
+			query:          "from=" + now.Add(-4*24*time.Hour).Format(time.RFC3339),
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			allowAbility:   true,
+			expectedStatus: http.StatusOK,
+			wantNames:      []string{"old", "alice", "bob"},
+			wantPeak:       2,
+			wantUnique:     3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverRepo, sessionRepo := setupRepos(t, now)
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(
+				serverRepo, playersessions.NewService(sessionRepo), rbacService, api.NewResponder(),
+			)
+
+			if tt.allowAbility {
+				apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/"+tt.serverID+"/players/sessions?"+tt.query, nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response sessionsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			names := lo.Map(response.Sessions, func(s sessionResponse, _ int) string { return s.Name })
+			assert.Equal(t, tt.wantNames, names)
+			assert.Equal(t, tt.wantPeak, response.Peak.Players)
+			assert.Equal(t, tt.wantUnique, response.UniquePlayers)
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_SessionDetails(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	serverRepo, sessionRepo := setupRepos(t, now)
+	rbacRepo := inmemory.NewRBACRepository()
+	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+	handler := NewHandler(serverRepo, playersessions.NewService(sessionRepo), rbacService, api.NewResponder())
+	apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/servers/1/players/sessions", nil)
+	req = req.WithContext(apitesting.ContextWithUser(&testUser1))
+	req = mux.SetURLVars(req, map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response sessionsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Sessions, 2)
+
+	alice := response.Sessions[0]
+	assert.Equal(t, "STEAM_1", alice.UniqID)
+	assert.Equal(t, int64(2*time.Hour/time.Second), alice.Duration)
+	require.NotNil(t, alice.LeftAt)
+
+	bob := response.Sessions[1]
+	assert.Nil(t, bob.LeftAt)
+
+	require.Len(t, response.Players, 2)
+	assert.Equal(t, []string{"bob"}, response.Players[0].Names)
+	assert.True(t, response.Players[0].Online)
+
+	require.NotNil(t, response.Peak.Time)
+	assert.True(t, response.Peak.Time.Equal(now.Add(-2*time.Hour)))
+}
@@ -0,0 +1,88 @@
+package getplayersessions
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/playersessions"
+)
+
+type sessionsResponse struct {
+	From          time.Time         `json:"from"`
+	To            time.Time         `json:"to"`
+	Peak          peakResponse      `json:"peak"`
+	UniquePlayers int               `json:"unique_players"`
+	Players       []playerResponse  `json:"players"`
+	Sessions      []sessionResponse `json:"sessions"`
+}
+
+type peakResponse struct {
+	Players int        `json:"players"`
+	Time    *time.Time `json:"time"`
+}
+
+type playerResponse struct {
+	UniqID        string    `json:"uniq_id"`
+	Names         []string  `json:"names"`
+	SessionsCount int       `json:"sessions_count"`
+	Playtime      int64     `json:"playtime"`
+	FirstSeenAt   time.Time `json:"first_seen_at"`
+	LastSeenAt    time.Time `json:"last_seen_at"`
+	Online        bool      `json:"online"`
+}
+
+type sessionResponse struct {
+	ID         uint       `json:"id"`
+	UniqID     string     `json:"uniq_id"`
+	Name       string     `json:"name"`
+	JoinedAt   time.Time  `json:"joined_at"`
+	LeftAt     *time.Time `json:"left_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	Duration   int64      `json:"duration"`
+}
+
+func newSessionsResponse(in *input, sessions []domain.PlayerSession, now time.Time) sessionsResponse {
+	peak, peakTime := playersessions.PeakConcurrency(sessions, in.From, in.To)
+	summaries := playersessions.Summarize(sessions, now)
+
+	response := sessionsResponse{
+		From:          in.From,
+		To:            in.To,
+		Peak:          peakResponse{Players: peak},
+		UniquePlayers: len(summaries),
+		Players:       make([]playerResponse, 0, len(summaries)),
+		Sessions:      make([]sessionResponse, 0, len(sessions)),
+	}
+
+	if peak > 0 {
+		response.Peak.Time = &peakTime
+	}
+
+	for _, summary := range summaries {
+		response.Players = append(response.Players, playerResponse{
+			UniqID:        summary.UniqID,
+			Names:         summary.Names,
+			SessionsCount: summary.SessionsCount,
+			Playtime:      int64(summary.Playtime.Seconds()),
+			FirstSeenAt:   summary.FirstSeenAt,
+			LastSeenAt:    summary.LastSeenAt,
+			Online:        summary.Online,
+		})
+	}
+
+	for i := range sessions {
+		session := &sessions[i]
+
+		response.Sessions = append(response.Sessions, sessionResponse{
+			ID:         session.ID,
+			UniqID:     session.UniqID,
+			Name:       session.Name,
+			JoinedAt:   session.JoinedAt,
+			LeftAt:     session.LeftAt,
+			LastSeenAt: session.LastSeenAt,
+			Duration:   int64(session.Duration(now).Seconds()),
+		})
+	}
+
+	return response
+}
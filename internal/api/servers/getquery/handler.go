@@ -2,33 +2,32 @@ package getquery
 
 import (
 	"net/http"
-	"strings"
 
 	"github.com/gameap/gameap/internal/api/base"
 	serversbase "github.com/gameap/gameap/internal/api/servers/base"
-	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/serverquery"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
-	"github.com/gameap/gameap/pkg/quercon/query"
 	"github.com/pkg/errors"
 )
 
 type Handler struct {
 	serverFinder *serversbase.ServerFinder
-	gameRepo     repositories.GameRepository
+	gameFinder   *serversbase.GameFinder
 	responder    base.Responder
 }
 
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	gameRepo repositories.GameRepository,
+	gameModRepo repositories.GameModRepository,
 	rbac base.RBAC,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
 		serverFinder: serversbase.NewServerFinder(serverRepo, rbac),
-		gameRepo:     gameRepo,
+		gameFinder:   serversbase.NewGameFinder(gameRepo, gameModRepo),
 		responder:    responder,
 	}
 }
@@ -65,42 +64,22 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	port := server.ServerPort
-	if server.QueryPort != nil {
-		port = *server.QueryPort
-	}
-
-	games, err := h.gameRepo.Find(ctx, filters.FindGameByCodes(server.GameID), nil, nil)
+	game, err := h.gameFinder.FindServerGame(ctx, server)
 	if err != nil {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.WithMessage(err, "failed to find game for server"),
-			http.StatusInternalServerError,
-		))
-
-		return
-	}
-	if len(games) == 0 {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.New("game for server not found"),
-			http.StatusInternalServerError,
-		))
+		h.responder.WriteError(ctx, rw, err)
 
 		return
 	}
 
-	game := games[0]
-
-	queryProtocol, ok := getQueryProtocolByEngine(strings.ToLower(game.Engine))
-	if !ok {
+	result, err := serverquery.Query(ctx, server, game)
+	if errors.Is(err, serverquery.ErrUnsupportedEngine) {
 		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.New("unsupported game engine for query"),
+			err,
 			http.StatusBadRequest,
 		))
 
 		return
 	}
-
-	result, err := query.Query(ctx, server.ServerIP, port, queryProtocol)
 	if err != nil && (result == nil || !result.Online) {
 		h.responder.Write(ctx, rw, newQueryResponse(nil, server))
 
@@ -3,6 +3,7 @@ package getquery
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -244,7 +245,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacRepo := inmemory.NewRBACRepository()
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
-			handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+			handler := NewHandler(serverRepo, gameRepo, inmemory.NewGameModRepository(), rbacService, responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, gameRepo, rbacRepo)
@@ -290,6 +291,102 @@ func TestHandler_ServeHTTP(t *testing.T) {
 	}
 }
 
+// startFakeMinecraftPingServer starts a TCP server which answers the Server List Ping with the given JSON status.
+func startFakeMinecraftPingServer(t *testing.T, status string) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		// Handshake and status request, the content is not checked
+		_, _ = conn.Read(make([]byte, 1024))
+
+		// Packet ID (0x00) and the JSON string prefixed with the length, lengths are single byte VarInts
+		body := append([]byte{0x00, byte(len(status))}, status...)
+		_, _ = conn.Write(append([]byte{byte(len(body))}, body...))
+	}()
+
+	tcpAddr, ok := listener.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+
+	return tcpAddr.Port
+}
+
+func TestHandler_ServeHTTP_MinecraftPingFallback(t *testing.T) {
+	port := startFakeMinecraftPingServer(t,
+		`{"version":{"name":"1.20.4"},"players":{"max":20,"online":3},"description":"Minecraft Server"}`,
+	)
+
+	serverRepo := inmemory.NewServerRepository()
+	gameRepo := inmemory.NewGameRepository()
+	rbacRepo := inmemory.NewRBACRepository()
+	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+	handler := NewHandler(serverRepo, gameRepo, inmemory.NewGameModRepository(), rbacService, api.NewResponder())
+
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "minecraft",
+		Name:   "Minecraft",
+		Engine: "Minecraft",
+	}))
+
+	now := time.Now()
+	require.NoError(t, serverRepo.Save(context.Background(), &domain.Server{
+		ID:         1,
+		UUID:       uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		Enabled:    true,
+		Installed:  1,
+		Name:       "Minecraft Server",
+		GameID:     "minecraft",
+		DSID:       1,
+		GameModID:  1,
+		ServerIP:   "127.0.0.1",
+		ServerPort: port,
+		CreatedAt:  &now,
+		UpdatedAt:  &now,
+	}))
+	serverRepo.AddUserServer(1, 1)
+
+	ctx := auth.ContextWithSession(context.Background(), &auth.Session{
+		Login: "testuser",
+		Email: "test@example.com",
+		User:  &testUser1,
+	})
+
+	// UDP query port is closed, the handler falls back to the Server List Ping on the game port
+	req := httptest.NewRequest(http.MethodGet, "/api/servers/1/query", nil)
+	req = req.WithContext(ctx)
+	req = mux.SetURLVars(req, map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response queryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	assert.Equal(t, "online", response.Status)
+	require.NotNil(t, response.Hostname)
+	assert.Equal(t, "Minecraft Server", *response.Hostname)
+	require.NotNil(t, response.Players)
+	assert.Equal(t, "3/20", *response.Players)
+	require.NotNil(t, response.Version)
+	assert.Equal(t, "1.20.4", *response.Version)
+}
+
 func TestHandler_NewHandler(t *testing.T) {
 	serverRepo := inmemory.NewServerRepository()
 	gameRepo := inmemory.NewGameRepository()
@@ -297,10 +394,10 @@ func TestHandler_NewHandler(t *testing.T) {
 	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+	handler := NewHandler(serverRepo, gameRepo, inmemory.NewGameModRepository(), rbacService, responder)
 
 	require.NotNil(t, handler)
-	assert.Equal(t, gameRepo, handler.gameRepo)
+	assert.NotNil(t, handler.gameFinder)
 	assert.Equal(t, responder, handler.responder)
 }
 
@@ -313,6 +410,12 @@ func TestNewQueryResponse(t *testing.T) {
 		wantHostname *string
 		wantMap      *string
 		wantPlayers  *string
+		wantVersion  *string
+		wantPassword *bool
+		wantSecure   *bool
+		wantBots     *int
+		wantTags     []string
+		wantRules    map[string]string
 		wantJoinLink *string
 	}{
 		{
@@ -353,6 +456,36 @@ func TestNewQueryResponse(t *testing.T) {
 			wantMap:      lo.ToPtr("de_dust2"),
 			wantPlayers:  lo.ToPtr("5/32"),
 		},
+		{
+			name: "online result with extended data",
+			result: &query.Result{
+				Online:        true,
+				Name:          "Test Server",
+				Map:           "de_dust2",
+				PlayersNum:    5,
+				MaxPlayersNum: 32,
+				Version:       "1.38.7.9",
+				Password:      true,
+				Secure:        true,
+				Bots:          2,
+				Tags:          []string{"secure", "competitive"},
+				Rules:         map[string]string{"mp_timelimit": "30"},
+			},
+			server: &domain.Server{
+				ServerIP:   "192.168.1.1",
+				ServerPort: 27015,
+			},
+			wantStatus:   "online",
+			wantHostname: lo.ToPtr("Test Server"),
+			wantMap:      lo.ToPtr("de_dust2"),
+			wantPlayers:  lo.ToPtr("5/32"),
+			wantVersion:  lo.ToPtr("1.38.7.9"),
+			wantPassword: lo.ToPtr(true),
+			wantSecure:   lo.ToPtr(true),
+			wantBots:     lo.ToPtr(2),
+			wantTags:     []string{"secure", "competitive"},
+			wantRules:    map[string]string{"mp_timelimit": "30"},
+		},
 		{
 			name: "online result with zero players",
 			result: &query.Result{
@@ -400,6 +533,17 @@ func TestNewQueryResponse(t *testing.T) {
 				assert.Nil(t, response.Players)
 			}
 
+			assert.Equal(t, tt.wantVersion, response.Version)
+			assert.Equal(t, tt.wantTags, response.Tags)
+			assert.Equal(t, tt.wantRules, response.Rules)
+
+			if tt.wantPassword != nil {
+				assert.Equal(t, tt.wantPassword, response.PasswordProtected)
+				assert.Nil(t, response.Password)
+				assert.Equal(t, tt.wantSecure, response.Secure)
+				assert.Equal(t, tt.wantBots, response.Bots)
+			}
+
 			if tt.wantJoinLink != nil {
 				require.NotNil(t, response.JoinLink)
 				assert.Equal(t, *tt.wantJoinLink, *response.JoinLink)
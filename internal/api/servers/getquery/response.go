@@ -8,13 +8,20 @@ import (
 )
 
 type queryResponse struct {
-	Status   string  `json:"status"`
-	Hostname *string `json:"hostname,omitempty"`
-	Map      *string `json:"map,omitempty"`
-	Players  *string `json:"players,omitempty"`
-	Version  *string `json:"version,omitempty"`
-	Password *string `json:"password,omitempty"`
-	JoinLink *string `json:"joinlink,omitempty"`
+	Status   string            `json:"status"`
+	Hostname *string           `json:"hostname,omitempty"`
+	Map      *string           `json:"map,omitempty"`
+	Players  *string           `json:"players,omitempty"`
+	Version  *string           `json:"version,omitempty"`
+	Password *string           `json:"password,omitempty"`
+	Secure   *bool             `json:"secure,omitempty"`
+	Bots     *int              `json:"bots,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Rules    map[string]string `json:"rules,omitempty"`
+	JoinLink *string           `json:"joinlink,omitempty"`
+
+	// PasswordProtected is set when the server requires a password to join.
+	PasswordProtected *bool `json:"password_protected,omitempty"`
 }
 
 func newQueryResponse(result *query.Result, _ *domain.Server) queryResponse {
@@ -26,10 +33,22 @@ func newQueryResponse(result *query.Result, _ *domain.Server) queryResponse {
 
 	players := fmt.Sprintf("%d/%d", result.PlayersNum, result.MaxPlayersNum)
 
-	return queryResponse{
+	response := queryResponse{
 		Status:   "online",
 		Hostname: &result.Name,
 		Map:      &result.Map,
 		Players:  &players,
+		Secure:   &result.Secure,
+		Bots:     &result.Bots,
+		Tags:     result.Tags,
+		Rules:    result.Rules,
+
+		PasswordProtected: &result.Password,
 	}
+
+	if result.Version != "" {
+		response.Version = &result.Version
+	}
+
+	return response
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/serverquery"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
@@ -16,6 +17,7 @@ import (
 type Handler struct {
 	serverFinder *serversbase.ServerFinder
 	gameRepo     repositories.GameRepository
+	queryStore   *serverquery.Store
 	rbac         base.RBAC
 	responder    base.Responder
 }
@@ -23,12 +25,14 @@ type Handler struct {
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	gameRepo repositories.GameRepository,
+	queryStore *serverquery.Store,
 	rbac base.RBAC,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
 		serverFinder: serversbase.NewServerFinder(serverRepo, rbac),
 		gameRepo:     gameRepo,
+		queryStore:   queryStore,
 		rbac:         rbac,
 		responder:    responder,
 	}
@@ -88,11 +92,22 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var query *queryState
+	if state, ok := h.queryStore.Get(server.ID); ok {
+		query = newQueryState(state)
+	}
+
 	if isAdmin {
-		h.responder.Write(ctx, rw, newAdminServerResponseFromServer(server, game))
+		response := newAdminServerResponseFromServer(server, game)
+		response.Query = query
+
+		h.responder.Write(ctx, rw, response)
 
 		return
 	}
 
-	h.responder.Write(ctx, rw, newUserServerResponseFromServer(server, game))
+	response := newUserServerResponseFromServer(server, game)
+	response.Query = query
+
+	h.responder.Write(ctx, rw, response)
 }
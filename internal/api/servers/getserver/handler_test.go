@@ -12,8 +12,10 @@ import (
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/serverquery"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gameap/gameap/pkg/quercon/query"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -235,7 +237,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacRepo := inmemory.NewRBACRepository()
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
-			handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+			handler := NewHandler(serverRepo, gameRepo, serverquery.NewStore(0), rbacService, responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, rbacRepo)
@@ -288,7 +290,8 @@ func TestHandler_ServerResponseFields(t *testing.T) {
 	rbacRepo := inmemory.NewRBACRepository()
 	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 	responder := api.NewResponder()
-	handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+	queryStore := serverquery.NewStore(time.Minute)
+	handler := NewHandler(serverRepo, gameRepo, queryStore, rbacService, responder)
 
 	now := time.Now()
 	userName := "Admin User"
@@ -358,6 +361,15 @@ func TestHandler_ServerResponseFields(t *testing.T) {
 	require.NoError(t, serverRepo.Save(context.Background(), server))
 	serverRepo.AddUserServer(1, 1)
 
+	queryStore.Set(1, query.Result{
+		Online:        true,
+		Name:          "Test Server",
+		Map:           "de_dust2",
+		PlayersNum:    1,
+		MaxPlayersNum: 32,
+		Players:       []query.ResultPlayer{{Name: "Player", Score: 10}},
+	})
+
 	session := &auth.Session{
 		Login: "admin",
 		Email: "admin@example.com",
@@ -421,6 +433,14 @@ func TestHandler_ServerResponseFields(t *testing.T) {
 	assert.Equal(t, "{\"key\":\"value\"}", *serverResp.Vars)
 	assert.NotNil(t, serverResp.CreatedAt)
 	assert.NotNil(t, serverResp.UpdatedAt)
+	require.NotNil(t, serverResp.Query)
+	assert.True(t, serverResp.Query.Online)
+	assert.Equal(t, "Test Server", serverResp.Query.Hostname)
+	assert.Equal(t, "de_dust2", serverResp.Query.Map)
+	assert.Equal(t, 1, serverResp.Query.PlayersNum)
+	assert.Equal(t, 32, serverResp.Query.MaxPlayersNum)
+	assert.Equal(t, []queryPlayerResponse{{Name: "Player", Score: 10}}, serverResp.Query.Players)
+	assert.False(t, serverResp.Query.Stale)
 }
 
 func TestHandler_NewHandler(t *testing.T) {
@@ -430,7 +450,7 @@ func TestHandler_NewHandler(t *testing.T) {
 	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+	handler := NewHandler(serverRepo, gameRepo, serverquery.NewStore(0), rbacService, responder)
 
 	require.NotNil(t, handler)
 	assert.NotNil(t, handler.serverFinder)
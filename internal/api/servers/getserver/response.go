@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/serverquery"
 )
 
 type adminGameResponse struct {
@@ -42,6 +43,7 @@ type adminServerResponse struct {
 	Game             *adminGameResponse `json:"game"`
 	LastProcessCheck *time.Time         `json:"last_process_check"`
 	Online           bool               `json:"online"`
+	Query            *queryState        `json:"query"`
 	Rcon             *string            `json:"rcon"`
 	Dir              string             `json:"dir"`
 	SuUser           *string            `json:"su_user"`
@@ -186,6 +188,7 @@ type userServerResponse struct {
 	Game             *userGameResponse `json:"game"`
 	LastProcessCheck *time.Time        `json:"last_process_check"`
 	Online           bool              `json:"online"`
+	Query            *queryState       `json:"query"`
 	ProcessActive    bool              `json:"process_active"`
 }
 
@@ -222,3 +225,41 @@ func newUserGameResponse(g *domain.Game) *userGameResponse {
 		EngineVersion: g.EngineVersion,
 	}
 }
+
+// queryState is the latest result of the background server query.
+type queryState struct {
+	Online        bool                  `json:"online"`
+	Hostname      string                `json:"hostname"`
+	Map           string                `json:"map"`
+	PlayersNum    int                   `json:"players_num"`
+	MaxPlayersNum int                   `json:"max_players_num"`
+	Players       []queryPlayerResponse `json:"players"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+	Stale         bool                  `json:"stale"`
+}
+
+type queryPlayerResponse struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+func newQueryState(state serverquery.State) *queryState {
+	players := make([]queryPlayerResponse, 0, len(state.Result.Players))
+	for _, player := range state.Result.Players {
+		players = append(players, queryPlayerResponse{
+			Name:  player.Name,
+			Score: player.Score,
+		})
+	}
+
+	return &queryState{
+		Online:        state.Result.Online,
+		Hostname:      state.Result.Name,
+		Map:           state.Result.Map,
+		PlayersNum:    state.Result.PlayersNum,
+		MaxPlayersNum: state.Result.MaxPlayersNum,
+		Players:       players,
+		UpdatedAt:     state.UpdatedAt,
+		Stale:         state.Stale,
+	}
+}
@@ -337,6 +337,7 @@ func TestHandler_AdminHasAllAbilities(t *testing.T) {
 	assert.True(t, abilities.GameServerConsoleSend)
 	assert.True(t, abilities.GameServerRconConsole)
 	assert.True(t, abilities.GameServerRconPlayers)
+	assert.True(t, abilities.GameServerRconFastRcon)
 }
 
 func TestHandler_RegularUserHasLimitedAbilities(t *testing.T) {
@@ -419,6 +420,7 @@ func TestHandler_RegularUserHasLimitedAbilities(t *testing.T) {
 	assert.False(t, abilities.GameServerConsoleSend)
 	assert.False(t, abilities.GameServerRconConsole)
 	assert.False(t, abilities.GameServerRconPlayers)
+	assert.False(t, abilities.GameServerRconFastRcon)
 }
 
 func TestHandler_NewHandler(t *testing.T) {
@@ -436,19 +438,20 @@ func TestHandler_NewHandler(t *testing.T) {
 
 func TestNewAbilitiesResponse(t *testing.T) {
 	abilities := map[domain.AbilityName]bool{
-		domain.AbilityNameGameServerCommon:      true,
-		domain.AbilityNameGameServerStart:       true,
-		domain.AbilityNameGameServerStop:        false,
-		domain.AbilityNameGameServerRestart:     false,
-		domain.AbilityNameGameServerPause:       false,
-		domain.AbilityNameGameServerUpdate:      false,
-		domain.AbilityNameGameServerFiles:       false,
-		domain.AbilityNameGameServerTasks:       false,
-		domain.AbilityNameGameServerSettings:    false,
-		domain.AbilityNameGameServerConsoleView: false,
-		domain.AbilityNameGameServerConsoleSend: false,
-		domain.AbilityNameGameServerRconConsole: false,
-		domain.AbilityNameGameServerRconPlayers: false,
+		domain.AbilityNameGameServerCommon:       true,
+		domain.AbilityNameGameServerStart:        true,
+		domain.AbilityNameGameServerStop:         false,
+		domain.AbilityNameGameServerRestart:      false,
+		domain.AbilityNameGameServerPause:        false,
+		domain.AbilityNameGameServerUpdate:       false,
+		domain.AbilityNameGameServerFiles:        false,
+		domain.AbilityNameGameServerTasks:        false,
+		domain.AbilityNameGameServerSettings:     false,
+		domain.AbilityNameGameServerConsoleView:  false,
+		domain.AbilityNameGameServerConsoleSend:  false,
+		domain.AbilityNameGameServerRconConsole:  false,
+		domain.AbilityNameGameServerRconPlayers:  false,
+		domain.AbilityNameGameServerRconFastRcon: true,
 	}
 
 	response := newAbilitiesResponse(abilities)
@@ -466,4 +469,5 @@ func TestNewAbilitiesResponse(t *testing.T) {
 	assert.False(t, response.GameServerConsoleSend)
 	assert.False(t, response.GameServerRconConsole)
 	assert.False(t, response.GameServerRconPlayers)
+	assert.True(t, response.GameServerRconFastRcon)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/serverquery"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
@@ -15,6 +16,7 @@ import (
 type Handler struct {
 	serverRepo repositories.ServerRepository
 	gameRepo   repositories.GameRepository
+	queryStore *serverquery.Store
 	rbac       base.RBAC
 	responder  base.Responder
 }
@@ -22,12 +24,14 @@ type Handler struct {
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	gameRepo repositories.GameRepository,
+	queryStore *serverquery.Store,
 	rbac base.RBAC,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
 		serverRepo: serverRepo,
 		gameRepo:   gameRepo,
+		queryStore: queryStore,
 		rbac:       rbac,
 		responder:  responder,
 	}
@@ -72,7 +76,12 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	serversResponse := newServersResponseFromServers(servers, games)
+	serverIDs := make([]uint, 0, len(servers))
+	for _, server := range servers {
+		serverIDs = append(serverIDs, server.ID)
+	}
+
+	serversResponse := newServersResponseFromServers(servers, games, h.queryStore.GetMany(serverIDs))
 
 	h.responder.Write(ctx, rw, serversResponse)
 }
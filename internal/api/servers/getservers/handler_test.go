@@ -12,8 +12,10 @@ import (
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/serverquery"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gameap/gameap/pkg/quercon/query"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -140,7 +142,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacRepo := inmemory.NewRBACRepository()
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
-			handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+			handler := NewHandler(serverRepo, gameRepo, serverquery.NewStore(0), rbacService, responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo)
@@ -189,7 +191,8 @@ func TestHandler_ServersResponseFields(t *testing.T) {
 	rbacRepo := inmemory.NewRBACRepository()
 	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 	responder := api.NewResponder()
-	handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+	queryStore := serverquery.NewStore(time.Minute)
+	handler := NewHandler(serverRepo, gameRepo, queryStore, rbacService, responder)
 
 	now := time.Now()
 	userName := "John Doe"
@@ -230,6 +233,14 @@ func TestHandler_ServersResponseFields(t *testing.T) {
 	require.NoError(t, serverRepo.Save(context.Background(), server))
 	serverRepo.AddUserServer(1, 1)
 
+	queryStore.Set(1, query.Result{
+		Online:        true,
+		Name:          "Test Server",
+		Map:           "de_dust2",
+		PlayersNum:    5,
+		MaxPlayersNum: 16,
+	})
+
 	session := &auth.Session{
 		Login: "johndoe",
 		Email: "john@example.com",
@@ -266,6 +277,13 @@ func TestHandler_ServersResponseFields(t *testing.T) {
 	require.NotNil(t, serverResp.RconPort)
 	assert.Equal(t, 27017, *serverResp.RconPort)
 	assert.True(t, serverResp.ProcessActive)
+	require.NotNil(t, serverResp.Query)
+	assert.True(t, serverResp.Query.Online)
+	assert.Equal(t, "de_dust2", serverResp.Query.Map)
+	assert.Equal(t, 5, serverResp.Query.PlayersNum)
+	assert.Equal(t, 16, serverResp.Query.MaxPlayersNum)
+	assert.False(t, serverResp.Query.Stale)
+	assert.WithinDuration(t, time.Now(), serverResp.Query.UpdatedAt, time.Minute)
 }
 
 func TestNewServersResponseFromServers(t *testing.T) {
@@ -310,10 +328,34 @@ func TestNewServersResponseFromServers(t *testing.T) {
 		},
 	}
 
-	response := newServersResponseFromServers(servers, games)
+	queryStates := map[uint]serverquery.State{
+		1: {
+			Result: query.Result{
+				Online:        true,
+				Name:          "CS Server",
+				Map:           "de_dust2",
+				PlayersNum:    3,
+				MaxPlayersNum: 32,
+			},
+			UpdatedAt: now,
+		},
+	}
+
+	response := newServersResponseFromServers(servers, games, queryStates)
 
 	require.Len(t, response, 2)
 
+	require.NotNil(t, response[0].Query)
+	assert.Equal(t, &queryState{
+		Online:        true,
+		Hostname:      "CS Server",
+		Map:           "de_dust2",
+		PlayersNum:    3,
+		MaxPlayersNum: 32,
+		UpdatedAt:     now,
+	}, response[0].Query)
+	assert.Nil(t, response[1].Query)
+
 	assert.Equal(t, uint(1), response[0].ID)
 	assert.Equal(t, "Server 1", response[0].Name)
 	assert.True(t, response[0].Enabled)
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/serverquery"
 )
 
 type gameResponse struct {
@@ -31,9 +32,37 @@ type serverResponse struct {
 	LastProcessCheck *time.Time    `json:"last_process_check"`
 	Game             *gameResponse `json:"game"`
 	Online           bool          `json:"online"`
+	Query            *queryState   `json:"query"`
 }
 
-func newServersResponseFromServers(servers []domain.Server, games []domain.Game) []serverResponse {
+// queryState is the latest result of the background server query.
+type queryState struct {
+	Online        bool      `json:"online"`
+	Hostname      string    `json:"hostname"`
+	Map           string    `json:"map"`
+	PlayersNum    int       `json:"players_num"`
+	MaxPlayersNum int       `json:"max_players_num"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Stale         bool      `json:"stale"`
+}
+
+func newQueryState(state serverquery.State) *queryState {
+	return &queryState{
+		Online:        state.Result.Online,
+		Hostname:      state.Result.Name,
+		Map:           state.Result.Map,
+		PlayersNum:    state.Result.PlayersNum,
+		MaxPlayersNum: state.Result.MaxPlayersNum,
+		UpdatedAt:     state.UpdatedAt,
+		Stale:         state.Stale,
+	}
+}
+
+func newServersResponseFromServers(
+	servers []domain.Server,
+	games []domain.Game,
+	queryStates map[uint]serverquery.State,
+) []serverResponse {
 	// Create a map of games by code for quick lookup
 	gamesByCode := make(map[string]*domain.Game)
 	for i := range games {
@@ -43,7 +72,13 @@ func newServersResponseFromServers(servers []domain.Server, games []domain.Game)
 	response := make([]serverResponse, 0, len(servers))
 
 	for _, s := range servers {
-		response = append(response, newServerResponseFromServer(&s, gamesByCode))
+		resp := newServerResponseFromServer(&s, gamesByCode)
+
+		if state, ok := queryStates[s.ID]; ok {
+			resp.Query = newQueryState(state)
+		}
+
+		response = append(response, resp)
 	}
 
 	return response
@@ -0,0 +1,223 @@
+package getstats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/serverstats"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func setupRepos(t *testing.T, now time.Time) (*inmemory.ServerRepository, *inmemory.ServerStatRepository) {
+	t.Helper()
+
+	serverRepo := inmemory.NewServerRepository()
+	statRepo := inmemory.NewServerStatRepository()
+
+	require.NoError(t, serverRepo.Save(context.Background(), &domain.Server{
+		ID:         1,
+		UUID:       uuid.New(),
+		UUIDShort:  "short1",
+		Enabled:    true,
+		Installed:  domain.ServerInstalledStatusInstalled,
+		Name:       "Test Server",
+		GameID:     "cstrike",
+		DSID:       1,
+		ServerIP:   "127.0.0.1",
+		ServerPort: 27015,
+	}))
+	serverRepo.AddUserServer(testUser1.ID, 1)
+
+	require.NoError(t, serverRepo.Save(context.Background(), &domain.Server{
+		ID:         2,
+		UUID:       uuid.New(),
+		UUIDShort:  "short2",
+		Enabled:    true,
+		Installed:  domain.ServerInstalledStatusInstalled,
+		Name:       "Other Server",
+		GameID:     "cstrike",
+		DSID:       1,
+		ServerIP:   "127.0.0.1",
+		ServerPort: 27016,
+	}))
+
+	hour := now.Add(-2 * time.Hour).Truncate(time.Hour)
+
+	stats := []domain.ServerStat{
+		{ServerID: 1, Time: hour.Add(time.Minute), Online: true, PlayersNum: 2, MaxPlayersNum: 10},
+		{ServerID: 1, Time: hour.Add(2 * time.Minute), Online: true, PlayersNum: 4, MaxPlayersNum: 10},
+		{ServerID: 1, Time: now.Add(-72 * time.Hour), Resolution: domain.ServerStatResolutionHourly, PlayersNum: 8},
+		{ServerID: 2, Time: hour.Add(time.Minute), Online: true, PlayersNum: 20},
+	}
+
+	for i := range stats {
+		// Rows without a kind are query samples
+		if stats[i].Kind == "" {
+			stats[i].Kind = domain.ServerStatKindQuery
+		}
+
+		require.NoError(t, statRepo.Save(context.Background(), &stats[i]))
+	}
+
+	return serverRepo, statRepo
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name                 string
+		serverID             string
+		query                string
+		ctx                  context.Context
+		expectedStatus       int
+		wantError            string
+		wantResolution       string
+		wantPlayersNums      []int
+		wantPointResolutions []uint
+	}{
+		{
+			name:           "user not authenticated",
+			serverID:       "1",
+			ctx:            context.Background(),
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "invalid server id",
+			serverID:       "invalid",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid server id",
+		},
+		{
+			name:           "server not found",
+			serverID:       "999",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "user does not have access to server",
+			serverID:       "2",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "invalid resolution",
+			serverID:       "1",
+			query:          "resolution=daily",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid resolution",
+		},
+		{
+			name:           "invalid time",
+			serverID:       "1",
+			query:          "from=yesterday",
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid from value",
+		},
+		{
+			name:           "from after to",
+			serverID:       "1",
+			query:          "from=" + now.Format(time.RFC3339) + "&to=" + now.Add(-time.Hour).Format(time.RFC3339),
+			ctx:            apitesting.ContextWithUser(&testUser1),
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "from must be before to",
+		},
+		{
+			name:                 "default range is the last day in raw resolution",
+			serverID:             "1",
+			ctx:                  apitesting.ContextWithUser(&testUser1),
+			expectedStatus:       http.StatusOK,
+			wantResolution:       "raw",
+			wantPlayersNums:      []int{2, 4},
+			wantPointResolutions: []uint{0, 0},
+		},
+		{
+			name:                 "long range is returned hourly",
+			serverID:             "1",
+			query:                "from=" + strconv.FormatInt(now.Add(-7*24*time.Hour).Unix(), 10),
+			ctx:                  apitesting.ContextWithUser(&testUser1),
+			expectedStatus:       http.StatusOK,
+			wantResolution:       "hourly",
+			wantPlayersNums:      []int{8, 3},
+			wantPointResolutions: []uint{3600, 3600},
+		},
+		{
+			name:                 "explicit raw resolution",
+			serverID:             "1",
+			query:                "resolution=raw&from=" + now.Add(-7*24*time.Hour).Format(time.RFC3339),
+			ctx:                  apitesting.ContextWithUser(&testUser1),
+			expectedStatus:       http.StatusOK,
+			wantResolution:       "raw",
+			wantPlayersNums:      []int{2, 4},
+			wantPointResolutions: []uint{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverRepo, statRepo := setupRepos(t, now)
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+			handler := NewHandler(serverRepo, serverstats.NewService(statRepo), rbacService, api.NewResponder())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/servers/"+tt.serverID+"/stats?"+tt.query, nil)
+			req = req.WithContext(tt.ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				errorMsg, ok := response["error"].(string)
+				require.True(t, ok)
+				assert.Contains(t, errorMsg, tt.wantError)
+
+				return
+			}
+
+			var response statsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantResolution, response.Resolution)
+
+			playersNums := make([]int, 0, len(response.Points))
+			resolutions := make([]uint, 0, len(response.Points))
+			for _, point := range response.Points {
+				playersNums = append(playersNums, point.PlayersNum)
+				resolutions = append(resolutions, point.Resolution)
+			}
+
+			assert.Equal(t, tt.wantPlayersNums, playersNums)
+			assert.Equal(t, tt.wantPointResolutions, resolutions)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package getstats
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/serverstats"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRange = 24 * time.Hour
+
+	resolutionRaw    = "raw"
+	resolutionHourly = "hourly"
+)
+
+type input struct {
+	From       time.Time
+	To         time.Time
+	Resolution domain.ServerStatResolution
+}
+
+func readInput(r *http.Request, now time.Time) (*input, error) {
+	queryReader := api.NewQueryReader(r)
+
+	result := &input{
+		From: now.Add(-defaultRange),
+		To:   now,
+	}
+
+	from, err := queryReader.ReadTime("from")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid from value")
+	}
+	if from != nil {
+		result.From = *from
+	}
+
+	to, err := queryReader.ReadTime("to")
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid to value")
+	}
+	if to != nil {
+		result.To = *to
+	}
+
+	if !result.From.Before(result.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	resolution, err := queryReader.ReadString("resolution")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read resolution")
+	}
+
+	switch resolution {
+	case resolutionRaw:
+		result.Resolution = domain.ServerStatResolutionRaw
+	case resolutionHourly:
+		result.Resolution = domain.ServerStatResolutionHourly
+	case "":
+		// Raw samples are kept only for a limited time, older ones are available hourly
+		if result.To.Sub(result.From) > serverstats.RawRetention {
+			result.Resolution = domain.ServerStatResolutionHourly
+		} else {
+			result.Resolution = domain.ServerStatResolutionRaw
+		}
+	default:
+		return nil, errors.Errorf("invalid resolution %q, expected %q or %q", resolution, resolutionRaw, resolutionHourly)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package getstats
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type statsResponse struct {
+	From       time.Time       `json:"from"`
+	To         time.Time       `json:"to"`
+	Resolution string          `json:"resolution"`
+	Points     []pointResponse `json:"points"`
+}
+
+type pointResponse struct {
+	Time          time.Time `json:"time"`
+	Resolution    uint      `json:"resolution"`
+	Online        bool      `json:"online"`
+	PlayersNum    int       `json:"players_num"`
+	MaxPlayersNum int       `json:"max_players_num"`
+}
+
+func newStatsResponse(in *input, stats []domain.ServerStat) statsResponse {
+	response := statsResponse{
+		From:       in.From,
+		To:         in.To,
+		Resolution: resolutionRaw,
+		Points:     make([]pointResponse, 0, len(stats)),
+	}
+
+	if in.Resolution == domain.ServerStatResolutionHourly {
+		response.Resolution = resolutionHourly
+	}
+
+	for _, stat := range stats {
+		response.Points = append(response.Points, pointResponse{
+			Time:          stat.Time,
+			Resolution:    uint(stat.Resolution),
+			Online:        stat.Online,
+			PlayersNum:    stat.PlayersNum,
+			MaxPlayersNum: stat.MaxPlayersNum,
+		})
+	}
+
+	return response
+}
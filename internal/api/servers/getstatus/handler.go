@@ -6,23 +6,31 @@ import (
 	"github.com/gameap/gameap/internal/api/base"
 	serversbase "github.com/gameap/gameap/internal/api/servers/base"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/serverresources"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
 )
 
+type usageProvider interface {
+	Usage(serverID uint) (serverresources.Usage, bool)
+}
+
 type Handler struct {
 	serverFinder *serversbase.ServerFinder
+	usages       usageProvider
 	responder    base.Responder
 }
 
 func NewHandler(
 	serverRepo repositories.ServerRepository,
+	usages usageProvider,
 	rbac base.RBAC,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
 		serverFinder: serversbase.NewServerFinder(serverRepo, rbac),
+		usages:       usages,
 		responder:    responder,
 	}
 }
@@ -59,5 +67,10 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.responder.Write(ctx, rw, newStatusResponse(server))
+	var usage *serverresources.Usage
+	if u, ok := h.usages.Usage(server.ID); ok {
+		usage = &u
+	}
+
+	h.responder.Write(ctx, rw, newStatusResponse(server, usage))
 }
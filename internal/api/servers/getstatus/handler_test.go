@@ -12,10 +12,12 @@ import (
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/serverresources"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,6 +34,14 @@ var testUser2 = domain.User{
 	Email: "admin@example.com",
 }
 
+type fakeUsages map[uint]serverresources.Usage
+
+func (f fakeUsages) Usage(serverID uint) (serverresources.Usage, bool) {
+	usage, ok := f[serverID]
+
+	return usage, ok
+}
+
 func TestHandler_ServeHTTP(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -315,7 +325,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacRepo := inmemory.NewRBACRepository()
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
-			handler := NewHandler(serverRepo, rbacService, responder)
+			handler := NewHandler(serverRepo, fakeUsages{}, rbacService, responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, rbacRepo)
@@ -355,7 +365,7 @@ func TestHandler_NewHandler(t *testing.T) {
 	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, rbacService, responder)
+	handler := NewHandler(serverRepo, fakeUsages{}, rbacService, responder)
 
 	require.NotNil(t, handler)
 	assert.NotNil(t, handler.serverFinder)
@@ -420,8 +430,87 @@ func TestNewStatusResponse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			response := newStatusResponse(tt.server)
+			response := newStatusResponse(tt.server, nil)
 			assert.Equal(t, tt.expectedProcessActive, response.ProcessActive)
 		})
 	}
 }
+
+func TestHandler_ServeHTTP_Resources(t *testing.T) {
+	now := time.Now()
+	lastCheck := now.Add(-30 * time.Second)
+
+	serverRepo := inmemory.NewServerRepository()
+	require.NoError(t, serverRepo.Save(context.Background(), &domain.Server{
+		ID:               1,
+		UUID:             uuid.New(),
+		UUIDShort:        "short1",
+		Enabled:          true,
+		Installed:        1,
+		Name:             "Test Server 1",
+		GameID:           "cs",
+		DSID:             1,
+		ServerIP:         "127.0.0.1",
+		ServerPort:       27015,
+		ProcessActive:    true,
+		LastProcessCheck: &lastCheck,
+		CPULimit:         lo.ToPtr(100),
+		RAMLimit:         lo.ToPtr(512),
+	}))
+	serverRepo.AddUserServer(testUser1.ID, 1)
+
+	usages := fakeUsages{
+		1: {
+			Time:    now,
+			CPU:     42.5,
+			RSS:     256 * 1024 * 1024,
+			NetRX:   1000,
+			NetTX:   2000,
+			NetRate: lo.ToPtr(128.0),
+		},
+	}
+
+	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), inmemory.NewRBACRepository(), 0)
+	handler := NewHandler(serverRepo, usages, rbacService, api.NewResponder())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/servers/1/status", nil)
+	req = req.WithContext(auth.ContextWithSession(context.Background(), &auth.Session{
+		Login: testUser1.Login,
+		Email: testUser1.Email,
+		User:  &testUser1,
+	}))
+	req = mux.SetURLVars(req, map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var status statusResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+
+	resources := status.Resources
+	require.NotNil(t, resources.UpdatedAt)
+	require.NotNil(t, resources.CPU.Usage)
+	assert.InDelta(t, 42.5, *resources.CPU.Usage, 0.001)
+	require.NotNil(t, resources.CPU.Limit)
+	assert.InDelta(t, 100, *resources.CPU.Limit, 0.001)
+	assert.Equal(t, lo.ToPtr(uint64(256*1024*1024)), resources.RAM.Usage)
+	assert.Equal(t, lo.ToPtr(uint64(512*1024*1024)), resources.RAM.Limit)
+	require.NotNil(t, resources.Net.Usage)
+	assert.InDelta(t, 128, *resources.Net.Usage, 0.001)
+	assert.Nil(t, resources.Net.Limit)
+	assert.Equal(t, lo.ToPtr(uint64(1000)), resources.Net.RXBytes)
+	assert.Equal(t, lo.ToPtr(uint64(2000)), resources.Net.TXBytes)
+}
+
+func TestNewStatusResponse_WithoutUsage(t *testing.T) {
+	response := newStatusResponse(&domain.Server{NetLimit: lo.ToPtr(64)}, nil)
+
+	assert.Nil(t, response.Resources.UpdatedAt)
+	assert.Nil(t, response.Resources.CPU.Usage)
+	assert.Nil(t, response.Resources.CPU.Limit)
+	assert.Nil(t, response.Resources.RAM.Usage)
+	require.NotNil(t, response.Resources.Net.Limit)
+	assert.InDelta(t, 64*1024, *response.Resources.Net.Limit, 0.001)
+}
@@ -1,15 +1,68 @@
 package getstatus
 
 import (
+	"time"
+
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/serverresources"
 )
 
 type statusResponse struct {
-	ProcessActive bool `json:"processActive"`
+	ProcessActive bool              `json:"processActive"`
+	Resources     resourcesResponse `json:"resources"`
+}
+
+// resourcesResponse is the latest resource usage next to the server limits.
+// Usage is null until it is collected with the node stats script, limit is null when the resource isn't limited.
+type resourcesResponse struct {
+	UpdatedAt *time.Time  `json:"updated_at"`
+	CPU       cpuResponse `json:"cpu"`
+	RAM       ramResponse `json:"ram"`
+	Net       netResponse `json:"net"`
+}
+
+// cpuResponse is in percent of one core.
+type cpuResponse struct {
+	Usage *float64 `json:"usage"`
+	Limit *float64 `json:"limit"`
+}
+
+// ramResponse is in bytes.
+type ramResponse struct {
+	Usage *uint64 `json:"usage"`
+	Limit *uint64 `json:"limit"`
 }
 
-func newStatusResponse(s *domain.Server) statusResponse {
-	return statusResponse{
+// netResponse usage and limit are in bytes per second.
+type netResponse struct {
+	Usage   *float64 `json:"usage"`
+	Limit   *float64 `json:"limit"`
+	RXBytes *uint64  `json:"rx_bytes"`
+	TXBytes *uint64  `json:"tx_bytes"`
+}
+
+func newStatusResponse(s *domain.Server, usage *serverresources.Usage) statusResponse {
+	limits := serverresources.ServerLimits(s)
+
+	response := statusResponse{
 		ProcessActive: s.IsOnline(),
+		Resources: resourcesResponse{
+			CPU: cpuResponse{Limit: limits.CPU},
+			RAM: ramResponse{Limit: limits.RAM},
+			Net: netResponse{Limit: limits.Net},
+		},
 	}
+
+	if usage == nil {
+		return response
+	}
+
+	response.Resources.UpdatedAt = &usage.Time
+	response.Resources.CPU.Usage = &usage.CPU
+	response.Resources.RAM.Usage = &usage.RSS
+	response.Resources.Net.Usage = usage.NetRate
+	response.Resources.Net.RXBytes = &usage.NetRX
+	response.Resources.Net.TXBytes = &usage.NetTX
+
+	return response
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	apitesting "github.com/gameap/gameap/internal/api/testing"
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
@@ -724,7 +725,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				tt.setupRepo(serverRepo, rbacRepo)
 			}
 
-			handler := NewHandler(serverRepo, serverControlService, rbacService, responder)
+			handler := NewHandler(serverRepo, serverControlService, apitesting.NewNodeHealthChecker(t), rbacService, responder)
 
 			ctx := context.Background()
 			if tt.setupAuth != nil {
@@ -775,7 +776,7 @@ func TestHandler_NewHandler(t *testing.T) {
 	)
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, serverControlService, rbacService, responder)
+	handler := NewHandler(serverRepo, serverControlService, apitesting.NewNodeHealthChecker(t), rbacService, responder)
 
 	require.NotNil(t, handler)
 	assert.NotNil(t, handler.serverFinder)
@@ -800,7 +801,7 @@ func TestHandler_CommandMapContainsAllCommands(t *testing.T) {
 	)
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, serverControlService, rbacService, responder)
+	handler := NewHandler(serverRepo, serverControlService, apitesting.NewNodeHealthChecker(t), rbacService, responder)
 
 	expectedCommands := []string{"start", "stop", "restart", "update", "install", "reinstall"}
 
@@ -824,7 +825,7 @@ func TestHandler_AbilitiesMapContainsCorrectAbilities(t *testing.T) {
 	)
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, serverControlService, rbacService, responder)
+	handler := NewHandler(serverRepo, serverControlService, apitesting.NewNodeHealthChecker(t), rbacService, responder)
 
 	tests := []struct {
 		command           string
@@ -1003,7 +1004,7 @@ func TestHandler_FindUserServer(t *testing.T) {
 			)
 			responder := api.NewResponder()
 
-			handler := NewHandler(serverRepo, serverControlService, rbacService, responder)
+			handler := NewHandler(serverRepo, serverControlService, apitesting.NewNodeHealthChecker(t), rbacService, responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, rbacRepo)
@@ -1108,7 +1109,7 @@ func TestHandler_DaemonTaskCreation(t *testing.T) {
 				allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, abilityName)
 			}
 
-			handler := NewHandler(serverRepo, serverControlService, rbacService, responder)
+			handler := NewHandler(serverRepo, serverControlService, apitesting.NewNodeHealthChecker(t), rbacService, responder)
 
 			session := &auth.Session{
 				Login: "testuser",
@@ -1143,3 +1144,64 @@ func TestHandler_DaemonTaskCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_NodeOffline(t *testing.T) {
+	serverRepo := inmemory.NewServerRepository()
+	rbacRepo := inmemory.NewRBACRepository()
+	daemonTaskRepo := inmemory.NewDaemonTaskRepository()
+
+	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+	serverControlService := servercontrol.NewService(
+		daemonTaskRepo,
+		inmemory.NewServerSettingRepository(),
+		services.NewNilTransactionManager(),
+	)
+
+	now := time.Now()
+	startCmd := testStartCommand
+	server := &domain.Server{
+		ID:           1,
+		UUID:         uuid.New(),
+		Enabled:      true,
+		Installed:    1,
+		Name:         "Test Server",
+		GameID:       "cstrike",
+		DSID:         1,
+		ServerIP:     "192.168.1.1",
+		ServerPort:   27015,
+		StartCommand: &startCmd,
+	}
+	require.NoError(t, serverRepo.Save(context.Background(), server))
+	serverRepo.AddUserServer(testUser1.ID, server.ID)
+
+	allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, domain.AbilityNameGameServerCommon)
+	allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, domain.AbilityNameGameServerStart)
+
+	nodeHealth := apitesting.NewNodeHealthChecker(t, domain.NodeHealth{
+		NodeID:    1,
+		Online:    false,
+		Error:     "connection refused",
+		CheckedAt: now,
+	})
+
+	handler := NewHandler(serverRepo, serverControlService, nodeHealth, rbacService, api.NewResponder())
+
+	ctx := auth.ContextWithSession(context.Background(), &auth.Session{
+		Login: "testuser",
+		Email: "test@example.com",
+		User:  &testUser1,
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/servers/1/command/start", nil)
+	req = req.WithContext(ctx)
+	req = mux.SetURLVars(req, map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	tasks, err := daemonTaskRepo.FindAll(ctx, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, tasks, "no task is created while the node is offline")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/nodehealth"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
@@ -34,6 +35,8 @@ type fileService interface {
 type Handler struct {
 	serverFinder   *serversbase.ServerFinder
 	abilityChecker *serversbase.AbilityChecker
+	policyChecker  *serversbase.CommandPolicyChecker
+	nodeState      *serversbase.NodeStateChecker
 	nodeRepo       repositories.NodeRepository
 	daemonCommands daemonCommands
 	fileService    fileService
@@ -43,6 +46,8 @@ type Handler struct {
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	nodeRepo repositories.NodeRepository,
+	nodeHealth *nodehealth.Checker,
+	commandPolicyRepo repositories.CommandPolicyRepository,
 	rbac base.RBAC,
 	daemonCommands daemonCommands,
 	fs fileService,
@@ -51,6 +56,8 @@ func NewHandler(
 	return &Handler{
 		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
 		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		policyChecker:  serversbase.NewCommandPolicyChecker(commandPolicyRepo, rbac),
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
 		nodeRepo:       nodeRepo,
 		daemonCommands: daemonCommands,
 		fileService:    fs,
@@ -120,6 +127,20 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := h.policyChecker.CheckOrError(
+		ctx, session.User.ID, server.ID, domain.CommandPolicyTargetConsole, in.Command,
+	); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err := h.nodeState.CheckOrError(ctx, server); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
 	if err := h.sendConsoleCommand(ctx, server, in.Command); err != nil {
 		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to send console command"))
 
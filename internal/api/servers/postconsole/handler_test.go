@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	apitesting "github.com/gameap/gameap/internal/api/testing"
 	"github.com/gameap/gameap/internal/daemon"
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/rbac"
@@ -753,7 +754,10 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			responder := api.NewResponder()
 			mockFS := tt.setupMockFS()
 			mockDaemon := tt.setupMockDaemon()
-			handler := NewHandler(serverRepo, nodeRepo, rbacService, mockDaemon, mockFS, responder)
+			handler := NewHandler(
+				serverRepo, nodeRepo, apitesting.NewNodeHealthChecker(t), inmemory.NewCommandPolicyRepository(),
+				rbacService, mockDaemon, mockFS, responder,
+			)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, nodeRepo, rbacRepo)
@@ -805,6 +809,128 @@ func TestHandler_ServeHTTP(t *testing.T) {
 	}
 }
 
+func TestHandler_ServeHTTP_CommandPolicies(t *testing.T) {
+	tests := []struct {
+		name           string
+		command        string
+		expectedStatus int
+		wantError      string
+		wantUpload     bool
+	}{
+		{
+			name:           "allowed_command",
+			command:        "say hello",
+			expectedStatus: http.StatusOK,
+			wantUpload:     true,
+		},
+		{
+			name:           "denied_command",
+			command:        "quit",
+			expectedStatus: http.StatusForbidden,
+			wantError:      `command "quit" is denied by command policy #1 (glob "quit")`,
+		},
+		{
+			name:           "denied_chained_command",
+			command:        "say bye; quit",
+			expectedStatus: http.StatusForbidden,
+			wantError:      `command "quit" is denied by command policy #1 (glob "quit")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Now()
+
+			serverRepo := inmemory.NewServerRepository()
+			nodeRepo := inmemory.NewNodeRepository()
+			policyRepo := inmemory.NewCommandPolicyRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+
+			uploaded := false
+			mockFS := &mockFileService{
+				uploadFunc: func(_ context.Context, _ *domain.Node, _ string, _ []byte, _ os.FileMode) error {
+					uploaded = true
+
+					return nil
+				},
+			}
+
+			handler := NewHandler(
+				serverRepo, nodeRepo, apitesting.NewNodeHealthChecker(t), policyRepo,
+				rbacService, &mockDaemonCommands{}, mockFS, api.NewResponder(),
+			)
+
+			require.NoError(t, nodeRepo.Save(ctx, &domain.Node{
+				ID:          1,
+				Enabled:     true,
+				Name:        "test-node",
+				OS:          "linux",
+				WorkPath:    "/srv/gameap",
+				GdaemonHost: "172.18.0.5",
+				GdaemonPort: 31717,
+				CreatedAt:   &now,
+				UpdatedAt:   &now,
+			}))
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:        1,
+				UUID:      uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+				UUIDShort: "short1",
+				Enabled:   true,
+				Installed: 1,
+				Name:      "Test Server 1",
+				GameID:    "cs",
+				DSID:      1,
+				GameModID: 1,
+				ServerIP:  "127.0.0.1",
+				Dir:       "/home/gameap/servers/test1",
+				CreatedAt: &now,
+				UpdatedAt: &now,
+			}))
+			serverRepo.AddUserServer(testUser1.ID, 1)
+
+			require.NoError(t, rbacRepo.Allow(ctx, testUser1.ID, domain.EntityTypeUser, []domain.Ability{
+				{
+					Name:       domain.AbilityNameGameServerConsoleSend,
+					EntityID:   lo.ToPtr(uint(1)),
+					EntityType: lo.ToPtr(domain.EntityTypeServer),
+				},
+			}))
+
+			require.NoError(t, policyRepo.Save(ctx, &domain.CommandPolicy{
+				Target:      domain.CommandPolicyTargetConsole,
+				Action:      domain.CommandPolicyActionDeny,
+				PatternType: domain.CommandPolicyPatternTypeGlob,
+				Pattern:     "quit",
+			}))
+
+			body, err := json.Marshal(map[string]string{"command": tt.command})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/servers/1/console", bytes.NewReader(body))
+			req = req.WithContext(auth.ContextWithSession(ctx, &auth.Session{
+				Login: testUser1.Login,
+				Email: testUser1.Email,
+				User:  &testUser1,
+			}))
+			req = mux.SetURLVars(req, map[string]string{"server": "1"})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.wantUpload, uploaded)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantError, response["error"])
+			}
+		})
+	}
+}
+
 func TestHandler_NewHandler(t *testing.T) {
 	serverRepo := inmemory.NewServerRepository()
 	nodeRepo := inmemory.NewNodeRepository()
@@ -814,7 +940,10 @@ func TestHandler_NewHandler(t *testing.T) {
 	mockDaemon := &mockDaemonCommands{}
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, nodeRepo, rbacService, mockDaemon, mockFS, responder)
+	handler := NewHandler(
+		serverRepo, nodeRepo, apitesting.NewNodeHealthChecker(t), inmemory.NewCommandPolicyRepository(),
+		rbacService, mockDaemon, mockFS, responder,
+	)
 
 	require.NotNil(t, handler)
 	assert.NotNil(t, handler.serverFinder)
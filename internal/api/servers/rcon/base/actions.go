@@ -0,0 +1,124 @@
+package base
+
+import (
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/pkg/errors"
+)
+
+// Action is a server action performed with a command template of the game mod.
+type Action string
+
+const (
+	// ActionChangeMap changes the map with the ChmapCmd template, {map} is replaced with the map name.
+	ActionChangeMap Action = "change-map"
+
+	// ActionChangeName changes the server name with the ChnameCmd template, {name} is replaced with the name.
+	ActionChangeName Action = "change-name"
+
+	// ActionSetPassword sets the server password with the PasswdCmd template,
+	// {password} is replaced with the password.
+	ActionSetPassword Action = "set-password"
+
+	// ActionRestart restarts the game on the server with the SrestartCmd template.
+	ActionRestart Action = "restart"
+
+	// ActionSendMessage sends a message to all players with the SendmsgCmd template,
+	// {msg} is replaced with the message.
+	ActionSendMessage Action = "send-message"
+)
+
+var ErrActionNotAvailable = errors.New("action is not available for the game mod")
+
+// Actions is the list of all actions in the order they are reported.
+var Actions = []Action{
+	ActionChangeMap,
+	ActionChangeName,
+	ActionSetPassword,
+	ActionRestart,
+	ActionSendMessage,
+}
+
+func (a Action) IsValid() bool {
+	switch a {
+	case ActionChangeMap, ActionChangeName, ActionSetPassword, ActionRestart, ActionSendMessage:
+		return true
+	default:
+		return false
+	}
+}
+
+// Placeholder returns the name of the template placeholder replaced with the action argument,
+// empty for actions without an argument.
+func (a Action) Placeholder() string {
+	switch a {
+	case ActionChangeMap:
+		return "map"
+	case ActionChangeName:
+		return "name"
+	case ActionSetPassword:
+		return "password"
+	case ActionSendMessage:
+		return "msg"
+	default:
+		return ""
+	}
+}
+
+// Template returns the command template of the action from the game mod, empty when it is not set.
+func (a Action) Template(gameMod *domain.GameMod) string {
+	if gameMod == nil {
+		return ""
+	}
+
+	var template *string
+
+	switch a {
+	case ActionChangeMap:
+		template = gameMod.ChmapCmd
+	case ActionChangeName:
+		template = gameMod.ChnameCmd
+	case ActionSetPassword:
+		template = gameMod.PasswdCmd
+	case ActionRestart:
+		template = gameMod.SrestartCmd
+	case ActionSendMessage:
+		template = gameMod.SendmsgCmd
+	}
+
+	if template == nil {
+		return ""
+	}
+
+	return *template
+}
+
+// AvailableActions returns the actions with command templates set in the game mod.
+func AvailableActions(gameMod *domain.GameMod) []Action {
+	result := make([]Action, 0, len(Actions))
+
+	for _, action := range Actions {
+		if action.Template(gameMod) != "" {
+			result = append(result, action)
+		}
+	}
+
+	return result
+}
+
+// RenderAction renders the command template of the action with the argument.
+// The argument is escaped the same way as in other command templates.
+func RenderAction(gameMod *domain.GameMod, action Action, argument string) (string, error) {
+	template := action.Template(gameMod)
+	if template == "" {
+		return "", ErrActionNotAvailable
+	}
+
+	placeholder := action.Placeholder()
+	if placeholder == "" {
+		return template, nil
+	}
+
+	return RenderCommandTemplate(template, map[string]string{
+		placeholder: argument,
+	}), nil
+}
@@ -0,0 +1,105 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailableActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		gameMod *domain.GameMod
+		want    []Action
+	}{
+		{
+			name:    "nil_game_mod",
+			gameMod: nil,
+			want:    []Action{},
+		},
+		{
+			name: "all_templates",
+			gameMod: &domain.GameMod{
+				ChmapCmd:    lo.ToPtr("changelevel {map}"),
+				ChnameCmd:   lo.ToPtr("hostname {name}"),
+				PasswdCmd:   lo.ToPtr("sv_password {password}"),
+				SrestartCmd: lo.ToPtr("restart"),
+				SendmsgCmd:  lo.ToPtr(`say "{msg}"`),
+			},
+			want: []Action{ActionChangeMap, ActionChangeName, ActionSetPassword, ActionRestart, ActionSendMessage},
+		},
+		{
+			name: "empty_templates_are_skipped",
+			gameMod: &domain.GameMod{
+				ChmapCmd:    lo.ToPtr("changelevel {map}"),
+				ChnameCmd:   lo.ToPtr(""),
+				SrestartCmd: lo.ToPtr("restart"),
+			},
+			want: []Action{ActionChangeMap, ActionRestart},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AvailableActions(tt.gameMod))
+		})
+	}
+}
+
+func TestRenderAction(t *testing.T) {
+	gameMod := &domain.GameMod{
+		ChmapCmd:    lo.ToPtr("changelevel {map}"),
+		PasswdCmd:   lo.ToPtr(`sv_password "{password}"`),
+		SrestartCmd: lo.ToPtr("sv_restart 1"),
+	}
+
+	tests := []struct {
+		name     string
+		action   Action
+		argument string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "change_map",
+			action:   ActionChangeMap,
+			argument: "de_dust2",
+			want:     "changelevel de_dust2",
+		},
+		{
+			name:     "argument_is_escaped",
+			action:   ActionSetPassword,
+			argument: `"; quit`,
+			want:     `sv_password "', quit"`,
+		},
+		{
+			name:   "action_without_argument",
+			action: ActionRestart,
+			want:   "sv_restart 1",
+		},
+		{
+			name:     "template_not_set",
+			action:   ActionChangeName,
+			argument: "My Server",
+			wantErr:  ErrActionNotAvailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderAction(gameMod, tt.action, tt.argument)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
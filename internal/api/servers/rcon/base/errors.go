@@ -0,0 +1,29 @@
+package base
+
+import (
+	"net/http"
+
+	"github.com/gameap/gameap/internal/services/rconexec"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// WrapExecutorError sets the HTTP status of the RCON executor errors.
+// Other errors are returned as is.
+func WrapExecutorError(err error) error {
+	switch {
+	case errors.Is(err, rconexec.ErrServerOffline),
+		errors.Is(err, rconexec.ErrConnectionFailed):
+		return api.WrapHTTPError(err, http.StatusServiceUnavailable)
+	case errors.Is(err, rconexec.ErrRconPasswordNotConfigured):
+		return api.WrapHTTPError(err, http.StatusPreconditionFailed)
+	case errors.Is(err, rconexec.ErrUnsupportedProtocol):
+		return api.WrapHTTPError(err, http.StatusBadRequest)
+	case errors.Is(err, rconexec.ErrAuthenticationFailed):
+		return api.WrapHTTPError(err, http.StatusUnprocessableEntity)
+	case errors.Is(err, rconexec.ErrCommandFailed):
+		return api.WrapHTTPError(err, http.StatusInternalServerError)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,68 @@
+package base
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gameap/gameap/internal/services/rconexec"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapExecutorError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectStatus int
+	}{
+		{
+			name:         "server is offline",
+			err:          rconexec.ErrServerOffline,
+			expectStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:         "connection failed",
+			err:          errors.WithMessage(rconexec.ErrConnectionFailed, "connection refused"),
+			expectStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:         "rcon password not configured",
+			err:          rconexec.ErrRconPasswordNotConfigured,
+			expectStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:         "unsupported protocol",
+			err:          rconexec.ErrUnsupportedProtocol,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "authentication failed",
+			err:          rconexec.ErrAuthenticationFailed,
+			expectStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "command failed",
+			err:          errors.WithMessage(rconexec.ErrCommandFailed, "EOF"),
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := WrapExecutorError(test.err)
+
+			var httpErr *api.WrappedError
+			require.True(t, errors.As(err, &httpErr))
+			assert.Equal(t, test.expectStatus, httpErr.HTTPStatus())
+			assert.Equal(t, test.err.Error(), err.Error())
+		})
+	}
+}
+
+func TestWrapExecutorError_OtherErrors(t *testing.T) {
+	err := errors.New("game for server not found")
+
+	assert.Equal(t, err, WrapExecutorError(err))
+}
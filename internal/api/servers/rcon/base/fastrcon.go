@@ -0,0 +1,88 @@
+package base
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	maxFastRconStringLength = 128
+	maxFastRconPlayerLength = 64
+)
+
+var (
+	ErrFastRconArgumentRequired  = errors.New("argument is required")
+	ErrFastRconArgumentTooLong   = errors.New("argument is too long")
+	ErrFastRconArgumentNotInt    = errors.New("argument must be an integer")
+	ErrFastRconArgumentNotInEnum = errors.New("argument is not one of the allowed values")
+	ErrFastRconUnknownArgument   = errors.New("unknown argument")
+	ErrFastRconUnknownParamType  = errors.New("unknown parameter type")
+)
+
+// RenderFastRcon validates the arguments against the parameters of the fast RCON entry
+// and substitutes them into the command. Arguments are escaped the same way as in other command templates.
+func RenderFastRcon(fastRcon domain.GameModFastRcon, args map[string]string) (string, error) {
+	values := make(map[string]string, len(fastRcon.Params))
+
+	for _, param := range fastRcon.Params {
+		value, err := validateFastRconArgument(param, args[param.Name])
+		if err != nil {
+			return "", errors.WithMessagef(err, "invalid argument %s", param.Name)
+		}
+
+		values[param.Name] = value
+	}
+
+	for name := range args {
+		if _, ok := values[name]; !ok {
+			return "", errors.WithMessage(ErrFastRconUnknownArgument, name)
+		}
+	}
+
+	return RenderCommandTemplate(fastRcon.Command, values), nil
+}
+
+func validateFastRconArgument(param domain.GameModFastRconParam, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", ErrFastRconArgumentRequired
+	}
+
+	switch param.Type {
+	case domain.GameModFastRconParamTypeString:
+		if len(value) > maxFastRconStringLength {
+			return "", ErrFastRconArgumentTooLong
+		}
+	case domain.GameModFastRconParamTypePlayer:
+		if len(value) > maxFastRconPlayerLength {
+			return "", ErrFastRconArgumentTooLong
+		}
+	case domain.GameModFastRconParamTypeInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", ErrFastRconArgumentNotInt
+		}
+
+		if param.Min != nil && n < *param.Min {
+			return "", errors.Errorf("argument must be at least %d", *param.Min)
+		}
+
+		if param.Max != nil && n > *param.Max {
+			return "", errors.Errorf("argument must not exceed %d", *param.Max)
+		}
+
+		return strconv.Itoa(n), nil
+	case domain.GameModFastRconParamTypeEnum:
+		if !lo.Contains(param.Options, value) {
+			return "", ErrFastRconArgumentNotInEnum
+		}
+	default:
+		return "", ErrFastRconUnknownParamType
+	}
+
+	return value, nil
+}
@@ -0,0 +1,129 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderFastRcon(t *testing.T) {
+	changeMap := domain.GameModFastRcon{
+		Info:    "Change map",
+		Command: "changelevel {map}",
+		Params: []domain.GameModFastRconParam{
+			{Name: "map", Type: domain.GameModFastRconParamTypeEnum, Options: []string{"de_dust2", "de_inferno"}},
+		},
+	}
+
+	setSlots := domain.GameModFastRcon{
+		Info:    "Set slots",
+		Command: "maxplayers {slots}",
+		Params: []domain.GameModFastRconParam{
+			{Name: "slots", Type: domain.GameModFastRconParamTypeInt, Min: lo.ToPtr(1), Max: lo.ToPtr(32)},
+		},
+	}
+
+	kick := domain.GameModFastRcon{
+		Info:    "Kick with reason",
+		Command: `kick "{player}" "{reason}"`,
+		Params: []domain.GameModFastRconParam{
+			{Name: "player", Type: domain.GameModFastRconParamTypePlayer},
+			{Name: "reason", Type: domain.GameModFastRconParamTypeString},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		fastRcon domain.GameModFastRcon
+		args     map[string]string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "without_params",
+			fastRcon: domain.GameModFastRcon{Info: "Status", Command: "status"},
+			want:     "status",
+		},
+		{
+			name:     "enum",
+			fastRcon: changeMap,
+			args:     map[string]string{"map": "de_inferno"},
+			want:     "changelevel de_inferno",
+		},
+		{
+			name:     "enum_not_allowed",
+			fastRcon: changeMap,
+			args:     map[string]string{"map": "de_nuke"},
+			wantErr:  "invalid argument map: argument is not one of the allowed values",
+		},
+		{
+			name:     "int",
+			fastRcon: setSlots,
+			args:     map[string]string{"slots": " 016 "},
+			want:     "maxplayers 16",
+		},
+		{
+			name:     "int_not_a_number",
+			fastRcon: setSlots,
+			args:     map[string]string{"slots": "16; quit"},
+			wantErr:  "invalid argument slots: argument must be an integer",
+		},
+		{
+			name:     "int_below_min",
+			fastRcon: setSlots,
+			args:     map[string]string{"slots": "0"},
+			wantErr:  "invalid argument slots: argument must be at least 1",
+		},
+		{
+			name:     "int_above_max",
+			fastRcon: setSlots,
+			args:     map[string]string{"slots": "33"},
+			wantErr:  "invalid argument slots: argument must not exceed 32",
+		},
+		{
+			name:     "string_and_player_are_escaped",
+			fastRcon: kick,
+			args:     map[string]string{"player": "Player", "reason": `bye"; rcon_password 1`},
+			want:     `kick "Player" "bye', rcon_password 1"`,
+		},
+		{
+			name:     "missing_argument",
+			fastRcon: kick,
+			args:     map[string]string{"player": "Player"},
+			wantErr:  "invalid argument reason: argument is required",
+		},
+		{
+			name:     "unknown_argument",
+			fastRcon: changeMap,
+			args:     map[string]string{"map": "de_dust2", "extra": "1"},
+			wantErr:  "extra: unknown argument",
+		},
+		{
+			name: "unknown_param_type",
+			fastRcon: domain.GameModFastRcon{
+				Command: "exec {file}",
+				Params:  []domain.GameModFastRconParam{{Name: "file", Type: "file"}},
+			},
+			args:    map[string]string{"file": "server.cfg"},
+			wantErr: "invalid argument file: unknown parameter type",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			command, err := RenderFastRcon(test.fastRcon, test.args)
+
+			if test.wantErr != "" {
+				require.EqualError(t, err, test.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, command)
+		})
+	}
+}
@@ -3,55 +3,22 @@ package base
 import (
 	"testing"
 
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestDetermineProtocolByEngine(t *testing.T) {
-	tests := []struct {
-		name     string
-		engine   string
-		want     string
-		wantErr  bool
-		errorMsg string
-	}{
-		{
-			name:    "source_engine",
-			engine:  "source",
-			want:    "source",
-			wantErr: false,
-		},
-		{
-			name:    "goldsource_engine",
-			engine:  "goldsource",
-			want:    "goldsource",
-			wantErr: false,
-		},
-		{
-			name:     "unsupported_engine",
-			engine:   "unreal",
-			wantErr:  true,
-			errorMsg: "unable to determine RCON protocol for engine",
-		},
-		{
-			name:     "empty_engine",
-			engine:   "",
-			wantErr:  true,
-			errorMsg: "unable to determine RCON protocol for engine",
-		},
-	}
+func TestNewPlayerManager(t *testing.T) {
+	mgr, err := NewPlayerManager(domain.Game{Code: "rust", PlayerManager: lo.ToPtr(players.ManagerSource)})
+	require.NoError(t, err)
+	assert.IsType(t, &players.SourcePlayerManager{}, mgr)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			protocol, err := DetermineProtocolByEngine(tt.engine)
+	mgr, err = NewPlayerManager(domain.Game{Code: "minecraft"})
+	require.NoError(t, err)
+	assert.IsType(t, &players.MinecraftPlayerManager{}, mgr)
 
-			if tt.wantErr {
-				require.Error(t, err)
-				assert.Contains(t, err.Error(), tt.errorMsg)
-			} else {
-				require.NoError(t, err)
-				assert.Equal(t, tt.want, string(protocol))
-			}
-		})
-	}
+	_, err = NewPlayerManager(domain.Game{Code: "rust"})
+	require.ErrorIs(t, err, players.ErrPlayersManagementNotSupported)
 }
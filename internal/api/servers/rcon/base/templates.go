@@ -0,0 +1,21 @@
+package base
+
+import (
+	"strings"
+
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+)
+
+// RenderCommandTemplate replaces {key} placeholders of the game mod command template
+// (e.g. `say "{msg}"`) with the values.
+// The values are user input, so they are escaped with players.EscapeArgument
+// to stay a single argument of a single command.
+func RenderCommandTemplate(template string, values map[string]string) string {
+	oldnew := make([]string, 0, len(values)*2)
+
+	for key, value := range values {
+		oldnew = append(oldnew, "{"+key+"}", players.EscapeArgument(value))
+	}
+
+	return strings.NewReplacer(oldnew...).Replace(template)
+}
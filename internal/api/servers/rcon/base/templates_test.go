@@ -0,0 +1,59 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderCommandTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		values   map[string]string
+		want     string
+	}{
+		{
+			name:     "quoted_placeholder",
+			template: `say "{msg}"`,
+			values:   map[string]string{"msg": "hello"},
+			want:     `say "hello"`,
+		},
+		{
+			name:     "unquoted_placeholder",
+			template: "changelevel {map}",
+			values:   map[string]string{"map": "de_dust2"},
+			want:     "changelevel de_dust2",
+		},
+		{
+			name:     "quotes_are_replaced",
+			template: `say "{msg}"`,
+			values:   map[string]string{"msg": `"; rcon_password 123; say "`},
+			want:     `say "', rcon_password 123, say '"`,
+		},
+		{
+			name:     "line_breaks_are_replaced",
+			template: "say {msg}",
+			values:   map[string]string{"msg": "hello\nquit\r\n"},
+			want:     "say hello quit",
+		},
+		{
+			name:     "unknown_placeholders_are_kept",
+			template: "say {msg} {other}",
+			values:   map[string]string{"msg": "hello"},
+			want:     "say hello {other}",
+		},
+		{
+			name:     "placeholders_in_values_are_not_replaced",
+			template: "{a} {b}",
+			values:   map[string]string{"a": "{b}", "b": "x"},
+			want:     "{b} x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RenderCommandTemplate(tt.template, tt.values))
+		})
+	}
+}
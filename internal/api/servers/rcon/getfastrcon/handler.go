@@ -1,6 +1,7 @@
 package getfastrcon
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gameap/gameap/internal/api/base"
@@ -66,9 +67,7 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = h.abilityChecker.CheckOrError(
-		ctx, session.User.ID, server.ID, []domain.AbilityName{domain.AbilityNameGameServerRconConsole},
-	); err != nil {
+	if err = h.checkAbilities(ctx, session.User.ID, server.ID); err != nil {
 		h.responder.WriteError(ctx, rw, err)
 
 		return
@@ -98,3 +97,21 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 
 	h.responder.Write(ctx, rw, newFastRconResponse(gameMod.FastRcon))
 }
+
+// checkAbilities checks that the user can run either arbitrary commands or fast RCON macros.
+func (h *Handler) checkAbilities(ctx context.Context, userID, serverID uint) error {
+	canUseConsole, err := h.abilityChecker.Check(
+		ctx, userID, serverID, []domain.AbilityName{domain.AbilityNameGameServerRconConsole},
+	)
+	if err != nil {
+		return err
+	}
+
+	if canUseConsole {
+		return nil
+	}
+
+	return h.abilityChecker.CheckOrError(
+		ctx, userID, serverID, []domain.AbilityName{domain.AbilityNameGameServerRconFastRcon},
+	)
+}
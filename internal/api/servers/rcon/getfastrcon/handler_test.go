@@ -16,6 +16,7 @@ import (
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -174,6 +175,112 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectFastRcon: true,
 		},
+		{
+			name:     "fast_rcon_ability_only",
+			serverID: "1",
+			setupAuth: func() context.Context {
+				session := &auth.Session{
+					Login: "testuser",
+					Email: "test@example.com",
+					User:  &testUser1,
+				}
+
+				return auth.ContextWithSession(context.Background(), session)
+			},
+			setupRepo: func(
+				serverRepo *inmemory.ServerRepository,
+				gameModRepo *inmemory.GameModRepository,
+				rbacRepo *inmemory.RBACRepository,
+			) {
+				now := time.Now()
+
+				server := &domain.Server{
+					ID:            1,
+					UUID:          uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+					UUIDShort:     "short1",
+					Enabled:       true,
+					Installed:     1,
+					Blocked:       false,
+					Name:          "Test Server 1",
+					GameID:        "cs",
+					DSID:          1,
+					GameModID:     1,
+					ServerIP:      "127.0.0.1",
+					ServerPort:    27015,
+					Dir:           "/home/gameap/servers/test1",
+					ProcessActive: false,
+					CreatedAt:     &now,
+					UpdatedAt:     &now,
+				}
+
+				gameMod := &domain.GameMod{
+					ID:       1,
+					GameCode: "cs",
+					Name:     "Counter-Strike 1.6",
+					FastRcon: domain.GameModFastRconList{},
+				}
+
+				require.NoError(t, serverRepo.Save(context.Background(), server))
+				serverRepo.AddUserServer(1, 1)
+				require.NoError(t, gameModRepo.Save(context.Background(), gameMod))
+
+				allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, domain.AbilityNameGameServerRconFastRcon)
+			},
+			expectedStatus: http.StatusOK,
+			expectFastRcon: true,
+		},
+		{
+			name:     "user_without_rcon_abilities",
+			serverID: "1",
+			setupAuth: func() context.Context {
+				session := &auth.Session{
+					Login: "testuser",
+					Email: "test@example.com",
+					User:  &testUser1,
+				}
+
+				return auth.ContextWithSession(context.Background(), session)
+			},
+			setupRepo: func(
+				serverRepo *inmemory.ServerRepository,
+				gameModRepo *inmemory.GameModRepository,
+				_ *inmemory.RBACRepository,
+			) {
+				now := time.Now()
+
+				server := &domain.Server{
+					ID:            1,
+					UUID:          uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+					UUIDShort:     "short1",
+					Enabled:       true,
+					Installed:     1,
+					Blocked:       false,
+					Name:          "Test Server 1",
+					GameID:        "cs",
+					DSID:          1,
+					GameModID:     1,
+					ServerIP:      "127.0.0.1",
+					ServerPort:    27015,
+					Dir:           "/home/gameap/servers/test1",
+					ProcessActive: false,
+					CreatedAt:     &now,
+					UpdatedAt:     &now,
+				}
+
+				gameMod := &domain.GameMod{
+					ID:       1,
+					GameCode: "cs",
+					Name:     "Counter-Strike 1.6",
+					FastRcon: domain.GameModFastRconList{},
+				}
+
+				require.NoError(t, serverRepo.Save(context.Background(), server))
+				serverRepo.AddUserServer(1, 1)
+				require.NoError(t, gameModRepo.Save(context.Background(), gameMod))
+			},
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
 		{
 			name:     "server_not_found",
 			serverID: "999",
@@ -548,6 +655,19 @@ func TestNewFastRconResponse(t *testing.T) {
 			},
 			want: 2,
 		},
+		{
+			name: "with_params",
+			fastRcon: domain.GameModFastRconList{
+				{
+					Info:    "Change map",
+					Command: "changelevel {map}",
+					Params: []domain.GameModFastRconParam{
+						{Name: "map", Type: domain.GameModFastRconParamTypeEnum, Options: []string{"de_dust2"}},
+					},
+				},
+			},
+			want: 1,
+		},
 		{
 			name:     "empty_list",
 			fastRcon: domain.GameModFastRconList{},
@@ -570,8 +690,28 @@ func TestNewFastRconResponse(t *testing.T) {
 				for i, item := range tt.fastRcon {
 					assert.Equal(t, item.Info, response[i].Info)
 					assert.Equal(t, item.Command, response[i].Command)
+					assert.Len(t, response[i].Params, len(item.Params))
 				}
 			}
 		})
 	}
 }
+
+func TestNewFastRconResponse_Params(t *testing.T) {
+	response := newFastRconResponse(domain.GameModFastRconList{
+		{
+			Info:    "Set slots",
+			Command: "maxplayers {slots}",
+			Params: []domain.GameModFastRconParam{
+				{Name: "slots", Type: domain.GameModFastRconParamTypeInt, Label: "Slots", Min: lo.ToPtr(1), Max: lo.ToPtr(32)},
+				{Name: "player", Type: domain.GameModFastRconParamTypePlayer},
+			},
+		},
+	})
+
+	require.Len(t, response, 1)
+	assert.Equal(t, []fastRconItemParam{
+		{Name: "slots", Type: "int", Label: "Slots", Min: lo.ToPtr(1), Max: lo.ToPtr(32)},
+		{Name: "player", Type: "player", Label: "player"},
+	}, response[0].Params)
+}
@@ -3,8 +3,18 @@ package getfastrcon
 import "github.com/gameap/gameap/internal/domain"
 
 type fastRconItem struct {
-	Info    string `json:"info"`
-	Command string `json:"command"`
+	Info    string              `json:"info"`
+	Command string              `json:"command"`
+	Params  []fastRconItemParam `json:"params"`
+}
+
+type fastRconItemParam struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Label   string   `json:"label"`
+	Options []string `json:"options,omitempty"`
+	Min     *int     `json:"min,omitempty"`
+	Max     *int     `json:"max,omitempty"`
 }
 
 type fastRconResponse []fastRconItem
@@ -19,8 +29,30 @@ func newFastRconResponse(fastRcon domain.GameModFastRconList) fastRconResponse {
 		response = append(response, fastRconItem{
 			Info:    item.Info,
 			Command: item.Command,
+			Params:  newFastRconItemParams(item.Params),
 		})
 	}
 
 	return response
 }
+
+func newFastRconItemParams(params []domain.GameModFastRconParam) []fastRconItemParam {
+	result := make([]fastRconItemParam, 0, len(params))
+	for _, param := range params {
+		label := param.Label
+		if label == "" {
+			label = param.Name
+		}
+
+		result = append(result, fastRconItemParam{
+			Name:    param.Name,
+			Type:    string(param.Type),
+			Label:   label,
+			Options: param.Options,
+			Min:     param.Min,
+			Max:     param.Max,
+		})
+	}
+
+	return result
+}
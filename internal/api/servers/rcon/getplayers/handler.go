@@ -2,41 +2,59 @@ package getplayers
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
 	"net/http"
-	"time"
 
 	"github.com/gameap/gameap/internal/api/base"
 	serversbase "github.com/gameap/gameap/internal/api/servers/base"
 	rconbase "github.com/gameap/gameap/internal/api/servers/rcon/base"
 	"github.com/gameap/gameap/internal/domain"
-	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/internal/services/rconexec"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
-	"github.com/gameap/gameap/pkg/quercon/rcon"
 	"github.com/gameap/gameap/pkg/quercon/rcon/players"
 	"github.com/pkg/errors"
 )
 
+// playersTracker records players lists into the player sessions history.
+type playersTracker interface {
+	TrackRconPlayers(ctx context.Context, serverID uint, list []players.Player) error
+}
+
+type commandExecutor interface {
+	ExecuteGame(ctx context.Context, server *domain.Server, game *domain.Game, command string) (string, error)
+}
+
 type Handler struct {
 	serverFinder   *serversbase.ServerFinder
 	abilityChecker *serversbase.AbilityChecker
-	gameRepo       repositories.GameRepository
+	nodeState      *serversbase.NodeStateChecker
+	gameFinder     *serversbase.GameFinder
+	executor       commandExecutor
+	tracker        playersTracker
 	responder      base.Responder
 }
 
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	gameRepo repositories.GameRepository,
+	gameModRepo repositories.GameModRepository,
+	tracker playersTracker,
+	nodeHealth *nodehealth.Checker,
 	rbac base.RBAC,
 	responder base.Responder,
 ) *Handler {
+	gameFinder := serversbase.NewGameFinder(gameRepo, gameModRepo)
+
 	return &Handler{
 		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
 		abilityChecker: serversbase.NewAbilityChecker(rbac),
-		gameRepo:       gameRepo,
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
+		gameFinder:     gameFinder,
+		executor:       rconexec.NewExecutor(gameFinder),
+		tracker:        tracker,
 		responder:      responder,
 	}
 }
@@ -69,42 +87,29 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !server.IsOnline() {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.New("server is offline"),
-			http.StatusServiceUnavailable,
-		))
-
-		return
-	}
-
-	game, err := h.findGame(ctx, server.GameID)
-	if err != nil {
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
 		h.responder.WriteError(ctx, rw, err)
 
 		return
 	}
 
-	protocol, err := rconbase.DetermineProtocol(*game)
-	if err != nil {
+	if !server.IsOnline() {
 		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.WithMessage(err, "unsupported game"),
-			http.StatusBadRequest,
+			errors.New("server is offline"),
+			http.StatusServiceUnavailable,
 		))
 
 		return
 	}
 
-	if server.Rcon == nil || *server.Rcon == "" {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.New("rcon password not configured for server"),
-			http.StatusPreconditionFailed,
-		))
+	game, err := h.gameFinder.FindServerGame(ctx, server)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
 
 		return
 	}
 
-	playerManager, err := players.NewPlayerManagerByGameCode(server.GameID)
+	playerManager, err := rconbase.NewPlayerManager(*game)
 	if err != nil {
 		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
 			errors.WithMessage(err, "player management not supported for this game"),
@@ -114,16 +119,33 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	playersList, err := h.getPlayers(ctx, server, protocol, playerManager)
+	playersList, err := h.getPlayers(ctx, server, game, playerManager)
 	if err != nil {
 		h.responder.WriteError(ctx, rw, err)
 
 		return
 	}
 
+	h.trackPlayers(ctx, server.ID, playersList)
+
 	h.responder.Write(ctx, rw, newPlayersResponse(playersList))
 }
 
+func (h *Handler) trackPlayers(ctx context.Context, serverID uint, list []players.Player) {
+	if h.tracker == nil {
+		return
+	}
+
+	if err := h.tracker.TrackRconPlayers(ctx, serverID, list); err != nil {
+		slog.WarnContext(
+			ctx,
+			"Failed to track player sessions",
+			slog.Uint64("server_id", uint64(serverID)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
 func (h *Handler) getServer(ctx context.Context, r *http.Request, user *domain.User) (*domain.Server, error) {
 	input := api.NewInputReader(r)
 
@@ -138,74 +160,15 @@ func (h *Handler) getServer(ctx context.Context, r *http.Request, user *domain.U
 	return h.serverFinder.FindUserServer(ctx, user, serverID)
 }
 
-func (h *Handler) findGame(ctx context.Context, gameID string) (*domain.Game, error) {
-	games, err := h.gameRepo.Find(ctx, filters.FindGameByCodes(gameID), nil, nil)
-	if err != nil {
-		return nil, api.WrapHTTPError(
-			errors.WithMessage(err, "failed to find game for server"),
-			http.StatusInternalServerError,
-		)
-	}
-	if len(games) == 0 {
-		return nil, api.WrapHTTPError(
-			errors.New("game for server not found"),
-			http.StatusInternalServerError,
-		)
-	}
-
-	return &games[0], nil
-}
-
 func (h *Handler) getPlayers(
 	ctx context.Context,
 	server *domain.Server,
-	protocol rcon.Protocol,
+	game *domain.Game,
 	playerManager players.PlayerManager,
 ) ([]players.Player, error) {
-	rconAddress := fmt.Sprintf("%s:%d", server.ServerIP, getRconPort(server))
-
-	rconConfig := rcon.Config{
-		Address:  rconAddress,
-		Password: *server.Rcon,
-		Protocol: protocol,
-		Timeout:  10 * time.Second,
-	}
-
-	client, err := rcon.NewClient(rconConfig)
-	if err != nil {
-		return nil, api.WrapHTTPError(
-			errors.WithMessage(err, "failed to create rcon client"),
-			http.StatusInternalServerError,
-		)
-	}
-
-	if err := client.Open(ctx); err != nil {
-		if errors.Is(err, rcon.ErrAuthenticationFailed) {
-			return nil, api.WrapHTTPError(
-				errors.WithMessage(err, "rcon authentication failed"),
-				http.StatusUnprocessableEntity,
-			)
-		}
-
-		return nil, api.WrapHTTPError(
-			errors.WithMessage(err, "failed to connect to rcon"),
-			http.StatusServiceUnavailable,
-		)
-	}
-	defer func(client rcon.Client) {
-		err := client.Close()
-		if err != nil {
-			slog.WarnContext(ctx, "failed to close rcon client")
-		}
-	}(client)
-
-	command := playerManager.PlayersCommand()
-	output, err := client.Execute(ctx, command)
+	output, err := h.executor.ExecuteGame(ctx, server, game, playerManager.PlayersCommand())
 	if err != nil {
-		return nil, api.WrapHTTPError(
-			errors.WithMessage(err, "failed to execute rcon command"),
-			http.StatusInternalServerError,
-		)
+		return nil, rconbase.WrapExecutorError(err)
 	}
 
 	playersList, err := playerManager.ParsePlayers(output)
@@ -218,11 +181,3 @@ func (h *Handler) getPlayers(
 
 	return playersList, nil
 }
-
-func getRconPort(server *domain.Server) int {
-	if server.RconPort != nil {
-		return *server.RconPort
-	}
-
-	return server.ServerPort
-}
@@ -8,8 +8,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/samber/lo"
-
+	apitesting "github.com/gameap/gameap/internal/api/testing"
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
@@ -460,7 +459,15 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacRepo := inmemory.NewRBACRepository()
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
-			handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+			handler := NewHandler(
+				serverRepo,
+				gameRepo,
+				inmemory.NewGameModRepository(),
+				nil,
+				apitesting.NewNodeHealthChecker(t),
+				rbacService,
+				responder,
+			)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, gameRepo, rbacRepo)
@@ -502,46 +509,22 @@ func TestHandler_NewHandler(t *testing.T) {
 	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+	handler := NewHandler(
+		serverRepo,
+		gameRepo,
+		inmemory.NewGameModRepository(),
+		nil,
+		apitesting.NewNodeHealthChecker(t),
+		rbacService,
+		responder,
+	)
 
 	require.NotNil(t, handler)
 	assert.NotNil(t, handler.serverFinder)
-	assert.NotNil(t, handler.gameRepo)
+	assert.NotNil(t, handler.gameFinder)
 	assert.Equal(t, responder, handler.responder)
 }
 
-func TestGetRconPort(t *testing.T) {
-	tests := []struct {
-		name   string
-		server *domain.Server
-		want   int
-	}{
-		{
-			name: "custom_rcon_port",
-			server: &domain.Server{
-				ServerPort: 27015,
-				RconPort:   lo.ToPtr(27020),
-			},
-			want: 27020,
-		},
-		{
-			name: "default_to_server_port",
-			server: &domain.Server{
-				ServerPort: 27015,
-				RconPort:   nil,
-			},
-			want: 27015,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			port := getRconPort(tt.server)
-			assert.Equal(t, tt.want, port)
-		})
-	}
-}
-
 func TestNewPlayersResponse(t *testing.T) {
 	tests := []struct {
 		name    string
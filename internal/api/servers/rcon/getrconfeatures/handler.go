@@ -16,20 +16,23 @@ import (
 type Handler struct {
 	serverFinder   *serversbase.ServerFinder
 	abilityChecker *serversbase.AbilityChecker
-	gameRepo       repositories.GameRepository
+	gameFinder     *serversbase.GameFinder
+	gameModRepo    repositories.GameModRepository
 	responder      base.Responder
 }
 
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	gameRepo repositories.GameRepository,
+	gameModRepo repositories.GameModRepository,
 	rbac base.RBAC,
 	responder base.Responder,
 ) *Handler {
 	return &Handler{
 		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
 		abilityChecker: serversbase.NewAbilityChecker(rbac),
-		gameRepo:       gameRepo,
+		gameFinder:     serversbase.NewGameFinder(gameRepo, gameModRepo),
+		gameModRepo:    gameModRepo,
 		responder:      responder,
 	}
 }
@@ -74,25 +77,24 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	games, err := h.gameRepo.Find(ctx, filters.FindGameByCodes(server.GameID), nil, nil)
+	game, err := h.gameFinder.FindServerGame(ctx, server)
 	if err != nil {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.WithMessage(err, "failed to find game for server"),
-			http.StatusInternalServerError,
-		))
+		h.responder.WriteError(ctx, rw, err)
 
 		return
 	}
-	if len(games) == 0 {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.New("game for server not found"),
-			http.StatusInternalServerError,
-		))
+
+	gameMods, err := h.gameModRepo.Find(ctx, &filters.FindGameMod{IDs: []uint{server.GameModID}}, nil, nil)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "failed to find game mod"))
 
 		return
 	}
 
-	game := games[0]
+	var gameMod *domain.GameMod
+	if len(gameMods) > 0 {
+		gameMod = &gameMods[0]
+	}
 
-	h.responder.Write(ctx, rw, newFeaturesResponse(game))
+	h.responder.Write(ctx, rw, newFeaturesResponse(*game, gameMod))
 }
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	rconbase "github.com/gameap/gameap/internal/api/servers/rcon/base"
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
@@ -16,6 +17,7 @@ import (
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -165,7 +167,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			expectedStatus:        http.StatusOK,
 			expectFeatures:        true,
 			expectedRcon:          true,
-			expectedPlayersManage: false,
+			expectedPlayersManage: true,
 		},
 		{
 			name:     "successful_features_retrieval__unsupported_engine_supported_game_code",
@@ -413,7 +415,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			expectedStatus:        http.StatusOK,
 			expectFeatures:        true,
 			expectedRcon:          true,
-			expectedPlayersManage: false,
+			expectedPlayersManage: true,
 		},
 		{
 			name:     "game_not_found_for_server",
@@ -466,7 +468,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacRepo := inmemory.NewRBACRepository()
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
-			handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+			handler := NewHandler(serverRepo, gameRepo, inmemory.NewGameModRepository(), rbacService, responder)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, gameRepo, rbacRepo)
@@ -508,11 +510,11 @@ func TestHandler_NewHandler(t *testing.T) {
 	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 	responder := api.NewResponder()
 
-	handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+	handler := NewHandler(serverRepo, gameRepo, inmemory.NewGameModRepository(), rbacService, responder)
 
 	require.NotNil(t, handler)
 	assert.NotNil(t, handler.serverFinder)
-	assert.NotNil(t, handler.gameRepo)
+	assert.NotNil(t, handler.gameFinder)
 	assert.Equal(t, responder, handler.responder)
 }
 
@@ -548,9 +550,21 @@ func TestNewFeaturesResponse(t *testing.T) {
 			expectedPlayersManage: false,
 		},
 		{
-			name:                  "source_engine_with_unsupported_game",
+			name:                  "source_engine_with_csgo_game",
 			game:                  domain.Game{Code: "csgo", Engine: "source"},
 			expectedRcon:          true,
+			expectedPlayersManage: true,
+		},
+		{
+			name:                  "source_engine_with_tf2_game",
+			game:                  domain.Game{Code: "tf2", Engine: "source"},
+			expectedRcon:          true,
+			expectedPlayersManage: true,
+		},
+		{
+			name:                  "source_engine_with_unsupported_game",
+			game:                  domain.Game{Code: "unknown_game", Engine: "source"},
+			expectedRcon:          true,
 			expectedPlayersManage: false,
 		},
 		{
@@ -587,9 +601,50 @@ func TestNewFeaturesResponse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			response := newFeaturesResponse(tt.game)
+			response := newFeaturesResponse(tt.game, nil)
 			assert.Equal(t, tt.expectedRcon, response.Rcon)
 			assert.Equal(t, tt.expectedPlayersManage, response.PlayersManage)
 		})
 	}
 }
+
+func TestNewFeaturesResponse_Actions(t *testing.T) {
+	gameMod := &domain.GameMod{
+		ChmapCmd:    lo.ToPtr("changelevel {map}"),
+		SrestartCmd: lo.ToPtr("restart"),
+		SendmsgCmd:  lo.ToPtr(""),
+	}
+
+	tests := []struct {
+		name        string
+		game        domain.Game
+		gameMod     *domain.GameMod
+		wantActions []rconbase.Action
+	}{
+		{
+			name:        "game_mod_templates",
+			game:        domain.Game{Code: "cs", Engine: "goldsource"},
+			gameMod:     gameMod,
+			wantActions: []rconbase.Action{rconbase.ActionChangeMap, rconbase.ActionRestart},
+		},
+		{
+			name:        "game_mod_not_found",
+			game:        domain.Game{Code: "cs", Engine: "goldsource"},
+			gameMod:     nil,
+			wantActions: []rconbase.Action{},
+		},
+		{
+			name:        "rcon_not_supported",
+			game:        domain.Game{Code: "rust", Engine: "rust"},
+			gameMod:     gameMod,
+			wantActions: []rconbase.Action{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := newFeaturesResponse(tt.game, tt.gameMod)
+			assert.Equal(t, tt.wantActions, response.Actions)
+		})
+	}
+}
@@ -3,25 +3,40 @@ package getrconfeatures
 import (
 	"github.com/gameap/gameap/internal/api/servers/rcon/base"
 	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/services/rconexec"
 	"github.com/gameap/gameap/pkg/quercon/rcon"
 )
 
 type featuresResponse struct {
 	Rcon          bool `json:"rcon"`
 	PlayersManage bool `json:"playersManage"`
+
+	// Actions are the server actions available with the game mod command templates.
+	Actions []base.Action `json:"actions"`
 }
 
-func newFeaturesResponse(game domain.Game) featuresResponse {
-	protocol, err := base.DetermineProtocol(game)
+func newFeaturesResponse(game domain.Game, gameMod *domain.GameMod) featuresResponse {
+	protocol, err := rconexec.DetermineProtocol(game)
 	if err != nil {
 		return featuresResponse{
 			Rcon:          false,
 			PlayersManage: false,
+			Actions:       []base.Action{},
 		}
 	}
 
+	_, err = base.NewPlayerManager(game)
+
+	rconSupported := rcon.IsProtocolSupported(protocol)
+
+	actions := []base.Action{}
+	if rconSupported {
+		actions = base.AvailableActions(gameMod)
+	}
+
 	return featuresResponse{
-		Rcon:          rcon.IsProtocolSupported(protocol),
-		PlayersManage: rcon.IsPlayerManagementSupported(game.Code),
+		Rcon:          rconSupported,
+		PlayersManage: err == nil,
+		Actions:       actions,
 	}
 }
@@ -3,7 +3,6 @@ package kickplayer
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -13,32 +12,43 @@ import (
 	serversbase "github.com/gameap/gameap/internal/api/servers/base"
 	rconbase "github.com/gameap/gameap/internal/api/servers/rcon/base"
 	"github.com/gameap/gameap/internal/domain"
-	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/internal/services/rconexec"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
-	"github.com/gameap/gameap/pkg/quercon/rcon"
-	"github.com/gameap/gameap/pkg/quercon/rcon/players"
 	"github.com/pkg/errors"
 )
 
+type commandExecutor interface {
+	ExecuteGame(ctx context.Context, server *domain.Server, game *domain.Game, command string) (string, error)
+}
+
 type Handler struct {
 	serverFinder   *serversbase.ServerFinder
 	abilityChecker *serversbase.AbilityChecker
-	gameRepo       repositories.GameRepository
+	nodeState      *serversbase.NodeStateChecker
+	gameFinder     *serversbase.GameFinder
+	executor       commandExecutor
 	responder      base.Responder
 }
 
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	gameRepo repositories.GameRepository,
+	gameModRepo repositories.GameModRepository,
+	nodeHealth *nodehealth.Checker,
 	rbac base.RBAC,
 	responder base.Responder,
 ) *Handler {
+	gameFinder := serversbase.NewGameFinder(gameRepo, gameModRepo)
+
 	return &Handler{
 		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
 		abilityChecker: serversbase.NewAbilityChecker(rbac),
-		gameRepo:       gameRepo,
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
+		gameFinder:     gameFinder,
+		executor:       rconexec.NewExecutor(gameFinder),
 		responder:      responder,
 	}
 }
@@ -103,6 +113,12 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
 	if !server.IsOnline() {
 		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
 			errors.New("server is offline"),
@@ -119,33 +135,14 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	game, err := h.findGame(ctx, server.GameID)
+	game, err := h.gameFinder.FindServerGame(ctx, server)
 	if err != nil {
 		h.responder.WriteError(ctx, rw, err)
 
 		return
 	}
 
-	protocol, err := rconbase.DetermineProtocol(*game)
-	if err != nil {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.WithMessage(err, "unsupported game"),
-			http.StatusBadRequest,
-		))
-
-		return
-	}
-
-	if server.Rcon == nil || *server.Rcon == "" {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.New("rcon password not configured for server"),
-			http.StatusPreconditionFailed,
-		))
-
-		return
-	}
-
-	playerManager, err := players.NewPlayerManagerByGameCode(server.GameID)
+	playerManager, err := rconbase.NewPlayerManager(*game)
 	if err != nil {
 		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
 			errors.WithMessage(err, "player management not supported for this game"),
@@ -188,12 +185,11 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		ctx,
 		"Executing RCON command",
 		slog.String("command", rconCommand),
-		slog.String("protocol", string(protocol)),
 	)
 
-	output, err := h.executeRconCommand(ctx, server, protocol, rconCommand)
+	output, err := h.executor.ExecuteGame(ctx, server, game, rconCommand)
 	if err != nil {
-		h.responder.WriteError(ctx, rw, err)
+		h.responder.WriteError(ctx, rw, rconbase.WrapExecutorError(err))
 
 		return
 	}
@@ -233,86 +229,3 @@ func (h *Handler) readKickInput(r *http.Request) (*kickRequest, error) {
 
 	return &kickInput, nil
 }
-
-func (h *Handler) findGame(ctx context.Context, gameID string) (*domain.Game, error) {
-	games, err := h.gameRepo.Find(ctx, filters.FindGameByCodes(gameID), nil, nil)
-	if err != nil {
-		return nil, api.WrapHTTPError(
-			errors.WithMessage(err, "failed to find game for server"),
-			http.StatusInternalServerError,
-		)
-	}
-	if len(games) == 0 {
-		return nil, api.WrapHTTPError(
-			errors.New("game for server not found"),
-			http.StatusInternalServerError,
-		)
-	}
-
-	return &games[0], nil
-}
-
-func (h *Handler) executeRconCommand(
-	ctx context.Context,
-	server *domain.Server,
-	protocol rcon.Protocol,
-	command string,
-) (string, error) {
-	rconAddress := fmt.Sprintf("%s:%d", server.ServerIP, getRconPort(server))
-
-	rconConfig := rcon.Config{
-		Address:  rconAddress,
-		Password: *server.Rcon,
-		Protocol: protocol,
-		Timeout:  10 * time.Second,
-	}
-
-	client, err := rcon.NewClient(rconConfig)
-	if err != nil {
-		return "", api.WrapHTTPError(
-			errors.WithMessage(err, "failed to create rcon client"),
-			http.StatusInternalServerError,
-		)
-	}
-
-	if err := client.Open(ctx); err != nil {
-		if errors.Is(err, rcon.ErrAuthenticationFailed) {
-			return "", api.WrapHTTPError(
-				errors.WithMessage(err, "rcon authentication failed"),
-				http.StatusUnprocessableEntity,
-			)
-		}
-
-		return "", api.WrapHTTPError(
-			errors.WithMessage(err, "failed to connect to rcon"),
-			http.StatusServiceUnavailable,
-		)
-	}
-	defer func(client rcon.Client) {
-		err := client.Close()
-		if err != nil {
-			slog.Warn(
-				"failed to close rcon client",
-				slog.String("error", err.Error()),
-			)
-		}
-	}(client)
-
-	output, err := client.Execute(ctx, command)
-	if err != nil {
-		return "", api.WrapHTTPError(
-			errors.WithMessage(err, "failed to execute rcon command"),
-			http.StatusInternalServerError,
-		)
-	}
-
-	return output, nil
-}
-
-func getRconPort(server *domain.Server) int {
-	if server.RconPort != nil {
-		return *server.RconPort
-	}
-
-	return server.ServerPort
-}
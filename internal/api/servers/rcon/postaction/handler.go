@@ -0,0 +1,205 @@
+package postaction
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	rconbase "github.com/gameap/gameap/internal/api/servers/rcon/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/internal/services/rconexec"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/pkg/errors"
+)
+
+type commandExecutor interface {
+	Execute(ctx context.Context, server *domain.Server, command string) (string, error)
+}
+
+// Handler performs a server action (change map, change name, set password, restart, send message)
+// with the command template of the game mod. Rendered commands are checked against command policies
+// as the arguments are user input.
+type Handler struct {
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	nodeState      *serversbase.NodeStateChecker
+	policyChecker  *serversbase.CommandPolicyChecker
+	gameModRepo    repositories.GameModRepository
+	executor       commandExecutor
+	responder      base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	gameRepo repositories.GameRepository,
+	gameModRepo repositories.GameModRepository,
+	commandPolicyRepo repositories.CommandPolicyRepository,
+	nodeHealth *nodehealth.Checker,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
+		policyChecker:  serversbase.NewCommandPolicyChecker(commandPolicyRepo, rbac),
+		gameModRepo:    gameModRepo,
+		executor:       rconexec.NewExecutor(serversbase.NewGameFinder(gameRepo, gameModRepo)),
+		responder:      responder,
+	}
+}
+
+//nolint:funlen
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	inputReader := api.NewInputReader(r)
+
+	actionName, err := inputReader.ReadString("action")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid action"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	action := rconbase.Action(actionName)
+	if !action.IsValid() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.Errorf("unknown action %q", actionName),
+			http.StatusNotFound,
+		))
+
+		return
+	}
+
+	serverID, err := inputReader.ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.abilityChecker.CheckOrError(
+		ctx, session.User.ID, server.ID, []domain.AbilityName{domain.AbilityNameGameServerRconConsole},
+	); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	input, err := readActionInput(r)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = input.Validate(action); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "validation failed"))
+
+		return
+	}
+
+	command, err := h.renderCommand(ctx, server, action, input.Argument(action))
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.policyChecker.CheckOrError(
+		ctx, session.User.ID, server.ID, domain.CommandPolicyTargetRcon, command,
+	); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	output, err := h.executor.Execute(ctx, server, command)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(rconbase.WrapExecutorError(err), "failed to perform action"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newActionResponse(command, output))
+}
+
+// readActionInput reads the action arguments, the body may be empty for actions without arguments.
+func readActionInput(r *http.Request) (*actionRequest, error) {
+	input := &actionRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(input)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		)
+	}
+
+	return input, nil
+}
+
+func (h *Handler) renderCommand(
+	ctx context.Context,
+	server *domain.Server,
+	action rconbase.Action,
+	argument string,
+) (string, error) {
+	gameMods, err := h.gameModRepo.Find(ctx, &filters.FindGameMod{IDs: []uint{server.GameModID}}, nil, nil)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to find game mod")
+	}
+
+	var gameMod *domain.GameMod
+	if len(gameMods) > 0 {
+		gameMod = &gameMods[0]
+	}
+
+	command, err := rconbase.RenderAction(gameMod, action, argument)
+	if err != nil {
+		if errors.Is(err, rconbase.ErrActionNotAvailable) {
+			return "", api.WrapHTTPError(err, http.StatusNotImplemented)
+		}
+
+		return "", errors.WithMessage(err, "failed to render command")
+	}
+
+	return command, nil
+}
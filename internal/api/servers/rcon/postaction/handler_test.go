@@ -0,0 +1,330 @@
+package postaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func setupServer(
+	t *testing.T,
+	serverRepo *inmemory.ServerRepository,
+	rbacRepo *inmemory.RBACRepository,
+	allowed bool,
+) {
+	t.Helper()
+
+	now := time.Now()
+
+	require.NoError(t, serverRepo.Save(context.Background(), &domain.Server{
+		ID:               1,
+		UUID:             uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		UUIDShort:        "short1",
+		Enabled:          true,
+		Installed:        1,
+		Name:             "Test Server 1",
+		GameID:           "cstrike",
+		DSID:             1,
+		GameModID:        1,
+		ServerIP:         "127.0.0.1",
+		ServerPort:       27015,
+		Rcon:             lo.ToPtr("test_password"),
+		ProcessActive:    true,
+		LastProcessCheck: &now,
+		CreatedAt:        &now,
+		UpdatedAt:        &now,
+	}))
+	serverRepo.AddUserServer(testUser1.ID, 1)
+
+	if !allowed {
+		return
+	}
+
+	apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconConsole)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	fullGameMod := &domain.GameMod{
+		ID:          1,
+		GameCode:    "cstrike",
+		Name:        "Classic",
+		ChmapCmd:    lo.ToPtr("changelevel {map}"),
+		ChnameCmd:   lo.ToPtr(`hostname "{name}"`),
+		PasswdCmd:   lo.ToPtr(`sv_password "{password}"`),
+		SrestartCmd: lo.ToPtr("sv_restart 1"),
+		SendmsgCmd:  lo.ToPtr(`say "{msg}"`),
+	}
+
+	tests := []struct {
+		name           string
+		serverID       string
+		action         string
+		gameMod        *domain.GameMod
+		notAllowed     bool
+		denyPattern    string
+		setupAuth      func() context.Context
+		requestBody    string
+		expectedStatus int
+		wantError      string
+		wantCommand    string
+	}{
+		{
+			name:           "user_not_authenticated",
+			serverID:       "1",
+			action:         "change-map",
+			setupAuth:      context.Background,
+			requestBody:    `{"map": "de_dust2"}`,
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "unknown_action",
+			serverID:       "1",
+			action:         "quit",
+			gameMod:        fullGameMod,
+			expectedStatus: http.StatusNotFound,
+			wantError:      `unknown action "quit"`,
+		},
+		{
+			name:           "invalid_server_id",
+			serverID:       "invalid",
+			action:         "restart",
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid server id",
+		},
+		{
+			name:           "server_not_found",
+			serverID:       "999",
+			action:         "restart",
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "ability_not_allowed",
+			serverID:       "1",
+			action:         "restart",
+			gameMod:        fullGameMod,
+			notAllowed:     true,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "change_map",
+			serverID:       "1",
+			action:         "change-map",
+			gameMod:        fullGameMod,
+			requestBody:    `{"map": "de_dust2"}`,
+			expectedStatus: http.StatusOK,
+			wantCommand:    "changelevel de_dust2",
+		},
+		{
+			name:           "invalid_map",
+			serverID:       "1",
+			action:         "change-map",
+			gameMod:        fullGameMod,
+			requestBody:    `{"map": "de_dust2; quit"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "map must contain only letters, digits",
+		},
+		{
+			name:           "missing_map",
+			serverID:       "1",
+			action:         "change-map",
+			gameMod:        fullGameMod,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "map is required",
+		},
+		{
+			name:           "change_name_is_escaped",
+			serverID:       "1",
+			action:         "change-name",
+			gameMod:        fullGameMod,
+			requestBody:    `{"name": "My \"Best\" Server; quit"}`,
+			expectedStatus: http.StatusOK,
+			wantCommand:    `hostname "My 'Best' Server, quit"`,
+		},
+		{
+			name:           "set_password",
+			serverID:       "1",
+			action:         "set-password",
+			gameMod:        fullGameMod,
+			requestBody:    `{"password": "secret"}`,
+			expectedStatus: http.StatusOK,
+			wantCommand:    `sv_password "secret"`,
+		},
+		{
+			name:           "remove_password",
+			serverID:       "1",
+			action:         "set-password",
+			gameMod:        fullGameMod,
+			requestBody:    `{"password": ""}`,
+			expectedStatus: http.StatusOK,
+			wantCommand:    `sv_password ""`,
+		},
+		{
+			name:           "password_with_spaces",
+			serverID:       "1",
+			action:         "set-password",
+			gameMod:        fullGameMod,
+			requestBody:    `{"password": "a b"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "password must not contain spaces",
+		},
+		{
+			name:           "restart_without_body",
+			serverID:       "1",
+			action:         "restart",
+			gameMod:        fullGameMod,
+			expectedStatus: http.StatusOK,
+			wantCommand:    "sv_restart 1",
+		},
+		{
+			name:           "restart_with_arguments",
+			serverID:       "1",
+			action:         "restart",
+			gameMod:        fullGameMod,
+			requestBody:    `{"map": "de_dust2"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "action does not accept arguments",
+		},
+		{
+			name:           "send_message",
+			serverID:       "1",
+			action:         "send-message",
+			gameMod:        fullGameMod,
+			requestBody:    `{"message": "hello"}`,
+			expectedStatus: http.StatusOK,
+			wantCommand:    `say "hello"`,
+		},
+		{
+			name:     "template_not_set",
+			serverID: "1",
+			action:   "change-name",
+			gameMod: &domain.GameMod{
+				ID:       1,
+				GameCode: "cstrike",
+				Name:     "Classic",
+				ChmapCmd: lo.ToPtr("changelevel {map}"),
+			},
+			requestBody:    `{"name": "Server"}`,
+			expectedStatus: http.StatusNotImplemented,
+			wantError:      "Not Implemented",
+		},
+		{
+			name:           "invalid_json",
+			serverID:       "1",
+			action:         "change-map",
+			gameMod:        fullGameMod,
+			requestBody:    `{"map": }`,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid request body",
+		},
+		{
+			name:           "denied_by_command_policy",
+			serverID:       "1",
+			action:         "change-map",
+			gameMod:        fullGameMod,
+			denyPattern:    "changelevel *",
+			requestBody:    `{"map": "de_dust2"}`,
+			expectedStatus: http.StatusForbidden,
+			wantError:      `command "changelevel de_dust2" is denied by command policy #1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			serverRepo := inmemory.NewServerRepository()
+			gameRepo := inmemory.NewGameRepository()
+			gameModRepo := inmemory.NewGameModRepository()
+			policyRepo := inmemory.NewCommandPolicyRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			executor := &apitesting.Executor{Output: "ok"}
+
+			handler := NewHandler(
+				serverRepo,
+				gameRepo,
+				gameModRepo,
+				policyRepo,
+				apitesting.NewNodeHealthChecker(t),
+				rbacService,
+				api.NewResponder(),
+			)
+			handler.executor = executor
+
+			if tt.gameMod != nil {
+				setupServer(t, serverRepo, rbacRepo, !tt.notAllowed)
+				require.NoError(t, gameModRepo.Save(ctx, tt.gameMod))
+			}
+
+			if tt.denyPattern != "" {
+				require.NoError(t, policyRepo.Save(ctx, &domain.CommandPolicy{
+					Target:      domain.CommandPolicyTargetAll,
+					Action:      domain.CommandPolicyActionDeny,
+					PatternType: domain.CommandPolicyPatternTypeGlob,
+					Pattern:     tt.denyPattern,
+				}))
+			}
+
+			setupAuth := tt.setupAuth
+			if setupAuth == nil {
+				setupAuth = func() context.Context {
+					return apitesting.ContextWithUser(&testUser1)
+				}
+			}
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api/servers/"+tt.serverID+"/rcon/actions/"+tt.action,
+				bytes.NewReader([]byte(tt.requestBody)),
+			)
+			req = req.WithContext(setupAuth())
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID, "action": tt.action})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				assert.Contains(t, response["error"], tt.wantError)
+			}
+
+			if tt.wantCommand != "" {
+				assert.Equal(t, []string{tt.wantCommand}, executor.Commands)
+
+				var response actionResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantCommand, response.Command)
+				assert.Equal(t, "ok", response.Output)
+			} else {
+				assert.Empty(t, executor.Commands)
+			}
+		})
+	}
+}
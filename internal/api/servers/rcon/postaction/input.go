@@ -0,0 +1,128 @@
+package postaction
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	rconbase "github.com/gameap/gameap/internal/api/servers/rcon/base"
+	"github.com/gameap/gameap/pkg/api"
+)
+
+const (
+	maxMapLength      = 64
+	maxNameLength     = 128
+	maxPasswordLength = 64
+	maxMessageLength  = 512
+)
+
+var mapRegexp = regexp.MustCompile(`^[\w\-./]+$`)
+
+var (
+	ErrMapIsRequired       = api.NewValidationError("map is required")
+	ErrMapIsTooLong        = api.NewValidationError("map must not exceed 64 characters")
+	ErrInvalidMap          = api.NewValidationError("map must contain only letters, digits, '_', '-', '.' and '/'")
+	ErrNameIsRequired      = api.NewValidationError("name is required")
+	ErrNameIsTooLong       = api.NewValidationError("name must not exceed 128 characters")
+	ErrPasswordIsTooLong   = api.NewValidationError("password must not exceed 64 characters")
+	ErrPasswordHasSpaces   = api.NewValidationError("password must not contain spaces")
+	ErrMessageIsRequired   = api.NewValidationError("message is required")
+	ErrMessageIsTooLong    = api.NewValidationError("message must not exceed 512 characters")
+	ErrUnexpectedArguments = api.NewValidationError("action does not accept arguments")
+)
+
+type actionRequest struct {
+	Map     string `json:"map"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+
+	// Password is the new server password, empty to remove the password.
+	Password string `json:"password"`
+}
+
+func (r *actionRequest) Validate(action rconbase.Action) error {
+	switch action {
+	case rconbase.ActionChangeMap:
+		return r.validateMap()
+	case rconbase.ActionChangeName:
+		return r.validateName()
+	case rconbase.ActionSetPassword:
+		return r.validatePassword()
+	case rconbase.ActionSendMessage:
+		return r.validateMessage()
+	default:
+		if r.Map != "" || r.Name != "" || r.Password != "" || r.Message != "" {
+			return ErrUnexpectedArguments
+		}
+
+		return nil
+	}
+}
+
+func (r *actionRequest) validateMap() error {
+	if r.Map == "" {
+		return ErrMapIsRequired
+	}
+
+	if len(r.Map) > maxMapLength {
+		return ErrMapIsTooLong
+	}
+
+	if !mapRegexp.MatchString(r.Map) {
+		return ErrInvalidMap
+	}
+
+	return nil
+}
+
+func (r *actionRequest) validateName() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return ErrNameIsRequired
+	}
+
+	if len(r.Name) > maxNameLength {
+		return ErrNameIsTooLong
+	}
+
+	return nil
+}
+
+func (r *actionRequest) validatePassword() error {
+	if len(r.Password) > maxPasswordLength {
+		return ErrPasswordIsTooLong
+	}
+
+	if strings.IndexFunc(r.Password, unicode.IsSpace) >= 0 {
+		return ErrPasswordHasSpaces
+	}
+
+	return nil
+}
+
+func (r *actionRequest) validateMessage() error {
+	if strings.TrimSpace(r.Message) == "" {
+		return ErrMessageIsRequired
+	}
+
+	if len(r.Message) > maxMessageLength {
+		return ErrMessageIsTooLong
+	}
+
+	return nil
+}
+
+// Argument returns the argument substituted into the command template of the action.
+func (r *actionRequest) Argument(action rconbase.Action) string {
+	switch action {
+	case rconbase.ActionChangeMap:
+		return r.Map
+	case rconbase.ActionChangeName:
+		return r.Name
+	case rconbase.ActionSetPassword:
+		return r.Password
+	case rconbase.ActionSendMessage:
+		return r.Message
+	default:
+		return ""
+	}
+}
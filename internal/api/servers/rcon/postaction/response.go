@@ -0,0 +1,13 @@
+package postaction
+
+type actionResponse struct {
+	Command string `json:"command"`
+	Output  string `json:"output"`
+}
+
+func newActionResponse(command, output string) actionResponse {
+	return actionResponse{
+		Command: command,
+		Output:  output,
+	}
+}
@@ -3,11 +3,9 @@ package postcommand
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
-	"time"
 
 	"github.com/gameap/gameap/internal/api/base"
 	serversbase "github.com/gameap/gameap/internal/api/servers/base"
@@ -15,29 +13,47 @@ import (
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/filters"
 	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/internal/services/rconexec"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
-	"github.com/gameap/gameap/pkg/quercon/rcon"
 	"github.com/pkg/errors"
 )
 
+type commandExecutor interface {
+	ExecuteGame(ctx context.Context, server *domain.Server, game *domain.Game, command string) (string, error)
+}
+
 type Handler struct {
 	serverFinder   *serversbase.ServerFinder
 	abilityChecker *serversbase.AbilityChecker
-	gameRepo       repositories.GameRepository
+	nodeState      *serversbase.NodeStateChecker
+	policyChecker  *serversbase.CommandPolicyChecker
+	gameFinder     *serversbase.GameFinder
+	executor       commandExecutor
+	gameModRepo    repositories.GameModRepository
 	responder      base.Responder
 }
 
 func NewHandler(
 	serverRepo repositories.ServerRepository,
 	gameRepo repositories.GameRepository,
+	gameModRepo repositories.GameModRepository,
+	commandPolicyRepo repositories.CommandPolicyRepository,
+	nodeHealth *nodehealth.Checker,
 	rbac base.RBAC,
 	responder base.Responder,
 ) *Handler {
+	gameFinder := serversbase.NewGameFinder(gameRepo, gameModRepo)
+
 	return &Handler{
 		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
 		abilityChecker: serversbase.NewAbilityChecker(rbac),
-		gameRepo:       gameRepo,
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
+		policyChecker:  serversbase.NewCommandPolicyChecker(commandPolicyRepo, rbac),
+		gameFinder:     gameFinder,
+		executor:       rconexec.NewExecutor(gameFinder),
+		gameModRepo:    gameModRepo,
 		responder:      responder,
 	}
 }
@@ -62,9 +78,14 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = h.abilityChecker.CheckOrError(
-		ctx, session.User.ID, server.ID, []domain.AbilityName{domain.AbilityNameGameServerRconConsole},
-	); err != nil {
+	canUseConsole, err := h.checkAbilities(ctx, session.User.ID, server.ID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
 		h.responder.WriteError(ctx, rw, err)
 
 		return
@@ -86,35 +107,32 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	game, err := h.findGame(ctx, server.GameID)
-	if err != nil {
-		h.responder.WriteError(ctx, rw, err)
+	if !canUseConsole && !commandInput.IsFastRcon() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user does not have required permissions"),
+			http.StatusForbidden,
+		))
 
 		return
 	}
 
-	protocol, err := rconbase.DetermineProtocol(*game)
+	command, err := h.resolveCommand(ctx, session.User.ID, server, commandInput)
 	if err != nil {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.WithMessage(err, "unsupported game"),
-			http.StatusBadRequest,
-		))
+		h.responder.WriteError(ctx, rw, err)
 
 		return
 	}
 
-	if server.Rcon == nil || *server.Rcon == "" {
-		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
-			errors.New("rcon password not configured for server"),
-			http.StatusPreconditionFailed,
-		))
+	game, err := h.gameFinder.FindServerGame(ctx, server)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
 
 		return
 	}
 
-	output, err := h.executeRconCommand(ctx, server, protocol, commandInput.Command)
+	output, err := h.executor.ExecuteGame(ctx, server, game, command)
 	if err != nil {
-		h.responder.WriteError(ctx, rw, err)
+		h.responder.WriteError(ctx, rw, rconbase.WrapExecutorError(err))
 
 		return
 	}
@@ -136,118 +154,114 @@ func (h *Handler) getServer(ctx context.Context, r *http.Request, user *domain.U
 	return h.serverFinder.FindUserServer(ctx, user, serverID)
 }
 
-func (h *Handler) readCommandInput(r *http.Request) (*commandRequest, error) {
-	body, err := io.ReadAll(r.Body)
+// checkAbilities checks that the user can run either arbitrary commands or fast RCON macros.
+// It returns true when arbitrary commands are allowed.
+func (h *Handler) checkAbilities(ctx context.Context, userID, serverID uint) (bool, error) {
+	canUseConsole, err := h.abilityChecker.Check(
+		ctx, userID, serverID, []domain.AbilityName{domain.AbilityNameGameServerRconConsole},
+	)
 	if err != nil {
-		return nil, api.WrapHTTPError(
-			errors.WithMessage(err, "failed to read request body"),
-			http.StatusBadRequest,
-		)
-	}
-	defer func() {
-		err := r.Body.Close()
-		if err != nil {
-			slog.Warn("failed to close request body", "error", err)
-		}
-	}()
-
-	var commandInput commandRequest
-	if err := json.Unmarshal(body, &commandInput); err != nil {
-		return nil, api.WrapHTTPError(
-			errors.WithMessage(err, "failed to parse request body"),
-			http.StatusBadRequest,
-		)
+		return false, err
 	}
 
-	if err := commandInput.Validate(); err != nil {
-		return nil, api.WrapHTTPError(
-			err,
-			http.StatusBadRequest,
-		)
+	if canUseConsole {
+		return true, nil
 	}
 
-	return &commandInput, nil
+	return false, h.abilityChecker.CheckOrError(
+		ctx, userID, serverID, []domain.AbilityName{domain.AbilityNameGameServerRconFastRcon},
+	)
 }
 
-func (h *Handler) findGame(ctx context.Context, gameID string) (*domain.Game, error) {
-	games, err := h.gameRepo.Find(ctx, filters.FindGameByCodes(gameID), nil, nil)
-	if err != nil {
-		return nil, api.WrapHTTPError(
-			errors.WithMessage(err, "failed to find game for server"),
-			http.StatusInternalServerError,
-		)
+// resolveCommand returns the command to execute. Rendered fast RCON commands contain
+// user arguments, so they are checked against command policies as arbitrary commands are.
+func (h *Handler) resolveCommand(
+	ctx context.Context,
+	userID uint,
+	server *domain.Server,
+	commandInput *commandRequest,
+) (string, error) {
+	command := commandInput.Command
+
+	if commandInput.IsFastRcon() {
+		var err error
+
+		command, err = h.renderFastRcon(ctx, server, *commandInput.FastRcon, commandInput.Args)
+		if err != nil {
+			return "", err
+		}
 	}
-	if len(games) == 0 {
-		return nil, api.WrapHTTPError(
-			errors.New("game for server not found"),
-			http.StatusInternalServerError,
-		)
+
+	err := h.policyChecker.CheckOrError(
+		ctx, userID, server.ID, domain.CommandPolicyTargetRcon, command,
+	)
+	if err != nil {
+		return "", err
 	}
 
-	return &games[0], nil
+	return command, nil
 }
 
-func (h *Handler) executeRconCommand(
+func (h *Handler) renderFastRcon(
 	ctx context.Context,
 	server *domain.Server,
-	protocol rcon.Protocol,
-	command string,
+	index int,
+	args map[string]string,
 ) (string, error) {
-	rconAddress := fmt.Sprintf("%s:%d", server.ServerIP, getRconPort(server))
-
-	rconConfig := rcon.Config{
-		Address:  rconAddress,
-		Password: *server.Rcon,
-		Protocol: protocol,
-		Timeout:  10 * time.Second,
-	}
-
-	client, err := rcon.NewClient(rconConfig)
+	gameMods, err := h.gameModRepo.Find(ctx, &filters.FindGameMod{
+		IDs: []uint{server.GameModID},
+	}, nil, nil)
 	if err != nil {
 		return "", api.WrapHTTPError(
-			errors.WithMessage(err, "failed to create rcon client"),
+			errors.WithMessage(err, "failed to find game mod for server"),
 			http.StatusInternalServerError,
 		)
 	}
 
-	if err := client.Open(ctx); err != nil {
-		if errors.Is(err, rcon.ErrAuthenticationFailed) {
-			return "", api.WrapHTTPError(
-				errors.WithMessage(err, "rcon authentication failed"),
-				http.StatusUnprocessableEntity,
-			)
-		}
-
+	if len(gameMods) == 0 || index >= len(gameMods[0].FastRcon) {
 		return "", api.WrapHTTPError(
-			errors.WithMessage(err, "failed to connect to rcon"),
-			http.StatusServiceUnavailable,
+			errors.New("fast rcon command not found"),
+			http.StatusNotFound,
 		)
 	}
-	defer func(client rcon.Client) {
-		err := client.Close()
-		if err != nil {
-			slog.Warn(
-				"failed to close rcon client",
-				slog.String("error", err.Error()),
-			)
-		}
-	}(client)
 
-	output, err := client.Execute(ctx, command)
+	command, err := rconbase.RenderFastRcon(gameMods[0].FastRcon[index], args)
 	if err != nil {
-		return "", api.WrapHTTPError(
-			errors.WithMessage(err, "failed to execute rcon command"),
-			http.StatusInternalServerError,
-		)
+		return "", api.WrapHTTPError(err, http.StatusUnprocessableEntity)
 	}
 
-	return output, nil
+	return command, nil
 }
 
-func getRconPort(server *domain.Server) int {
-	if server.RconPort != nil {
-		return *server.RconPort
+func (h *Handler) readCommandInput(r *http.Request) (*commandRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to read request body"),
+			http.StatusBadRequest,
+		)
+	}
+	defer func() {
+		err := r.Body.Close()
+		if err != nil {
+			slog.Warn("failed to close request body", "error", err)
+		}
+	}()
+
+	var commandInput commandRequest
+	if err := json.Unmarshal(body, &commandInput); err != nil {
+		return nil, api.WrapHTTPError(
+			errors.WithMessage(err, "failed to parse request body"),
+			http.StatusBadRequest,
+		)
+	}
+
+	if err := commandInput.Validate(); err != nil {
+		return nil, api.WrapHTTPError(
+			err,
+			http.StatusBadRequest,
+		)
 	}
 
-	return server.ServerPort
+	return &commandInput, nil
 }
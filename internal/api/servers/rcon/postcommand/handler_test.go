@@ -9,8 +9,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/samber/lo"
-
+	apitesting "github.com/gameap/gameap/internal/api/testing"
 	"github.com/gameap/gameap/internal/domain"
 	"github.com/gameap/gameap/internal/rbac"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
@@ -19,6 +18,7 @@ import (
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -582,7 +582,15 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			rbacRepo := inmemory.NewRBACRepository()
 			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
 			responder := api.NewResponder()
-			handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+			handler := NewHandler(
+				serverRepo,
+				gameRepo,
+				inmemory.NewGameModRepository(),
+				inmemory.NewCommandPolicyRepository(),
+				apitesting.NewNodeHealthChecker(t),
+				rbacService,
+				responder,
+			)
 
 			if tt.setupRepo != nil {
 				tt.setupRepo(serverRepo, gameRepo, rbacRepo)
@@ -620,53 +628,367 @@ func TestHandler_ServeHTTP(t *testing.T) {
 	}
 }
 
-func TestHandler_NewHandler(t *testing.T) {
-	serverRepo := inmemory.NewServerRepository()
-	gameRepo := inmemory.NewGameRepository()
-	rbacRepo := inmemory.NewRBACRepository()
-	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
-	responder := api.NewResponder()
+func TestHandler_ServeHTTP_FastRcon(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    any
+		ability        domain.AbilityName
+		expectedStatus int
+		wantError      string
+	}{
+		{
+			name: "fast_rcon_with_fast_rcon_ability",
+			requestBody: map[string]any{
+				"fast_rcon": 1,
+				"args":      map[string]string{"map": "de_dust2"},
+			},
+			ability:        domain.AbilityNameGameServerRconFastRcon,
+			expectedStatus: http.StatusServiceUnavailable,
+			wantError:      "Service Unavailable",
+		},
+		{
+			name: "fast_rcon_with_console_ability",
+			requestBody: map[string]any{
+				"fast_rcon": 0,
+			},
+			ability:        domain.AbilityNameGameServerRconConsole,
+			expectedStatus: http.StatusServiceUnavailable,
+			wantError:      "Service Unavailable",
+		},
+		{
+			name: "command_with_fast_rcon_ability_only",
+			requestBody: map[string]any{
+				"command": "status",
+			},
+			ability:        domain.AbilityNameGameServerRconFastRcon,
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name: "fast_rcon_without_abilities",
+			requestBody: map[string]any{
+				"fast_rcon": 0,
+			},
+			expectedStatus: http.StatusForbidden,
+			wantError:      "user does not have required permissions",
+		},
+		{
+			name: "fast_rcon_invalid_argument",
+			requestBody: map[string]any{
+				"fast_rcon": 1,
+				"args":      map[string]string{"map": "de_dust2; quit"},
+			},
+			ability:        domain.AbilityNameGameServerRconFastRcon,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "invalid argument map: argument is not one of the allowed values",
+		},
+		{
+			name: "fast_rcon_missing_argument",
+			requestBody: map[string]any{
+				"fast_rcon": 1,
+			},
+			ability:        domain.AbilityNameGameServerRconFastRcon,
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "invalid argument map: argument is required",
+		},
+		{
+			name: "fast_rcon_not_found",
+			requestBody: map[string]any{
+				"fast_rcon": 2,
+			},
+			ability:        domain.AbilityNameGameServerRconFastRcon,
+			expectedStatus: http.StatusNotFound,
+			wantError:      "fast rcon command not found",
+		},
+		{
+			name: "fast_rcon_with_command",
+			requestBody: map[string]any{
+				"fast_rcon": 0,
+				"command":   "status",
+			},
+			ability:        domain.AbilityNameGameServerRconFastRcon,
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "command and fast_rcon must not be used together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverRepo := inmemory.NewServerRepository()
+			gameRepo := inmemory.NewGameRepository()
+			gameModRepo := inmemory.NewGameModRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(
+				serverRepo,
+				gameRepo,
+				gameModRepo,
+				inmemory.NewCommandPolicyRepository(),
+				apitesting.NewNodeHealthChecker(t),
+				rbacService,
+				api.NewResponder(),
+			)
+
+			now := time.Now()
+			rconPassword := testRconPassword
+
+			require.NoError(t, serverRepo.Save(context.Background(), &domain.Server{
+				ID:               1,
+				UUID:             uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+				UUIDShort:        "short1",
+				Enabled:          true,
+				Installed:        1,
+				Name:             "Test Server 1",
+				GameID:           "cs",
+				DSID:             1,
+				GameModID:        1,
+				ServerIP:         "127.0.0.1",
+				ServerPort:       1,
+				Rcon:             &rconPassword,
+				ProcessActive:    true,
+				LastProcessCheck: &now,
+				CreatedAt:        &now,
+				UpdatedAt:        &now,
+			}))
+			serverRepo.AddUserServer(testUser1.ID, 1)
+
+			require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+				Code:   "cs",
+				Name:   "Counter-Strike",
+				Engine: "goldsource",
+			}))
+			require.NoError(t, gameModRepo.Save(context.Background(), &domain.GameMod{
+				ID:       1,
+				GameCode: "cs",
+				Name:     "Classic",
+				FastRcon: domain.GameModFastRconList{
+					{Info: "Status", Command: "status"},
+					{
+						Info:    "Change map",
+						Command: "changelevel {map}",
+						Params: []domain.GameModFastRconParam{
+							{Name: "map", Type: domain.GameModFastRconParamTypeEnum, Options: []string{"de_dust2"}},
+						},
+					},
+				},
+			}))
+
+			if tt.ability != "" {
+				allowUserAbilityForServer(t, rbacRepo, testUser1.ID, 1, tt.ability)
+			}
 
-	handler := NewHandler(serverRepo, gameRepo, rbacService, responder)
+			ctx := auth.ContextWithSession(context.Background(), &auth.Session{
+				Login: testUser1.Login,
+				Email: testUser1.Email,
+				User:  &testUser1,
+			})
 
-	require.NotNil(t, handler)
-	assert.NotNil(t, handler.serverFinder)
-	assert.NotNil(t, handler.gameRepo)
-	assert.Equal(t, responder, handler.responder)
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/servers/1/rcon", bytes.NewReader(body))
+			req = req.WithContext(ctx)
+			req = mux.SetURLVars(req, map[string]string{"server": "1"})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "error", response["status"])
+			errorMsg, ok := response["error"].(string)
+			require.True(t, ok)
+			assert.Contains(t, errorMsg, tt.wantError)
+		})
+	}
 }
 
-func TestGetRconPort(t *testing.T) {
+func TestHandler_ServeHTTP_CommandPolicies(t *testing.T) {
 	tests := []struct {
-		name   string
-		server *domain.Server
-		want   int
+		name           string
+		requestBody    any
+		expectedStatus int
+		wantError      string
 	}{
 		{
-			name: "custom_rcon_port",
-			server: &domain.Server{
-				ServerPort: 27015,
-				RconPort:   lo.ToPtr(27020),
-			},
-			want: 27020,
+			name:           "denied_command",
+			requestBody:    map[string]any{"command": "rcon_password 123"},
+			expectedStatus: http.StatusForbidden,
+			wantError:      `command "rcon_password 123" is denied by command policy #1 (glob "*password*")`,
 		},
 		{
-			name: "default_to_server_port",
-			server: &domain.Server{
-				ServerPort: 27015,
-				RconPort:   nil,
-			},
-			want: 27015,
+			name:           "allowed_command",
+			requestBody:    map[string]any{"command": "status"},
+			expectedStatus: http.StatusServiceUnavailable,
+			wantError:      "Service Unavailable",
+		},
+		{
+			name:           "allowed_fast_rcon",
+			requestBody:    map[string]any{"fast_rcon": 0, "args": map[string]string{"map": "de_dust2"}},
+			expectedStatus: http.StatusServiceUnavailable,
+			wantError:      "Service Unavailable",
+		},
+		{
+			name:           "denied_fast_rcon_arguments",
+			requestBody:    map[string]any{"fast_rcon": 0, "args": map[string]string{"map": "x, rcon_password 1"}},
+			expectedStatus: http.StatusForbidden,
+			wantError:      `(glob "*password*")`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			port := getRconPort(tt.server)
-			assert.Equal(t, tt.want, port)
+			ctx := context.Background()
+			now := time.Now()
+			rconPassword := testRconPassword
+
+			serverRepo := inmemory.NewServerRepository()
+			gameRepo := inmemory.NewGameRepository()
+			gameModRepo := inmemory.NewGameModRepository()
+			policyRepo := inmemory.NewCommandPolicyRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			handler := NewHandler(
+				serverRepo,
+				gameRepo,
+				gameModRepo,
+				policyRepo,
+				apitesting.NewNodeHealthChecker(t),
+				rbacService,
+				api.NewResponder(),
+			)
+
+			require.NoError(t, serverRepo.Save(ctx, &domain.Server{
+				ID:               1,
+				UUID:             uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+				UUIDShort:        "short1",
+				Enabled:          true,
+				Installed:        1,
+				Name:             "Test Server 1",
+				GameID:           "cs",
+				DSID:             1,
+				GameModID:        1,
+				ServerIP:         "127.0.0.1",
+				ServerPort:       1,
+				Rcon:             &rconPassword,
+				ProcessActive:    true,
+				LastProcessCheck: &now,
+				CreatedAt:        &now,
+				UpdatedAt:        &now,
+			}))
+			serverRepo.AddUserServer(testUser1.ID, 1)
+
+			require.NoError(t, gameRepo.Save(ctx, &domain.Game{
+				Code:   "cs",
+				Name:   "Counter-Strike",
+				Engine: "goldsource",
+			}))
+			require.NoError(t, gameModRepo.Save(ctx, &domain.GameMod{
+				ID:       1,
+				GameCode: "cs",
+				Name:     "Classic",
+				FastRcon: domain.GameModFastRconList{
+					{
+						Info:    "Change map",
+						Command: "changelevel {map}",
+						Params: []domain.GameModFastRconParam{
+							{Name: "map", Type: domain.GameModFastRconParamTypeString},
+						},
+					},
+				},
+			}))
+
+			allowUserAbilityForServer(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconConsole)
+
+			require.NoError(t, policyRepo.Save(ctx, &domain.CommandPolicy{
+				ServerID:    lo.ToPtr(uint(1)),
+				Target:      domain.CommandPolicyTargetRcon,
+				Action:      domain.CommandPolicyActionDeny,
+				PatternType: domain.CommandPolicyPatternTypeGlob,
+				Pattern:     "*password*",
+			}))
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/servers/1/rcon", bytes.NewReader(body))
+			req = req.WithContext(auth.ContextWithSession(ctx, &auth.Session{
+				Login: testUser1.Login,
+				Email: testUser1.Email,
+				User:  &testUser1,
+			}))
+			req = mux.SetURLVars(req, map[string]string{"server": "1"})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			errorMsg, ok := response["error"].(string)
+			require.True(t, ok)
+			assert.Contains(t, errorMsg, tt.wantError)
 		})
 	}
 }
 
+func TestHandler_NodeOffline(t *testing.T) {
+	server := apitesting.NewServer("cstrike")
+	server.Rcon = lo.ToPtr(testRconPassword)
+	serverRepo := apitesting.NewServerRepository(t, testUser1.ID, server)
+	rbacRepo := inmemory.NewRBACRepository()
+	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+	allowUserAbilityForServer(t, rbacRepo, testUser1.ID, server.ID, domain.AbilityNameGameServerRconConsole)
+
+	handler := NewHandler(
+		serverRepo,
+		inmemory.NewGameRepository(),
+		inmemory.NewGameModRepository(),
+		inmemory.NewCommandPolicyRepository(),
+		apitesting.NewNodeHealthChecker(t, apitesting.OfflineNodeHealth(server.DSID)),
+		rbacService,
+		api.NewResponder(),
+	)
+
+	body, err := json.Marshal(map[string]any{"command": "status"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/servers/1/rcon", bytes.NewReader(body))
+	req = req.WithContext(apitesting.ContextWithUser(&testUser1))
+	req = mux.SetURLVars(req, map[string]string{"server": "1"})
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestHandler_NewHandler(t *testing.T) {
+	serverRepo := inmemory.NewServerRepository()
+	gameRepo := inmemory.NewGameRepository()
+	rbacRepo := inmemory.NewRBACRepository()
+	rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+	responder := api.NewResponder()
+
+	handler := NewHandler(
+		serverRepo,
+		gameRepo,
+		inmemory.NewGameModRepository(),
+		inmemory.NewCommandPolicyRepository(),
+		apitesting.NewNodeHealthChecker(t),
+		rbacService,
+		responder,
+	)
+
+	require.NotNil(t, handler)
+	assert.NotNil(t, handler.serverFinder)
+	assert.NotNil(t, handler.gameFinder)
+	assert.Equal(t, responder, handler.responder)
+}
+
 func TestCommandRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -704,6 +1026,31 @@ func TestCommandRequest_Validate(t *testing.T) {
 			wantErr:  true,
 			errorMsg: "command must not exceed 127 characters",
 		},
+		{
+			name: "valid_fast_rcon",
+			request: commandRequest{
+				FastRcon: lo.ToPtr(0),
+				Args:     map[string]string{"map": "de_dust2"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative_fast_rcon",
+			request: commandRequest{
+				FastRcon: lo.ToPtr(-1),
+			},
+			wantErr:  true,
+			errorMsg: "fast_rcon must not be negative",
+		},
+		{
+			name: "fast_rcon_with_command",
+			request: commandRequest{
+				Command:  "status",
+				FastRcon: lo.ToPtr(0),
+			},
+			wantErr:  true,
+			errorMsg: "command and fast_rcon must not be used together",
+		},
 	}
 
 	for _, tt := range tests {
@@ -8,9 +8,30 @@ import (
 
 type commandRequest struct {
 	Command string `json:"command"`
+
+	// FastRcon is the index of the fast RCON entry of the server game mod.
+	// It is used instead of the command.
+	FastRcon *int              `json:"fast_rcon"`
+	Args     map[string]string `json:"args"`
+}
+
+func (c *commandRequest) IsFastRcon() bool {
+	return c.FastRcon != nil
 }
 
 func (c *commandRequest) Validate() error {
+	if c.IsFastRcon() {
+		if c.Command != "" {
+			return errors.New("command and fast_rcon must not be used together")
+		}
+
+		if *c.FastRcon < 0 {
+			return errors.New("fast_rcon must not be negative")
+		}
+
+		return nil
+	}
+
 	if c.Command == "" {
 		return errors.New("command is required")
 	}
@@ -0,0 +1,193 @@
+package sendmessage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gameap/gameap/internal/api/base"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
+	rconbase "github.com/gameap/gameap/internal/api/servers/rcon/base"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/internal/services/rconexec"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/pkg/errors"
+)
+
+type commandExecutor interface {
+	Execute(ctx context.Context, server *domain.Server, command string) (string, error)
+}
+
+// Handler sends a private chat message to a player or a message to all players.
+// Games without a player manager fall back to the SendmsgCmd template of the game mod,
+// which supports messages to all players only.
+type Handler struct {
+	serverFinder   *serversbase.ServerFinder
+	abilityChecker *serversbase.AbilityChecker
+	nodeState      *serversbase.NodeStateChecker
+	gameFinder     *serversbase.GameFinder
+	gameModRepo    repositories.GameModRepository
+	executor       commandExecutor
+	responder      base.Responder
+}
+
+func NewHandler(
+	serverRepo repositories.ServerRepository,
+	gameRepo repositories.GameRepository,
+	gameModRepo repositories.GameModRepository,
+	nodeHealth *nodehealth.Checker,
+	rbac base.RBAC,
+	responder base.Responder,
+) *Handler {
+	return &Handler{
+		serverFinder:   serversbase.NewServerFinder(serverRepo, rbac),
+		abilityChecker: serversbase.NewAbilityChecker(rbac),
+		nodeState:      serversbase.NewNodeStateChecker(nodeHealth),
+		gameFinder:     serversbase.NewGameFinder(gameRepo, gameModRepo),
+		gameModRepo:    gameModRepo,
+		executor:       rconexec.NewExecutor(serversbase.NewGameFinder(gameRepo, gameModRepo)),
+		responder:      responder,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	session := auth.SessionFromContext(ctx)
+	if !session.IsAuthenticated() {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.New("user not authenticated"),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	serverID, err := api.NewInputReader(r).ReadUint("server")
+	if err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid server id"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	server, err := h.serverFinder.FindUserServer(ctx, session.User, serverID)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.abilityChecker.CheckOrError(
+		ctx, session.User.ID, server.ID, []domain.AbilityName{domain.AbilityNameGameServerRconPlayers},
+	); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	if err = h.nodeState.CheckOrError(ctx, server); err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	input := &messageRequest{}
+	if err = json.NewDecoder(r.Body).Decode(input); err != nil {
+		h.responder.WriteError(ctx, rw, api.WrapHTTPError(
+			errors.WithMessage(err, "invalid request body"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(err, "validation failed"))
+
+		return
+	}
+
+	command, err := h.makeCommand(ctx, server, input)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, err)
+
+		return
+	}
+
+	output, err := h.executor.Execute(ctx, server, command)
+	if err != nil {
+		h.responder.WriteError(ctx, rw, errors.WithMessage(rconbase.WrapExecutorError(err), "failed to send message"))
+
+		return
+	}
+
+	h.responder.Write(ctx, rw, newMessageResponse(output))
+}
+
+func (h *Handler) makeCommand(ctx context.Context, server *domain.Server, input *messageRequest) (string, error) {
+	game, err := h.gameFinder.FindServerGame(ctx, server)
+	if err != nil {
+		return "", err
+	}
+
+	playerManager, err := rconbase.NewPlayerManager(*game)
+	if err != nil {
+		if input.IsBroadcast() {
+			return h.makeTemplateCommand(ctx, server, input.Message)
+		}
+
+		return "", api.WrapHTTPError(
+			errors.WithMessage(err, "private messages are not supported for this game"),
+			http.StatusNotImplemented,
+		)
+	}
+
+	var command string
+
+	if input.IsBroadcast() {
+		command, err = playerManager.BroadcastCommand(input.Message)
+	} else {
+		var player players.Player
+
+		player, err = input.ToPlayer()
+		if err != nil {
+			return "", api.WrapHTTPError(err, http.StatusBadRequest)
+		}
+
+		command, err = playerManager.MessageCommand(player, input.Message)
+	}
+	if err != nil {
+		return "", api.WrapHTTPError(
+			errors.WithMessage(err, "failed to build command"),
+			http.StatusBadRequest,
+		)
+	}
+
+	return command, nil
+}
+
+func (h *Handler) makeTemplateCommand(ctx context.Context, server *domain.Server, message string) (string, error) {
+	gameMods, err := h.gameModRepo.Find(ctx, &filters.FindGameMod{IDs: []uint{server.GameModID}}, nil, nil)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to find game mod")
+	}
+
+	if len(gameMods) == 0 || gameMods[0].SendmsgCmd == nil || *gameMods[0].SendmsgCmd == "" {
+		return "", api.WrapHTTPError(
+			errors.New("messages are not supported for this game"),
+			http.StatusNotImplemented,
+		)
+	}
+
+	return rconbase.RenderCommandTemplate(*gameMods[0].SendmsgCmd, map[string]string{
+		"msg": message,
+	}), nil
+}
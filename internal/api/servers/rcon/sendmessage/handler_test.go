@@ -0,0 +1,276 @@
+package sendmessage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apitesting "github.com/gameap/gameap/internal/api/testing"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/rbac"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUser1 = domain.User{
+	ID:    1,
+	Login: "testuser",
+	Email: "test@example.com",
+}
+
+func setupServer(
+	t *testing.T,
+	serverRepo *inmemory.ServerRepository,
+	rbacRepo *inmemory.RBACRepository,
+	gameID string,
+	allowed bool,
+) {
+	t.Helper()
+
+	now := time.Now()
+
+	require.NoError(t, serverRepo.Save(context.Background(), &domain.Server{
+		ID:               1,
+		UUID:             uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		UUIDShort:        "short1",
+		Enabled:          true,
+		Installed:        1,
+		Name:             "Test Server 1",
+		GameID:           gameID,
+		DSID:             1,
+		GameModID:        1,
+		ServerIP:         "127.0.0.1",
+		ServerPort:       27015,
+		Rcon:             lo.ToPtr("test_password"),
+		ProcessActive:    true,
+		LastProcessCheck: &now,
+		CreatedAt:        &now,
+		UpdatedAt:        &now,
+	}))
+	serverRepo.AddUserServer(testUser1.ID, 1)
+
+	if !allowed {
+		return
+	}
+
+	apitesting.AllowServerAbility(t, rbacRepo, testUser1.ID, 1, domain.AbilityNameGameServerRconPlayers)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverID       string
+		gameID         string
+		sendmsgCmd     *string
+		playerManager  *string
+		notAllowed     bool
+		setupAuth      func() context.Context
+		requestBody    any
+		expectedStatus int
+		wantError      string
+		wantCommand    string
+	}{
+		{
+			name:           "user_not_authenticated",
+			serverID:       "1",
+			setupAuth:      context.Background,
+			requestBody:    map[string]any{"message": "hello"},
+			expectedStatus: http.StatusUnauthorized,
+			wantError:      "user not authenticated",
+		},
+		{
+			name:           "invalid_server_id",
+			serverID:       "invalid",
+			requestBody:    map[string]any{"message": "hello"},
+			expectedStatus: http.StatusBadRequest,
+			wantError:      "invalid server id",
+		},
+		{
+			name:           "server_not_found",
+			serverID:       "999",
+			requestBody:    map[string]any{"message": "hello"},
+			expectedStatus: http.StatusNotFound,
+			wantError:      "server not found",
+		},
+		{
+			name:           "ability_not_allowed",
+			serverID:       "1",
+			gameID:         "cstrike",
+			notAllowed:     true,
+			requestBody:    map[string]any{"message": "hello"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "empty_message",
+			serverID:       "1",
+			gameID:         "cstrike",
+			requestBody:    map[string]any{"player": "12"},
+			expectedStatus: http.StatusUnprocessableEntity,
+			wantError:      "message is required",
+		},
+		{
+			name:           "valve_private_message",
+			serverID:       "1",
+			gameID:         "cstrike",
+			requestBody:    map[string]any{"player": "12", "message": `hi "there"`},
+			expectedStatus: http.StatusOK,
+			wantCommand:    `amx_psay "#12" "hi 'there'"`,
+		},
+		{
+			name:     "valve_private_message_player_object",
+			serverID: "1",
+			gameID:   "cstrike",
+			requestBody: map[string]any{
+				"player":  map[string]any{"uniqid": "STEAM_0:1:123"},
+				"message": "hi",
+			},
+			expectedStatus: http.StatusOK,
+			wantCommand:    `amx_psay "STEAM_0:1:123" "hi"`,
+		},
+		{
+			name:           "valve_broadcast",
+			serverID:       "1",
+			gameID:         "cstrike",
+			requestBody:    map[string]any{"message": "hello\nquit"},
+			expectedStatus: http.StatusOK,
+			wantCommand:    `say "hello quit"`,
+		},
+		{
+			name:           "minecraft_private_message",
+			serverID:       "1",
+			gameID:         "minecraft",
+			requestBody:    map[string]any{"player": "069a79f4-44e9-4726-a5be-fca90e38aaf5", "message": "hi"},
+			expectedStatus: http.StatusOK,
+			wantCommand:    "tell 069a79f4-44e9-4726-a5be-fca90e38aaf5 hi",
+		},
+		{
+			name:           "minecraft_broadcast",
+			serverID:       "1",
+			gameID:         "minecraft",
+			requestBody:    map[string]any{"player": nil, "message": "hello"},
+			expectedStatus: http.StatusOK,
+			wantCommand:    "say hello",
+		},
+		{
+			name:           "game_mod_template_broadcast",
+			serverID:       "1",
+			gameID:         "rust",
+			sendmsgCmd:     lo.ToPtr(`say "{msg}"`),
+			requestBody:    map[string]any{"message": `hello"; quit`},
+			expectedStatus: http.StatusOK,
+			wantCommand:    `say "hello', quit"`,
+		},
+		{
+			name:           "game_mod_player_manager",
+			serverID:       "1",
+			gameID:         "mcpaper",
+			playerManager:  lo.ToPtr("minecraft"),
+			requestBody:    map[string]any{"player": "alice", "message": "hi"},
+			expectedStatus: http.StatusOK,
+			wantCommand:    "tell alice hi",
+		},
+		{
+			name:           "game_mod_template_missing",
+			serverID:       "1",
+			gameID:         "rust",
+			requestBody:    map[string]any{"message": "hello"},
+			expectedStatus: http.StatusNotImplemented,
+			wantError:      "Not Implemented",
+		},
+		{
+			name:           "private_message_not_supported",
+			serverID:       "1",
+			gameID:         "rust",
+			sendmsgCmd:     lo.ToPtr(`say "{msg}"`),
+			requestBody:    map[string]any{"player": "12", "message": "hello"},
+			expectedStatus: http.StatusNotImplemented,
+			wantError:      "Not Implemented",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverRepo := inmemory.NewServerRepository()
+			gameRepo := inmemory.NewGameRepository()
+			gameModRepo := inmemory.NewGameModRepository()
+			rbacRepo := inmemory.NewRBACRepository()
+			rbacService := rbac.NewRBAC(services.NewNilTransactionManager(), rbacRepo, 0)
+			executor := &apitesting.Executor{Output: "ok"}
+
+			handler := NewHandler(
+				serverRepo,
+				gameRepo,
+				gameModRepo,
+				apitesting.NewNodeHealthChecker(t),
+				rbacService,
+				api.NewResponder(),
+			)
+			handler.executor = executor
+
+			if tt.gameID != "" {
+				setupServer(t, serverRepo, rbacRepo, tt.gameID, !tt.notAllowed)
+				require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+					Code: tt.gameID,
+					Name: tt.gameID,
+				}))
+				require.NoError(t, gameModRepo.Save(context.Background(), &domain.GameMod{
+					ID:            1,
+					GameCode:      tt.gameID,
+					Name:          "Default",
+					SendmsgCmd:    tt.sendmsgCmd,
+					PlayerManager: tt.playerManager,
+				}))
+			}
+
+			setupAuth := tt.setupAuth
+			if setupAuth == nil {
+				setupAuth = func() context.Context {
+					return apitesting.ContextWithUser(&testUser1)
+				}
+			}
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api/servers/"+tt.serverID+"/rcon/players/message",
+				bytes.NewReader(body),
+			)
+			req = req.WithContext(setupAuth())
+			req = mux.SetURLVars(req, map[string]string{"server": tt.serverID})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.wantError != "" {
+				var response map[string]any
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "error", response["status"])
+				assert.Contains(t, response["error"], tt.wantError)
+			}
+
+			if tt.wantCommand != "" {
+				assert.Equal(t, []string{tt.wantCommand}, executor.Commands)
+
+				var response messageResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "ok", response.Message)
+			} else {
+				assert.Empty(t, executor.Commands)
+			}
+		})
+	}
+}
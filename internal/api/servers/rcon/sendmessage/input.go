@@ -0,0 +1,70 @@
+package sendmessage
+
+import (
+	"encoding/json"
+
+	"github.com/gameap/gameap/pkg/api"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/pkg/errors"
+)
+
+const maxMessageLength = 512
+
+var (
+	ErrMessageIsRequired = api.NewValidationError("message is required")
+	ErrMessageIsTooLong  = api.NewValidationError("message must not exceed 512 characters")
+)
+
+type playerInput struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	UniqID string `json:"uniqid"`
+}
+
+type messageRequest struct {
+	// Player is the player ID or the player object, the message is sent to all players without it.
+	Player  json.RawMessage `json:"player"`
+	Message string          `json:"message"`
+}
+
+func (r *messageRequest) Validate() error {
+	if r.Message == "" {
+		return ErrMessageIsRequired
+	}
+
+	if len(r.Message) > maxMessageLength {
+		return ErrMessageIsTooLong
+	}
+
+	return nil
+}
+
+// IsBroadcast reports whether the message is sent to all players.
+func (r *messageRequest) IsBroadcast() bool {
+	return len(r.Player) == 0 || string(r.Player) == "null" || string(r.Player) == `""`
+}
+
+func (r *messageRequest) ToPlayer() (players.Player, error) {
+	var stringID string
+	if err := json.Unmarshal(r.Player, &stringID); err == nil {
+		return players.Player{
+			ID:     stringID,
+			UniqID: stringID,
+		}, nil
+	}
+
+	var playerObj playerInput
+	if err := json.Unmarshal(r.Player, &playerObj); err != nil {
+		return players.Player{}, errors.New("player must be a string ID or player object")
+	}
+
+	if playerObj.UniqID == "" {
+		playerObj.UniqID = playerObj.ID
+	}
+
+	return players.Player{
+		ID:     playerObj.ID,
+		Name:   playerObj.Name,
+		UniqID: playerObj.UniqID,
+	}, nil
+}
@@ -0,0 +1,11 @@
+package sendmessage
+
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+func newMessageResponse(message string) messageResponse {
+	return messageResponse{
+		Message: message,
+	}
+}
@@ -0,0 +1,72 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/daemon/fakedaemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/files"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeNode is a node with ID 1 served by the fake daemon.
+type FakeNode struct {
+	Daemon   *fakedaemon.Server
+	Node     *domain.Node
+	Repo     *inmemory.NodeRepository
+	Files    *daemon.FileService
+	Commands *daemon.CommandService
+}
+
+// NewFakeNode starts the fake daemon and saves the Linux node served by it.
+// The daemon is closed when the test finishes.
+func NewFakeNode(t *testing.T, workPath string, opts ...fakedaemon.Option) *FakeNode {
+	t.Helper()
+
+	server, err := fakedaemon.New(opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+
+	ctx := context.Background()
+	certRepo := inmemory.NewClientCertificateRepository()
+	fileManager := files.NewInMemoryFileManager()
+
+	node := &domain.Node{
+		ID:       1,
+		Enabled:  true,
+		Name:     "node",
+		OS:       domain.NodeOSLinux,
+		WorkPath: workPath,
+	}
+	require.NoError(t, server.ConfigureNode(ctx, node, certRepo, fileManager))
+
+	nodeRepo := inmemory.NewNodeRepository()
+	require.NoError(t, nodeRepo.Save(ctx, node))
+
+	pools := daemon.NewPoolRegistry(daemon.NewBreakers(0, 0), 0)
+	t.Cleanup(func() {
+		_ = pools.Close()
+	})
+
+	return &FakeNode{
+		Daemon:   server,
+		Node:     node,
+		Repo:     nodeRepo,
+		Files:    daemon.NewFileService(certRepo, fileManager, pools),
+		Commands: daemon.NewCommandService(certRepo, fileManager, pools),
+	}
+}
+
+// WriteFiles writes the files to the node filesystem.
+func (n *FakeNode) WriteFiles(t *testing.T, files map[string][]byte) {
+	t.Helper()
+
+	for name, data := range files {
+		require.NoError(t, n.Daemon.WriteFile(name, data))
+	}
+}
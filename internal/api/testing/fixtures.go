@@ -0,0 +1,86 @@
+// Package testing contains fixtures shared by the API handler tests.
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+// ContextWithUser returns the context with the session of the user.
+func ContextWithUser(user *domain.User) context.Context {
+	return auth.ContextWithSession(context.Background(), &auth.Session{
+		Login: user.Login,
+		Email: user.Email,
+		User:  user,
+	})
+}
+
+// AllowServerAbility grants the user the ability on the server.
+func AllowServerAbility(
+	t *testing.T,
+	repo *inmemory.RBACRepository,
+	userID uint,
+	serverID uint,
+	name domain.AbilityName,
+) {
+	t.Helper()
+
+	ability := domain.CreateAbilityForEntity(name, serverID, domain.EntityTypeServer)
+	require.NoError(t, repo.SaveAbility(context.Background(), &ability))
+	require.NoError(t, repo.Allow(
+		context.Background(),
+		userID,
+		domain.EntityTypeUser,
+		[]domain.Ability{ability},
+	))
+}
+
+// NewServer returns an installed and running server with ID 1 on the node with ID 1.
+func NewServer(gameID string) *domain.Server {
+	return &domain.Server{
+		ID:               1,
+		UUID:             uuid.New(),
+		UUIDShort:        "short1",
+		Enabled:          true,
+		Installed:        domain.ServerInstalledStatusInstalled,
+		Name:             "Test Server",
+		GameID:           gameID,
+		DSID:             1,
+		ServerIP:         "127.0.0.1",
+		ServerPort:       27015,
+		ProcessActive:    true,
+		LastProcessCheck: lo.ToPtr(time.Now()),
+	}
+}
+
+// NewServerRepository returns the repository with the server owned by the user.
+func NewServerRepository(t *testing.T, userID uint, server *domain.Server) *inmemory.ServerRepository {
+	t.Helper()
+
+	serverRepo := inmemory.NewServerRepository()
+
+	require.NoError(t, serverRepo.Save(context.Background(), server))
+	serverRepo.AddUserServer(userID, server.ID)
+
+	return serverRepo
+}
+
+// Executor records the RCON commands and responds to all of them with Output.
+type Executor struct {
+	Output   string
+	Commands []string
+}
+
+func (e *Executor) Execute(_ context.Context, _ *domain.Server, command string) (string, error) {
+	e.Commands = append(e.Commands, command)
+
+	return e.Output, nil
+}
@@ -0,0 +1,35 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/stretchr/testify/require"
+)
+
+// NewNodeHealthChecker returns the node health checker with the saved node states.
+// Nodes without a state are considered online.
+func NewNodeHealthChecker(t *testing.T, healths ...domain.NodeHealth) *nodehealth.Checker {
+	t.Helper()
+
+	healthRepo := inmemory.NewNodeHealthRepository()
+	for i := range healths {
+		require.NoError(t, healthRepo.Save(context.Background(), &healths[i]))
+	}
+
+	return nodehealth.NewChecker(inmemory.NewNodeRepository(), healthRepo, nil, time.Minute)
+}
+
+// OfflineNodeHealth returns the state of the node whose last health check failed.
+func OfflineNodeHealth(nodeID uint) domain.NodeHealth {
+	return domain.NodeHealth{
+		NodeID:    nodeID,
+		Online:    false,
+		Error:     "connection refused",
+		CheckedAt: time.Now(),
+	}
+}
@@ -115,6 +115,32 @@ func Run(runParams RunParams) {
 		slog.String("build_date", defaults.BuildDate),
 	)
 
+	go container.DaemonPools().Run(ctx)
+
+	if cfg.QueryPoller.Enabled {
+		go container.ServerQueryPoller().Run(ctx)
+
+		if cfg.QueryPoller.StatsEnabled {
+			go container.ServerStatsService().Run(ctx)
+		}
+	}
+
+	if cfg.NodeStats.Enabled {
+		go container.NodeStatsCollector().Run(ctx)
+	}
+
+	if cfg.NodeHealth.Enabled {
+		go container.NodeHealthChecker().Run(ctx)
+	}
+
+	if cfg.ServerResources.Enabled {
+		go container.ServerResourcesMonitor().Run(ctx)
+	}
+
+	if cfg.PlayerBans.ExpiryEnabled {
+		go container.PlayerBansService().Run(ctx)
+	}
+
 	slog.InfoContext(ctx, fmt.Sprintf("Starting HTTP server on %s:%d", cfg.HTTPHost, cfg.HTTPPort))
 
 	if cfg.TLSEnabled() {
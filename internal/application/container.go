@@ -15,6 +15,7 @@ import (
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 	internalapi "github.com/gameap/gameap/internal/api"
 	"github.com/gameap/gameap/internal/api/middlewares"
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
 	"github.com/gameap/gameap/internal/cache"
 	"github.com/gameap/gameap/internal/certificates"
 	"github.com/gameap/gameap/internal/config"
@@ -29,7 +30,18 @@ import (
 	"github.com/gameap/gameap/internal/repositories/postgres"
 	"github.com/gameap/gameap/internal/repositories/sqlite"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/gameadmins"
+	"github.com/gameap/gameap/internal/services/minecraftaccess"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/internal/services/nodestats"
+	"github.com/gameap/gameap/internal/services/playerbans"
+	"github.com/gameap/gameap/internal/services/playersessions"
+	"github.com/gameap/gameap/internal/services/rconexec"
 	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/gameap/gameap/internal/services/servermaps"
+	"github.com/gameap/gameap/internal/services/serverquery"
+	"github.com/gameap/gameap/internal/services/serverresources"
+	"github.com/gameap/gameap/internal/services/serverstats"
 	"github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/pkg/errors"
@@ -77,6 +89,12 @@ type Container struct {
 	serverSettingRepository       repositories.ServerSettingRepository
 	nodeRepository                repositories.NodeRepository
 	clientCertificateRepository   repositories.ClientCertificateRepository
+	serverStatRepository          repositories.ServerStatRepository
+	nodeStatRepository            repositories.NodeStatRepository
+	playerSessionRepository       repositories.PlayerSessionRepository
+	playerBanRepository           repositories.PlayerBanRepository
+	commandPolicyRepository       repositories.CommandPolicyRepository
+	nodeHealthRepository          repositories.NodeHealthRepository
 
 	// Services
 	authService          auth.Service
@@ -88,8 +106,21 @@ type Container struct {
 	cache                cache.Cache
 	fileManager          files.FileManager
 	certificatesService  *certificates.Service
+	serverQueryStore     *serverquery.Store
+	serverQueryPoller    *serverquery.Poller
+	serverStatsService   *serverstats.Service
+	playerSessions       *playersessions.Service
+	playerBans           *playerbans.Service
+	minecraftAccess      *minecraftaccess.Service
+	gameAdmins           *gameadmins.Service
+	serverMaps           *servermaps.Service
+	nodeStatsCollector   *nodestats.Collector
+	serverResources      *serverresources.Monitor
+	nodeHealthChecker    *nodehealth.Checker
 
 	// Daemon Services
+	daemonBreakers *daemon.Breakers
+	daemonPools    *daemon.PoolRegistry
 	daemonStatus   *daemon.StatusService
 	daemonFiles    *daemon.FileService
 	daemonCommands *daemon.CommandService
@@ -596,6 +627,150 @@ func (c *Container) createServerTaskRepository() repositories.ServerTaskReposito
 	}
 }
 
+func (c *Container) ServerStatRepository() repositories.ServerStatRepository {
+	if c.serverStatRepository == nil {
+		c.serverStatRepository = c.createServerStatRepository()
+	}
+
+	return c.serverStatRepository
+}
+
+func (c *Container) createServerStatRepository() repositories.ServerStatRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewServerStatRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewServerStatRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewServerStatRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewServerStatRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewServerStatRepository()
+	}
+}
+
+func (c *Container) NodeStatRepository() repositories.NodeStatRepository {
+	if c.nodeStatRepository == nil {
+		c.nodeStatRepository = c.createNodeStatRepository()
+	}
+
+	return c.nodeStatRepository
+}
+
+func (c *Container) createNodeStatRepository() repositories.NodeStatRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewNodeStatRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewNodeStatRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewNodeStatRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewNodeStatRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewNodeStatRepository()
+	}
+}
+
+func (c *Container) PlayerSessionRepository() repositories.PlayerSessionRepository {
+	if c.playerSessionRepository == nil {
+		c.playerSessionRepository = c.createPlayerSessionRepository()
+	}
+
+	return c.playerSessionRepository
+}
+
+func (c *Container) createPlayerSessionRepository() repositories.PlayerSessionRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewPlayerSessionRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewPlayerSessionRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewPlayerSessionRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewPlayerSessionRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewPlayerSessionRepository()
+	}
+}
+
+func (c *Container) PlayerBanRepository() repositories.PlayerBanRepository {
+	if c.playerBanRepository == nil {
+		c.playerBanRepository = c.createPlayerBanRepository()
+	}
+
+	return c.playerBanRepository
+}
+
+func (c *Container) createPlayerBanRepository() repositories.PlayerBanRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewPlayerBanRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewPlayerBanRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewPlayerBanRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewPlayerBanRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewPlayerBanRepository()
+	}
+}
+
+func (c *Container) CommandPolicyRepository() repositories.CommandPolicyRepository {
+	if c.commandPolicyRepository == nil {
+		c.commandPolicyRepository = c.createCommandPolicyRepository()
+	}
+
+	return c.commandPolicyRepository
+}
+
+func (c *Container) createCommandPolicyRepository() repositories.CommandPolicyRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewCommandPolicyRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewCommandPolicyRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewCommandPolicyRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewCommandPolicyRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewCommandPolicyRepository()
+	}
+}
+
+func (c *Container) NodeHealthRepository() repositories.NodeHealthRepository {
+	if c.nodeHealthRepository == nil {
+		c.nodeHealthRepository = c.createNodeHealthRepository()
+	}
+
+	return c.nodeHealthRepository
+}
+
+func (c *Container) createNodeHealthRepository() repositories.NodeHealthRepository {
+	switch c.config.DatabaseDriver {
+	case databaseDriverMySQL:
+		return mysql.NewNodeHealthRepository(c.TransactionalDB())
+	case databaseDriverPostgres, databaseDriverPGX:
+		return postgres.NewNodeHealthRepository(c.TransactionalDB())
+	case databaseDriverSQLite:
+		return sqlite.NewNodeHealthRepository(c.TransactionalDB())
+	case databaseDriverInMemory:
+		return inmemory.NewNodeHealthRepository()
+	default:
+		// Use in-memory repository as fallback
+		return inmemory.NewNodeHealthRepository()
+	}
+}
+
 func (c *Container) ServerTaskFailRepository() repositories.ServerTaskFailRepository {
 	if c.serverTaskFailRepository == nil {
 		c.serverTaskFailRepository = c.createServerTaskFailRepository()
@@ -864,11 +1039,231 @@ func (c *Container) createGameUpgradeService() *services.GameUpgradeService {
 	)
 }
 
+func (c *Container) ServerQueryStore() *serverquery.Store {
+	if c.serverQueryStore == nil {
+		// Results are considered stale after several missed polling rounds
+		c.serverQueryStore = serverquery.NewStore(3 * c.serverQueryPollerInterval())
+	}
+
+	return c.serverQueryStore
+}
+
+func (c *Container) ServerQueryPoller() *serverquery.Poller {
+	if c.serverQueryPoller == nil {
+		c.serverQueryPoller = c.createServerQueryPoller()
+	}
+
+	return c.serverQueryPoller
+}
+
+func (c *Container) createServerQueryPoller() *serverquery.Poller {
+	var recorders serverquery.ResultRecorders
+	if c.config.QueryPoller.StatsEnabled {
+		recorders = append(recorders, c.ServerStatsService())
+	}
+
+	if c.config.QueryPoller.PlayerSessionsEnabled {
+		recorders = append(recorders, c.PlayerSessionsService())
+	}
+
+	var recorder serverquery.ResultRecorder
+	if len(recorders) > 0 {
+		recorder = recorders
+	}
+
+	return serverquery.NewPoller(
+		c.ServerRepository(),
+		c.GameRepository(),
+		c.GameModRepository(),
+		c.ServerQueryStore(),
+		recorder,
+		serverquery.PollerConfig{
+			Interval:        c.serverQueryPollerInterval(),
+			NodeConcurrency: c.config.QueryPoller.NodeConcurrency,
+		},
+	)
+}
+
+func (c *Container) ServerStatsService() *serverstats.Service {
+	if c.serverStatsService == nil {
+		c.serverStatsService = serverstats.NewService(c.ServerStatRepository())
+	}
+
+	return c.serverStatsService
+}
+
+func (c *Container) PlayerSessionsService() *playersessions.Service {
+	if c.playerSessions == nil {
+		c.playerSessions = playersessions.NewService(c.PlayerSessionRepository())
+	}
+
+	return c.playerSessions
+}
+
+func (c *Container) PlayerBansService() *playerbans.Service {
+	if c.playerBans == nil {
+		interval, err := time.ParseDuration(c.config.PlayerBans.ExpiryInterval)
+		if err != nil || interval <= 0 {
+			interval = time.Minute // Default to 1 minute
+		}
+
+		c.playerBans = playerbans.NewService(
+			c.PlayerBanRepository(),
+			c.ServerRepository(),
+			c.NodeRepository(),
+			serversbase.NewGameFinder(c.GameRepository(), c.GameModRepository()),
+			rconexec.NewExecutor(serversbase.NewGameFinder(c.GameRepository(), c.GameModRepository())),
+			c.DaemonFiles(),
+			interval,
+		)
+	}
+
+	return c.playerBans
+}
+
+func (c *Container) MinecraftAccessService() *minecraftaccess.Service {
+	if c.minecraftAccess == nil {
+		c.minecraftAccess = minecraftaccess.NewService(
+			c.NodeRepository(),
+			serversbase.NewGameFinder(c.GameRepository(), c.GameModRepository()),
+			rconexec.NewExecutor(serversbase.NewGameFinder(c.GameRepository(), c.GameModRepository())),
+			c.DaemonFiles(),
+			minecraftaccess.NewProfileResolver(
+				c.config.Minecraft.ProfileResolver,
+				c.config.Minecraft.MojangAPIURL,
+			),
+		)
+	}
+
+	return c.minecraftAccess
+}
+
+func (c *Container) GameAdminsService() *gameadmins.Service {
+	if c.gameAdmins == nil {
+		c.gameAdmins = gameadmins.NewService(
+			c.NodeRepository(),
+			rconexec.NewExecutor(serversbase.NewGameFinder(c.GameRepository(), c.GameModRepository())),
+			c.DaemonFiles(),
+		)
+	}
+
+	return c.gameAdmins
+}
+
+func (c *Container) ServerMapsService() *servermaps.Service {
+	if c.serverMaps == nil {
+		c.serverMaps = servermaps.NewService(
+			c.NodeRepository(),
+			serversbase.NewGameFinder(c.GameRepository(), c.GameModRepository()),
+			c.DaemonFiles(),
+			c.ServerQueryStore(),
+		)
+	}
+
+	return c.serverMaps
+}
+
+func (c *Container) NodeStatsCollector() *nodestats.Collector {
+	if c.nodeStatsCollector == nil {
+		interval, err := time.ParseDuration(c.config.NodeStats.Interval)
+		if err != nil || interval <= 0 {
+			interval = 5 * time.Minute // Default to 5 minutes
+		}
+
+		c.nodeStatsCollector = nodestats.NewCollector(
+			c.NodeRepository(),
+			c.NodeStatRepository(),
+			c.DaemonCommands(),
+			interval,
+		)
+	}
+
+	return c.nodeStatsCollector
+}
+
+func (c *Container) ServerResourcesMonitor() *serverresources.Monitor {
+	if c.serverResources == nil {
+		interval, err := time.ParseDuration(c.config.ServerResources.Interval)
+		if err != nil || interval <= 0 {
+			interval = time.Minute // Default to 1 minute
+		}
+
+		c.serverResources = serverresources.NewMonitor(
+			c.ServerRepository(),
+			c.NodeRepository(),
+			c.DaemonCommands(),
+			interval,
+			serverresources.LogEventHandler{},
+		)
+	}
+
+	return c.serverResources
+}
+
+func (c *Container) NodeHealthChecker() *nodehealth.Checker {
+	if c.nodeHealthChecker == nil {
+		interval, err := time.ParseDuration(c.config.NodeHealth.Interval)
+		if err != nil || interval <= 0 {
+			interval = time.Minute // Default to 1 minute
+		}
+
+		c.nodeHealthChecker = nodehealth.NewChecker(
+			c.NodeRepository(),
+			c.NodeHealthRepository(),
+			c.DaemonStatus(),
+			interval,
+			nodehealth.LogEventHandler{},
+		)
+	}
+
+	return c.nodeHealthChecker
+}
+
+func (c *Container) serverQueryPollerInterval() time.Duration {
+	interval, err := time.ParseDuration(c.config.QueryPoller.Interval)
+	if err != nil || interval <= 0 {
+		interval = time.Minute // Default to 1 minute
+	}
+
+	return interval
+}
+
+func (c *Container) DaemonBreakers() *daemon.Breakers {
+	if c.daemonBreakers == nil {
+		openTimeout, err := time.ParseDuration(c.config.DaemonBreaker.OpenTimeout)
+		if err != nil {
+			openTimeout = 0 // Use the default timeout
+		}
+
+		c.daemonBreakers = daemon.NewBreakers(c.config.DaemonBreaker.FailureThreshold, openTimeout)
+	}
+
+	return c.daemonBreakers
+}
+
+func (c *Container) DaemonPools() *daemon.PoolRegistry {
+	if c.daemonPools == nil {
+		idleTimeout, err := time.ParseDuration(c.config.DaemonPool.IdleTimeout)
+		if err != nil {
+			idleTimeout = 0 // Use the default timeout
+		}
+
+		c.daemonPools = daemon.NewPoolRegistry(c.DaemonBreakers(), idleTimeout)
+
+		c.appendShutdownFunc(func() error {
+			return c.daemonPools.Close()
+		})
+	}
+
+	return c.daemonPools
+}
+
 func (c *Container) DaemonStatus() *daemon.StatusService {
 	if c.daemonStatus == nil {
 		c.daemonStatus = daemon.NewStatusService(
 			c.ClientCertificateRepository(),
 			c.FileManager(),
+			c.DaemonPools(),
 		)
 	}
 
@@ -880,6 +1275,7 @@ func (c *Container) DaemonFiles() *daemon.FileService {
 		c.daemonFiles = daemon.NewFileService(
 			c.ClientCertificateRepository(),
 			c.FileManager(),
+			c.DaemonPools(),
 		)
 	}
 
@@ -891,6 +1287,7 @@ func (c *Container) DaemonCommands() *daemon.CommandService {
 		c.daemonCommands = daemon.NewCommandService(
 			c.ClientCertificateRepository(),
 			c.FileManager(),
+			c.DaemonPools(),
 		)
 	}
 
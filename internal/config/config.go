@@ -80,6 +80,49 @@ type Config struct {
 		EnvPath string `env:"LEGACY_ENV_PATH" envDefault:""`
 	}
 
+	QueryPoller struct {
+		Enabled         bool   `env:"QUERY_POLLER_ENABLED" envDefault:"true"`
+		Interval        string `env:"QUERY_POLLER_INTERVAL" envDefault:"60s"`
+		NodeConcurrency int    `env:"QUERY_POLLER_NODE_CONCURRENCY" envDefault:"4"`
+		StatsEnabled    bool   `env:"QUERY_POLLER_STATS_ENABLED" envDefault:"true"`
+
+		PlayerSessionsEnabled bool `env:"QUERY_POLLER_PLAYER_SESSIONS_ENABLED" envDefault:"true"`
+	}
+
+	NodeStats struct {
+		Enabled  bool   `env:"NODE_STATS_ENABLED" envDefault:"false"`
+		Interval string `env:"NODE_STATS_INTERVAL" envDefault:"5m"`
+	}
+
+	NodeHealth struct {
+		Enabled  bool   `env:"NODE_HEALTH_ENABLED" envDefault:"true"`
+		Interval string `env:"NODE_HEALTH_INTERVAL" envDefault:"60s"`
+	}
+
+	DaemonBreaker struct {
+		FailureThreshold int    `env:"DAEMON_BREAKER_FAILURE_THRESHOLD" envDefault:"3"`
+		OpenTimeout      string `env:"DAEMON_BREAKER_OPEN_TIMEOUT" envDefault:"30s"`
+	}
+
+	DaemonPool struct {
+		IdleTimeout string `env:"DAEMON_POOL_IDLE_TIMEOUT" envDefault:"5m"`
+	}
+
+	ServerResources struct {
+		Enabled  bool   `env:"SERVER_RESOURCES_ENABLED" envDefault:"false"`
+		Interval string `env:"SERVER_RESOURCES_INTERVAL" envDefault:"60s"`
+	}
+
+	PlayerBans struct {
+		ExpiryEnabled  bool   `env:"PLAYER_BANS_EXPIRY_ENABLED" envDefault:"true"`
+		ExpiryInterval string `env:"PLAYER_BANS_EXPIRY_INTERVAL" envDefault:"60s"`
+	}
+
+	Minecraft struct {
+		ProfileResolver string `env:"MINECRAFT_PROFILE_RESOLVER" envDefault:"mojang"`
+		MojangAPIURL    string `env:"MINECRAFT_MOJANG_API_URL" envDefault:"https://api.mojang.com"`
+	}
+
 	GlobalAPI struct {
 		URL string `env:"GLOBAL_API_URL" envDefault:"https://api.gameap.com"`
 	}
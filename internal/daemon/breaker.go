@@ -0,0 +1,213 @@
+package daemon
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultBreakerFailureThreshold = 3
+	defaultBreakerOpenTimeout      = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned when connections to a node are rejected
+// because of too many consecutive connection failures.
+var ErrCircuitOpen = errors.New("daemon circuit breaker is open")
+
+type BreakerState string
+
+const (
+	BreakerStateClosed   BreakerState = "closed"
+	BreakerStateOpen     BreakerState = "open"
+	BreakerStateHalfOpen BreakerState = "half-open"
+)
+
+// BreakerStats is a snapshot of a node circuit breaker.
+type BreakerStats struct {
+	NodeID              uint
+	State               BreakerState
+	ConsecutiveFailures int
+	LastError           string
+	OpenedAt            *time.Time
+}
+
+// Breaker guards connection attempts to a single node.
+// It opens after threshold consecutive failures and, once openTimeout has
+// passed, lets a single probe through to decide whether to close again.
+type Breaker struct {
+	threshold   int
+	openTimeout time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	state     BreakerState
+	failures  int
+	lastError string
+	openedAt  time.Time
+	probing   bool
+}
+
+// Allow reports whether a connection attempt may be made.
+// Every allowed attempt must be followed by Success or Failure.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerStateOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return ErrCircuitOpen
+		}
+
+		b.state = BreakerStateHalfOpen
+		b.probing = true
+
+		return nil
+	case BreakerStateHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+
+		b.probing = true
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Success records a successful connection attempt and closes the breaker.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerStateClosed
+	b.failures = 0
+	b.lastError = ""
+	b.probing = false
+}
+
+// Failure records a failed connection attempt.
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+
+	if err != nil {
+		b.lastError = err.Error()
+	}
+
+	if b.state == BreakerStateHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerStateOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Release returns an allowed attempt that ended without a result,
+// for example when the caller's context was canceled.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *Breaker) Stats(nodeID uint) BreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := BreakerStats{
+		NodeID:              nodeID,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+	}
+
+	if b.state != BreakerStateClosed {
+		openedAt := b.openedAt
+		stats.OpenedAt = &openedAt
+	}
+
+	return stats
+}
+
+// Breakers holds circuit breakers per node, shared by all daemon services.
+type Breakers struct {
+	threshold   int
+	openTimeout time.Duration
+	now         func() time.Time
+
+	mu       sync.Mutex
+	breakers map[uint]*Breaker
+}
+
+// NewBreakers creates a registry of per-node circuit breakers.
+// Non-positive values fall back to the defaults.
+func NewBreakers(threshold int, openTimeout time.Duration) *Breakers {
+	if threshold <= 0 {
+		threshold = defaultBreakerFailureThreshold
+	}
+	if openTimeout <= 0 {
+		openTimeout = defaultBreakerOpenTimeout
+	}
+
+	return &Breakers{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		now:         time.Now,
+		breakers:    make(map[uint]*Breaker),
+	}
+}
+
+// Get returns the breaker for the node, creating it if needed.
+func (r *Breakers) Get(nodeID uint) *Breaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, exists := r.breakers[nodeID]
+	if !exists {
+		b = &Breaker{
+			threshold:   r.threshold,
+			openTimeout: r.openTimeout,
+			now:         r.now,
+			state:       BreakerStateClosed,
+		}
+		r.breakers[nodeID] = b
+	}
+
+	return b
+}
+
+// Reset forgets the breaker state of the node.
+func (r *Breakers) Reset(nodeID uint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.breakers, nodeID)
+}
+
+// Stats returns snapshots of all known breakers ordered by node ID.
+func (r *Breakers) Stats() []BreakerStats {
+	r.mu.Lock()
+	breakers := make(map[uint]*Breaker, len(r.breakers))
+	for nodeID, b := range r.breakers {
+		breakers[nodeID] = b
+	}
+	r.mu.Unlock()
+
+	stats := make([]BreakerStats, 0, len(breakers))
+	for nodeID, b := range breakers {
+		stats = append(stats, b.Stats(nodeID))
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].NodeID < stats[j].NodeID
+	})
+
+	return stats
+}
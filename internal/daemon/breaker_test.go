@@ -0,0 +1,177 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestDial = errors.New("dial failed")
+
+func newTestBreakers(now *time.Time) *Breakers {
+	breakers := NewBreakers(2, time.Minute)
+	breakers.now = func() time.Time {
+		return *now
+	}
+
+	return breakers
+}
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newTestBreakers(&now).Get(1)
+
+	require.NoError(t, b.Allow())
+	b.Failure(errTestDial)
+	assert.Equal(t, BreakerStateClosed, b.Stats(1).State)
+
+	require.NoError(t, b.Allow())
+	b.Failure(errTestDial)
+
+	stats := b.Stats(1)
+	assert.Equal(t, BreakerStateOpen, stats.State)
+	assert.Equal(t, 2, stats.ConsecutiveFailures)
+	assert.Equal(t, "dial failed", stats.LastError)
+	require.NotNil(t, stats.OpenedAt)
+	assert.Equal(t, now, *stats.OpenedAt)
+
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newTestBreakers(&now).Get(1)
+
+	require.NoError(t, b.Allow())
+	b.Failure(errTestDial)
+	require.NoError(t, b.Allow())
+	b.Success()
+	require.NoError(t, b.Allow())
+	b.Failure(errTestDial)
+
+	stats := b.Stats(1)
+	assert.Equal(t, BreakerStateClosed, stats.State)
+	assert.Equal(t, 1, stats.ConsecutiveFailures)
+	assert.Nil(t, stats.OpenedAt)
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	tests := []struct {
+		name          string
+		probeErr      error
+		expectedState BreakerState
+	}{
+		{
+			name:          "probe_succeeds",
+			expectedState: BreakerStateClosed,
+		},
+		{
+			name:          "probe_fails",
+			probeErr:      errTestDial,
+			expectedState: BreakerStateOpen,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			b := newTestBreakers(&now).Get(1)
+
+			for range 2 {
+				require.NoError(t, b.Allow())
+				b.Failure(errTestDial)
+			}
+
+			now = now.Add(time.Minute)
+
+			require.NoError(t, b.Allow())
+			assert.Equal(t, BreakerStateHalfOpen, b.Stats(1).State)
+			assert.ErrorIs(t, b.Allow(), ErrCircuitOpen, "only one probe is allowed")
+
+			if test.probeErr != nil {
+				b.Failure(test.probeErr)
+			} else {
+				b.Success()
+			}
+
+			stats := b.Stats(1)
+			assert.Equal(t, test.expectedState, stats.State)
+			if test.expectedState == BreakerStateOpen {
+				assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+				assert.Equal(t, now, *stats.OpenedAt)
+			} else {
+				assert.NoError(t, b.Allow())
+			}
+		})
+	}
+}
+
+func TestBreaker_ReleaseAllowsNextProbe(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newTestBreakers(&now).Get(1)
+
+	for range 2 {
+		require.NoError(t, b.Allow())
+		b.Failure(errTestDial)
+	}
+
+	now = now.Add(time.Minute)
+
+	require.NoError(t, b.Allow())
+	b.Release()
+
+	assert.NoError(t, b.Allow())
+}
+
+func TestBreakers_Stats(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	breakers := newTestBreakers(&now)
+
+	assert.Same(t, breakers.Get(2), breakers.Get(2))
+	breakers.Get(1).Failure(errTestDial)
+
+	stats := breakers.Stats()
+
+	require.Len(t, stats, 2)
+	assert.Equal(t, uint(1), stats[0].NodeID)
+	assert.Equal(t, 1, stats[0].ConsecutiveFailures)
+	assert.Equal(t, uint(2), stats[1].NodeID)
+	assert.Equal(t, BreakerStateClosed, stats[1].State)
+}
+
+func TestPool_BreakerFailsFast(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().(*net.TCPAddr)
+	require.NoError(t, listener.Close())
+
+	breaker := NewBreakers(1, time.Minute).Get(1)
+
+	pool, err := NewPool(config{
+		Host:    addr.IP.String(),
+		Port:    addr.Port,
+		Timeout: time.Second,
+	}, breaker)
+	require.NoError(t, err)
+	defer func() {
+		_ = pool.Close()
+	}()
+
+	_, err = pool.Acquire(context.Background())
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, BreakerStateOpen, breaker.Stats(1).State)
+
+	_, err = pool.Acquire(context.Background())
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	stats := pool.Stats(1)
+	assert.Equal(t, uint(1), stats.NodeID)
+	assert.Equal(t, int32(defaultPoolMaxSize), stats.MaxResources)
+	assert.Equal(t, int32(0), stats.TotalResources)
+}
@@ -3,7 +3,6 @@ package daemon
 import (
 	"context"
 	"log/slog"
-	"sync"
 	"time"
 
 	"github.com/gameap/gameap/internal/daemon/binnapi"
@@ -20,18 +19,17 @@ const (
 
 type CommandService struct {
 	configMaker *configMaker
-
-	mu    sync.RWMutex
-	pools map[uint]*Pool
+	pools       *PoolRegistry
 }
 
 func NewCommandService(
 	certRepo repositories.ClientCertificateRepository,
 	fileManager files.FileManager,
+	pools *PoolRegistry,
 ) *CommandService {
 	return &CommandService{
 		configMaker: newConfigMaker(certRepo, fileManager),
-		pools:       make(map[uint]*Pool),
+		pools:       pools,
 	}
 }
 
@@ -62,15 +60,10 @@ func (s *CommandService) ExecuteCommand(
 		opt(&req)
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to get pool")
-	}
-
 	var resp binnapi.CommandExecResponseMessage
 
 	err = Retry(commandsRetryCount, commandsRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -119,34 +112,6 @@ func (s *CommandService) ExecuteCommand(
 	}, nil
 }
 
-func (s *CommandService) getPool(nodeID uint, cfg config) (*Pool, error) {
-	s.mu.RLock()
-	pool, exists := s.pools[nodeID]
-	s.mu.RUnlock()
-
-	if exists {
-		return pool, nil
-	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Double-check existence to avoid race condition
-	pool, exists = s.pools[nodeID]
-	if exists {
-		return pool, nil
-	}
-
-	pool, err := NewPool(cfg)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to create pool")
-	}
-
-	s.pools[nodeID] = pool
-
-	return pool, nil
-}
-
 type CommandServiceOption func(*binnapi.CommandExecRequestMessage)
 
 func CommandServiceOptionWithWorkDir(workDir string) CommandServiceOption {
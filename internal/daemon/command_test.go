@@ -127,7 +127,7 @@ func TestCommandService_ExecuteCommand_WithMockDaemon(t *testing.T) {
 	err = fileManager.Write(ctx, node.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	service := NewCommandService(certRepo, fileManager)
+	service := NewCommandService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	result, err := service.ExecuteCommand(
@@ -203,7 +203,7 @@ func TestCommandService_ExecuteCommand_CommandFailure(t *testing.T) {
 	err = fileManager.Write(ctx, node.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	service := NewCommandService(certRepo, fileManager)
+	service := NewCommandService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	result, err := service.ExecuteCommand(
@@ -225,7 +225,7 @@ func TestCommandService_ExecuteCommand_NodeNotFound(t *testing.T) {
 	certRepo := inmemory.NewClientCertificateRepository()
 	fileManager := files.NewInMemoryFileManager()
 
-	service := NewCommandService(certRepo, fileManager)
+	service := NewCommandService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	ctx := context.Background()
@@ -266,7 +266,7 @@ func TestCommandService_ExecuteCommand_MissingServerCertificate(t *testing.T) {
 	err = nodeRepo.Save(ctx, node)
 	require.NoError(t, err)
 
-	service := NewCommandService(certRepo, fileManager)
+	service := NewCommandService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	result, err := service.ExecuteCommand(ctx, node, "ls -al")
@@ -310,7 +310,7 @@ func TestCommandService_ExecuteCommand_MissingClientCertificate(t *testing.T) {
 	err = fileManager.Write(ctx, node.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	service := NewCommandService(certRepo, fileManager)
+	service := NewCommandService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	result, err := service.ExecuteCommand(ctx, node, "ls -al")
@@ -357,7 +357,7 @@ func TestCommandService_ExecuteCommand_MissingPrivateKey(t *testing.T) {
 	err = fileManager.Write(ctx, node.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	service := NewCommandService(certRepo, fileManager)
+	service := NewCommandService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	result, err := service.ExecuteCommand(ctx, node, "ls -al")
@@ -432,7 +432,7 @@ func TestCommandService_ExecuteCommand_PoolReuse(t *testing.T) {
 	err = fileManager.Write(ctx, node.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	service := NewCommandService(certRepo, fileManager)
+	service := NewCommandService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	result1, err := service.ExecuteCommand(ctx, node, "echo test1", CommandServiceOptionWithWorkDir("/root"))
@@ -448,9 +448,7 @@ func TestCommandService_ExecuteCommand_PoolReuse(t *testing.T) {
 	assert.Equal(t, "command 3 output", result3.Output)
 
 	// ASSERT
-	service.mu.RLock()
-	poolCount := len(service.pools)
-	service.mu.RUnlock()
+	poolCount := len(service.pools.Stats())
 
 	assert.Equal(t, 1, poolCount, "Expected only one pool to be created for the same node")
 	mockServer.AssertMinRequestCount(3)
@@ -532,7 +530,7 @@ func TestCommandService_ExecuteCommand_MultipleNodes(t *testing.T) {
 	err = fileManager.Write(ctx, node1.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	service := NewCommandService(certRepo, fileManager)
+	service := NewCommandService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	result1, err := service.ExecuteCommand(ctx, node1, "echo node1")
@@ -544,9 +542,7 @@ func TestCommandService_ExecuteCommand_MultipleNodes(t *testing.T) {
 	assert.NotNil(t, result2)
 
 	// ASSERT
-	service.mu.RLock()
-	poolCount := len(service.pools)
-	service.mu.RUnlock()
+	poolCount := len(service.pools.Stats())
 
 	assert.Equal(t, 2, poolCount, "Expected separate pools for each node")
 }
@@ -613,7 +609,7 @@ func TestCommandService_ExecuteCommand_DifferentWorkDirectories(t *testing.T) {
 	err = fileManager.Write(ctx, node.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	service := NewCommandService(certRepo, fileManager)
+	service := NewCommandService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	result1, err := service.ExecuteCommand(
@@ -713,7 +709,7 @@ func TestCommandService_ExecuteCommand_DefaultWorkDirectory(t *testing.T) {
 	err = fileManager.Write(ctx, node.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	service := NewCommandService(certRepo, fileManager)
+	service := NewCommandService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	result, err := service.ExecuteCommand(ctx, node, "pwd")
@@ -536,7 +536,7 @@ func TestMockDaemonServerWithPreparedResponses(t *testing.T) {
 		PrivateKey:        []byte(clientKey),
 		Timeout:           10 * time.Second,
 		Mode:              binnapi.ModeStatus,
-	})
+	}, nil)
 	require.NoError(t, err)
 
 	// Acquire connection
@@ -600,7 +600,7 @@ func TestMockDaemonServer(t *testing.T) {
 		PrivateKey:        []byte(clientKey),
 		Timeout:           10 * time.Second,
 		Mode:              binnapi.ModeStatus,
-	})
+	}, nil)
 	require.NoError(t, err)
 
 	// Acquire connection
@@ -647,7 +647,7 @@ func TestMockDaemonServerRequestAssertion(t *testing.T) {
 		PrivateKey:        []byte(clientKey),
 		Timeout:           10 * time.Second,
 		Mode:              binnapi.ModeStatus,
-	})
+	}, nil)
 	require.NoError(t, err)
 
 	// Acquire connection
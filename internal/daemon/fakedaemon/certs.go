@@ -0,0 +1,139 @@
+package fakedaemon
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const certificateValidity = 24 * time.Hour
+
+// certificates are generated for every server, the CA signs both
+// the daemon server certificate and the panel client certificate.
+type certificates struct {
+	serverCert []byte
+	serverKey  []byte
+	clientCert []byte
+	clientKey  []byte
+
+	clientFingerprint string
+	expires           time.Time
+}
+
+func generateCertificates() (*certificates, error) {
+	now := time.Now()
+	expires := now.Add(certificateValidity)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate CA key")
+	}
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"GameAP"}, CommonName: "Fake GameAP Daemon CA"},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              expires,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA certificate")
+	}
+
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse CA certificate")
+	}
+
+	serverCert, serverKey, _, err := issueCertificate(caCert, caKey, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{Organization: []string{"GameAP"}, CommonName: "localhost"},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     expires,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to issue server certificate")
+	}
+
+	clientCert, clientKey, clientDER, err := issueCertificate(caCert, caKey, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{Organization: []string{"GameAP"}, CommonName: "GameAP"},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     expires,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to issue client certificate")
+	}
+
+	fingerprint := sha256.Sum256(clientDER)
+
+	return &certificates{
+		serverCert:        serverCert,
+		serverKey:         serverKey,
+		clientCert:        clientCert,
+		clientKey:         clientKey,
+		clientFingerprint: hex.EncodeToString(fingerprint[:]),
+		expires:           expires,
+	}, nil
+}
+
+func issueCertificate(
+	caCert *x509.Certificate,
+	caKey *ecdsa.PrivateKey,
+	template *x509.Certificate,
+) (certPEM []byte, keyPEM []byte, certDER []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to generate key")
+	}
+
+	certDER, err = x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to create certificate")
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to marshal key")
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM, certDER, nil
+}
+
+func (c *certificates) tlsConfig() (*tls.Config, error) {
+	cert, err := tls.X509KeyPair(c.serverCert, c.serverKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load server key pair")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		// The panel may use client certificates issued by its own CA
+		ClientAuth: tls.RequireAnyClientCert,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package fakedaemon
+
+import (
+	"io"
+	"strings"
+
+	"github.com/gameap/gameap/internal/daemon/binnapi"
+	"github.com/pkg/errors"
+)
+
+type CommandResult struct {
+	Output   string
+	ExitCode int
+}
+
+type ExecutedCommand struct {
+	Command string
+	WorkDir string
+}
+
+// CommandHandler returns the result of the command, ok is false
+// when the handler does not handle the command.
+type CommandHandler func(command, workDir string) (result CommandResult, ok bool)
+
+// HandleCommand scripts the result of the command.
+func (s *Server) HandleCommand(command string, result CommandResult) {
+	command = strings.TrimSpace(command)
+
+	s.HandleCommandFunc(func(c, _ string) (CommandResult, bool) {
+		return result, strings.TrimSpace(c) == command
+	})
+}
+
+// HandleCommandFunc adds a command handler.
+// Handlers are checked in the order they were added.
+func (s *Server) HandleCommandFunc(handler CommandHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers = append(s.handlers, handler)
+}
+
+// ExecutedCommands returns the commands received by the server.
+func (s *Server) ExecutedCommands() []ExecutedCommand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	executed := make([]ExecutedCommand, len(s.executed))
+	copy(executed, s.executed)
+
+	return executed
+}
+
+func (s *Server) handleCommand(w io.Writer, request rawMessage) error {
+	var req binnapi.CommandExecRequestMessage
+	if err := req.UnmarshalBINN(request); err != nil {
+		return writeError(w, "invalid command request")
+	}
+
+	result := s.executeCommand(req.Command, req.WorkDir)
+
+	err := binnapi.WriteMessage(w, binnapi.CommandExecResponseMessage{
+		Code:     binnapi.StatusCodeOK,
+		ExitCode: result.ExitCode,
+		Output:   result.Output,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to write command response")
+	}
+
+	return nil
+}
+
+func (s *Server) executeCommand(command, workDir string) CommandResult {
+	s.mu.Lock()
+	s.executed = append(s.executed, ExecutedCommand{
+		Command: command,
+		WorkDir: workDir,
+	})
+	handlers := s.handlers
+	defaultResult := s.defaultResult
+	s.mu.Unlock()
+
+	for _, handler := range handlers {
+		if result, ok := handler(command, workDir); ok {
+			return result
+		}
+	}
+
+	return defaultResult
+}
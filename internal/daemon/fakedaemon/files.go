@@ -0,0 +1,376 @@
+package fakedaemon
+
+import (
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/et-nik/binngo/decode"
+	"github.com/gameap/gameap/internal/daemon/binnapi"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultDirPerm  = 0o755
+	defaultFilePerm = 0o644
+
+	mimeDetectSize = 512
+)
+
+// WriteFile writes a file to the node filesystem, creating parent directories.
+func (s *Server) WriteFile(name string, data []byte) error {
+	p := s.localPath(name)
+
+	if err := os.MkdirAll(filepath.Dir(p), defaultDirPerm); err != nil {
+		return errors.Wrap(err, "failed to create directory")
+	}
+
+	return errors.Wrap(os.WriteFile(p, data, defaultFilePerm), "failed to write file")
+}
+
+// ReadFile reads a file from the node filesystem.
+func (s *Server) ReadFile(name string) ([]byte, error) {
+	data, err := os.ReadFile(s.localPath(name))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+
+	return data, nil
+}
+
+// localPath maps a node path into the root, paths can't escape it.
+func (s *Server) localPath(name string) string {
+	return filepath.Join(s.root, filepath.FromSlash(path.Clean("/"+name)))
+}
+
+func (s *Server) handleFiles(conn net.Conn, request rawMessage) error {
+	var fields []any
+	if err := decode.Unmarshal(request, &fields); err != nil || len(fields) == 0 {
+		return writeError(conn, "invalid files request")
+	}
+
+	operation, ok := fields[0].(uint8)
+	if !ok {
+		return writeError(conn, "invalid files operation")
+	}
+
+	switch binnapi.FilesOperation(operation) {
+	case binnapi.FilesOperationReadDir:
+		return s.readDir(conn, request)
+	case binnapi.FilesOperationMakeDir:
+		return s.mkDir(conn, request)
+	case binnapi.FilesOperationFileMove:
+		return s.move(conn, request)
+	case binnapi.FilesOperationFileRemove:
+		return s.remove(conn, request)
+	case binnapi.FilesOperationFileInfo:
+		return s.fileInfo(conn, request)
+	case binnapi.FilesOperationFileChmod:
+		return s.chmod(conn, request)
+	case binnapi.FilesOperationFileSend:
+		if len(fields) > 1 && fields[1] == uint8(binnapi.FilesGetFileFromClient) {
+			return s.upload(conn, request)
+		}
+
+		return s.download(conn, request)
+	default:
+		return binnapi.WriteMessage(conn, &binnapi.BaseResponseMessage{
+			Code: binnapi.StatusCodeUnknownCommand,
+			Info: "unknown files operation",
+		})
+	}
+}
+
+func (s *Server) readDir(w io.Writer, request rawMessage) error {
+	var req binnapi.ReadDirRequestMessage
+	if err := req.UnmarshalBINN(request); err != nil {
+		return writeError(w, "invalid read dir request")
+	}
+
+	entries, err := os.ReadDir(s.localPath(req.Directory))
+	if err != nil {
+		return s.writeFSError(w, err)
+	}
+
+	list := make([]any, 0, len(entries))
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		fi := binnapi.CreateFileInfoResponseMessageFromFileInfo(info)
+		list = append(list, []any{fi.Name, fi.Size, fi.TimeModified, fi.Type, fi.Perm})
+	}
+
+	return writeOK(w, list)
+}
+
+func (s *Server) mkDir(w io.Writer, request rawMessage) error {
+	var req binnapi.MkDirRequestMessage
+	if err := req.UnmarshalBINN(request); err != nil {
+		return writeError(w, "invalid mkdir request")
+	}
+
+	if err := os.MkdirAll(s.localPath(req.Directory), defaultDirPerm); err != nil {
+		return s.writeFSError(w, err)
+	}
+
+	return writeOK(w, nil)
+}
+
+func (s *Server) move(w io.Writer, request rawMessage) error {
+	var req binnapi.MoveRequestMessage
+	if err := req.UnmarshalBINN(request); err != nil {
+		return writeError(w, "invalid move request")
+	}
+
+	source := s.localPath(req.Source)
+	destination := s.localPath(req.Destination)
+
+	var err error
+	if req.Copy {
+		err = copyPath(source, destination)
+	} else {
+		err = os.Rename(source, destination)
+	}
+	if err != nil {
+		return s.writeFSError(w, err)
+	}
+
+	return writeOK(w, nil)
+}
+
+func (s *Server) remove(w io.Writer, request rawMessage) error {
+	var req binnapi.RemoveRequestMessage
+	if err := req.UnmarshalBINN(request); err != nil {
+		return writeError(w, "invalid remove request")
+	}
+
+	p := s.localPath(req.Path)
+	if _, err := os.Lstat(p); err != nil {
+		return s.writeFSError(w, err)
+	}
+
+	var err error
+	if req.Recursive {
+		err = os.RemoveAll(p)
+	} else {
+		err = os.Remove(p)
+	}
+	if err != nil {
+		return s.writeFSError(w, err)
+	}
+
+	return writeOK(w, nil)
+}
+
+func (s *Server) fileInfo(w io.Writer, request rawMessage) error {
+	var req binnapi.FileInfoRequestMessage
+	if err := req.UnmarshalBINN(request); err != nil {
+		return writeError(w, "invalid file info request")
+	}
+
+	p := s.localPath(req.Path)
+
+	info, err := os.Stat(p)
+	if err != nil {
+		return s.writeFSError(w, err)
+	}
+
+	fi := binnapi.CreateFileInfoResponseMessageFromFileInfo(info)
+
+	return writeOK(w, []any{
+		fi.Name,
+		fi.Size,
+		fi.Type,
+		fi.TimeModified,
+		fi.TimeModified,
+		fi.TimeModified,
+		fi.Perm,
+		detectMime(p, info),
+	})
+}
+
+func (s *Server) chmod(w io.Writer, request rawMessage) error {
+	var req binnapi.ChmodMessage
+	if err := req.UnmarshalBINN(request); err != nil {
+		return writeError(w, "invalid chmod request")
+	}
+
+	if err := os.Chmod(s.localPath(req.Path), fs.FileMode(req.Perm)); err != nil {
+		return s.writeFSError(w, err)
+	}
+
+	return writeOK(w, nil)
+}
+
+func (s *Server) download(w io.Writer, request rawMessage) error {
+	var req binnapi.DownloadRequestMessage
+	if err := req.UnmarshalBINN(request); err != nil {
+		return writeError(w, "invalid download request")
+	}
+
+	f, err := os.Open(s.localPath(req.FilePath))
+	if err != nil {
+		return s.writeFSError(w, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return s.writeFSError(w, err)
+	}
+	if info.IsDir() {
+		return writeError(w, "path is a directory")
+	}
+
+	err = binnapi.WriteMessage(w, &binnapi.BaseResponseMessage{
+		Code: binnapi.StatusCodeReadyToTransfer,
+		Info: "File is ready to transfer",
+		Data: uint64(info.Size()), // #nosec G115 -- file size is non-negative
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to write download response")
+	}
+
+	_, err = io.Copy(w, f)
+
+	return errors.Wrap(err, "failed to send file")
+}
+
+func (s *Server) upload(rw io.ReadWriter, request rawMessage) error {
+	var req binnapi.UploadRequestMessage
+	if err := req.UnmarshalBINN(request); err != nil {
+		return writeError(rw, "invalid upload request")
+	}
+
+	p := s.localPath(req.FilePath)
+
+	if req.MakeDirs {
+		if err := os.MkdirAll(filepath.Dir(p), defaultDirPerm); err != nil {
+			return s.writeFSError(rw, err)
+		}
+	}
+
+	perms := req.Perms.Perm()
+	if perms == 0 {
+		perms = defaultFilePerm
+	}
+
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perms)
+	if err != nil {
+		return s.writeFSError(rw, err)
+	}
+	defer f.Close()
+
+	err = binnapi.WriteMessage(rw, &binnapi.BaseResponseMessage{
+		Code: binnapi.StatusCodeReadyToTransfer,
+		Info: "Ready to receive file",
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to write upload response")
+	}
+
+	if req.FileSize > 0 {
+		_, err = io.CopyN(f, rw, int64(req.FileSize)) // #nosec G115 -- limited by the client
+		if err != nil {
+			return errors.Wrap(err, "failed to receive file")
+		}
+	}
+
+	return writeOK(rw, nil)
+}
+
+// writeFSError writes a filesystem error without exposing the root directory.
+func (s *Server) writeFSError(w io.Writer, err error) error {
+	info := strings.ReplaceAll(err.Error(), s.root, "")
+
+	return writeError(w, info)
+}
+
+func writeOK(w io.Writer, data any) error {
+	return binnapi.WriteMessage(w, &binnapi.BaseResponseMessage{
+		Code: binnapi.StatusCodeOK,
+		Info: "OK",
+		Data: data,
+	})
+}
+
+func detectMime(p string, info os.FileInfo) string {
+	if info.IsDir() {
+		return "inode/directory"
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	defer f.Close()
+
+	buf := make([]byte, mimeDetectSize)
+	n, _ := io.ReadFull(f, buf)
+
+	return http.DetectContentType(buf[:n])
+}
+
+func copyPath(source, destination string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return copyFile(source, destination, info.Mode().Perm())
+	}
+
+	return filepath.WalkDir(source, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(destination, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+
+		return copyFile(p, target, info.Mode().Perm())
+	})
+}
+
+func copyFile(source, destination string, perm fs.FileMode) error {
+	src, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(destination, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+
+		return err
+	}
+
+	return dst.Close()
+}
@@ -0,0 +1,57 @@
+package fakedaemon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/files"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+)
+
+// ConfigureNode points the node to the server. It stores the server and client
+// certificates in the file manager and saves the client certificate.
+// The node itself is not saved.
+func (s *Server) ConfigureNode(
+	ctx context.Context,
+	node *domain.Node,
+	certRepo repositories.ClientCertificateRepository,
+	fileManager files.FileManager,
+) error {
+	prefix := fmt.Sprintf("certs/fakedaemon_%d", s.Port())
+
+	cert := &domain.ClientCertificate{
+		Fingerprint: s.certs.clientFingerprint,
+		Expires:     s.certs.expires,
+		Certificate: prefix + "_client.crt",
+		PrivateKey:  prefix + "_client.key",
+	}
+
+	for p, data := range map[string][]byte{
+		prefix + "_server.crt": s.certs.serverCert,
+		cert.Certificate:       s.certs.clientCert,
+		cert.PrivateKey:        s.certs.clientKey,
+	} {
+		if err := fileManager.Write(ctx, p, data); err != nil {
+			return errors.WithMessage(err, "failed to write certificate")
+		}
+	}
+
+	if err := certRepo.Save(ctx, cert); err != nil {
+		return errors.WithMessage(err, "failed to save client certificate")
+	}
+
+	node.GdaemonHost = s.Host()
+	node.GdaemonPort = s.Port()
+	node.GdaemonServerCert = prefix + "_server.crt"
+	node.ClientCertificateID = cert.ID
+
+	if s.login != "" {
+		login, password := s.login, s.password
+		node.GdaemonLogin = &login
+		node.GdaemonPassword = &password
+	}
+
+	return nil
+}
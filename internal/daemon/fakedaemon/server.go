@@ -0,0 +1,346 @@
+// Package fakedaemon implements an in-process GameAP daemon speaking the binn
+// protocol over TLS. Files are kept in a directory on the local filesystem and
+// command results are scripted, so the panel can be run against a simulated node.
+package fakedaemon
+
+import (
+	"crypto/tls"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon/binnapi"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultAddress   = "127.0.0.1:0"
+	defaultVersion   = "3.0.0-fake"
+	defaultBuildDate = "2025-01-01"
+
+	idleTimeout = 5 * time.Minute
+)
+
+var (
+	ErrAuthenticationFailed = errors.New("authentication failed")
+	ErrUnsupportedMode      = errors.New("unsupported mode")
+)
+
+type Option func(*Server)
+
+// WithAddress sets the listen address, a random local port is used by default.
+func WithAddress(address string) Option {
+	return func(s *Server) {
+		s.address = address
+	}
+}
+
+// WithRoot sets the directory serving as the node filesystem.
+// A temporary directory removed on Close is used by default.
+func WithRoot(root string) Option {
+	return func(s *Server) {
+		s.root = root
+	}
+}
+
+// WithCredentials makes the server reject logins with other credentials.
+// Any credentials are accepted by default.
+func WithCredentials(login, password string) Option {
+	return func(s *Server) {
+		s.login = login
+		s.password = password
+	}
+}
+
+// WithVersion sets the version reported in status requests.
+func WithVersion(version, buildDate string) Option {
+	return func(s *Server) {
+		s.version = version
+		s.buildDate = buildDate
+	}
+}
+
+// WithDefaultCommandResult sets the result of commands not matching
+// any handler. Unmatched commands succeed with empty output by default.
+func WithDefaultCommandResult(result CommandResult) Option {
+	return func(s *Server) {
+		s.defaultResult = result
+	}
+}
+
+type Server struct {
+	address    string
+	root       string
+	removeRoot bool
+	login      string
+	password   string
+	version    string
+	buildDate  string
+	startedAt  time.Time
+
+	certs    *certificates
+	listener net.Listener
+
+	mu            sync.Mutex
+	conns         map[net.Conn]struct{}
+	handlers      []CommandHandler
+	defaultResult CommandResult
+	executed      []ExecutedCommand
+
+	wg     sync.WaitGroup
+	done   chan struct{}
+	closed sync.Once
+}
+
+// New creates the server and starts accepting connections.
+func New(opts ...Option) (*Server, error) {
+	s := &Server{
+		address:   defaultAddress,
+		version:   defaultVersion,
+		buildDate: defaultBuildDate,
+		startedAt: time.Now(),
+		conns:     make(map[net.Conn]struct{}),
+		done:      make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.root == "" {
+		root, err := os.MkdirTemp("", "gameap-fakedaemon-")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create root directory")
+		}
+
+		s.root = root
+		s.removeRoot = true
+	}
+
+	certs, err := generateCertificates()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to generate certificates")
+	}
+	s.certs = certs
+
+	tlsConfig, err := certs.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := tls.Listen("tcp", s.address, tlsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen")
+	}
+	s.listener = listener
+
+	s.wg.Add(1)
+	go s.accept()
+
+	return s, nil
+}
+
+// Close stops the server, closes all connections and removes the temporary root.
+func (s *Server) Close() error {
+	var err error
+
+	s.closed.Do(func() {
+		close(s.done)
+
+		err = s.listener.Close()
+
+		s.mu.Lock()
+		for conn := range s.conns {
+			_ = conn.Close()
+		}
+		s.mu.Unlock()
+
+		s.wg.Wait()
+
+		if s.removeRoot {
+			if removeErr := os.RemoveAll(s.root); removeErr != nil && err == nil {
+				err = errors.Wrap(removeErr, "failed to remove root directory")
+			}
+		}
+	})
+
+	return err
+}
+
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.Addr())
+
+	return host
+}
+
+func (s *Server) Port() int {
+	_, port, _ := net.SplitHostPort(s.Addr())
+	p, _ := strconv.Atoi(port)
+
+	return p
+}
+
+// Root returns the directory serving as the node filesystem.
+func (s *Server) Root() string {
+	return s.root
+}
+
+// ServerCertificate returns the PEM encoded daemon server certificate.
+func (s *Server) ServerCertificate() []byte {
+	return s.certs.serverCert
+}
+
+// ClientCertificate returns a PEM encoded client certificate accepted by the server.
+func (s *Server) ClientCertificate() []byte {
+	return s.certs.clientCert
+}
+
+// ClientPrivateKey returns the PEM encoded private key of ClientCertificate.
+func (s *Server) ClientPrivateKey() []byte {
+	return s.certs.clientKey
+}
+
+func (s *Server) accept() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			slog.Warn("fakedaemon: failed to accept connection", slog.String("error", err.Error()))
+
+			continue
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.serve(conn)
+	}
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		_ = conn.Close()
+
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+
+	mode, err := s.authenticate(conn)
+	if err != nil {
+		if !isClosed(err) {
+			slog.Debug("fakedaemon: login failed", slog.String("error", err.Error()))
+		}
+
+		return
+	}
+
+	for {
+		if err = conn.SetReadDeadline(time.Now().Add(idleTimeout)); err != nil {
+			return
+		}
+
+		var request rawMessage
+		err = binnapi.ReadMessage(conn, &request)
+		if err != nil {
+			if !isClosed(err) {
+				slog.Debug("fakedaemon: failed to read request", slog.String("error", err.Error()))
+			}
+
+			return
+		}
+
+		switch mode {
+		case binnapi.ModeCMD:
+			err = s.handleCommand(conn, request)
+		case binnapi.ModeFiles:
+			err = s.handleFiles(conn, request)
+		case binnapi.ModeStatus:
+			err = s.handleStatus(conn, request)
+		default:
+			err = ErrUnsupportedMode
+		}
+		if err != nil {
+			slog.Debug("fakedaemon: failed to handle request", slog.String("error", err.Error()))
+
+			return
+		}
+	}
+}
+
+func (s *Server) authenticate(conn net.Conn) (binnapi.Mode, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(idleTimeout)); err != nil {
+		return 0, errors.Wrap(err, "failed to set read deadline")
+	}
+
+	var login binnapi.LoginRequestMessage
+	if err := binnapi.ReadMessage(conn, &login); err != nil {
+		return 0, err
+	}
+
+	if s.login != "" && (login.Login != s.login || login.Password != s.password) {
+		_ = writeError(conn, ErrAuthenticationFailed.Error())
+
+		return 0, ErrAuthenticationFailed
+	}
+
+	switch login.Mode {
+	case binnapi.ModeCMD, binnapi.ModeFiles, binnapi.ModeStatus:
+	default:
+		_ = writeError(conn, ErrUnsupportedMode.Error())
+
+		return 0, ErrUnsupportedMode
+	}
+
+	err := binnapi.WriteMessage(conn, &binnapi.BaseResponseMessage{
+		Code: binnapi.StatusCodeOK,
+		Info: "Auth success",
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return login.Mode, nil
+}
+
+func writeError(w io.Writer, info string) error {
+	return binnapi.WriteMessage(w, &binnapi.BaseResponseMessage{
+		Code: binnapi.StatusCodeError,
+		Info: info,
+	})
+}
+
+func isClosed(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// rawMessage keeps the binn encoded request to decode it by its mode.
+type rawMessage []byte
+
+func (m *rawMessage) UnmarshalBINN(b []byte) error {
+	*m = append((*m)[:0], b...)
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package fakedaemon_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/daemon/fakedaemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/files"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEnv struct {
+	server   *fakedaemon.Server
+	node     *domain.Node
+	commands *daemon.CommandService
+	files    *daemon.FileService
+	status   *daemon.StatusService
+}
+
+func setup(t *testing.T, opts ...fakedaemon.Option) *testEnv {
+	t.Helper()
+
+	server, err := fakedaemon.New(opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+	})
+
+	certRepo := inmemory.NewClientCertificateRepository()
+	fileManager := files.NewInMemoryFileManager()
+
+	node := &domain.Node{ID: 1, Enabled: true, Name: "fake", OS: "linux", WorkPath: "/srv/gameap"}
+	require.NoError(t, server.ConfigureNode(context.Background(), node, certRepo, fileManager))
+
+	pools := daemon.NewPoolRegistry(daemon.NewBreakers(0, 0), 0)
+	t.Cleanup(func() {
+		_ = pools.Close()
+	})
+
+	return &testEnv{
+		server:   server,
+		node:     node,
+		commands: daemon.NewCommandService(certRepo, fileManager, pools),
+		files:    daemon.NewFileService(certRepo, fileManager, pools),
+		status:   daemon.NewStatusService(certRepo, fileManager, pools),
+	}
+}
+
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+func TestServer_Status(t *testing.T) {
+	env := setup(t, fakedaemon.WithVersion("3.1.0", "2025-06-01"))
+	ctx := testContext(t)
+
+	version, err := env.status.Version(ctx, env.node)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", version.Version)
+	assert.Equal(t, "2025-06-01", version.BuildDate)
+
+	status, err := env.status.Status(ctx, env.node)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", status.Version)
+	assert.Zero(t, status.WorkingTasks)
+}
+
+func TestServer_Credentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		login    string
+		password string
+		wantErr  bool
+	}{
+		{
+			name:     "valid_credentials",
+			login:    "gameap",
+			password: "secret",
+		},
+		{
+			name:     "invalid_password",
+			login:    "gameap",
+			password: "wrong",
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := setup(t, fakedaemon.WithCredentials("gameap", "secret"))
+			ctx := testContext(t)
+
+			require.NotNil(t, env.node.GdaemonLogin)
+			env.node.GdaemonLogin = &test.login
+			env.node.GdaemonPassword = &test.password
+
+			_, err := env.status.Version(ctx, env.node)
+			if test.wantErr {
+				assert.ErrorContains(t, err, "authentication failed")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServer_Commands(t *testing.T) {
+	env := setup(t, fakedaemon.WithDefaultCommandResult(fakedaemon.CommandResult{
+		Output:   "command not found",
+		ExitCode: 127,
+	}))
+	ctx := testContext(t)
+
+	env.server.HandleCommand("./server.sh start", fakedaemon.CommandResult{Output: "started"})
+	env.server.HandleCommandFunc(func(command, workDir string) (fakedaemon.CommandResult, bool) {
+		return fakedaemon.CommandResult{Output: workDir, ExitCode: 3}, command == "pwd"
+	})
+
+	result, err := env.commands.ExecuteCommand(ctx, env.node, "./server.sh start")
+	require.NoError(t, err)
+	assert.Equal(t, &daemon.CommandResult{Output: "started"}, result)
+
+	result, err = env.commands.ExecuteCommand(
+		ctx, env.node, "pwd", daemon.CommandServiceOptionWithWorkDir("/srv/gameap"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, &daemon.CommandResult{Output: "/srv/gameap", ExitCode: 3}, result)
+
+	result, err = env.commands.ExecuteCommand(ctx, env.node, "unknown")
+	require.NoError(t, err)
+	assert.Equal(t, 127, result.ExitCode)
+
+	assert.Equal(t, []fakedaemon.ExecutedCommand{
+		{Command: "./server.sh start", WorkDir: "/"},
+		{Command: "pwd", WorkDir: "/srv/gameap"},
+		{Command: "unknown", WorkDir: "/"},
+	}, env.server.ExecutedCommands())
+}
+
+func TestServer_Files(t *testing.T) {
+	env := setup(t)
+	ctx := testContext(t)
+
+	require.NoError(t, env.server.WriteFile("/srv/gameap/servers/1/server.cfg", []byte("hostname test")))
+
+	t.Run("read_dir", func(t *testing.T) {
+		require.NoError(t, env.files.MkDir(ctx, env.node, "/srv/gameap/servers/1/maps"))
+
+		list, err := env.files.ReadDir(ctx, env.node, "/srv/gameap/servers/1")
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Name < list[j].Name
+		})
+		assert.Equal(t, "maps", list[0].Name)
+		assert.Equal(t, daemon.FileTypeDir, list[0].Type)
+		assert.Equal(t, "server.cfg", list[1].Name)
+		assert.Equal(t, daemon.FileTypeFile, list[1].Type)
+		assert.Equal(t, uint64(13), list[1].Size)
+	})
+
+	t.Run("upload_and_download", func(t *testing.T) {
+		err := env.files.Upload(ctx, env.node, "/srv/gameap/servers/1/cfg/motd.txt", []byte("welcome"), 0o640)
+		require.NoError(t, err)
+
+		content, err := env.files.Download(ctx, env.node, "/srv/gameap/servers/1/cfg/motd.txt")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("welcome"), content)
+
+		info, err := os.Stat(filepath.Join(env.server.Root(), "srv/gameap/servers/1/cfg/motd.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+	})
+
+	t.Run("download_missing_file", func(t *testing.T) {
+		_, err := env.files.Download(ctx, env.node, "/srv/gameap/missing.txt")
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), env.server.Root())
+	})
+
+	t.Run("copy_move_remove", func(t *testing.T) {
+		require.NoError(t, env.files.Copy(ctx, env.node, "/srv/gameap/servers/1/server.cfg", "/srv/gameap/server.cfg"))
+		require.NoError(t, env.files.Move(ctx, env.node, "/srv/gameap/server.cfg", "/srv/gameap/moved.cfg"))
+
+		content, err := env.server.ReadFile("/srv/gameap/moved.cfg")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hostname test"), content)
+
+		_, err = env.server.ReadFile("/srv/gameap/server.cfg")
+		require.Error(t, err)
+
+		require.NoError(t, env.files.Remove(ctx, env.node, "/srv/gameap/moved.cfg", false))
+		_, err = env.server.ReadFile("/srv/gameap/moved.cfg")
+		require.Error(t, err)
+
+		require.Error(t, env.files.Remove(ctx, env.node, "/srv/gameap/moved.cfg", false))
+	})
+
+	t.Run("chmod_and_info", func(t *testing.T) {
+		require.NoError(t, env.files.Chmod(ctx, env.node, "/srv/gameap/servers/1/server.cfg", 0o600))
+
+		details, err := env.files.GetFileInfo(ctx, env.node, "/srv/gameap/servers/1/server.cfg")
+		require.NoError(t, err)
+		assert.Equal(t, "server.cfg", details.Name)
+		assert.Equal(t, uint64(13), details.Size)
+		assert.Equal(t, uint32(0o600), details.Perm)
+		assert.Equal(t, daemon.FileTypeFile, details.Type)
+		assert.Contains(t, details.Mime, "text/plain")
+	})
+
+	t.Run("paths_do_not_escape_root", func(t *testing.T) {
+		require.NoError(t, env.files.Upload(ctx, env.node, "../../escape.txt", []byte("x"), 0o644))
+
+		content, err := os.ReadFile(filepath.Join(env.server.Root(), "escape.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("x"), content)
+	})
+}
@@ -0,0 +1,35 @@
+package fakedaemon
+
+import (
+	"io"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon/binnapi"
+)
+
+func (s *Server) handleStatus(w io.Writer, request rawMessage) error {
+	var req binnapi.StatusRequestMessage
+	if err := req.UnmarshalBINN(request); err != nil {
+		return writeError(w, "invalid status request")
+	}
+
+	switch req {
+	case binnapi.StatusRequestVersion:
+		return binnapi.WriteMessage(w, &binnapi.StatusVersionResponseMessage{
+			Version:   s.version,
+			BuildDate: s.buildDate,
+		})
+	case binnapi.StatusRequestStatusBase:
+		return binnapi.WriteMessage(w, &binnapi.StatusInfoBaseResponseMessage{
+			Uptime:        time.Since(s.startedAt).Truncate(time.Second).String(),
+			WorkingTasks:  "0",
+			WaitingTasks:  "0",
+			OnlineServers: "0",
+		})
+	default:
+		return binnapi.WriteMessage(w, &binnapi.BaseResponseMessage{
+			Code: binnapi.StatusCodeUnknownCommand,
+			Info: "unknown status request",
+		})
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"math"
 	"net"
 	"os"
-	"sync"
 	"time"
 
 	"github.com/gameap/gameap/internal/daemon/binnapi"
@@ -59,18 +58,17 @@ const (
 
 type FileService struct {
 	configMaker *configMaker
-
-	mu    sync.RWMutex
-	pools map[uint]*Pool
+	pools       *PoolRegistry
 }
 
 func NewFileService(
 	certRepo repositories.ClientCertificateRepository,
 	fileManager files.FileManager,
+	pools *PoolRegistry,
 ) *FileService {
 	return &FileService{
 		configMaker: newConfigMaker(certRepo, fileManager),
-		pools:       make(map[uint]*Pool),
+		pools:       pools,
 	}
 }
 
@@ -85,15 +83,10 @@ func (s *FileService) ReadDir(
 		return nil, errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to get pool")
-	}
-
 	var resp binnapi.BaseResponseMessage
 
 	err = Retry(filesRetryCount, filesRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -167,15 +160,10 @@ func (s *FileService) MkDir(ctx context.Context, node *domain.Node, directory st
 		return errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return errors.WithMessage(err, "failed to get pool")
-	}
-
 	var resp binnapi.BaseResponseMessage
 
 	err = Retry(filesRetryCount, filesRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -230,15 +218,10 @@ func (s *FileService) move(ctx context.Context, node *domain.Node, source, desti
 		return errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return errors.WithMessage(err, "failed to get pool")
-	}
-
 	var resp binnapi.BaseResponseMessage
 
 	err = Retry(filesRetryCount, filesRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -287,16 +270,11 @@ func (s *FileService) Download(ctx context.Context, node *domain.Node, filePath
 		return nil, errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to get pool")
-	}
-
 	var resp binnapi.BaseResponseMessage
 	var file []byte
 
 	err = Retry(filesRetryCount, filesRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -365,18 +343,13 @@ func (s *FileService) DownloadStream(
 		return nil, errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to get pool")
-	}
-
 	var conn net.Conn
 	var resp binnapi.BaseResponseMessage
 	var fileSize uint64
 
 	err = Retry(filesRetryCount, filesRetryDelay, func() error {
 		var err error
-		conn, err = pool.Acquire(ctx)
+		conn, err = s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -493,15 +466,10 @@ func (s *FileService) UploadStream(
 		return errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return errors.WithMessage(err, "failed to get pool")
-	}
-
 	var resp binnapi.BaseResponseMessage
 
 	err = Retry(filesRetryCount, filesRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -565,15 +533,10 @@ func (s *FileService) Remove(ctx context.Context, node *domain.Node, path string
 		return errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return errors.WithMessage(err, "failed to get pool")
-	}
-
 	var resp binnapi.BaseResponseMessage
 
 	err = Retry(filesRetryCount, filesRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -621,15 +584,10 @@ func (s *FileService) GetFileInfo(ctx context.Context, node *domain.Node, path s
 		return nil, errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to get pool")
-	}
-
 	var resp binnapi.BaseResponseMessage
 
 	err = Retry(filesRetryCount, filesRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -690,15 +648,10 @@ func (s *FileService) Chmod(ctx context.Context, node *domain.Node, path string,
 		return errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return errors.WithMessage(err, "failed to get pool")
-	}
-
 	var resp binnapi.BaseResponseMessage
 
 	err = Retry(filesRetryCount, filesRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -738,31 +691,3 @@ func (s *FileService) Chmod(ctx context.Context, node *domain.Node, path string,
 
 	return nil
 }
-
-func (s *FileService) getPool(nodeID uint, cfg config) (*Pool, error) {
-	s.mu.RLock()
-	pool, exists := s.pools[nodeID]
-	s.mu.RUnlock()
-
-	if exists {
-		return pool, nil
-	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Double-check existence to avoid race condition
-	pool, exists = s.pools[nodeID]
-	if exists {
-		return pool, nil
-	}
-
-	pool, err := NewPool(cfg)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to create pool")
-	}
-
-	s.pools[nodeID] = pool
-
-	return pool, nil
-}
@@ -58,7 +58,7 @@ func setupFileServiceTest(t *testing.T, mockServer *MockDaemonServer) (*FileServ
 	err = fileManager.Write(ctx, node.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	fileService := NewFileService(certRepo, fileManager)
+	fileService := NewFileService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	return fileService, node
 }
@@ -425,7 +425,7 @@ func TestFileService_NodeNotFound(t *testing.T) {
 	certRepo := inmemory.NewClientCertificateRepository()
 	fileManager := files.NewInMemoryFileManager()
 
-	fileService := NewFileService(certRepo, fileManager)
+	fileService := NewFileService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	ctx := context.Background()
@@ -464,9 +464,7 @@ func TestFileService_PoolReuse(t *testing.T) {
 	require.NoError(t, err)
 
 	// ASSERT
-	fileService.mu.RLock()
-	poolCount := len(fileService.pools)
-	fileService.mu.RUnlock()
+	poolCount := len(fileService.pools.Stats())
 
 	assert.Equal(t, 1, poolCount, "Expected only one pool to be created for the same node")
 	mockServer.AssertMinRequestCount(3)
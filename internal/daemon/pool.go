@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gameap/gameap/internal/daemon/binnapi"
 	"github.com/jackc/puddle/v2"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
@@ -33,12 +34,36 @@ func (e puddlePanicError) Error() string {
 }
 
 type Pool struct {
-	p *puddle.Pool[net.Conn]
+	p    *puddle.Pool[net.Conn]
+	mode binnapi.Mode
 }
 
-func NewPool(cfg config) (*Pool, error) {
+// NewPool creates a connection pool for the daemon described by cfg.
+// When breaker is not nil, new connections are only dialed while it allows them.
+func NewPool(cfg config, breaker *Breaker) (*Pool, error) {
 	constructor := func(ctx context.Context) (net.Conn, error) {
-		return Connect(ctx, cfg)
+		if breaker == nil {
+			return Connect(ctx, cfg)
+		}
+
+		if err := breaker.Allow(); err != nil {
+			return nil, err
+		}
+
+		conn, err := Connect(ctx, cfg)
+		if err != nil {
+			if ctx.Err() != nil {
+				breaker.Release()
+			} else {
+				breaker.Failure(err)
+			}
+
+			return nil, err
+		}
+
+		breaker.Success()
+
+		return conn, nil
 	}
 
 	destructor := func(conn net.Conn) {
@@ -58,7 +83,8 @@ func NewPool(cfg config) (*Pool, error) {
 	}
 
 	return &Pool{
-		p: p,
+		p:    p,
+		mode: cfg.Mode,
 	}, nil
 }
 
@@ -120,6 +146,39 @@ func (p *Pool) Stat() *puddle.Stat {
 	return p.p.Stat()
 }
 
+// PoolStats is a snapshot of a node connection pool.
+type PoolStats struct {
+	NodeID                uint
+	Mode                  binnapi.Mode
+	TotalResources        int32
+	AcquiredResources     int32
+	IdleResources         int32
+	ConstructingResources int32
+	MaxResources          int32
+	AcquireCount          int64
+	AcquireDuration       time.Duration
+	EmptyAcquireCount     int64
+	CanceledAcquireCount  int64
+}
+
+func (p *Pool) Stats(nodeID uint) PoolStats {
+	stat := p.p.Stat()
+
+	return PoolStats{
+		NodeID:                nodeID,
+		Mode:                  p.mode,
+		TotalResources:        stat.TotalResources(),
+		AcquiredResources:     stat.AcquiredResources(),
+		IdleResources:         stat.IdleResources(),
+		ConstructingResources: stat.ConstructingResources(),
+		MaxResources:          stat.MaxResources(),
+		AcquireCount:          stat.AcquireCount(),
+		AcquireDuration:       stat.AcquireDuration(),
+		EmptyAcquireCount:     stat.EmptyAcquireCount(),
+		CanceledAcquireCount:  stat.CanceledAcquireCount(),
+	}
+}
+
 func (p *Pool) WriteContext(ctx context.Context, buffer []byte) (n int, err error) {
 	defer func() {
 		if r := recover(); r != nil {
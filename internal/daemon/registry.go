@@ -0,0 +1,245 @@
+package daemon
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon/binnapi"
+	"github.com/jackc/puddle/v2"
+	"github.com/pkg/errors"
+)
+
+const defaultPoolIdleTimeout = 5 * time.Minute
+
+var ErrPoolRegistryClosed = errors.New("daemon pool registry is closed")
+
+type poolKey struct {
+	nodeID uint
+	mode   binnapi.Mode
+}
+
+type registryEntry struct {
+	pool     *Pool
+	lastUsed atomic.Int64 // unix nanoseconds
+}
+
+// PoolRegistry keeps one connection pool per node and connection mode,
+// shared by the command, file and status services.
+type PoolRegistry struct {
+	breakers    *Breakers
+	idleTimeout time.Duration
+	now         func() time.Time
+
+	mu     sync.RWMutex
+	pools  map[poolKey]*registryEntry
+	closed bool
+}
+
+// NewPoolRegistry creates a pool registry. Pools unused for idleTimeout
+// are closed by Run. A non-positive idleTimeout falls back to the default.
+func NewPoolRegistry(breakers *Breakers, idleTimeout time.Duration) *PoolRegistry {
+	if idleTimeout <= 0 {
+		idleTimeout = defaultPoolIdleTimeout
+	}
+
+	return &PoolRegistry{
+		breakers:    breakers,
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+		pools:       make(map[poolKey]*registryEntry),
+	}
+}
+
+// Get returns the pool for the node and the mode of cfg, creating it if needed.
+func (r *PoolRegistry) Get(nodeID uint, cfg config) (*Pool, error) {
+	key := poolKey{nodeID: nodeID, mode: cfg.Mode}
+
+	if pool, ok, err := r.getExisting(key); ok || err != nil {
+		return pool, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil, ErrPoolRegistryClosed
+	}
+
+	// Double-check existence to avoid race condition
+	entry, exists := r.pools[key]
+	if !exists {
+		pool, err := NewPool(cfg, r.breakers.Get(nodeID))
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to create pool")
+		}
+
+		entry = &registryEntry{pool: pool}
+		r.pools[key] = entry
+	}
+
+	entry.lastUsed.Store(r.now().UnixNano())
+
+	return entry.pool, nil
+}
+
+// getExisting returns the existing pool for the key.
+// The last use is refreshed under the lock taken by EvictIdle, so the pool isn't evicted before it is used.
+func (r *PoolRegistry) getExisting(key poolKey) (*Pool, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return nil, false, ErrPoolRegistryClosed
+	}
+
+	entry, exists := r.pools[key]
+	if !exists {
+		return nil, false, nil
+	}
+
+	entry.lastUsed.Store(r.now().UnixNano())
+
+	return entry.pool, true, nil
+}
+
+// Acquire acquires a connection from the pool for the node and the mode of cfg.
+// A pool closed by Invalidate or EvictIdle after Get is already removed from the registry,
+// so the connection is acquired once more from a new pool.
+func (r *PoolRegistry) Acquire(ctx context.Context, nodeID uint, cfg config) (net.Conn, error) {
+	pool, err := r.Get(nodeID, cfg)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get pool")
+	}
+
+	conn, err := pool.Acquire(ctx)
+	if !errors.Is(err, puddle.ErrClosedPool) {
+		return conn, err
+	}
+
+	pool, err = r.Get(nodeID, cfg)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get pool")
+	}
+
+	return pool.Acquire(ctx)
+}
+
+// Invalidate closes all pools of the node and resets its circuit breaker.
+// It must be called when the node connection settings change or the node is deleted.
+// Connections in use are closed once released.
+func (r *PoolRegistry) Invalidate(nodeID uint) {
+	r.mu.Lock()
+	var pools []*Pool
+	for key, entry := range r.pools {
+		if key.nodeID == nodeID {
+			pools = append(pools, entry.pool)
+			delete(r.pools, key)
+		}
+	}
+	r.mu.Unlock()
+
+	r.breakers.Reset(nodeID)
+
+	for _, pool := range pools {
+		go closePool(pool)
+	}
+}
+
+// Run closes idle pools until the context is canceled.
+func (r *PoolRegistry) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.idleTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.EvictIdle()
+		}
+	}
+}
+
+// EvictIdle closes pools that are unused for the idle timeout
+// and have no connections in use.
+func (r *PoolRegistry) EvictIdle() {
+	deadline := r.now().Add(-r.idleTimeout).UnixNano()
+
+	r.mu.Lock()
+	var pools []*Pool
+	for key, entry := range r.pools {
+		if entry.lastUsed.Load() > deadline {
+			continue
+		}
+
+		stat := entry.pool.Stat()
+		if stat.AcquiredResources() > 0 || stat.ConstructingResources() > 0 {
+			continue
+		}
+
+		pools = append(pools, entry.pool)
+		delete(r.pools, key)
+	}
+	r.mu.Unlock()
+
+	for _, pool := range pools {
+		closePool(pool)
+	}
+}
+
+// Close closes all pools and rejects further Get calls.
+// It waits until the connections in use are released.
+func (r *PoolRegistry) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	pools := r.pools
+	r.pools = make(map[poolKey]*registryEntry)
+	r.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, entry := range pools {
+		wg.Add(1)
+		go func(pool *Pool) {
+			defer wg.Done()
+
+			closePool(pool)
+		}(entry.pool)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// Stats returns stats of all open pools ordered by node ID and mode.
+func (r *PoolRegistry) Stats() []PoolStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := make([]PoolStats, 0, len(r.pools))
+	for key, entry := range r.pools {
+		stats = append(stats, entry.pool.Stats(key.nodeID))
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].NodeID != stats[j].NodeID {
+			return stats[i].NodeID < stats[j].NodeID
+		}
+
+		return stats[i].Mode < stats[j].Mode
+	})
+
+	return stats
+}
+
+func closePool(pool *Pool) {
+	err := pool.Close()
+	if err != nil {
+		slog.Warn("Failed to close daemon pool", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,181 @@
+package daemon
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon/binnapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPoolRegistry(now *time.Time) *PoolRegistry {
+	registry := NewPoolRegistry(newTestBreakers(now), time.Minute)
+	registry.now = func() time.Time {
+		return *now
+	}
+
+	return registry
+}
+
+func TestPoolRegistry_Get(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	registry := newTestPoolRegistry(&now)
+	defer func() {
+		_ = registry.Close()
+	}()
+
+	cmdPool, err := registry.Get(1, config{Host: "127.0.0.1", Mode: binnapi.ModeCMD})
+	require.NoError(t, err)
+
+	samePool, err := registry.Get(1, config{Host: "127.0.0.1", Mode: binnapi.ModeCMD})
+	require.NoError(t, err)
+	assert.Same(t, cmdPool, samePool)
+
+	filesPool, err := registry.Get(1, config{Host: "127.0.0.1", Mode: binnapi.ModeFiles})
+	require.NoError(t, err)
+	assert.NotSame(t, cmdPool, filesPool)
+
+	otherNodePool, err := registry.Get(2, config{Host: "127.0.0.2", Mode: binnapi.ModeCMD})
+	require.NoError(t, err)
+	assert.NotSame(t, cmdPool, otherNodePool)
+
+	stats := registry.Stats()
+	require.Len(t, stats, 3)
+	assert.Equal(t, uint(1), stats[0].NodeID)
+	assert.Equal(t, binnapi.ModeCMD, stats[0].Mode)
+	assert.Equal(t, uint(1), stats[1].NodeID)
+	assert.Equal(t, binnapi.ModeFiles, stats[1].Mode)
+	assert.Equal(t, uint(2), stats[2].NodeID)
+}
+
+func TestPoolRegistry_Invalidate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	registry := newTestPoolRegistry(&now)
+	defer func() {
+		_ = registry.Close()
+	}()
+
+	oldPool, err := registry.Get(1, config{Host: "127.0.0.1", Mode: binnapi.ModeCMD})
+	require.NoError(t, err)
+	_, err = registry.Get(1, config{Host: "127.0.0.1", Mode: binnapi.ModeStatus})
+	require.NoError(t, err)
+	otherNodePool, err := registry.Get(2, config{Host: "127.0.0.2", Mode: binnapi.ModeCMD})
+	require.NoError(t, err)
+
+	for range 2 {
+		registry.breakers.Get(1).Failure(errTestDial)
+	}
+	require.Equal(t, BreakerStateOpen, registry.breakers.Get(1).Stats(1).State)
+
+	registry.Invalidate(1)
+
+	stats := registry.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, uint(2), stats[0].NodeID)
+	assert.Equal(t, BreakerStateClosed, registry.breakers.Get(1).Stats(1).State)
+
+	newPool, err := registry.Get(1, config{Host: "127.0.0.3", Mode: binnapi.ModeCMD})
+	require.NoError(t, err)
+	assert.NotSame(t, oldPool, newPool)
+
+	samePool, err := registry.Get(2, config{Host: "127.0.0.2", Mode: binnapi.ModeCMD})
+	require.NoError(t, err)
+	assert.Same(t, otherNodePool, samePool)
+}
+
+func TestPoolRegistry_EvictIdle(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	registry := newTestPoolRegistry(&now)
+	defer func() {
+		_ = registry.Close()
+	}()
+
+	_, err := registry.Get(1, config{Host: "127.0.0.1", Mode: binnapi.ModeCMD})
+	require.NoError(t, err)
+
+	now = now.Add(30 * time.Second)
+
+	_, err = registry.Get(2, config{Host: "127.0.0.2", Mode: binnapi.ModeCMD})
+	require.NoError(t, err)
+
+	now = now.Add(30 * time.Second)
+
+	registry.EvictIdle()
+
+	stats := registry.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, uint(2), stats[0].NodeID)
+
+	now = now.Add(30 * time.Second)
+
+	registry.EvictIdle()
+
+	assert.Empty(t, registry.Stats())
+}
+
+func TestPoolRegistry_GetConcurrentWithEvictIdle(t *testing.T) {
+	cfg := config{Host: "127.0.0.1", Mode: binnapi.ModeCMD}
+
+	for range 100 {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		registry := newTestPoolRegistry(&now)
+
+		_, err := registry.Get(1, cfg)
+		require.NoError(t, err)
+
+		// The pool is idle, Get must either refresh it before EvictIdle or get a new one after it.
+		// The clock yields to let EvictIdle run between the lookup and the refresh of the last use.
+		now = now.Add(2 * time.Minute)
+		registry.now = func() time.Time {
+			time.Sleep(100 * time.Microsecond)
+
+			return now
+		}
+
+		var wg sync.WaitGroup
+		var pool *Pool
+		start := make(chan struct{})
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			pool, err = registry.Get(1, cfg)
+		}()
+		go func() {
+			defer wg.Done()
+			<-start
+
+			registry.EvictIdle()
+		}()
+
+		close(start)
+		wg.Wait()
+
+		require.NoError(t, err)
+
+		registered, err := registry.Get(1, cfg)
+		require.NoError(t, err)
+		require.Same(t, registered, pool, "Get returned an evicted pool")
+
+		require.NoError(t, registry.Close())
+	}
+}
+
+func TestPoolRegistry_Close(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	registry := newTestPoolRegistry(&now)
+
+	_, err := registry.Get(1, config{Host: "127.0.0.1", Mode: binnapi.ModeCMD})
+	require.NoError(t, err)
+
+	require.NoError(t, registry.Close())
+
+	assert.Empty(t, registry.Stats())
+
+	_, err = registry.Get(1, config{Host: "127.0.0.1", Mode: binnapi.ModeCMD})
+	assert.ErrorIs(t, err, ErrPoolRegistryClosed)
+}
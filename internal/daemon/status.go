@@ -4,7 +4,6 @@ import (
 	"context"
 	"log/slog"
 	"strconv"
-	"sync"
 	"time"
 
 	"github.com/gameap/gameap/internal/daemon/binnapi"
@@ -21,18 +20,17 @@ const (
 
 type StatusService struct {
 	configMaker *configMaker
-
-	mu    sync.RWMutex
-	pools map[uint]*Pool
+	pools       *PoolRegistry
 }
 
 func NewStatusService(
 	certRepo repositories.ClientCertificateRepository,
 	fileManager files.FileManager,
+	pools *PoolRegistry,
 ) *StatusService {
 	return &StatusService{
 		configMaker: newConfigMaker(certRepo, fileManager),
-		pools:       make(map[uint]*Pool),
+		pools:       pools,
 	}
 }
 
@@ -56,15 +54,10 @@ func (s *StatusService) Version(ctx context.Context, node *domain.Node) (*NodeVe
 		return nil, errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to get pool")
-	}
-
 	var versionResp binnapi.StatusVersionResponseMessage
 
 	err = Retry(statusRetryCount, statusRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -107,16 +100,11 @@ func (s *StatusService) Status(ctx context.Context, node *domain.Node) (*NodeSta
 		return nil, errors.WithMessage(err, "failed to make config")
 	}
 
-	pool, err := s.getPool(node.ID, cfg)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to get pool")
-	}
-
 	var versionResp binnapi.StatusVersionResponseMessage
 	var baseResp binnapi.StatusInfoBaseResponseMessage
 
 	err = Retry(statusRetryCount, statusRetryDelay, func() error {
-		conn, err := pool.Acquire(ctx)
+		conn, err := s.pools.Acquire(ctx, node.ID, cfg)
 		if err != nil {
 			return errors.WithMessage(err, "failed to acquire connection from pool")
 		}
@@ -196,31 +184,3 @@ func (s *StatusService) Status(ctx context.Context, node *domain.Node) (*NodeSta
 		OnlineServers: onlineServers,
 	}, nil
 }
-
-func (s *StatusService) getPool(nodeID uint, cfg config) (*Pool, error) {
-	s.mu.RLock()
-	pool, exists := s.pools[nodeID]
-	s.mu.RUnlock()
-
-	if exists {
-		return pool, nil
-	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Double-check existence to avoid race condition
-	pool, exists = s.pools[nodeID]
-	if exists {
-		return pool, nil
-	}
-
-	pool, err := NewPool(cfg)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to create pool")
-	}
-
-	s.pools[nodeID] = pool
-
-	return pool, nil
-}
@@ -146,7 +146,7 @@ func TestStatusService_Status_Success(t *testing.T) {
 	err = fileManager.Write(ctx, node.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	statusService := NewStatusService(certRepo, fileManager)
+	statusService := NewStatusService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	status, err := statusService.Status(ctx, node)
@@ -206,7 +206,7 @@ func TestStatusService_Status_MissingServerCertificate(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create status service
-	statusService := NewStatusService(certRepo, fileManager)
+	statusService := NewStatusService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// Execute test
 	status, err := statusService.Status(ctx, node)
@@ -256,7 +256,7 @@ func TestStatusService_Status_MissingClientCertificate(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create status service
-	statusService := NewStatusService(certRepo, fileManager)
+	statusService := NewStatusService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// Execute test
 	status, err := statusService.Status(ctx, node)
@@ -310,7 +310,7 @@ func TestStatusService_Status_MissingPrivateKey(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create status service
-	statusService := NewStatusService(certRepo, fileManager)
+	statusService := NewStatusService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// Execute test
 	status, err := statusService.Status(ctx, node)
@@ -376,7 +376,7 @@ func TestStatusService_Status_PoolReuse(t *testing.T) {
 	err = fileManager.Write(ctx, node.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	statusService := NewStatusService(certRepo, fileManager)
+	statusService := NewStatusService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT - Execute multiple status requests
 	status1, err := statusService.Status(ctx, node)
@@ -392,9 +392,7 @@ func TestStatusService_Status_PoolReuse(t *testing.T) {
 	assert.Equal(t, 3, status3.WorkingTasks)
 
 	// ASSERT
-	statusService.mu.RLock()
-	poolCount := len(statusService.pools)
-	statusService.mu.RUnlock()
+	poolCount := len(statusService.pools.Stats())
 
 	assert.Equal(t, 1, poolCount, "Expected only one pool to be created for the same node")
 	mockServer.AssertMinRequestCount(6)
@@ -470,7 +468,7 @@ func TestStatusService_Status_MultipleNodes(t *testing.T) {
 	err = fileManager.Write(ctx, node1.GdaemonServerCert, []byte(daemonServerCert))
 	require.NoError(t, err)
 
-	statusService := NewStatusService(certRepo, fileManager)
+	statusService := NewStatusService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// ACT
 	status1, err := statusService.Status(ctx, node1)
@@ -486,9 +484,7 @@ func TestStatusService_Status_MultipleNodes(t *testing.T) {
 	assert.Equal(t, 1, status2.WorkingTasks)
 
 	// ASSERT
-	statusService.mu.RLock()
-	poolCount := len(statusService.pools)
-	statusService.mu.RUnlock()
+	poolCount := len(statusService.pools.Stats())
 
 	assert.Equal(t, 2, poolCount, "Expected separate pools for each node")
 	mockServer.AssertMinRequestCount(4)
@@ -500,7 +496,7 @@ func TestStatusService_Status_InvalidNodeID(t *testing.T) {
 	fileManager := files.NewInMemoryFileManager()
 
 	// Create status service
-	statusService := NewStatusService(certRepo, fileManager)
+	statusService := NewStatusService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// Execute test with invalid node ID (0)
 	ctx := context.Background()
@@ -581,7 +577,7 @@ func TestStatusService_Status_WithMockDaemon(t *testing.T) {
 	require.NoError(t, err)
 
 	// Create status service
-	statusService := NewStatusService(certRepo, fileManager)
+	statusService := NewStatusService(certRepo, fileManager, NewPoolRegistry(NewBreakers(0, 0), 0))
 
 	// Execute test
 	status, err := statusService.Status(ctx, node)
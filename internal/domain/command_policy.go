@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type CommandPolicyTarget string
+
+const (
+	// CommandPolicyTargetRcon applies the policy to commands sent over RCON.
+	CommandPolicyTargetRcon CommandPolicyTarget = "rcon"
+
+	// CommandPolicyTargetConsole applies the policy to commands sent to the server console.
+	CommandPolicyTargetConsole CommandPolicyTarget = "console"
+
+	// CommandPolicyTargetAll applies the policy to both RCON and console commands.
+	CommandPolicyTargetAll CommandPolicyTarget = "all"
+)
+
+func (t CommandPolicyTarget) IsValid() bool {
+	switch t {
+	case CommandPolicyTargetRcon, CommandPolicyTargetConsole, CommandPolicyTargetAll:
+		return true
+	default:
+		return false
+	}
+}
+
+type CommandPolicyAction string
+
+const (
+	CommandPolicyActionAllow CommandPolicyAction = "allow"
+	CommandPolicyActionDeny  CommandPolicyAction = "deny"
+)
+
+func (a CommandPolicyAction) IsValid() bool {
+	return a == CommandPolicyActionAllow || a == CommandPolicyActionDeny
+}
+
+type CommandPolicyPatternType string
+
+const (
+	// CommandPolicyPatternTypeGlob matches the whole command, * matches any characters, ? matches one character.
+	CommandPolicyPatternTypeGlob CommandPolicyPatternType = "glob"
+
+	// CommandPolicyPatternTypeRegex matches any part of the command unless the expression is anchored.
+	CommandPolicyPatternTypeRegex CommandPolicyPatternType = "regex"
+)
+
+func (t CommandPolicyPatternType) IsValid() bool {
+	return t == CommandPolicyPatternTypeGlob || t == CommandPolicyPatternTypeRegex
+}
+
+// CommandPolicy is a rule allowing or denying RCON and console commands to users.
+// Policies are defined for a server or for all servers, for users of a role or for all users.
+// Patterns are case-insensitive.
+type CommandPolicy struct {
+	ID uint `db:"id"`
+
+	// ServerID is nil for policies applied to all servers.
+	ServerID *uint `db:"server_id"`
+
+	// Role is the name of the role the policy is applied to, nil for all users.
+	Role *string `db:"role"`
+
+	Target      CommandPolicyTarget      `db:"target"`
+	Action      CommandPolicyAction      `db:"action"`
+	PatternType CommandPolicyPatternType `db:"pattern_type"`
+	Pattern     string                   `db:"pattern"`
+	Description string                   `db:"description"`
+	CreatedAt   *time.Time               `db:"created_at"`
+	UpdatedAt   *time.Time               `db:"updated_at"`
+}
+
+// AppliesTo reports whether the policy is applied to the commands of the target sent by a user with the roles.
+func (p *CommandPolicy) AppliesTo(target CommandPolicyTarget, roles []string) bool {
+	if p.Target != CommandPolicyTargetAll && p.Target != target {
+		return false
+	}
+
+	return p.Role == nil || slices.Contains(roles, *p.Role)
+}
+
+// Compile returns the regular expression of the policy pattern.
+func (p *CommandPolicy) Compile() (*regexp.Regexp, error) {
+	switch p.PatternType {
+	case CommandPolicyPatternTypeGlob:
+		return regexp.Compile("(?i)^" + globToRegexp(p.Pattern) + "$")
+	case CommandPolicyPatternTypeRegex:
+		return regexp.Compile("(?i)" + p.Pattern)
+	default:
+		return nil, errors.Errorf("unknown pattern type %q", p.PatternType)
+	}
+}
+
+func globToRegexp(pattern string) string {
+	b := strings.Builder{}
+	b.Grow(len(pattern) * 2)
+
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	return b.String()
+}
+
+// CommandPolicyViolation describes why a command is rejected by policies.
+type CommandPolicyViolation struct {
+	// Command is the part of the command which is rejected.
+	Command string
+
+	// Policy is the matched deny policy, nil when the command doesn't match any allow policy.
+	Policy *CommandPolicy
+}
+
+func (v *CommandPolicyViolation) Error() string {
+	if v.Policy == nil {
+		return fmt.Sprintf("command %q is not allowed by any command policy", v.Command)
+	}
+
+	return fmt.Sprintf(
+		"command %q is denied by command policy #%d (%s %q)",
+		v.Command, v.Policy.ID, v.Policy.PatternType, v.Policy.Pattern,
+	)
+}
+
+// CheckCommandPolicies checks the command against the policies.
+// Deny policies take precedence over allow policies. When there are allow policies,
+// the command must match at least one of them. Commands chained with ';' or new lines
+// are checked separately. It returns *CommandPolicyViolation when the command is rejected.
+func CheckCommandPolicies(policies []CommandPolicy, command string) error {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	type compiledPolicy struct {
+		policy *CommandPolicy
+		re     *regexp.Regexp
+	}
+
+	compiled := make([]compiledPolicy, 0, len(policies))
+	hasAllow := false
+
+	for i := range policies {
+		re, err := policies[i].Compile()
+		if err != nil {
+			return errors.WithMessagef(err, "invalid pattern of command policy #%d", policies[i].ID)
+		}
+
+		compiled = append(compiled, compiledPolicy{policy: &policies[i], re: re})
+
+		if policies[i].Action == CommandPolicyActionAllow {
+			hasAllow = true
+		}
+	}
+
+	for _, part := range SplitCommands(command) {
+		allowed := !hasAllow
+
+		for _, cp := range compiled {
+			if !cp.re.MatchString(part) {
+				continue
+			}
+
+			if cp.policy.Action == CommandPolicyActionDeny {
+				return &CommandPolicyViolation{Command: part, Policy: cp.policy}
+			}
+
+			allowed = true
+		}
+
+		if !allowed {
+			return &CommandPolicyViolation{Command: part}
+		}
+	}
+
+	return nil
+}
+
+// SplitCommands splits chained commands separated by ';' or new lines.
+// Separators inside double quotes are kept, as game servers do.
+func SplitCommands(command string) []string {
+	var (
+		result   []string
+		current  strings.Builder
+		inQuotes bool
+	)
+
+	flush := func() {
+		part := strings.TrimSpace(current.String())
+		if part != "" {
+			result = append(result, part)
+		}
+
+		current.Reset()
+	}
+
+	for _, r := range command {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case (r == ';' && !inQuotes) || r == '\n' || r == '\r':
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	flush()
+
+	return result
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandPolicy_AppliesTo(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy CommandPolicy
+		target CommandPolicyTarget
+		roles  []string
+		want   bool
+	}{
+		{
+			name:   "same_target_all_users",
+			policy: CommandPolicy{Target: CommandPolicyTargetRcon},
+			target: CommandPolicyTargetRcon,
+			want:   true,
+		},
+		{
+			name:   "other_target",
+			policy: CommandPolicy{Target: CommandPolicyTargetConsole},
+			target: CommandPolicyTargetRcon,
+			want:   false,
+		},
+		{
+			name:   "all_targets",
+			policy: CommandPolicy{Target: CommandPolicyTargetAll},
+			target: CommandPolicyTargetConsole,
+			want:   true,
+		},
+		{
+			name:   "user_has_role",
+			policy: CommandPolicy{Target: CommandPolicyTargetAll, Role: lo.ToPtr("moderator")},
+			target: CommandPolicyTargetRcon,
+			roles:  []string{"user", "moderator"},
+			want:   true,
+		},
+		{
+			name:   "user_without_role",
+			policy: CommandPolicy{Target: CommandPolicyTargetAll, Role: lo.ToPtr("moderator")},
+			target: CommandPolicyTargetRcon,
+			roles:  []string{"user"},
+			want:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.policy.AppliesTo(test.target, test.roles))
+		})
+	}
+}
+
+func TestCheckCommandPolicies(t *testing.T) {
+	denyQuit := CommandPolicy{
+		ID:          1,
+		Action:      CommandPolicyActionDeny,
+		PatternType: CommandPolicyPatternTypeGlob,
+		Pattern:     "quit",
+	}
+	denyPassword := CommandPolicy{
+		ID:          2,
+		Action:      CommandPolicyActionDeny,
+		PatternType: CommandPolicyPatternTypeRegex,
+		Pattern:     `^(rcon_)?password\b`,
+	}
+	allowSay := CommandPolicy{
+		ID:          3,
+		Action:      CommandPolicyActionAllow,
+		PatternType: CommandPolicyPatternTypeGlob,
+		Pattern:     "say *",
+	}
+	allowStatus := CommandPolicy{
+		ID:          4,
+		Action:      CommandPolicyActionAllow,
+		PatternType: CommandPolicyPatternTypeGlob,
+		Pattern:     "status",
+	}
+
+	tests := []struct {
+		name     string
+		policies []CommandPolicy
+		command  string
+		wantErr  string
+	}{
+		{
+			name:    "no_policies",
+			command: "quit",
+		},
+		{
+			name:     "deny_glob_matches",
+			policies: []CommandPolicy{denyQuit},
+			command:  "QUIT",
+			wantErr:  `command "QUIT" is denied by command policy #1 (glob "quit")`,
+		},
+		{
+			name:     "deny_glob_does_not_match_longer_command",
+			policies: []CommandPolicy{denyQuit},
+			command:  "quit_vote",
+		},
+		{
+			name:     "deny_regex_matches",
+			policies: []CommandPolicy{denyQuit, denyPassword},
+			command:  "rcon_password 123",
+			wantErr:  `command "rcon_password 123" is denied by command policy #2 (regex "^(rcon_)?password\\b")`,
+		},
+		{
+			name:     "chained_command_is_checked_separately",
+			policies: []CommandPolicy{denyQuit},
+			command:  "status; quit",
+			wantErr:  `command "quit" is denied by command policy #1 (glob "quit")`,
+		},
+		{
+			name:     "new_line_separates_commands",
+			policies: []CommandPolicy{denyQuit},
+			command:  "status\nquit",
+			wantErr:  `command "quit" is denied by command policy #1 (glob "quit")`,
+		},
+		{
+			name:     "separator_in_quotes_is_kept",
+			policies: []CommandPolicy{denyQuit, allowSay},
+			command:  `say "hello; quit"`,
+		},
+		{
+			name:     "allow_matches",
+			policies: []CommandPolicy{allowSay, allowStatus},
+			command:  "status",
+		},
+		{
+			name:     "allow_does_not_match",
+			policies: []CommandPolicy{allowSay, allowStatus},
+			command:  "changelevel de_dust2",
+			wantErr:  `command "changelevel de_dust2" is not allowed by any command policy`,
+		},
+		{
+			name:     "deny_takes_precedence",
+			policies: []CommandPolicy{{ID: 5, Action: CommandPolicyActionAllow, PatternType: "glob", Pattern: "*"}, denyQuit},
+			command:  "quit",
+			wantErr:  `command "quit" is denied by command policy #1 (glob "quit")`,
+		},
+		{
+			name: "invalid_regex",
+			policies: []CommandPolicy{
+				{ID: 6, Action: CommandPolicyActionDeny, PatternType: CommandPolicyPatternTypeRegex, Pattern: "("},
+			},
+			command: "status",
+			wantErr: "invalid pattern of command policy #6: error parsing regexp: missing closing ): `(?i)(`",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckCommandPolicies(test.policies, test.command)
+
+			if test.wantErr == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.EqualError(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestSplitCommands(t *testing.T) {
+	assert.Equal(t, []string{"status", "say \"a;b\"", "users"}, SplitCommands(" status ;say \"a;b\";\r\nusers;"))
+	assert.Empty(t, SplitCommands(" ; "))
+}
@@ -13,4 +13,39 @@ type Game struct {
 	LocalRepositoryLinux    *string `db:"local_repository_linux"`    // maxlen=128
 	LocalRepositoryWindows  *string `db:"local_repository_windows"`  // maxlen=128
 	Enabled                 int     `db:"enabled"`                   //
+
+	// Protocol settings, the defaults by the engine and the game code are used when they are empty.
+	QueryProtocol   *string `db:"query_protocol"`    // maxlen=32
+	RconProtocol    *string `db:"rcon_protocol"`     // maxlen=32
+	PlayerManager   *string `db:"player_manager"`    // maxlen=32
+	QueryPortOffset *int    `db:"query_port_offset"` // query port relative to the server port
+	RconPortOffset  *int    `db:"rcon_port_offset"`  // rcon port relative to the server port
+}
+
+// ApplyGameMod overrides the protocol settings of the game with the ones set for the game mod.
+// Empty protocols of the game mod keep the ones of the game.
+func (g *Game) ApplyGameMod(mod *GameMod) {
+	if mod == nil {
+		return
+	}
+
+	if mod.QueryProtocol != nil && *mod.QueryProtocol != "" {
+		g.QueryProtocol = mod.QueryProtocol
+	}
+
+	if mod.RconProtocol != nil && *mod.RconProtocol != "" {
+		g.RconProtocol = mod.RconProtocol
+	}
+
+	if mod.PlayerManager != nil && *mod.PlayerManager != "" {
+		g.PlayerManager = mod.PlayerManager
+	}
+
+	if mod.QueryPortOffset != nil {
+		g.QueryPortOffset = mod.QueryPortOffset
+	}
+
+	if mod.RconPortOffset != nil {
+		g.RconPortOffset = mod.RconPortOffset
+	}
 }
@@ -27,6 +27,13 @@ type GameMod struct {
 	ChmapCmd                *string             `db:"chmap_cmd"`
 	SendmsgCmd              *string             `db:"sendmsg_cmd"`
 	PasswdCmd               *string             `db:"passwd_cmd"`
+
+	// Protocol settings overriding the ones of the game.
+	QueryProtocol   *string `db:"query_protocol"`
+	RconProtocol    *string `db:"rcon_protocol"`
+	PlayerManager   *string `db:"player_manager"`
+	QueryPortOffset *int    `db:"query_port_offset"`
+	RconPortOffset  *int    `db:"rcon_port_offset"`
 }
 
 func (gm *GameMod) Merge(other *GameMod) {
@@ -74,13 +81,70 @@ func (gm *GameMod) Merge(other *GameMod) {
 		gm.PasswdCmd = other.PasswdCmd
 	}
 
+	if other.QueryProtocol != nil {
+		gm.QueryProtocol = other.QueryProtocol
+	}
+
+	if other.RconProtocol != nil {
+		gm.RconProtocol = other.RconProtocol
+	}
+
+	if other.PlayerManager != nil {
+		gm.PlayerManager = other.PlayerManager
+	}
+
+	if other.QueryPortOffset != nil {
+		gm.QueryPortOffset = other.QueryPortOffset
+	}
+
+	if other.RconPortOffset != nil {
+		gm.RconPortOffset = other.RconPortOffset
+	}
+
 	gm.FastRcon = other.FastRcon
 	gm.Vars = other.Vars
 }
 
+// GameModFastRcon is a predefined RCON command (macro).
+// The command may contain {name} placeholders which are filled with the values of the parameters.
 type GameModFastRcon struct {
-	Info    string `json:"info"`
-	Command string `json:"command"`
+	Info    string                 `json:"info"`
+	Command string                 `json:"command"`
+	Params  []GameModFastRconParam `json:"params,omitempty"`
+}
+
+type GameModFastRconParamType string
+
+const (
+	GameModFastRconParamTypeString GameModFastRconParamType = "string"
+	GameModFastRconParamTypeInt    GameModFastRconParamType = "int"
+	GameModFastRconParamTypeEnum   GameModFastRconParamType = "enum"
+	GameModFastRconParamTypePlayer GameModFastRconParamType = "player"
+)
+
+func (t GameModFastRconParamType) IsValid() bool {
+	switch t {
+	case GameModFastRconParamTypeString,
+		GameModFastRconParamTypeInt,
+		GameModFastRconParamTypeEnum,
+		GameModFastRconParamTypePlayer:
+		return true
+	default:
+		return false
+	}
+}
+
+type GameModFastRconParam struct {
+	Name  string                   `json:"name"`
+	Type  GameModFastRconParamType `json:"type"`
+	Label string                   `json:"label,omitempty"`
+
+	// Options are the allowed values of the enum parameter.
+	Options []string `json:"options,omitempty"`
+
+	// Min and Max limit the value of the int parameter.
+	Min *int `json:"min,omitempty"`
+	Max *int `json:"max,omitempty"`
 }
 
 type GameModFastRconList []GameModFastRcon
@@ -429,6 +429,30 @@ func TestGameMod_Merge(t *testing.T) {
 		other    *GameMod
 		expected *GameMod
 	}{
+		{
+			name: "merge_protocol_settings",
+			base: &GameMod{
+				ID:            1,
+				GameCode:      "csgo",
+				Name:          "Counter-Strike: GO",
+				QueryProtocol: lo.ToPtr("source"),
+				RconProtocol:  lo.ToPtr("source"),
+			},
+			other: &GameMod{
+				RconProtocol:   lo.ToPtr("goldsource"),
+				PlayerManager:  lo.ToPtr("goldsrc"),
+				RconPortOffset: lo.ToPtr(1),
+			},
+			expected: &GameMod{
+				ID:             1,
+				GameCode:       "csgo",
+				Name:           "Counter-Strike: GO",
+				QueryProtocol:  lo.ToPtr("source"),
+				RconProtocol:   lo.ToPtr("goldsource"),
+				PlayerManager:  lo.ToPtr("goldsrc"),
+				RconPortOffset: lo.ToPtr(1),
+			},
+		},
 		{
 			name: "merge_all_nil_fields_with_values",
 			base: &GameMod{
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame_ApplyGameMod(t *testing.T) {
+	tests := []struct {
+		name     string
+		game     Game
+		mod      *GameMod
+		expected Game
+	}{
+		{
+			name:     "nil_game_mod",
+			game:     Game{QueryProtocol: lo.ToPtr("source")},
+			mod:      nil,
+			expected: Game{QueryProtocol: lo.ToPtr("source")},
+		},
+		{
+			name: "game_mod_overrides_protocols",
+			game: Game{
+				QueryProtocol: lo.ToPtr("source"),
+				RconProtocol:  lo.ToPtr("source"),
+				PlayerManager: lo.ToPtr("source"),
+			},
+			mod: &GameMod{
+				QueryProtocol:   lo.ToPtr("minecraft"),
+				RconProtocol:    lo.ToPtr("minecraft"),
+				PlayerManager:   lo.ToPtr("minecraft"),
+				QueryPortOffset: lo.ToPtr(1),
+				RconPortOffset:  lo.ToPtr(10),
+			},
+			expected: Game{
+				QueryProtocol:   lo.ToPtr("minecraft"),
+				RconProtocol:    lo.ToPtr("minecraft"),
+				PlayerManager:   lo.ToPtr("minecraft"),
+				QueryPortOffset: lo.ToPtr(1),
+				RconPortOffset:  lo.ToPtr(10),
+			},
+		},
+		{
+			name: "game_mod_without_protocols",
+			game: Game{
+				QueryProtocol:  lo.ToPtr("source"),
+				RconPortOffset: lo.ToPtr(5),
+			},
+			mod: &GameMod{},
+			expected: Game{
+				QueryProtocol:  lo.ToPtr("source"),
+				RconPortOffset: lo.ToPtr(5),
+			},
+		},
+		{
+			name: "game_mod_with_empty_protocols",
+			game: Game{
+				QueryProtocol: lo.ToPtr("source"),
+				RconProtocol:  lo.ToPtr("source"),
+				PlayerManager: lo.ToPtr("source"),
+			},
+			mod: &GameMod{
+				QueryProtocol: lo.ToPtr(""),
+				RconProtocol:  lo.ToPtr(""),
+				PlayerManager: lo.ToPtr(""),
+			},
+			expected: Game{
+				QueryProtocol: lo.ToPtr("source"),
+				RconProtocol:  lo.ToPtr("source"),
+				PlayerManager: lo.ToPtr("source"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := tt.game
+			game.ApplyGameMod(tt.mod)
+
+			assert.Equal(t, tt.expected, game)
+		})
+	}
+}
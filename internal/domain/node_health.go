@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// NodeHealth is the latest result of the daemon health check of the node stored in the node_health table.
+type NodeHealth struct {
+	NodeID uint `db:"node_id"`
+	Online bool `db:"online"`
+
+	// Latency is the round-trip time of the last successful check in milliseconds.
+	Latency uint `db:"latency"`
+
+	// Error is the error of the last failed check, empty when the node is online.
+	Error string `db:"error"`
+
+	// LastSeenAt is the time of the last successful check, nil when the node has never been reached.
+	LastSeenAt *time.Time `db:"last_seen_at"`
+	CheckedAt  time.Time  `db:"checked_at"`
+}
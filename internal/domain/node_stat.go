@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NodeStat is a sample of the node resource usage stored in the ds_stats table.
+// Values are stored in the legacy string columns, unparsable values are read as zero.
+type NodeStat struct {
+	ID      uint            `db:"id"`
+	NodeID  uint            `db:"dedicated_server_id"`
+	Time    time.Time       `db:"time"`
+	Load    NodeLoadAverage `db:"loa"`
+	RAM     NodeStatUsage   `db:"ram"`
+	CPU     NodeStatPercent `db:"cpu"`
+	Network NodeStatTraffic `db:"ifstat"`
+	Ping    uint            `db:"ping"` // Round-trip time to the daemon in milliseconds.
+	Disk    NodeStatUsage   `db:"drvspace"`
+}
+
+// NodeLoadAverage is the system load average for 1, 5 and 15 minutes.
+type NodeLoadAverage [3]float64
+
+func (l NodeLoadAverage) Value() (driver.Value, error) {
+	return fmt.Sprintf("%.2f %.2f %.2f", l[0], l[1], l[2]), nil
+}
+
+func (l *NodeLoadAverage) Scan(value any) error {
+	*l = NodeLoadAverage{}
+
+	fields := strings.Fields(nodeStatString(value))
+
+	for i := 0; i < len(fields) && i < len(l); i++ {
+		l[i], _ = strconv.ParseFloat(fields[i], 64)
+	}
+
+	return nil
+}
+
+// NodeStatUsage is the used and total amount of a resource in bytes, stored as "used/total".
+type NodeStatUsage struct {
+	Used  uint64
+	Total uint64
+}
+
+func (u NodeStatUsage) Value() (driver.Value, error) {
+	return formatNodeStatPair(u.Used, u.Total), nil
+}
+
+func (u *NodeStatUsage) Scan(value any) error {
+	u.Used, u.Total = parseNodeStatPair(nodeStatString(value))
+
+	return nil
+}
+
+// NodeStatTraffic is the number of received and transmitted bytes since the node boot, stored as "rx/tx".
+type NodeStatTraffic struct {
+	RX uint64
+	TX uint64
+}
+
+func (t NodeStatTraffic) Value() (driver.Value, error) {
+	return formatNodeStatPair(t.RX, t.TX), nil
+}
+
+func (t *NodeStatTraffic) Scan(value any) error {
+	t.RX, t.TX = parseNodeStatPair(nodeStatString(value))
+
+	return nil
+}
+
+// NodeStatPercent is a usage percentage, for example CPU usage.
+type NodeStatPercent float64
+
+func (p NodeStatPercent) Value() (driver.Value, error) {
+	return strconv.FormatFloat(float64(p), 'f', 2, 64), nil
+}
+
+func (p *NodeStatPercent) Scan(value any) error {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(nodeStatString(value)), 64)
+	*p = NodeStatPercent(f)
+
+	return nil
+}
+
+func nodeStatString(value any) string {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	default:
+		return ""
+	}
+}
+
+func formatNodeStatPair(a, b uint64) string {
+	return strconv.FormatUint(a, 10) + "/" + strconv.FormatUint(b, 10)
+}
+
+func parseNodeStatPair(s string) (uint64, uint64) {
+	first, second, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return 0, 0
+	}
+
+	a, _ := strconv.ParseUint(first, 10, 64)
+	b, _ := strconv.ParseUint(second, 10, 64)
+
+	return a, b
+}
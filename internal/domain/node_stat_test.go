@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeStat_ValueScan(t *testing.T) {
+	t.Run("load_average", func(t *testing.T) {
+		value, err := NodeLoadAverage{0.52, 1, 12.345}.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "0.52 1.00 12.35", value)
+
+		var load NodeLoadAverage
+		require.NoError(t, load.Scan([]byte("0.52 1.00 12.35")))
+		assert.Equal(t, NodeLoadAverage{0.52, 1, 12.35}, load)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		value, err := NodeStatUsage{Used: 1024, Total: 4096}.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "1024/4096", value)
+
+		var usage NodeStatUsage
+		require.NoError(t, usage.Scan("1024/4096"))
+		assert.Equal(t, NodeStatUsage{Used: 1024, Total: 4096}, usage)
+	})
+
+	t.Run("traffic", func(t *testing.T) {
+		value, err := NodeStatTraffic{RX: 100, TX: 200}.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "100/200", value)
+
+		var traffic NodeStatTraffic
+		require.NoError(t, traffic.Scan([]byte("100/200")))
+		assert.Equal(t, NodeStatTraffic{RX: 100, TX: 200}, traffic)
+	})
+
+	t.Run("percent", func(t *testing.T) {
+		value, err := NodeStatPercent(12.345).Value()
+		require.NoError(t, err)
+		assert.Equal(t, "12.35", value)
+
+		var percent NodeStatPercent
+		require.NoError(t, percent.Scan("12.35"))
+		assert.InDelta(t, 12.35, float64(percent), 0.001)
+	})
+
+	t.Run("null_and_legacy_values", func(t *testing.T) {
+		var load NodeLoadAverage
+		require.NoError(t, load.Scan(nil))
+		assert.Equal(t, NodeLoadAverage{}, load)
+
+		var usage NodeStatUsage
+		require.NoError(t, usage.Scan("512 MB"))
+		assert.Equal(t, NodeStatUsage{}, usage)
+
+		var percent NodeStatPercent
+		require.NoError(t, percent.Scan("high"))
+		assert.Zero(t, percent)
+	})
+}
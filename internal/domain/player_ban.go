@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+type PlayerBanSource string
+
+const (
+	// PlayerBanSourcePanel is a ban issued from the panel.
+	PlayerBanSourcePanel PlayerBanSource = "panel"
+
+	// PlayerBanSourceImport is a ban imported from the ban list of the game server.
+	PlayerBanSourceImport PlayerBanSource = "import"
+)
+
+// PlayerBan is a ban of a player on a game server registered in the panel.
+// The player is identified by UniqID (SteamID, Minecraft UUID) or by name, depending on the game.
+type PlayerBan struct {
+	ID       uint            `db:"id"`
+	ServerID uint            `db:"server_id"`
+	UniqID   string          `db:"uniq_id"`
+	Name     string          `db:"name"`
+	Reason   string          `db:"reason"`
+	Source   PlayerBanSource `db:"source"`
+
+	// IssuerID is the user who issued the ban, nil for imported bans.
+	IssuerID *uint `db:"issuer_id"`
+
+	CreatedAt time.Time `db:"created_at"`
+
+	// ExpiresAt is nil for permanent bans.
+	ExpiresAt *time.Time `db:"expires_at"`
+
+	// LiftedAt is set when the ban is lifted by a user or expired and the unban command was issued.
+	LiftedAt *time.Time `db:"lifted_at"`
+
+	// LiftedBy is the user who lifted the ban, nil when the ban expired.
+	LiftedBy *uint `db:"lifted_by"`
+}
+
+// IsPermanent reports whether the ban never expires.
+func (b *PlayerBan) IsPermanent() bool {
+	return b.ExpiresAt == nil
+}
+
+// IsActive reports whether the ban is not lifted and not expired at the given time.
+func (b *PlayerBan) IsActive(now time.Time) bool {
+	if b.LiftedAt != nil {
+		return false
+	}
+
+	return b.ExpiresAt == nil || b.ExpiresAt.After(now)
+}
+
+// IsExpired reports whether the ban expired, but the player wasn't unbanned yet.
+func (b *PlayerBan) IsExpired(now time.Time) bool {
+	return b.LiftedAt == nil && b.ExpiresAt != nil && !b.ExpiresAt.After(now)
+}
+
+// Duration returns the ban duration, zero for permanent bans.
+func (b *PlayerBan) Duration() time.Duration {
+	if b.ExpiresAt == nil {
+		return 0
+	}
+
+	return b.ExpiresAt.Sub(b.CreatedAt)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// PlayerSession is a period of time a player was on a game server.
+// A player is identified by UniqID (SteamID, Minecraft UUID, GUID) when it is known,
+// sessions tracked from query results only have a name.
+type PlayerSession struct {
+	ID       uint       `db:"id"`
+	ServerID uint       `db:"server_id"`
+	UniqID   string     `db:"uniq_id"`
+	Name     string     `db:"name"`
+	JoinedAt time.Time  `db:"joined_at"`
+	LeftAt   *time.Time `db:"left_at"`
+
+	// LastSeenAt is the last time the player was in the players list of the server.
+	LastSeenAt time.Time `db:"last_seen_at"`
+}
+
+// IsActive reports whether the player is still on the server.
+func (s *PlayerSession) IsActive() bool {
+	return s.LeftAt == nil
+}
+
+// Duration returns the session length. Active sessions are counted until now.
+func (s *PlayerSession) Duration(now time.Time) time.Duration {
+	end := now
+	if s.LeftAt != nil {
+		end = *s.LeftAt
+	}
+
+	if end.Before(s.JoinedAt) {
+		return 0
+	}
+
+	return end.Sub(s.JoinedAt)
+}
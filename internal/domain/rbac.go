@@ -23,19 +23,20 @@ type AbilityName string
 
 const (
 	// Game Server Abilities.
-	AbilityNameGameServerCommon      AbilityName = "game-server-common"
-	AbilityNameGameServerStart       AbilityName = "game-server-start"
-	AbilityNameGameServerStop        AbilityName = "game-server-stop"
-	AbilityNameGameServerRestart     AbilityName = "game-server-restart"
-	AbilityNameGameServerPause       AbilityName = "game-server-pause"
-	AbilityNameGameServerUpdate      AbilityName = "game-server-update"
-	AbilityNameGameServerFiles       AbilityName = "game-server-files"
-	AbilityNameGameServerTasks       AbilityName = "game-server-tasks"
-	AbilityNameGameServerSettings    AbilityName = "game-server-settings"
-	AbilityNameGameServerConsoleView AbilityName = "game-server-console-view"
-	AbilityNameGameServerConsoleSend AbilityName = "game-server-console-send"
-	AbilityNameGameServerRconConsole AbilityName = "game-server-rcon-console"
-	AbilityNameGameServerRconPlayers AbilityName = "game-server-rcon-players"
+	AbilityNameGameServerCommon       AbilityName = "game-server-common"
+	AbilityNameGameServerStart        AbilityName = "game-server-start"
+	AbilityNameGameServerStop         AbilityName = "game-server-stop"
+	AbilityNameGameServerRestart      AbilityName = "game-server-restart"
+	AbilityNameGameServerPause        AbilityName = "game-server-pause"
+	AbilityNameGameServerUpdate       AbilityName = "game-server-update"
+	AbilityNameGameServerFiles        AbilityName = "game-server-files"
+	AbilityNameGameServerTasks        AbilityName = "game-server-tasks"
+	AbilityNameGameServerSettings     AbilityName = "game-server-settings"
+	AbilityNameGameServerConsoleView  AbilityName = "game-server-console-view"
+	AbilityNameGameServerConsoleSend  AbilityName = "game-server-console-send"
+	AbilityNameGameServerRconConsole  AbilityName = "game-server-rcon-console"
+	AbilityNameGameServerRconPlayers  AbilityName = "game-server-rcon-players"
+	AbilityNameGameServerRconFastRcon AbilityName = "game-server-rcon-fast-rcon"
 
 	// General.
 	AbilityNameCreate AbilityName = "create"
@@ -65,6 +66,7 @@ var ServersAbilities = []AbilityName{
 	// Rcon
 	AbilityNameGameServerRconConsole,
 	AbilityNameGameServerRconPlayers,
+	AbilityNameGameServerRconFastRcon,
 }
 
 type Ability struct {
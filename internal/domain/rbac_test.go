@@ -36,6 +36,7 @@ func TestAbilityNameConstants_GameServer(t *testing.T) {
 	assert.Equal(t, AbilityName("game-server-console-send"), AbilityNameGameServerConsoleSend)
 	assert.Equal(t, AbilityName("game-server-rcon-console"), AbilityNameGameServerRconConsole)
 	assert.Equal(t, AbilityName("game-server-rcon-players"), AbilityNameGameServerRconPlayers)
+	assert.Equal(t, AbilityName("game-server-rcon-fast-rcon"), AbilityNameGameServerRconFastRcon)
 }
 
 func TestAbilityNameConstants_General(t *testing.T) {
@@ -64,9 +65,10 @@ func TestServersAbilities(t *testing.T) {
 		AbilityNameGameServerConsoleSend,
 		AbilityNameGameServerRconConsole,
 		AbilityNameGameServerRconPlayers,
+		AbilityNameGameServerRconFastRcon,
 	}
 
-	assert.Equal(t, len(expectedAbilities), len(ServersAbilities), "should have 13 server abilities")
+	assert.Equal(t, len(expectedAbilities), len(ServersAbilities), "should have 14 server abilities")
 	assert.Equal(t, expectedAbilities, ServersAbilities)
 
 	for _, ability := range expectedAbilities {
@@ -71,6 +71,30 @@ func (s *Server) IsOnline() bool {
 	return s.ProcessActive && s.LastProcessCheck.UTC().After(time.Now().UTC().Add(-timeExpireProcessCheck))
 }
 
+// ResolveQueryPort returns the query port of the server. If it isn't set,
+// the port is calculated from the server port and the query port offset of the game.
+func (s *Server) ResolveQueryPort(game *Game) int {
+	return resolvePort(s.ServerPort, s.QueryPort, game, func(g *Game) *int { return g.QueryPortOffset })
+}
+
+// ResolveRconPort returns the RCON port of the server. If it isn't set,
+// the port is calculated from the server port and the RCON port offset of the game.
+func (s *Server) ResolveRconPort(game *Game) int {
+	return resolvePort(s.ServerPort, s.RconPort, game, func(g *Game) *int { return g.RconPortOffset })
+}
+
+func resolvePort(serverPort int, port *int, game *Game, offset func(g *Game) *int) int {
+	if port != nil {
+		return *port
+	}
+
+	if game != nil && offset(game) != nil {
+		return serverPort + *offset(game)
+	}
+
+	return serverPort
+}
+
 // ReplaceServerShortcodes replaces shortcode placeholders in a command string with server-specific values.
 // It first replaces any extra data provided, then replaces standard server shortcodes.
 // Shortcodes are replaced in the format {key} with their corresponding values.
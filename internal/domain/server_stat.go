@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// ServerStatResolution is the number of seconds covered by a stat row.
+type ServerStatResolution uint
+
+const (
+	// ServerStatResolutionRaw is a single sample of the server state.
+	ServerStatResolutionRaw ServerStatResolution = 0
+
+	// ServerStatResolutionHourly is a sample aggregated over an hour.
+	ServerStatResolutionHourly ServerStatResolution = 3600
+)
+
+func (r ServerStatResolution) Duration() time.Duration {
+	return time.Duration(r) * time.Second
+}
+
+// ServerStatKind tells what a stat row contains.
+type ServerStatKind string
+
+const (
+	// ServerStatKindResources is a resource usage row, the default of the rows written before query samples.
+	ServerStatKindResources ServerStatKind = "resources"
+
+	// ServerStatKindQuery is a query sample with the online status and players.
+	ServerStatKindQuery ServerStatKind = "query"
+)
+
+// ServerStat is a row of the servers_stats table.
+type ServerStat struct {
+	ID       uint      `db:"id"`
+	ServerID uint      `db:"server_id"`
+	Time     time.Time `db:"time"`
+
+	// Resource usage, empty for query samples.
+	RAM      string `db:"ram"`
+	CPU      string `db:"cpu"`
+	Netstat  string `db:"netstat"`
+	Drvspace string `db:"drvspace"`
+
+	Resolution    ServerStatResolution `db:"resolution"`
+	Online        bool                 `db:"online"`
+	PlayersNum    int                  `db:"players_num"`
+	MaxPlayersNum int                  `db:"max_players_num"`
+
+	Kind ServerStatKind `db:"kind"`
+}
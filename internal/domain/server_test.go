@@ -313,6 +313,56 @@ func TestServer_IsOnline(t *testing.T) {
 	}
 }
 
+func TestServer_ResolvePorts(t *testing.T) {
+	tests := []struct {
+		name          string
+		server        *Server
+		game          *Game
+		wantQueryPort int
+		wantRconPort  int
+	}{
+		{
+			name:          "default_to_server_port",
+			server:        &Server{ServerPort: 27015},
+			game:          &Game{},
+			wantQueryPort: 27015,
+			wantRconPort:  27015,
+		},
+		{
+			name:          "nil_game",
+			server:        &Server{ServerPort: 27015},
+			game:          nil,
+			wantQueryPort: 27015,
+			wantRconPort:  27015,
+		},
+		{
+			name: "custom_ports",
+			server: &Server{
+				ServerPort: 27015,
+				QueryPort:  lo.ToPtr(27016),
+				RconPort:   lo.ToPtr(27020),
+			},
+			game:          &Game{QueryPortOffset: lo.ToPtr(1), RconPortOffset: lo.ToPtr(2)},
+			wantQueryPort: 27016,
+			wantRconPort:  27020,
+		},
+		{
+			name:          "game_port_offsets",
+			server:        &Server{ServerPort: 25565},
+			game:          &Game{QueryPortOffset: lo.ToPtr(0), RconPortOffset: lo.ToPtr(10)},
+			wantQueryPort: 25565,
+			wantRconPort:  25575,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.wantQueryPort, test.server.ResolveQueryPort(test.game))
+			assert.Equal(t, test.wantRconPort, test.server.ResolveRconPort(test.game))
+		})
+	}
+}
+
 func TestServerInstalledStatusConstants(t *testing.T) {
 	assert.Equal(t, ServerInstalledStatus(0), ServerInstalledStatusNotInstalled)
 	assert.Equal(t, ServerInstalledStatus(1), ServerInstalledStatusInstalled)
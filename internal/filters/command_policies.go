@@ -0,0 +1,9 @@
+package filters
+
+type FindCommandPolicy struct {
+	IDs       []uint
+	ServerIDs []uint
+
+	// AppliedToServerIDs selects policies of the servers and policies applied to all servers.
+	AppliedToServerIDs []uint
+}
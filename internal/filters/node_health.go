@@ -0,0 +1,5 @@
+package filters
+
+type FindNodeHealth struct {
+	NodeIDs []uint
+}
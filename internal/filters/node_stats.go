@@ -0,0 +1,12 @@
+package filters
+
+import "time"
+
+type FindNodeStat struct {
+	IDs     []uint
+	NodeIDs []uint
+
+	// TimeFrom is inclusive, TimeTo is exclusive.
+	TimeFrom *time.Time
+	TimeTo   *time.Time
+}
@@ -0,0 +1,19 @@
+package filters
+
+import "time"
+
+type FindPlayerBan struct {
+	IDs       []uint
+	ServerIDs []uint
+	UniqIDs   []string
+	Names     []string
+
+	// Lifted filters bans lifted by a user or after the expiration (true) or not lifted yet (false).
+	Lifted *bool
+
+	// ActiveAt selects bans which are not lifted and not expired at the time.
+	ActiveAt *time.Time
+
+	// ExpiredAt selects bans which are not lifted but expired at the time.
+	ExpiredAt *time.Time
+}
@@ -0,0 +1,17 @@
+package filters
+
+import "time"
+
+type FindPlayerSession struct {
+	IDs       []uint
+	ServerIDs []uint
+	UniqIDs   []string
+	Names     []string
+
+	// Active filters sessions of players who are still on the server (true) or left (false).
+	Active *bool
+
+	// ActiveFrom and ActiveTo select sessions overlapping the time range, ActiveTo is exclusive.
+	ActiveFrom *time.Time
+	ActiveTo   *time.Time
+}
@@ -0,0 +1,18 @@
+package filters
+
+import (
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+type FindServerStat struct {
+	IDs         []uint
+	ServerIDs   []uint
+	Resolutions []domain.ServerStatResolution
+	Kinds       []domain.ServerStatKind
+
+	// TimeFrom is inclusive, TimeTo is exclusive.
+	TimeFrom *time.Time
+	TimeTo   *time.Time
+}
@@ -17,8 +17,14 @@ const DaemonTasksTable = "gdaemon_tasks"
 const ServerTasksTable = "servers_tasks"
 const ServerTaskFailsTable = "servers_tasks_fails"
 const ServerSettingsTable = "servers_settings"
+const ServerStatsTable = "servers_stats"
 const NodesTable = "dedicated_servers"
+const NodeStatsTable = "ds_stats"
 const ClientCertificatesTable = "client_certificates"
+const PlayerSessionsTable = "player_sessions"
+const PlayerBansTable = "player_bans"
+const CommandPoliciesTable = "command_policies"
+const NodeHealthTable = "node_health"
 
 var (
 	GameFields                = allFields(domain.Game{})
@@ -34,6 +40,12 @@ var (
 	ServerTaskFields          = allFields(domain.ServerTask{})
 	ServerTaskFailFields      = allFields(domain.ServerTaskFail{})
 	ServerSettingFields       = allFields(domain.ServerSetting{})
+	ServerStatFields          = allFields(domain.ServerStat{})
 	NodeFields                = allFields(domain.Node{})
+	NodeStatFields            = allFields(domain.NodeStat{})
 	ClientCertificateFields   = allFields(domain.ClientCertificate{})
+	PlayerSessionFields       = allFields(domain.PlayerSession{})
+	PlayerBanFields           = allFields(domain.PlayerBan{})
+	CommandPolicyFields       = allFields(domain.CommandPolicy{})
+	NodeHealthFields          = allFields(domain.NodeHealth{})
 )
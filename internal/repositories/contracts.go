@@ -243,3 +243,77 @@ type ClientCertificateRepository interface {
 
 	Delete(ctx context.Context, id uint) error
 }
+
+type ServerStatRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindServerStat,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.ServerStat, error)
+
+	Save(ctx context.Context, stat *domain.ServerStat) error
+
+	// DeleteMany removes all stats matching the filter.
+	DeleteMany(ctx context.Context, filter *filters.FindServerStat) error
+}
+
+type NodeStatRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindNodeStat,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.NodeStat, error)
+
+	Save(ctx context.Context, stat *domain.NodeStat) error
+
+	// DeleteMany removes all stats matching the filter.
+	DeleteMany(ctx context.Context, filter *filters.FindNodeStat) error
+}
+
+type NodeHealthRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindNodeHealth,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.NodeHealth, error)
+
+	// Save inserts or replaces the health of the node.
+	Save(ctx context.Context, health *domain.NodeHealth) error
+}
+
+type PlayerSessionRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindPlayerSession,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.PlayerSession, error)
+
+	Save(ctx context.Context, session *domain.PlayerSession) error
+}
+
+type PlayerBanRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindPlayerBan,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.PlayerBan, error)
+
+	Save(ctx context.Context, ban *domain.PlayerBan) error
+}
+
+type CommandPolicyRepository interface {
+	Find(
+		ctx context.Context,
+		filter *filters.FindCommandPolicy,
+		order []filters.Sorting,
+		pagination *filters.Pagination,
+	) ([]domain.CommandPolicy, error)
+
+	Save(ctx context.Context, policy *domain.CommandPolicy) error
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,179 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+)
+
+type CommandPolicyRepository struct {
+	mu       sync.RWMutex
+	policies map[uint]*domain.CommandPolicy
+	nextID   uint32
+}
+
+func NewCommandPolicyRepository() *CommandPolicyRepository {
+	return &CommandPolicyRepository{
+		policies: make(map[uint]*domain.CommandPolicy),
+	}
+}
+
+func (r *CommandPolicyRepository) Find(
+	_ context.Context,
+	filter *filters.FindCommandPolicy,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.CommandPolicy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policies := make([]domain.CommandPolicy, 0, len(r.policies))
+	for _, policy := range r.policies {
+		if filter != nil && !r.matchesFilter(policy, filter) {
+			continue
+		}
+
+		policies = append(policies, r.copyPolicy(policy))
+	}
+
+	r.sortPolicies(policies, order)
+
+	return r.applyPagination(policies, pagination), nil
+}
+
+func (r *CommandPolicyRepository) Save(_ context.Context, policy *domain.CommandPolicy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if policy.ID == 0 {
+		policy.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	saved := r.copyPolicy(policy)
+	r.policies[policy.ID] = &saved
+
+	return nil
+}
+
+func (r *CommandPolicyRepository) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.policies, id)
+
+	return nil
+}
+
+func (r *CommandPolicyRepository) copyPolicy(policy *domain.CommandPolicy) domain.CommandPolicy {
+	copied := *policy
+
+	if policy.ServerID != nil {
+		serverID := *policy.ServerID
+		copied.ServerID = &serverID
+	}
+
+	if policy.Role != nil {
+		role := *policy.Role
+		copied.Role = &role
+	}
+
+	if policy.CreatedAt != nil {
+		createdAt := *policy.CreatedAt
+		copied.CreatedAt = &createdAt
+	}
+
+	if policy.UpdatedAt != nil {
+		updatedAt := *policy.UpdatedAt
+		copied.UpdatedAt = &updatedAt
+	}
+
+	return copied
+}
+
+func (r *CommandPolicyRepository) matchesFilter(
+	policy *domain.CommandPolicy,
+	filter *filters.FindCommandPolicy,
+) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, policy.ID) {
+		return false
+	}
+
+	if len(filter.ServerIDs) > 0 &&
+		(policy.ServerID == nil || !slices.Contains(filter.ServerIDs, *policy.ServerID)) {
+		return false
+	}
+
+	if len(filter.AppliedToServerIDs) > 0 &&
+		policy.ServerID != nil && !slices.Contains(filter.AppliedToServerIDs, *policy.ServerID) {
+		return false
+	}
+
+	return true
+}
+
+func (r *CommandPolicyRepository) sortPolicies(policies []domain.CommandPolicy, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(policies, func(i, j int) bool {
+			return policies[i].ID < policies[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		for _, o := range order {
+			cm := r.comparePolicies(&policies[i], &policies[j], o.Field)
+			if cm != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cm > 0
+				}
+
+				return cm < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *CommandPolicyRepository) comparePolicies(a, b *domain.CommandPolicy, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "pattern":
+		return strings.Compare(a.Pattern, b.Pattern)
+	default:
+		return 0
+	}
+}
+
+func (r *CommandPolicyRepository) applyPagination(
+	policies []domain.CommandPolicy,
+	pagination *filters.Pagination,
+) []domain.CommandPolicy {
+	if pagination == nil {
+		return policies
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(policies) {
+		return []domain.CommandPolicy{}
+	}
+
+	end := min(offset+limit, len(policies))
+
+	return policies[offset:end]
+}
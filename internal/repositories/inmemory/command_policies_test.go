@@ -0,0 +1,18 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	repotesting "github.com/gameap/gameap/internal/repositories/testing"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCommandPolicyRepository(t *testing.T) {
+	suite.Run(t, repotesting.NewCommandPolicyRepositorySuite(
+		func(_ *testing.T) repositories.CommandPolicyRepository {
+			return inmemory.NewCommandPolicyRepository()
+		},
+	))
+}
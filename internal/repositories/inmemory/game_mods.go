@@ -127,6 +127,11 @@ func (r *GameModRepository) Save(_ context.Context, gameMod *domain.GameMod) err
 		ChmapCmd:                gameMod.ChmapCmd,
 		SendmsgCmd:              gameMod.SendmsgCmd,
 		PasswdCmd:               gameMod.PasswdCmd,
+		QueryProtocol:           gameMod.QueryProtocol,
+		RconProtocol:            gameMod.RconProtocol,
+		PlayerManager:           gameMod.PlayerManager,
+		QueryPortOffset:         gameMod.QueryPortOffset,
+		RconPortOffset:          gameMod.RconPortOffset,
 	}
 
 	return nil
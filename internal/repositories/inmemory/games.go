@@ -89,6 +89,11 @@ func (r *GameRepository) Save(_ context.Context, game *domain.Game) error {
 		LocalRepositoryLinux:    game.LocalRepositoryLinux,
 		LocalRepositoryWindows:  game.LocalRepositoryWindows,
 		Enabled:                 game.Enabled,
+		QueryProtocol:           game.QueryProtocol,
+		RconProtocol:            game.RconProtocol,
+		PlayerManager:           game.PlayerManager,
+		QueryPortOffset:         game.QueryPortOffset,
+		RconPortOffset:          game.RconPortOffset,
 	}
 
 	return nil
@@ -0,0 +1,129 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+)
+
+type NodeHealthRepository struct {
+	mu      sync.RWMutex
+	healths map[uint]*domain.NodeHealth
+}
+
+func NewNodeHealthRepository() *NodeHealthRepository {
+	return &NodeHealthRepository{
+		healths: make(map[uint]*domain.NodeHealth),
+	}
+}
+
+func (r *NodeHealthRepository) Find(
+	_ context.Context,
+	filter *filters.FindNodeHealth,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.NodeHealth, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	healths := make([]domain.NodeHealth, 0, len(r.healths))
+	for _, health := range r.healths {
+		if filter != nil && len(filter.NodeIDs) > 0 && !slices.Contains(filter.NodeIDs, health.NodeID) {
+			continue
+		}
+
+		healths = append(healths, r.copyHealth(health))
+	}
+
+	r.sortHealths(healths, order)
+
+	return r.applyPagination(healths, pagination), nil
+}
+
+func (r *NodeHealthRepository) Save(_ context.Context, health *domain.NodeHealth) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := r.copyHealth(health)
+	r.healths[health.NodeID] = &saved
+
+	return nil
+}
+
+func (r *NodeHealthRepository) copyHealth(health *domain.NodeHealth) domain.NodeHealth {
+	copied := *health
+
+	if health.LastSeenAt != nil {
+		lastSeenAt := *health.LastSeenAt
+		copied.LastSeenAt = &lastSeenAt
+	}
+
+	return copied
+}
+
+func (r *NodeHealthRepository) sortHealths(healths []domain.NodeHealth, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(healths, func(i, j int) bool {
+			return healths[i].NodeID < healths[j].NodeID
+		})
+
+		return
+	}
+
+	sort.Slice(healths, func(i, j int) bool {
+		for _, o := range order {
+			cm := r.compareHealths(&healths[i], &healths[j], o.Field)
+			if cm != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cm > 0
+				}
+
+				return cm < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *NodeHealthRepository) compareHealths(a, b *domain.NodeHealth, field string) int {
+	switch field {
+	case "node_id":
+		return cmp.Compare(a.NodeID, b.NodeID)
+	case "latency":
+		return cmp.Compare(a.Latency, b.Latency)
+	case "checked_at":
+		return a.CheckedAt.Compare(b.CheckedAt)
+	default:
+		return 0
+	}
+}
+
+func (r *NodeHealthRepository) applyPagination(
+	healths []domain.NodeHealth,
+	pagination *filters.Pagination,
+) []domain.NodeHealth {
+	if pagination == nil {
+		return healths
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(healths) {
+		return []domain.NodeHealth{}
+	}
+
+	end := min(offset+limit, len(healths))
+
+	return healths[offset:end]
+}
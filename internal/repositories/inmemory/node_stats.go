@@ -0,0 +1,205 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+)
+
+type NodeStatRepository struct {
+	mu     sync.RWMutex
+	stats  map[uint]*domain.NodeStat
+	nextID uint32
+
+	// Hash index for efficient filtering
+	nodeIDIndex map[uint]map[uint]struct{} // nodeID -> statIDs
+}
+
+func NewNodeStatRepository() *NodeStatRepository {
+	return &NodeStatRepository{
+		stats:       make(map[uint]*domain.NodeStat),
+		nodeIDIndex: make(map[uint]map[uint]struct{}),
+	}
+}
+
+func (r *NodeStatRepository) Find(
+	_ context.Context,
+	filter *filters.FindNodeStat,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.NodeStat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.getFilteredStatIDs(filter)
+
+	stats := make([]domain.NodeStat, 0, len(ids))
+	for _, id := range ids {
+		stats = append(stats, *r.stats[id])
+	}
+
+	r.sortStats(stats, order)
+
+	return r.applyPagination(stats, pagination), nil
+}
+
+func (r *NodeStatRepository) Save(_ context.Context, stat *domain.NodeStat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stat.ID != 0 {
+		if oldStat, exists := r.stats[stat.ID]; exists {
+			r.removeFromIndexes(oldStat)
+		}
+	} else {
+		stat.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	saved := *stat
+	r.stats[stat.ID] = &saved
+
+	r.addToIndexes(&saved)
+
+	return nil
+}
+
+func (r *NodeStatRepository) DeleteMany(_ context.Context, filter *filters.FindNodeStat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range r.getFilteredStatIDs(filter) {
+		r.removeFromIndexes(r.stats[id])
+		delete(r.stats, id)
+	}
+
+	return nil
+}
+
+func (r *NodeStatRepository) addToIndexes(stat *domain.NodeStat) {
+	if r.nodeIDIndex[stat.NodeID] == nil {
+		r.nodeIDIndex[stat.NodeID] = make(map[uint]struct{})
+	}
+	r.nodeIDIndex[stat.NodeID][stat.ID] = struct{}{}
+}
+
+func (r *NodeStatRepository) removeFromIndexes(stat *domain.NodeStat) {
+	if statSet, exists := r.nodeIDIndex[stat.NodeID]; exists {
+		delete(statSet, stat.ID)
+		if len(statSet) == 0 {
+			delete(r.nodeIDIndex, stat.NodeID)
+		}
+	}
+}
+
+func (r *NodeStatRepository) getFilteredStatIDs(filter *filters.FindNodeStat) []uint {
+	candidates := make([]uint, 0)
+
+	switch {
+	case filter != nil && len(filter.NodeIDs) > 0:
+		for _, nodeID := range filter.NodeIDs {
+			for id := range r.nodeIDIndex[nodeID] {
+				candidates = append(candidates, id)
+			}
+		}
+	default:
+		for id := range r.stats {
+			candidates = append(candidates, id)
+		}
+	}
+
+	if filter == nil {
+		return candidates
+	}
+
+	return slices.DeleteFunc(candidates, func(id uint) bool {
+		return !r.matchesFilter(r.stats[id], filter)
+	})
+}
+
+func (r *NodeStatRepository) matchesFilter(stat *domain.NodeStat, filter *filters.FindNodeStat) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, stat.ID) {
+		return false
+	}
+
+	// TimeFrom is inclusive
+	if filter.TimeFrom != nil && stat.Time.Before(*filter.TimeFrom) {
+		return false
+	}
+
+	// TimeTo is exclusive
+	if filter.TimeTo != nil && !stat.Time.Before(*filter.TimeTo) {
+		return false
+	}
+
+	return true
+}
+
+func (r *NodeStatRepository) sortStats(stats []domain.NodeStat, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(stats, func(i, j int) bool {
+			return stats[i].ID < stats[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		for _, o := range order {
+			cm := r.compareStats(&stats[i], &stats[j], o.Field)
+			if cm != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cm > 0
+				}
+
+				return cm < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *NodeStatRepository) compareStats(a, b *domain.NodeStat, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "dedicated_server_id":
+		return cmp.Compare(a.NodeID, b.NodeID)
+	case "time":
+		return a.Time.Compare(b.Time)
+	case "ping":
+		return cmp.Compare(a.Ping, b.Ping)
+	default:
+		return 0
+	}
+}
+
+func (r *NodeStatRepository) applyPagination(
+	stats []domain.NodeStat,
+	pagination *filters.Pagination,
+) []domain.NodeStat {
+	if pagination == nil {
+		return stats
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(stats) {
+		return []domain.NodeStat{}
+	}
+
+	end := min(offset+limit, len(stats))
+
+	return stats[offset:end]
+}
@@ -0,0 +1,232 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+)
+
+type PlayerBanRepository struct {
+	mu     sync.RWMutex
+	bans   map[uint]*domain.PlayerBan
+	nextID uint32
+
+	// Hash index for efficient filtering
+	serverIDIndex map[uint]map[uint]struct{} // serverID -> banIDs
+}
+
+func NewPlayerBanRepository() *PlayerBanRepository {
+	return &PlayerBanRepository{
+		bans:          make(map[uint]*domain.PlayerBan),
+		serverIDIndex: make(map[uint]map[uint]struct{}),
+	}
+}
+
+func (r *PlayerBanRepository) Find(
+	_ context.Context,
+	filter *filters.FindPlayerBan,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.PlayerBan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.getFilteredBanIDs(filter)
+
+	bans := make([]domain.PlayerBan, 0, len(ids))
+	for _, id := range ids {
+		bans = append(bans, r.copyBan(r.bans[id]))
+	}
+
+	r.sortBans(bans, order)
+
+	return r.applyPagination(bans, pagination), nil
+}
+
+func (r *PlayerBanRepository) Save(_ context.Context, ban *domain.PlayerBan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ban.ID != 0 {
+		if oldBan, exists := r.bans[ban.ID]; exists {
+			r.removeFromIndexes(oldBan)
+		}
+	} else {
+		ban.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	saved := r.copyBan(ban)
+	r.bans[ban.ID] = &saved
+
+	r.addToIndexes(&saved)
+
+	return nil
+}
+
+func (r *PlayerBanRepository) copyBan(ban *domain.PlayerBan) domain.PlayerBan {
+	copied := *ban
+
+	if ban.IssuerID != nil {
+		issuerID := *ban.IssuerID
+		copied.IssuerID = &issuerID
+	}
+
+	if ban.ExpiresAt != nil {
+		expiresAt := *ban.ExpiresAt
+		copied.ExpiresAt = &expiresAt
+	}
+
+	if ban.LiftedAt != nil {
+		liftedAt := *ban.LiftedAt
+		copied.LiftedAt = &liftedAt
+	}
+
+	if ban.LiftedBy != nil {
+		liftedBy := *ban.LiftedBy
+		copied.LiftedBy = &liftedBy
+	}
+
+	return copied
+}
+
+func (r *PlayerBanRepository) addToIndexes(ban *domain.PlayerBan) {
+	if r.serverIDIndex[ban.ServerID] == nil {
+		r.serverIDIndex[ban.ServerID] = make(map[uint]struct{})
+	}
+	r.serverIDIndex[ban.ServerID][ban.ID] = struct{}{}
+}
+
+func (r *PlayerBanRepository) removeFromIndexes(ban *domain.PlayerBan) {
+	if banSet, exists := r.serverIDIndex[ban.ServerID]; exists {
+		delete(banSet, ban.ID)
+		if len(banSet) == 0 {
+			delete(r.serverIDIndex, ban.ServerID)
+		}
+	}
+}
+
+func (r *PlayerBanRepository) getFilteredBanIDs(filter *filters.FindPlayerBan) []uint {
+	candidates := make([]uint, 0)
+
+	switch {
+	case filter != nil && len(filter.ServerIDs) > 0:
+		for _, serverID := range filter.ServerIDs {
+			for id := range r.serverIDIndex[serverID] {
+				candidates = append(candidates, id)
+			}
+		}
+	default:
+		for id := range r.bans {
+			candidates = append(candidates, id)
+		}
+	}
+
+	if filter == nil {
+		return candidates
+	}
+
+	return slices.DeleteFunc(candidates, func(id uint) bool {
+		return !r.matchesFilter(r.bans[id], filter)
+	})
+}
+
+func (r *PlayerBanRepository) matchesFilter(ban *domain.PlayerBan, filter *filters.FindPlayerBan) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, ban.ID) {
+		return false
+	}
+
+	if len(filter.UniqIDs) > 0 && !slices.Contains(filter.UniqIDs, ban.UniqID) {
+		return false
+	}
+
+	if len(filter.Names) > 0 && !slices.Contains(filter.Names, ban.Name) {
+		return false
+	}
+
+	if filter.Lifted != nil && *filter.Lifted != (ban.LiftedAt != nil) {
+		return false
+	}
+
+	if filter.ActiveAt != nil && !ban.IsActive(*filter.ActiveAt) {
+		return false
+	}
+
+	if filter.ExpiredAt != nil && !ban.IsExpired(*filter.ExpiredAt) {
+		return false
+	}
+
+	return true
+}
+
+func (r *PlayerBanRepository) sortBans(bans []domain.PlayerBan, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(bans, func(i, j int) bool {
+			return bans[i].ID < bans[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(bans, func(i, j int) bool {
+		for _, o := range order {
+			cm := r.compareBans(&bans[i], &bans[j], o.Field)
+			if cm != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cm > 0
+				}
+
+				return cm < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *PlayerBanRepository) compareBans(a, b *domain.PlayerBan, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "server_id":
+		return cmp.Compare(a.ServerID, b.ServerID)
+	case "uniq_id":
+		return strings.Compare(a.UniqID, b.UniqID)
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	default:
+		return 0
+	}
+}
+
+func (r *PlayerBanRepository) applyPagination(
+	bans []domain.PlayerBan,
+	pagination *filters.Pagination,
+) []domain.PlayerBan {
+	if pagination == nil {
+		return bans
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(bans) {
+		return []domain.PlayerBan{}
+	}
+
+	end := min(offset+limit, len(bans))
+
+	return bans[offset:end]
+}
@@ -0,0 +1,223 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+)
+
+type PlayerSessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[uint]*domain.PlayerSession
+	nextID   uint32
+
+	// Hash index for efficient filtering
+	serverIDIndex map[uint]map[uint]struct{} // serverID -> sessionIDs
+}
+
+func NewPlayerSessionRepository() *PlayerSessionRepository {
+	return &PlayerSessionRepository{
+		sessions:      make(map[uint]*domain.PlayerSession),
+		serverIDIndex: make(map[uint]map[uint]struct{}),
+	}
+}
+
+func (r *PlayerSessionRepository) Find(
+	_ context.Context,
+	filter *filters.FindPlayerSession,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.PlayerSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.getFilteredSessionIDs(filter)
+
+	sessions := make([]domain.PlayerSession, 0, len(ids))
+	for _, id := range ids {
+		sessions = append(sessions, r.copySession(r.sessions[id]))
+	}
+
+	r.sortSessions(sessions, order)
+
+	return r.applyPagination(sessions, pagination), nil
+}
+
+func (r *PlayerSessionRepository) Save(_ context.Context, session *domain.PlayerSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if session.ID != 0 {
+		if oldSession, exists := r.sessions[session.ID]; exists {
+			r.removeFromIndexes(oldSession)
+		}
+	} else {
+		session.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	saved := r.copySession(session)
+	r.sessions[session.ID] = &saved
+
+	r.addToIndexes(&saved)
+
+	return nil
+}
+
+func (r *PlayerSessionRepository) copySession(session *domain.PlayerSession) domain.PlayerSession {
+	copied := *session
+
+	if session.LeftAt != nil {
+		leftAt := *session.LeftAt
+		copied.LeftAt = &leftAt
+	}
+
+	return copied
+}
+
+func (r *PlayerSessionRepository) addToIndexes(session *domain.PlayerSession) {
+	if r.serverIDIndex[session.ServerID] == nil {
+		r.serverIDIndex[session.ServerID] = make(map[uint]struct{})
+	}
+	r.serverIDIndex[session.ServerID][session.ID] = struct{}{}
+}
+
+func (r *PlayerSessionRepository) removeFromIndexes(session *domain.PlayerSession) {
+	if sessionSet, exists := r.serverIDIndex[session.ServerID]; exists {
+		delete(sessionSet, session.ID)
+		if len(sessionSet) == 0 {
+			delete(r.serverIDIndex, session.ServerID)
+		}
+	}
+}
+
+func (r *PlayerSessionRepository) getFilteredSessionIDs(filter *filters.FindPlayerSession) []uint {
+	candidates := make([]uint, 0)
+
+	switch {
+	case filter != nil && len(filter.ServerIDs) > 0:
+		for _, serverID := range filter.ServerIDs {
+			for id := range r.serverIDIndex[serverID] {
+				candidates = append(candidates, id)
+			}
+		}
+	default:
+		for id := range r.sessions {
+			candidates = append(candidates, id)
+		}
+	}
+
+	if filter == nil {
+		return candidates
+	}
+
+	return slices.DeleteFunc(candidates, func(id uint) bool {
+		return !r.matchesFilter(r.sessions[id], filter)
+	})
+}
+
+func (r *PlayerSessionRepository) matchesFilter(
+	session *domain.PlayerSession,
+	filter *filters.FindPlayerSession,
+) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, session.ID) {
+		return false
+	}
+
+	if len(filter.UniqIDs) > 0 && !slices.Contains(filter.UniqIDs, session.UniqID) {
+		return false
+	}
+
+	if len(filter.Names) > 0 && !slices.Contains(filter.Names, session.Name) {
+		return false
+	}
+
+	if filter.Active != nil && *filter.Active != session.IsActive() {
+		return false
+	}
+
+	if filter.ActiveFrom != nil && session.LeftAt != nil && session.LeftAt.Before(*filter.ActiveFrom) {
+		return false
+	}
+
+	// ActiveTo is exclusive
+	if filter.ActiveTo != nil && !session.JoinedAt.Before(*filter.ActiveTo) {
+		return false
+	}
+
+	return true
+}
+
+func (r *PlayerSessionRepository) sortSessions(sessions []domain.PlayerSession, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(sessions, func(i, j int) bool {
+			return sessions[i].ID < sessions[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		for _, o := range order {
+			cm := r.compareSessions(&sessions[i], &sessions[j], o.Field)
+			if cm != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cm > 0
+				}
+
+				return cm < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *PlayerSessionRepository) compareSessions(a, b *domain.PlayerSession, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "server_id":
+		return cmp.Compare(a.ServerID, b.ServerID)
+	case "uniq_id":
+		return strings.Compare(a.UniqID, b.UniqID)
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "joined_at":
+		return a.JoinedAt.Compare(b.JoinedAt)
+	case "last_seen_at":
+		return a.LastSeenAt.Compare(b.LastSeenAt)
+	default:
+		return 0
+	}
+}
+
+func (r *PlayerSessionRepository) applyPagination(
+	sessions []domain.PlayerSession,
+	pagination *filters.Pagination,
+) []domain.PlayerSession {
+	if pagination == nil {
+		return sessions
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(sessions) {
+		return []domain.PlayerSession{}
+	}
+
+	end := min(offset+limit, len(sessions))
+
+	return sessions[offset:end]
+}
@@ -0,0 +1,215 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+)
+
+type ServerStatRepository struct {
+	mu     sync.RWMutex
+	stats  map[uint]*domain.ServerStat
+	nextID uint32
+
+	// Hash index for efficient filtering
+	serverIDIndex map[uint]map[uint]struct{} // serverID -> statIDs
+}
+
+func NewServerStatRepository() *ServerStatRepository {
+	return &ServerStatRepository{
+		stats:         make(map[uint]*domain.ServerStat),
+		serverIDIndex: make(map[uint]map[uint]struct{}),
+	}
+}
+
+func (r *ServerStatRepository) Find(
+	_ context.Context,
+	filter *filters.FindServerStat,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerStat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.getFilteredStatIDs(filter)
+
+	stats := make([]domain.ServerStat, 0, len(ids))
+	for _, id := range ids {
+		stats = append(stats, *r.stats[id])
+	}
+
+	r.sortStats(stats, order)
+
+	return r.applyPagination(stats, pagination), nil
+}
+
+func (r *ServerStatRepository) Save(_ context.Context, stat *domain.ServerStat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stat.ID != 0 {
+		if oldStat, exists := r.stats[stat.ID]; exists {
+			r.removeFromIndexes(oldStat)
+		}
+	} else {
+		stat.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	}
+
+	saved := *stat
+	r.stats[stat.ID] = &saved
+
+	r.addToIndexes(&saved)
+
+	return nil
+}
+
+func (r *ServerStatRepository) DeleteMany(_ context.Context, filter *filters.FindServerStat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range r.getFilteredStatIDs(filter) {
+		r.removeFromIndexes(r.stats[id])
+		delete(r.stats, id)
+	}
+
+	return nil
+}
+
+func (r *ServerStatRepository) addToIndexes(stat *domain.ServerStat) {
+	if r.serverIDIndex[stat.ServerID] == nil {
+		r.serverIDIndex[stat.ServerID] = make(map[uint]struct{})
+	}
+	r.serverIDIndex[stat.ServerID][stat.ID] = struct{}{}
+}
+
+func (r *ServerStatRepository) removeFromIndexes(stat *domain.ServerStat) {
+	if statSet, exists := r.serverIDIndex[stat.ServerID]; exists {
+		delete(statSet, stat.ID)
+		if len(statSet) == 0 {
+			delete(r.serverIDIndex, stat.ServerID)
+		}
+	}
+}
+
+func (r *ServerStatRepository) getFilteredStatIDs(filter *filters.FindServerStat) []uint {
+	candidates := make([]uint, 0)
+
+	switch {
+	case filter != nil && len(filter.ServerIDs) > 0:
+		for _, serverID := range filter.ServerIDs {
+			for id := range r.serverIDIndex[serverID] {
+				candidates = append(candidates, id)
+			}
+		}
+	default:
+		for id := range r.stats {
+			candidates = append(candidates, id)
+		}
+	}
+
+	if filter == nil {
+		return candidates
+	}
+
+	return slices.DeleteFunc(candidates, func(id uint) bool {
+		return !r.matchesFilter(r.stats[id], filter)
+	})
+}
+
+func (r *ServerStatRepository) matchesFilter(stat *domain.ServerStat, filter *filters.FindServerStat) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, stat.ID) {
+		return false
+	}
+
+	if len(filter.Resolutions) > 0 && !slices.Contains(filter.Resolutions, stat.Resolution) {
+		return false
+	}
+
+	if len(filter.Kinds) > 0 && !slices.Contains(filter.Kinds, stat.Kind) {
+		return false
+	}
+
+	// TimeFrom is inclusive
+	if filter.TimeFrom != nil && stat.Time.Before(*filter.TimeFrom) {
+		return false
+	}
+
+	// TimeTo is exclusive
+	if filter.TimeTo != nil && !stat.Time.Before(*filter.TimeTo) {
+		return false
+	}
+
+	return true
+}
+
+func (r *ServerStatRepository) sortStats(stats []domain.ServerStat, order []filters.Sorting) {
+	if len(order) == 0 {
+		sort.Slice(stats, func(i, j int) bool {
+			return stats[i].ID < stats[j].ID
+		})
+
+		return
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		for _, o := range order {
+			cm := r.compareStats(&stats[i], &stats[j], o.Field)
+			if cm != 0 {
+				if o.Direction == filters.SortDirectionDesc {
+					return cm > 0
+				}
+
+				return cm < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func (r *ServerStatRepository) compareStats(a, b *domain.ServerStat, field string) int {
+	switch field {
+	case "id":
+		return cmp.Compare(a.ID, b.ID)
+	case "server_id":
+		return cmp.Compare(a.ServerID, b.ServerID)
+	case "time":
+		return a.Time.Compare(b.Time)
+	case "resolution":
+		return cmp.Compare(a.Resolution, b.Resolution)
+	case "players_num":
+		return cmp.Compare(a.PlayersNum, b.PlayersNum)
+	default:
+		return 0
+	}
+}
+
+func (r *ServerStatRepository) applyPagination(
+	stats []domain.ServerStat,
+	pagination *filters.Pagination,
+) []domain.ServerStat {
+	if pagination == nil {
+		return stats
+	}
+
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = filters.DefaultLimit
+	}
+
+	offset := max(pagination.Offset, 0)
+
+	if offset >= len(stats) {
+		return []domain.ServerStat{}
+	}
+
+	end := min(offset+limit, len(stats))
+
+	return stats[offset:end]
+}
@@ -0,0 +1,217 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var (
+	wrappedCommandPolicyFields = lo.Map(base.CommandPolicyFields, func(s string, _ int) string {
+		b := strings.Builder{}
+		b.Grow(len(s) + 2)
+		b.WriteByte('`')
+		b.WriteString(s)
+		b.WriteByte('`')
+
+		return b.String()
+	})
+)
+
+type CommandPolicyRepository struct {
+	db base.DB
+}
+
+func NewCommandPolicyRepository(db base.DB) *CommandPolicyRepository {
+	return &CommandPolicyRepository{
+		db: db,
+	}
+}
+
+func (r *CommandPolicyRepository) Find(
+	ctx context.Context,
+	filter *filters.FindCommandPolicy,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.CommandPolicy, error) {
+	builder := sq.Select(wrappedCommandPolicyFields...).
+		From(base.CommandPoliciesTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var policies []domain.CommandPolicy
+
+	for rows.Next() {
+		var policy *domain.CommandPolicy
+		policy, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		policies = append(policies, *policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return policies, nil
+}
+
+func (r *CommandPolicyRepository) Save(ctx context.Context, policy *domain.CommandPolicy) error {
+	query, args, err := sq.Insert(base.CommandPoliciesTable).
+		Columns(base.CommandPolicyFields...).
+		Values(
+			policy.ID,
+			policy.ServerID,
+			policy.Role,
+			policy.Target,
+			policy.Action,
+			policy.PatternType,
+			policy.Pattern,
+			policy.Description,
+			policy.CreatedAt,
+			policy.UpdatedAt,
+		).
+		Suffix("ON DUPLICATE KEY UPDATE " +
+			"server_id=VALUES(server_id)," +
+			"role=VALUES(role)," +
+			"target=VALUES(target)," +
+			"action=VALUES(action)," +
+			"pattern_type=VALUES(pattern_type)," +
+			"pattern=VALUES(pattern)," +
+			"description=VALUES(description)," +
+			"created_at=VALUES(created_at)," +
+			"updated_at=VALUES(updated_at)").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if policy.ID == 0 {
+		lastID, err := result.LastInsertId()
+		if err != nil {
+			return errors.WithMessage(err, "failed to get last insert ID")
+		}
+		if lastID < 0 {
+			return errors.New("invalid last insert ID")
+		}
+		policy.ID = uint(lastID)
+	}
+
+	return nil
+}
+
+func (r *CommandPolicyRepository) Delete(ctx context.Context, id uint) error {
+	query, args, err := sq.Delete(base.CommandPoliciesTable).
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *CommandPolicyRepository) scan(row base.Scanner) (*domain.CommandPolicy, error) {
+	var policy domain.CommandPolicy
+
+	err := row.Scan(
+		&policy.ID,
+		&policy.ServerID,
+		&policy.Role,
+		&policy.Target,
+		&policy.Action,
+		&policy.PatternType,
+		&policy.Pattern,
+		&policy.Description,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &policy, nil
+}
+
+func (r *CommandPolicyRepository) filterToSq(filter *filters.FindCommandPolicy) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 3)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	if len(filter.AppliedToServerIDs) > 0 {
+		and = append(and, sq.Or{
+			sq.Eq{"server_id": nil},
+			sq.Eq{"server_id": filter.AppliedToServerIDs},
+		})
+	}
+
+	return and
+}
@@ -130,6 +130,11 @@ func (r *GameModRepository) Save(ctx context.Context, gameMod *domain.GameMod) e
 			gameMod.ChmapCmd,
 			gameMod.SendmsgCmd,
 			gameMod.PasswdCmd,
+			gameMod.QueryProtocol,
+			gameMod.RconProtocol,
+			gameMod.PlayerManager,
+			gameMod.QueryPortOffset,
+			gameMod.RconPortOffset,
 		).
 		Suffix("ON DUPLICATE KEY UPDATE " +
 			"game_code=VALUES(game_code)," +
@@ -148,7 +153,12 @@ func (r *GameModRepository) Save(ctx context.Context, gameMod *domain.GameMod) e
 			"srestart_cmd=VALUES(srestart_cmd)," +
 			"chmap_cmd=VALUES(chmap_cmd)," +
 			"sendmsg_cmd=VALUES(sendmsg_cmd)," +
-			"passwd_cmd=VALUES(passwd_cmd)").
+			"passwd_cmd=VALUES(passwd_cmd)," +
+			"query_protocol=VALUES(query_protocol)," +
+			"rcon_protocol=VALUES(rcon_protocol)," +
+			"player_manager=VALUES(player_manager)," +
+			"query_port_offset=VALUES(query_port_offset)," +
+			"rcon_port_offset=VALUES(rcon_port_offset)").
 		PlaceholderFormat(sq.Question).
 		ToSql()
 	if err != nil {
@@ -213,6 +223,11 @@ func (r *GameModRepository) scan(row base.Scanner) (*domain.GameMod, error) {
 		&gameMod.ChmapCmd,
 		&gameMod.SendmsgCmd,
 		&gameMod.PasswdCmd,
+		&gameMod.QueryProtocol,
+		&gameMod.RconProtocol,
+		&gameMod.PlayerManager,
+		&gameMod.QueryPortOffset,
+		&gameMod.RconPortOffset,
 	)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to scan row")
@@ -124,6 +124,11 @@ func (r *GameRepository) Save(ctx context.Context, game *domain.Game) error {
 			game.LocalRepositoryLinux,
 			game.LocalRepositoryWindows,
 			game.Enabled,
+			game.QueryProtocol,
+			game.RconProtocol,
+			game.PlayerManager,
+			game.QueryPortOffset,
+			game.RconPortOffset,
 		).
 		Suffix("ON DUPLICATE KEY UPDATE " +
 			"name=VALUES(name)," +
@@ -136,7 +141,12 @@ func (r *GameRepository) Save(ctx context.Context, game *domain.Game) error {
 			"remote_repository_windows=VALUES(remote_repository_windows)," +
 			"local_repository_linux=VALUES(local_repository_linux)," +
 			"local_repository_windows=VALUES(local_repository_windows)," +
-			"enabled=VALUES(enabled)").
+			"enabled=VALUES(enabled)," +
+			"query_protocol=VALUES(query_protocol)," +
+			"rcon_protocol=VALUES(rcon_protocol)," +
+			"player_manager=VALUES(player_manager)," +
+			"query_port_offset=VALUES(query_port_offset)," +
+			"rcon_port_offset=VALUES(rcon_port_offset)").
 		PlaceholderFormat(sq.Question).
 		ToSql()
 	if err != nil {
@@ -184,6 +194,11 @@ func (r *GameRepository) scan(row base.Scanner) (*domain.Game, error) {
 		&game.LocalRepositoryLinux,
 		&game.LocalRepositoryWindows,
 		&game.Enabled,
+		&game.QueryProtocol,
+		&game.RconProtocol,
+		&game.PlayerManager,
+		&game.QueryPortOffset,
+		&game.RconPortOffset,
 	)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to scan row")
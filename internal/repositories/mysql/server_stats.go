@@ -0,0 +1,232 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var (
+	wrappedServerStatFields = lo.Map(base.ServerStatFields, func(s string, _ int) string {
+		b := strings.Builder{}
+		b.Grow(len(s) + 2)
+		b.WriteByte('`')
+		b.WriteString(s)
+		b.WriteByte('`')
+
+		return b.String()
+	})
+)
+
+type ServerStatRepository struct {
+	db base.DB
+}
+
+func NewServerStatRepository(db base.DB) *ServerStatRepository {
+	return &ServerStatRepository{
+		db: db,
+	}
+}
+
+func (r *ServerStatRepository) Find(
+	ctx context.Context,
+	filter *filters.FindServerStat,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.ServerStat, error) {
+	builder := sq.Select(wrappedServerStatFields...).
+		From(base.ServerStatsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var stats []domain.ServerStat
+
+	for rows.Next() {
+		var stat *domain.ServerStat
+		stat, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		stats = append(stats, *stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return stats, nil
+}
+
+func (r *ServerStatRepository) Save(ctx context.Context, stat *domain.ServerStat) error {
+	query, args, err := sq.Insert(base.ServerStatsTable).
+		Columns(base.ServerStatFields...).
+		Values(
+			stat.ID,
+			stat.ServerID,
+			stat.Time,
+			stat.RAM,
+			stat.CPU,
+			stat.Netstat,
+			stat.Drvspace,
+			stat.Resolution,
+			stat.Online,
+			stat.PlayersNum,
+			stat.MaxPlayersNum,
+			stat.Kind,
+		).
+		Suffix("ON DUPLICATE KEY UPDATE " +
+			"server_id=VALUES(server_id)," +
+			"time=VALUES(time)," +
+			"ram=VALUES(ram)," +
+			"cpu=VALUES(cpu)," +
+			"netstat=VALUES(netstat)," +
+			"drvspace=VALUES(drvspace)," +
+			"resolution=VALUES(resolution)," +
+			"online=VALUES(online)," +
+			"players_num=VALUES(players_num)," +
+			"max_players_num=VALUES(max_players_num)," +
+			"kind=VALUES(kind)").
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if stat.ID == 0 {
+		lastID, err := result.LastInsertId()
+		if err != nil {
+			return errors.WithMessage(err, "failed to get last insert ID")
+		}
+		if lastID < 0 {
+			return errors.New("invalid last insert ID")
+		}
+		stat.ID = uint(lastID)
+	}
+
+	return nil
+}
+
+func (r *ServerStatRepository) DeleteMany(ctx context.Context, filter *filters.FindServerStat) error {
+	query, args, err := sq.Delete(base.ServerStatsTable).
+		Where(r.filterToSq(filter)).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *ServerStatRepository) scan(row base.Scanner) (*domain.ServerStat, error) {
+	var stat domain.ServerStat
+
+	err := row.Scan(
+		&stat.ID,
+		&stat.ServerID,
+		&stat.Time,
+		&stat.RAM,
+		&stat.CPU,
+		&stat.Netstat,
+		&stat.Drvspace,
+		&stat.Resolution,
+		&stat.Online,
+		&stat.PlayersNum,
+		&stat.MaxPlayersNum,
+		&stat.Kind,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &stat, nil
+}
+
+func (r *ServerStatRepository) filterToSq(filter *filters.FindServerStat) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 5)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	if len(filter.Resolutions) > 0 {
+		and = append(and, sq.Eq{"resolution": filter.Resolutions})
+	}
+
+	if len(filter.Kinds) > 0 {
+		and = append(and, sq.Eq{"kind": filter.Kinds})
+	}
+
+	if filter.TimeFrom != nil {
+		and = append(and, sq.GtOrEq{"time": filter.TimeFrom})
+	}
+
+	if filter.TimeTo != nil {
+		and = append(and, sq.Lt{"time": filter.TimeTo})
+	}
+
+	return and
+}
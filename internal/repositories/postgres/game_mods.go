@@ -132,6 +132,11 @@ func (r *GameModRepository) Save(ctx context.Context, gameMod *domain.GameMod) e
 				"chmap_cmd",
 				"sendmsg_cmd",
 				"passwd_cmd",
+				"query_protocol",
+				"rcon_protocol",
+				"player_manager",
+				"query_port_offset",
+				"rcon_port_offset",
 			).
 			Values(
 				gameMod.GameCode,
@@ -151,6 +156,11 @@ func (r *GameModRepository) Save(ctx context.Context, gameMod *domain.GameMod) e
 				gameMod.ChmapCmd,
 				gameMod.SendmsgCmd,
 				gameMod.PasswdCmd,
+				gameMod.QueryProtocol,
+				gameMod.RconProtocol,
+				gameMod.PlayerManager,
+				gameMod.QueryPortOffset,
+				gameMod.RconPortOffset,
 			).
 			Suffix("RETURNING id")
 	} else {
@@ -175,6 +185,11 @@ func (r *GameModRepository) Save(ctx context.Context, gameMod *domain.GameMod) e
 				gameMod.ChmapCmd,
 				gameMod.SendmsgCmd,
 				gameMod.PasswdCmd,
+				gameMod.QueryProtocol,
+				gameMod.RconProtocol,
+				gameMod.PlayerManager,
+				gameMod.QueryPortOffset,
+				gameMod.RconPortOffset,
 			).
 			Suffix("ON CONFLICT(id) DO UPDATE SET " +
 				"game_code=excluded.game_code," +
@@ -193,7 +208,12 @@ func (r *GameModRepository) Save(ctx context.Context, gameMod *domain.GameMod) e
 				"srestart_cmd=excluded.srestart_cmd," +
 				"chmap_cmd=excluded.chmap_cmd," +
 				"sendmsg_cmd=excluded.sendmsg_cmd," +
-				"passwd_cmd=excluded.passwd_cmd " +
+				"passwd_cmd=excluded.passwd_cmd," +
+				"query_protocol=excluded.query_protocol," +
+				"rcon_protocol=excluded.rcon_protocol," +
+				"player_manager=excluded.player_manager," +
+				"query_port_offset=excluded.query_port_offset," +
+				"rcon_port_offset=excluded.rcon_port_offset " +
 				"RETURNING id")
 	}
 
@@ -254,6 +274,11 @@ func (r *GameModRepository) scan(row base.Scanner) (*domain.GameMod, error) {
 		&gameMod.ChmapCmd,
 		&gameMod.SendmsgCmd,
 		&gameMod.PasswdCmd,
+		&gameMod.QueryProtocol,
+		&gameMod.RconProtocol,
+		&gameMod.PlayerManager,
+		&gameMod.QueryPortOffset,
+		&gameMod.RconPortOffset,
 	)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to scan row")
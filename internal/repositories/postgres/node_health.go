@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var (
+	wrappedNodeHealthFields = lo.Map(base.NodeHealthFields, func(s string, _ int) string {
+		b := strings.Builder{}
+		b.Grow(len(s) + 2)
+		b.WriteByte('"')
+		b.WriteString(s)
+		b.WriteByte('"')
+
+		return b.String()
+	})
+)
+
+type NodeHealthRepository struct {
+	db base.DB
+}
+
+func NewNodeHealthRepository(db base.DB) *NodeHealthRepository {
+	return &NodeHealthRepository{
+		db: db,
+	}
+}
+
+func (r *NodeHealthRepository) Find(
+	ctx context.Context,
+	filter *filters.FindNodeHealth,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.NodeHealth, error) {
+	builder := sq.Select(wrappedNodeHealthFields...).
+		From(base.NodeHealthTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("node_id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var healths []domain.NodeHealth
+
+	for rows.Next() {
+		var health *domain.NodeHealth
+		health, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		healths = append(healths, *health)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return healths, nil
+}
+
+func (r *NodeHealthRepository) Save(ctx context.Context, health *domain.NodeHealth) error {
+	query, args, err := sq.Insert(base.NodeHealthTable).
+		Columns(base.NodeHealthFields...).
+		Values(
+			health.NodeID,
+			health.Online,
+			health.Latency,
+			health.Error,
+			health.LastSeenAt,
+			health.CheckedAt,
+		).
+		Suffix("ON CONFLICT(node_id) DO UPDATE SET " +
+			"online=excluded.online," +
+			"latency=excluded.latency," +
+			"error=excluded.error," +
+			"last_seen_at=excluded.last_seen_at," +
+			"checked_at=excluded.checked_at").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *NodeHealthRepository) scan(row base.Scanner) (*domain.NodeHealth, error) {
+	var health domain.NodeHealth
+
+	err := row.Scan(
+		&health.NodeID,
+		&health.Online,
+		&health.Latency,
+		&health.Error,
+		&health.LastSeenAt,
+		&health.CheckedAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &health, nil
+}
+
+func (r *NodeHealthRepository) filterToSq(filter *filters.FindNodeHealth) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 1)
+
+	if len(filter.NodeIDs) > 0 {
+		and = append(and, sq.Eq{"node_id": filter.NodeIDs})
+	}
+
+	return and
+}
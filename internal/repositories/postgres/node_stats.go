@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var (
+	wrappedNodeStatFields = lo.Map(base.NodeStatFields, func(s string, _ int) string {
+		b := strings.Builder{}
+		b.Grow(len(s) + 2)
+		b.WriteByte('"')
+		b.WriteString(s)
+		b.WriteByte('"')
+
+		return b.String()
+	})
+)
+
+type NodeStatRepository struct {
+	db base.DB
+}
+
+func NewNodeStatRepository(db base.DB) *NodeStatRepository {
+	return &NodeStatRepository{
+		db: db,
+	}
+}
+
+func (r *NodeStatRepository) Find(
+	ctx context.Context,
+	filter *filters.FindNodeStat,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.NodeStat, error) {
+	builder := sq.Select(wrappedNodeStatFields...).
+		From(base.NodeStatsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var stats []domain.NodeStat
+
+	for rows.Next() {
+		var stat *domain.NodeStat
+		stat, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		stats = append(stats, *stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return stats, nil
+}
+
+func (r *NodeStatRepository) Save(ctx context.Context, stat *domain.NodeStat) error {
+	builder := sq.Insert(base.NodeStatsTable)
+
+	if stat.ID == 0 {
+		builder = builder.
+			Columns(
+				"dedicated_server_id",
+				"time",
+				"loa",
+				"ram",
+				"cpu",
+				"ifstat",
+				"ping",
+				"drvspace",
+			).
+			Values(
+				stat.NodeID,
+				stat.Time,
+				stat.Load,
+				stat.RAM,
+				stat.CPU,
+				stat.Network,
+				stat.Ping,
+				stat.Disk,
+			).
+			Suffix("RETURNING id")
+	} else {
+		builder = builder.
+			Columns(base.NodeStatFields...).
+			Values(
+				stat.ID,
+				stat.NodeID,
+				stat.Time,
+				stat.Load,
+				stat.RAM,
+				stat.CPU,
+				stat.Network,
+				stat.Ping,
+				stat.Disk,
+			).
+			Suffix("ON CONFLICT(id) DO UPDATE SET " +
+				"dedicated_server_id=excluded.dedicated_server_id," +
+				"time=excluded.time," +
+				"loa=excluded.loa," +
+				"ram=excluded.ram," +
+				"cpu=excluded.cpu," +
+				"ifstat=excluded.ifstat," +
+				"ping=excluded.ping," +
+				"drvspace=excluded.drvspace " +
+				"RETURNING id")
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	var returnedID uint
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if stat.ID == 0 {
+		stat.ID = returnedID
+	}
+
+	return nil
+}
+
+func (r *NodeStatRepository) DeleteMany(ctx context.Context, filter *filters.FindNodeStat) error {
+	query, args, err := sq.Delete(base.NodeStatsTable).
+		Where(r.filterToSq(filter)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (r *NodeStatRepository) scan(row base.Scanner) (*domain.NodeStat, error) {
+	var stat domain.NodeStat
+
+	err := row.Scan(
+		&stat.ID,
+		&stat.NodeID,
+		&stat.Time,
+		&stat.Load,
+		&stat.RAM,
+		&stat.CPU,
+		&stat.Network,
+		&stat.Ping,
+		&stat.Disk,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &stat, nil
+}
+
+func (r *NodeStatRepository) filterToSq(filter *filters.FindNodeStat) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 4)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.NodeIDs) > 0 {
+		and = append(and, sq.Eq{"dedicated_server_id": filter.NodeIDs})
+	}
+
+	if filter.TimeFrom != nil {
+		and = append(and, sq.GtOrEq{"time": filter.TimeFrom})
+	}
+
+	if filter.TimeTo != nil {
+		and = append(and, sq.Lt{"time": filter.TimeTo})
+	}
+
+	return and
+}
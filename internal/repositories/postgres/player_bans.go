@@ -0,0 +1,256 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var (
+	wrappedPlayerBanFields = lo.Map(base.PlayerBanFields, func(s string, _ int) string {
+		b := strings.Builder{}
+		b.Grow(len(s) + 2)
+		b.WriteByte('"')
+		b.WriteString(s)
+		b.WriteByte('"')
+
+		return b.String()
+	})
+)
+
+type PlayerBanRepository struct {
+	db base.DB
+}
+
+func NewPlayerBanRepository(db base.DB) *PlayerBanRepository {
+	return &PlayerBanRepository{
+		db: db,
+	}
+}
+
+func (r *PlayerBanRepository) Find(
+	ctx context.Context,
+	filter *filters.FindPlayerBan,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.PlayerBan, error) {
+	builder := sq.Select(wrappedPlayerBanFields...).
+		From(base.PlayerBansTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var bans []domain.PlayerBan
+
+	for rows.Next() {
+		var ban *domain.PlayerBan
+		ban, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		bans = append(bans, *ban)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return bans, nil
+}
+
+func (r *PlayerBanRepository) Save(ctx context.Context, ban *domain.PlayerBan) error {
+	builder := sq.Insert(base.PlayerBansTable)
+
+	if ban.ID == 0 {
+		builder = builder.
+			Columns(
+				"server_id",
+				"uniq_id",
+				"name",
+				"reason",
+				"source",
+				"issuer_id",
+				"created_at",
+				"expires_at",
+				"lifted_at",
+				"lifted_by",
+			).
+			Values(
+				ban.ServerID,
+				ban.UniqID,
+				ban.Name,
+				ban.Reason,
+				ban.Source,
+				ban.IssuerID,
+				ban.CreatedAt,
+				ban.ExpiresAt,
+				ban.LiftedAt,
+				ban.LiftedBy,
+			).
+			Suffix("RETURNING id")
+	} else {
+		builder = builder.
+			Columns(base.PlayerBanFields...).
+			Values(
+				ban.ID,
+				ban.ServerID,
+				ban.UniqID,
+				ban.Name,
+				ban.Reason,
+				ban.Source,
+				ban.IssuerID,
+				ban.CreatedAt,
+				ban.ExpiresAt,
+				ban.LiftedAt,
+				ban.LiftedBy,
+			).
+			Suffix("ON CONFLICT(id) DO UPDATE SET " +
+				"server_id=excluded.server_id," +
+				"uniq_id=excluded.uniq_id," +
+				"name=excluded.name," +
+				"reason=excluded.reason," +
+				"source=excluded.source," +
+				"issuer_id=excluded.issuer_id," +
+				"created_at=excluded.created_at," +
+				"expires_at=excluded.expires_at," +
+				"lifted_at=excluded.lifted_at," +
+				"lifted_by=excluded.lifted_by " +
+				"RETURNING id")
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	var returnedID uint
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if ban.ID == 0 {
+		ban.ID = returnedID
+	}
+
+	return nil
+}
+
+func (r *PlayerBanRepository) scan(row base.Scanner) (*domain.PlayerBan, error) {
+	var ban domain.PlayerBan
+
+	err := row.Scan(
+		&ban.ID,
+		&ban.ServerID,
+		&ban.UniqID,
+		&ban.Name,
+		&ban.Reason,
+		&ban.Source,
+		&ban.IssuerID,
+		&ban.CreatedAt,
+		&ban.ExpiresAt,
+		&ban.LiftedAt,
+		&ban.LiftedBy,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &ban, nil
+}
+
+func (r *PlayerBanRepository) filterToSq(filter *filters.FindPlayerBan) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 7)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	if len(filter.UniqIDs) > 0 {
+		and = append(and, sq.Eq{"uniq_id": filter.UniqIDs})
+	}
+
+	if len(filter.Names) > 0 {
+		and = append(and, sq.Eq{"name": filter.Names})
+	}
+
+	if filter.Lifted != nil {
+		if *filter.Lifted {
+			and = append(and, sq.NotEq{"lifted_at": nil})
+		} else {
+			and = append(and, sq.Eq{"lifted_at": nil})
+		}
+	}
+
+	if filter.ActiveAt != nil {
+		and = append(and,
+			sq.Eq{"lifted_at": nil},
+			sq.Or{
+				sq.Eq{"expires_at": nil},
+				sq.Gt{"expires_at": filter.ActiveAt},
+			},
+		)
+	}
+
+	if filter.ExpiredAt != nil {
+		and = append(and,
+			sq.Eq{"lifted_at": nil},
+			sq.NotEq{"expires_at": nil},
+			sq.LtOrEq{"expires_at": filter.ExpiredAt},
+		)
+	}
+
+	return and
+}
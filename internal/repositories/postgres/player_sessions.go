@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var (
+	wrappedPlayerSessionFields = lo.Map(base.PlayerSessionFields, func(s string, _ int) string {
+		b := strings.Builder{}
+		b.Grow(len(s) + 2)
+		b.WriteByte('"')
+		b.WriteString(s)
+		b.WriteByte('"')
+
+		return b.String()
+	})
+)
+
+type PlayerSessionRepository struct {
+	db base.DB
+}
+
+func NewPlayerSessionRepository(db base.DB) *PlayerSessionRepository {
+	return &PlayerSessionRepository{
+		db: db,
+	}
+}
+
+func (r *PlayerSessionRepository) Find(
+	ctx context.Context,
+	filter *filters.FindPlayerSession,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.PlayerSession, error) {
+	builder := sq.Select(wrappedPlayerSessionFields...).
+		From(base.PlayerSessionsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var sessions []domain.PlayerSession
+
+	for rows.Next() {
+		var session *domain.PlayerSession
+		session, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		sessions = append(sessions, *session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return sessions, nil
+}
+
+func (r *PlayerSessionRepository) Save(ctx context.Context, session *domain.PlayerSession) error {
+	builder := sq.Insert(base.PlayerSessionsTable)
+
+	if session.ID == 0 {
+		builder = builder.
+			Columns(
+				"server_id",
+				"uniq_id",
+				"name",
+				"joined_at",
+				"left_at",
+				"last_seen_at",
+			).
+			Values(
+				session.ServerID,
+				session.UniqID,
+				session.Name,
+				session.JoinedAt,
+				session.LeftAt,
+				session.LastSeenAt,
+			).
+			Suffix("RETURNING id")
+	} else {
+		builder = builder.
+			Columns(base.PlayerSessionFields...).
+			Values(
+				session.ID,
+				session.ServerID,
+				session.UniqID,
+				session.Name,
+				session.JoinedAt,
+				session.LeftAt,
+				session.LastSeenAt,
+			).
+			Suffix("ON CONFLICT(id) DO UPDATE SET " +
+				"server_id=excluded.server_id," +
+				"uniq_id=excluded.uniq_id," +
+				"name=excluded.name," +
+				"joined_at=excluded.joined_at," +
+				"left_at=excluded.left_at," +
+				"last_seen_at=excluded.last_seen_at " +
+				"RETURNING id")
+	}
+
+	query, args, err := builder.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	var returnedID uint
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if session.ID == 0 {
+		session.ID = returnedID
+	}
+
+	return nil
+}
+
+func (r *PlayerSessionRepository) scan(row base.Scanner) (*domain.PlayerSession, error) {
+	var session domain.PlayerSession
+
+	err := row.Scan(
+		&session.ID,
+		&session.ServerID,
+		&session.UniqID,
+		&session.Name,
+		&session.JoinedAt,
+		&session.LeftAt,
+		&session.LastSeenAt,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	return &session, nil
+}
+
+func (r *PlayerSessionRepository) filterToSq(filter *filters.FindPlayerSession) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 7)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	if len(filter.UniqIDs) > 0 {
+		and = append(and, sq.Eq{"uniq_id": filter.UniqIDs})
+	}
+
+	if len(filter.Names) > 0 {
+		and = append(and, sq.Eq{"name": filter.Names})
+	}
+
+	if filter.Active != nil {
+		if *filter.Active {
+			and = append(and, sq.Eq{"left_at": nil})
+		} else {
+			and = append(and, sq.NotEq{"left_at": nil})
+		}
+	}
+
+	if filter.ActiveFrom != nil {
+		and = append(and, sq.Or{
+			sq.Eq{"left_at": nil},
+			sq.GtOrEq{"left_at": filter.ActiveFrom},
+		})
+	}
+
+	if filter.ActiveTo != nil {
+		and = append(and, sq.Lt{"joined_at": filter.ActiveTo})
+	}
+
+	return and
+}
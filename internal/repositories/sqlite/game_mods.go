@@ -131,6 +131,11 @@ func (r *GameModRepository) Save(ctx context.Context, gameMod *domain.GameMod) e
 			gameMod.ChmapCmd,
 			gameMod.SendmsgCmd,
 			gameMod.PasswdCmd,
+			gameMod.QueryProtocol,
+			gameMod.RconProtocol,
+			gameMod.PlayerManager,
+			gameMod.QueryPortOffset,
+			gameMod.RconPortOffset,
 		).
 		Suffix("ON CONFLICT(id) DO UPDATE SET " +
 			"game_code=excluded.game_code," +
@@ -149,7 +154,12 @@ func (r *GameModRepository) Save(ctx context.Context, gameMod *domain.GameMod) e
 			"srestart_cmd=excluded.srestart_cmd," +
 			"chmap_cmd=excluded.chmap_cmd," +
 			"sendmsg_cmd=excluded.sendmsg_cmd," +
-			"passwd_cmd=excluded.passwd_cmd " +
+			"passwd_cmd=excluded.passwd_cmd," +
+			"query_protocol=excluded.query_protocol," +
+			"rcon_protocol=excluded.rcon_protocol," +
+			"player_manager=excluded.player_manager," +
+			"query_port_offset=excluded.query_port_offset," +
+			"rcon_port_offset=excluded.rcon_port_offset " +
 			"RETURNING id").
 		ToSql()
 	if err != nil {
@@ -207,6 +217,11 @@ func (r *GameModRepository) scan(row base.Scanner) (*domain.GameMod, error) {
 		&gameMod.ChmapCmd,
 		&gameMod.SendmsgCmd,
 		&gameMod.PasswdCmd,
+		&gameMod.QueryProtocol,
+		&gameMod.RconProtocol,
+		&gameMod.PlayerManager,
+		&gameMod.QueryPortOffset,
+		&gameMod.RconPortOffset,
 	)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to scan row")
@@ -124,6 +124,11 @@ func (r *GameRepository) Save(ctx context.Context, game *domain.Game) error {
 			game.LocalRepositoryLinux,
 			game.LocalRepositoryWindows,
 			game.Enabled,
+			game.QueryProtocol,
+			game.RconProtocol,
+			game.PlayerManager,
+			game.QueryPortOffset,
+			game.RconPortOffset,
 		).
 		Suffix("ON CONFLICT(code) DO UPDATE SET " +
 			"name=excluded.name," +
@@ -136,7 +141,12 @@ func (r *GameRepository) Save(ctx context.Context, game *domain.Game) error {
 			"remote_repository_windows=excluded.remote_repository_windows," +
 			"local_repository_linux=excluded.local_repository_linux," +
 			"local_repository_windows=excluded.local_repository_windows," +
-			"enabled=excluded.enabled").
+			"enabled=excluded.enabled," +
+			"query_protocol=excluded.query_protocol," +
+			"rcon_protocol=excluded.rcon_protocol," +
+			"player_manager=excluded.player_manager," +
+			"query_port_offset=excluded.query_port_offset," +
+			"rcon_port_offset=excluded.rcon_port_offset").
 		ToSql()
 	if err != nil {
 		return errors.WithMessage(err, "failed to build query")
@@ -182,6 +192,11 @@ func (r *GameRepository) scan(row base.Scanner) (*domain.Game, error) {
 		&game.LocalRepositoryLinux,
 		&game.LocalRepositoryWindows,
 		&game.Enabled,
+		&game.QueryProtocol,
+		&game.RconProtocol,
+		&game.PlayerManager,
+		&game.QueryPortOffset,
+		&game.RconPortOffset,
 	)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to scan row")
@@ -0,0 +1,230 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/base"
+	"github.com/samber/lo"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+var (
+	wrappedPlayerSessionFields = lo.Map(base.PlayerSessionFields, func(s string, _ int) string {
+		b := strings.Builder{}
+		b.Grow(len(s) + 2)
+		b.WriteByte('`')
+		b.WriteString(s)
+		b.WriteByte('`')
+
+		return b.String()
+	})
+)
+
+type PlayerSessionRepository struct {
+	db base.DB
+}
+
+func NewPlayerSessionRepository(db base.DB) *PlayerSessionRepository {
+	return &PlayerSessionRepository{
+		db: db,
+	}
+}
+
+func (r *PlayerSessionRepository) Find(
+	ctx context.Context,
+	filter *filters.FindPlayerSession,
+	order []filters.Sorting,
+	pagination *filters.Pagination,
+) ([]domain.PlayerSession, error) {
+	builder := sq.Select(wrappedPlayerSessionFields...).
+		From(base.PlayerSessionsTable).
+		Where(r.filterToSq(filter))
+
+	if len(order) > 0 {
+		for _, o := range order {
+			builder = builder.OrderBy(o.String())
+		}
+	} else {
+		builder = builder.OrderBy("id ASC")
+	}
+
+	if pagination != nil {
+		if pagination.Limit <= 0 {
+			pagination.Limit = filters.DefaultLimit
+		}
+
+		if pagination.Offset < 0 {
+			pagination.Offset = 0
+		}
+
+		builder = builder.Limit(uint64(pagination.Limit)).Offset(uint64(pagination.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to build query")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...) //nolint:sqlclosecheck // closed in defer
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to execute query")
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close rows stream", "query", query, "err", err)
+		}
+	}(rows)
+
+	var sessions []domain.PlayerSession
+
+	for rows.Next() {
+		var session *domain.PlayerSession
+		session, err = r.scan(rows)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to scan row")
+		}
+
+		sessions = append(sessions, *session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.WithMessage(err, "rows iteration error")
+	}
+
+	return sessions, nil
+}
+
+func (r *PlayerSessionRepository) Save(ctx context.Context, session *domain.PlayerSession) error {
+	var leftAt *string
+	if session.LeftAt != nil {
+		leftAt = lo.ToPtr(formatStatTime(*session.LeftAt))
+	}
+
+	query, args, err := sq.Insert(base.PlayerSessionsTable).
+		Columns(base.PlayerSessionFields...).
+		Values(
+			lo.EmptyableToPtr(session.ID),
+			session.ServerID,
+			session.UniqID,
+			session.Name,
+			formatStatTime(session.JoinedAt),
+			leftAt,
+			formatStatTime(session.LastSeenAt),
+		).
+		Suffix("ON CONFLICT(id) DO UPDATE SET " +
+			"server_id=excluded.server_id," +
+			"uniq_id=excluded.uniq_id," +
+			"name=excluded.name," +
+			"joined_at=excluded.joined_at," +
+			"left_at=excluded.left_at," +
+			"last_seen_at=excluded.last_seen_at " +
+			"RETURNING id").
+		ToSql()
+	if err != nil {
+		return errors.WithMessage(err, "failed to build query")
+	}
+
+	var returnedID uint
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute query")
+	}
+
+	if session.ID == 0 {
+		session.ID = returnedID
+	}
+
+	return nil
+}
+
+func (r *PlayerSessionRepository) scan(row base.Scanner) (*domain.PlayerSession, error) {
+	var session domain.PlayerSession
+	var joinedAtStr, lastSeenAtStr string
+	var leftAtStr *string
+
+	err := row.Scan(
+		&session.ID,
+		&session.ServerID,
+		&session.UniqID,
+		&session.Name,
+		&joinedAtStr,
+		&leftAtStr,
+		&lastSeenAtStr,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to scan row")
+	}
+
+	session.JoinedAt, err = base.ParseTime(joinedAtStr)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse joined_at time")
+	}
+
+	if leftAtStr != nil && *leftAtStr != "" {
+		leftAt, err := base.ParseTime(*leftAtStr)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse left_at time")
+		}
+		session.LeftAt = &leftAt
+	}
+
+	session.LastSeenAt, err = base.ParseTime(lastSeenAtStr)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse last_seen_at time")
+	}
+
+	return &session, nil
+}
+
+func (r *PlayerSessionRepository) filterToSq(filter *filters.FindPlayerSession) sq.Sqlizer {
+	if filter == nil {
+		return nil
+	}
+
+	and := make(sq.And, 0, 7)
+
+	if len(filter.IDs) > 0 {
+		and = append(and, sq.Eq{"id": filter.IDs})
+	}
+
+	if len(filter.ServerIDs) > 0 {
+		and = append(and, sq.Eq{"server_id": filter.ServerIDs})
+	}
+
+	if len(filter.UniqIDs) > 0 {
+		and = append(and, sq.Eq{"uniq_id": filter.UniqIDs})
+	}
+
+	if len(filter.Names) > 0 {
+		and = append(and, sq.Eq{"name": filter.Names})
+	}
+
+	if filter.Active != nil {
+		if *filter.Active {
+			and = append(and, sq.Eq{"left_at": nil})
+		} else {
+			and = append(and, sq.NotEq{"left_at": nil})
+		}
+	}
+
+	if filter.ActiveFrom != nil {
+		and = append(and, sq.Or{
+			sq.Eq{"left_at": nil},
+			sq.GtOrEq{"left_at": formatStatTime(*filter.ActiveFrom)},
+		})
+	}
+
+	if filter.ActiveTo != nil {
+		and = append(and, sq.Lt{"joined_at": formatStatTime(*filter.ActiveTo)})
+	}
+
+	return and
+}
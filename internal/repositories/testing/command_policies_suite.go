@@ -0,0 +1,153 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandPolicyRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.CommandPolicyRepository
+
+	fn func(t *testing.T) repositories.CommandPolicyRepository
+}
+
+func NewCommandPolicyRepositorySuite(
+	fn func(t *testing.T) repositories.CommandPolicyRepository,
+) *CommandPolicyRepositorySuite {
+	return &CommandPolicyRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *CommandPolicyRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *CommandPolicyRepositorySuite) TestCommandPolicyRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_policy", func(t *testing.T) {
+		now := time.Now().UTC().Truncate(time.Second)
+
+		policy := &domain.CommandPolicy{
+			ServerID:    lo.ToPtr(uint(1)),
+			Role:        lo.ToPtr("moderator"),
+			Target:      domain.CommandPolicyTargetRcon,
+			Action:      domain.CommandPolicyActionDeny,
+			PatternType: domain.CommandPolicyPatternTypeGlob,
+			Pattern:     "rcon_password *",
+			Description: "Do not change the RCON password",
+			CreatedAt:   &now,
+			UpdatedAt:   &now,
+		}
+
+		require.NoError(t, s.repo.Save(ctx, policy))
+		assert.NotZero(t, policy.ID)
+
+		results, err := s.repo.Find(ctx, &filters.FindCommandPolicy{IDs: []uint{policy.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, lo.ToPtr(uint(1)), results[0].ServerID)
+		assert.Equal(t, lo.ToPtr("moderator"), results[0].Role)
+		assert.Equal(t, domain.CommandPolicyTargetRcon, results[0].Target)
+		assert.Equal(t, domain.CommandPolicyActionDeny, results[0].Action)
+		assert.Equal(t, domain.CommandPolicyPatternTypeGlob, results[0].PatternType)
+		assert.Equal(t, "rcon_password *", results[0].Pattern)
+		assert.Equal(t, "Do not change the RCON password", results[0].Description)
+		require.NotNil(t, results[0].CreatedAt)
+		assert.True(t, now.Equal(*results[0].CreatedAt))
+	})
+
+	s.T().Run("update_existing_policy", func(t *testing.T) {
+		policy := &domain.CommandPolicy{
+			Target:      domain.CommandPolicyTargetAll,
+			Action:      domain.CommandPolicyActionDeny,
+			PatternType: domain.CommandPolicyPatternTypeGlob,
+			Pattern:     "quit",
+		}
+
+		require.NoError(t, s.repo.Save(ctx, policy))
+		originalID := policy.ID
+
+		policy.PatternType = domain.CommandPolicyPatternTypeRegex
+		policy.Pattern = "^(quit|exit)$"
+
+		require.NoError(t, s.repo.Save(ctx, policy))
+		assert.Equal(t, originalID, policy.ID)
+
+		results, err := s.repo.Find(ctx, &filters.FindCommandPolicy{IDs: []uint{policy.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Nil(t, results[0].ServerID)
+		assert.Nil(t, results[0].Role)
+		assert.Equal(t, domain.CommandPolicyPatternTypeRegex, results[0].PatternType)
+		assert.Equal(t, "^(quit|exit)$", results[0].Pattern)
+	})
+}
+
+func (s *CommandPolicyRepositorySuite) TestCommandPolicyRepositoryFind() {
+	ctx := context.Background()
+
+	policies := []*domain.CommandPolicy{
+		{Pattern: "quit"},
+		{ServerID: lo.ToPtr(uint(1)), Pattern: "exit"},
+		{ServerID: lo.ToPtr(uint(2)), Pattern: "restart"},
+	}
+
+	for _, policy := range policies {
+		policy.Target = domain.CommandPolicyTargetAll
+		policy.Action = domain.CommandPolicyActionDeny
+		policy.PatternType = domain.CommandPolicyPatternTypeGlob
+		require.NoError(s.T(), s.repo.Save(ctx, policy))
+	}
+
+	s.T().Run("find_all", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	s.T().Run("find_by_server", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindCommandPolicy{ServerIDs: []uint{1}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "exit", results[0].Pattern)
+	})
+
+	s.T().Run("find_applied_to_server", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindCommandPolicy{AppliedToServerIDs: []uint{2}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "quit", results[0].Pattern)
+		assert.Equal(t, "restart", results[1].Pattern)
+	})
+}
+
+func (s *CommandPolicyRepositorySuite) TestCommandPolicyRepositoryDelete() {
+	ctx := context.Background()
+
+	policy := &domain.CommandPolicy{
+		Target:      domain.CommandPolicyTargetAll,
+		Action:      domain.CommandPolicyActionDeny,
+		PatternType: domain.CommandPolicyPatternTypeGlob,
+		Pattern:     "quit",
+	}
+	require.NoError(s.T(), s.repo.Save(ctx, policy))
+
+	require.NoError(s.T(), s.repo.Delete(ctx, policy.ID))
+
+	results, err := s.repo.Find(ctx, &filters.FindCommandPolicy{IDs: []uint{policy.ID}}, nil, nil)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), results)
+}
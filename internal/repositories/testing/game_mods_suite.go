@@ -100,6 +100,30 @@ func (s *GameModRepositorySuite) TestGameModRepositorySave() {
 		assert.Equal(t, "kickid {player}", *results[0].KickCmd)
 	})
 
+	s.T().Run("save_game_mod_with_protocol_settings", func(t *testing.T) {
+		gameMod := &domain.GameMod{
+			GameCode:        "cstrike",
+			Name:            "ReHLDS",
+			QueryProtocol:   lo.ToPtr("source"),
+			RconProtocol:    lo.ToPtr("goldsource"),
+			PlayerManager:   lo.ToPtr("goldsrc"),
+			QueryPortOffset: lo.ToPtr(0),
+			RconPortOffset:  lo.ToPtr(5),
+		}
+
+		err := s.repo.Save(ctx, gameMod)
+		require.NoError(t, err)
+
+		results, err := s.repo.Find(ctx, &filters.FindGameMod{IDs: []uint{gameMod.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, lo.ToPtr("source"), results[0].QueryProtocol)
+		assert.Equal(t, lo.ToPtr("goldsource"), results[0].RconProtocol)
+		assert.Equal(t, lo.ToPtr("goldsrc"), results[0].PlayerManager)
+		assert.Equal(t, lo.ToPtr(0), results[0].QueryPortOffset)
+		assert.Equal(t, lo.ToPtr(5), results[0].RconPortOffset)
+	})
+
 	s.T().Run("save_game_mod_with_nil_fields", func(t *testing.T) {
 		gameMod := &domain.GameMod{
 			GameCode: "minecraft",
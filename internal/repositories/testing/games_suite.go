@@ -92,6 +92,43 @@ func (s *GameRepositorySuite) TestGameRepositorySave() {
 		assert.Equal(t, lo.ToPtr(uint(440)), games[0].SteamAppIDWindows)
 		assert.Equal(t, lo.ToPtr("90 mod tf"), games[0].SteamAppSetConfig)
 	})
+
+	s.T().Run("save_with_protocol_settings", func(t *testing.T) {
+		game := &domain.Game{
+			Code:            "rust",
+			Name:            "Rust",
+			Engine:          "unity",
+			QueryProtocol:   lo.ToPtr("source"),
+			RconProtocol:    lo.ToPtr("source"),
+			PlayerManager:   lo.ToPtr("source"),
+			QueryPortOffset: lo.ToPtr(1),
+			RconPortOffset:  lo.ToPtr(-10),
+			Enabled:         1,
+		}
+
+		err := s.repo.Save(ctx, game)
+		require.NoError(t, err)
+
+		games, err := s.repo.Find(ctx, &filters.FindGame{Codes: []string{"rust"}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, games, 1)
+		assert.Equal(t, lo.ToPtr("source"), games[0].QueryProtocol)
+		assert.Equal(t, lo.ToPtr("source"), games[0].RconProtocol)
+		assert.Equal(t, lo.ToPtr("source"), games[0].PlayerManager)
+		assert.Equal(t, lo.ToPtr(1), games[0].QueryPortOffset)
+		assert.Equal(t, lo.ToPtr(-10), games[0].RconPortOffset)
+
+		game.QueryProtocol = nil
+		game.RconPortOffset = nil
+		require.NoError(t, s.repo.Save(ctx, game))
+
+		games, err = s.repo.Find(ctx, &filters.FindGame{Codes: []string{"rust"}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, games, 1)
+		assert.Nil(t, games[0].QueryProtocol)
+		assert.Nil(t, games[0].RconPortOffset)
+		assert.Equal(t, lo.ToPtr(1), games[0].QueryPortOffset)
+	})
 }
 
 func (s *GameRepositorySuite) TestGameRepositoryFindAll() {
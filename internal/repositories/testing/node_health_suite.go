@@ -0,0 +1,141 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type NodeHealthRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.NodeHealthRepository
+
+	fn func(t *testing.T) repositories.NodeHealthRepository
+}
+
+func NewNodeHealthRepositorySuite(
+	fn func(t *testing.T) repositories.NodeHealthRepository,
+) *NodeHealthRepositorySuite {
+	return &NodeHealthRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *NodeHealthRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *NodeHealthRepositorySuite) TestNodeHealthRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_health", func(t *testing.T) {
+		now := time.Now().UTC().Truncate(time.Second)
+
+		health := &domain.NodeHealth{
+			NodeID:     1,
+			Online:     true,
+			Latency:    15,
+			LastSeenAt: &now,
+			CheckedAt:  now,
+		}
+
+		require.NoError(t, s.repo.Save(ctx, health))
+
+		results, err := s.repo.Find(ctx, &filters.FindNodeHealth{NodeIDs: []uint{1}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(1), results[0].NodeID)
+		assert.True(t, results[0].Online)
+		assert.Equal(t, uint(15), results[0].Latency)
+		assert.Empty(t, results[0].Error)
+		require.NotNil(t, results[0].LastSeenAt)
+		assert.True(t, now.Equal(*results[0].LastSeenAt))
+		assert.True(t, now.Equal(results[0].CheckedAt))
+	})
+
+	s.T().Run("replace_existing_health", func(t *testing.T) {
+		lastSeenAt := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+		checkedAt := time.Now().UTC().Truncate(time.Second)
+
+		require.NoError(t, s.repo.Save(ctx, &domain.NodeHealth{
+			NodeID:     2,
+			Online:     true,
+			Latency:    20,
+			LastSeenAt: &lastSeenAt,
+			CheckedAt:  lastSeenAt,
+		}))
+
+		require.NoError(t, s.repo.Save(ctx, &domain.NodeHealth{
+			NodeID:     2,
+			Online:     false,
+			Error:      "connection refused",
+			LastSeenAt: &lastSeenAt,
+			CheckedAt:  checkedAt,
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindNodeHealth{NodeIDs: []uint{2}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Online)
+		assert.Equal(t, uint(0), results[0].Latency)
+		assert.Equal(t, "connection refused", results[0].Error)
+		require.NotNil(t, results[0].LastSeenAt)
+		assert.True(t, lastSeenAt.Equal(*results[0].LastSeenAt))
+		assert.True(t, checkedAt.Equal(results[0].CheckedAt))
+	})
+
+	s.T().Run("never_seen_node", func(t *testing.T) {
+		require.NoError(t, s.repo.Save(ctx, &domain.NodeHealth{
+			NodeID:    3,
+			Error:     "timeout",
+			CheckedAt: time.Now().UTC().Truncate(time.Second),
+		}))
+
+		results, err := s.repo.Find(ctx, &filters.FindNodeHealth{NodeIDs: []uint{3}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Nil(t, results[0].LastSeenAt)
+	})
+}
+
+func (s *NodeHealthRepositorySuite) TestNodeHealthRepositoryFind() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for _, nodeID := range []uint{3, 1, 2} {
+		require.NoError(s.T(), s.repo.Save(ctx, &domain.NodeHealth{
+			NodeID:    nodeID,
+			Online:    true,
+			CheckedAt: now,
+		}))
+	}
+
+	s.T().Run("find_all", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, uint(1), results[0].NodeID)
+		assert.Equal(t, uint(2), results[1].NodeID)
+		assert.Equal(t, uint(3), results[2].NodeID)
+	})
+
+	s.T().Run("find_by_node_ids", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindNodeHealth{NodeIDs: []uint{2, 3}}, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	s.T().Run("find_unknown_node", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindNodeHealth{NodeIDs: []uint{100}}, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
@@ -0,0 +1,170 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type NodeStatRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.NodeStatRepository
+
+	fn func(t *testing.T) repositories.NodeStatRepository
+}
+
+func NewNodeStatRepositorySuite(fn func(t *testing.T) repositories.NodeStatRepository) *NodeStatRepositorySuite {
+	return &NodeStatRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *NodeStatRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *NodeStatRepositorySuite) TestNodeStatRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_stat", func(t *testing.T) {
+		stat := &domain.NodeStat{
+			NodeID:  1,
+			Time:    time.Now().UTC().Truncate(time.Second),
+			Load:    domain.NodeLoadAverage{0.5, 0.25, 0.1},
+			RAM:     domain.NodeStatUsage{Used: 1 << 30, Total: 4 << 30},
+			CPU:     12.5,
+			Network: domain.NodeStatTraffic{RX: 1000, TX: 2000},
+			Ping:    15,
+			Disk:    domain.NodeStatUsage{Used: 10 << 30, Total: 100 << 30},
+		}
+
+		err := s.repo.Save(ctx, stat)
+		require.NoError(t, err)
+		assert.NotZero(t, stat.ID)
+
+		results, err := s.repo.Find(ctx, &filters.FindNodeStat{IDs: []uint{stat.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(1), results[0].NodeID)
+		assert.True(t, stat.Time.Equal(results[0].Time))
+		assert.Equal(t, stat.Load, results[0].Load)
+		assert.Equal(t, stat.RAM, results[0].RAM)
+		assert.InDelta(t, 12.5, float64(results[0].CPU), 0.001)
+		assert.Equal(t, stat.Network, results[0].Network)
+		assert.Equal(t, uint(15), results[0].Ping)
+		assert.Equal(t, stat.Disk, results[0].Disk)
+	})
+
+	s.T().Run("update_existing_stat", func(t *testing.T) {
+		stat := &domain.NodeStat{
+			NodeID: 2,
+			Time:   time.Now().UTC().Truncate(time.Second),
+			Ping:   10,
+		}
+
+		require.NoError(t, s.repo.Save(ctx, stat))
+		originalID := stat.ID
+
+		stat.Ping = 20
+
+		require.NoError(t, s.repo.Save(ctx, stat))
+		assert.Equal(t, originalID, stat.ID)
+
+		results, err := s.repo.Find(ctx, &filters.FindNodeStat{IDs: []uint{stat.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(20), results[0].Ping)
+	})
+}
+
+func (s *NodeStatRepositorySuite) TestNodeStatRepositoryFind() {
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	stats := []*domain.NodeStat{
+		{NodeID: 10, Time: now.Add(-3 * time.Hour), Ping: 1},
+		{NodeID: 10, Time: now.Add(-2 * time.Hour), Ping: 2},
+		{NodeID: 10, Time: now.Add(-time.Hour), Ping: 3},
+		{NodeID: 11, Time: now.Add(-time.Hour), Ping: 4},
+	}
+
+	for _, stat := range stats {
+		require.NoError(s.T(), s.repo.Save(ctx, stat))
+	}
+
+	s.T().Run("find_by_node_id", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindNodeStat{NodeIDs: []uint{10}}, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	s.T().Run("find_by_time_range", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindNodeStat{
+			NodeIDs:  []uint{10},
+			TimeFrom: lo.ToPtr(now.Add(-2 * time.Hour)),
+			TimeTo:   lo.ToPtr(now.Add(-time.Hour)),
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(2), results[0].Ping)
+	})
+
+	s.T().Run("find_with_order_by_time", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindNodeStat{NodeIDs: []uint{10}}, []filters.Sorting{
+			{Field: "time", Direction: filters.SortDirectionDesc},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, uint(3), results[0].Ping)
+		assert.Equal(t, uint(1), results[2].Ping)
+	})
+
+	s.T().Run("find_with_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindNodeStat{NodeIDs: []uint{10}}, nil, &filters.Pagination{
+			Limit: 2,
+		})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+}
+
+func (s *NodeStatRepositorySuite) TestNodeStatRepositoryDeleteMany() {
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	stats := []*domain.NodeStat{
+		{NodeID: 20, Time: now.Add(-100 * 24 * time.Hour)},
+		{NodeID: 20, Time: now.Add(-time.Hour)},
+		{NodeID: 21, Time: now.Add(-100 * 24 * time.Hour)},
+	}
+
+	for _, stat := range stats {
+		require.NoError(s.T(), s.repo.Save(ctx, stat))
+	}
+
+	err := s.repo.DeleteMany(ctx, &filters.FindNodeStat{
+		NodeIDs: []uint{20},
+		TimeTo:  lo.ToPtr(now.Add(-90 * 24 * time.Hour)),
+	})
+	require.NoError(s.T(), err)
+
+	results, err := s.repo.Find(ctx, &filters.FindNodeStat{NodeIDs: []uint{20}}, nil, nil)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	assert.Equal(s.T(), stats[1].ID, results[0].ID)
+
+	results, err = s.repo.Find(ctx, &filters.FindNodeStat{NodeIDs: []uint{21}}, nil, nil)
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), results, 1)
+}
@@ -0,0 +1,180 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PlayerBanRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.PlayerBanRepository
+
+	fn func(t *testing.T) repositories.PlayerBanRepository
+}
+
+func NewPlayerBanRepositorySuite(
+	fn func(t *testing.T) repositories.PlayerBanRepository,
+) *PlayerBanRepositorySuite {
+	return &PlayerBanRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *PlayerBanRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *PlayerBanRepositorySuite) TestPlayerBanRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_ban", func(t *testing.T) {
+		now := time.Now().UTC().Truncate(time.Second)
+
+		ban := &domain.PlayerBan{
+			ServerID:  1,
+			UniqID:    "STEAM_0:1:12345",
+			Name:      "Cheater",
+			Reason:    "wallhack",
+			Source:    domain.PlayerBanSourcePanel,
+			IssuerID:  lo.ToPtr(uint(3)),
+			CreatedAt: now,
+			ExpiresAt: lo.ToPtr(now.Add(time.Hour)),
+		}
+
+		err := s.repo.Save(ctx, ban)
+		require.NoError(t, err)
+		assert.NotZero(t, ban.ID)
+
+		results, err := s.repo.Find(ctx, &filters.FindPlayerBan{IDs: []uint{ban.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(1), results[0].ServerID)
+		assert.Equal(t, "STEAM_0:1:12345", results[0].UniqID)
+		assert.Equal(t, "Cheater", results[0].Name)
+		assert.Equal(t, "wallhack", results[0].Reason)
+		assert.Equal(t, domain.PlayerBanSourcePanel, results[0].Source)
+		assert.Equal(t, lo.ToPtr(uint(3)), results[0].IssuerID)
+		assert.True(t, now.Equal(results[0].CreatedAt))
+		require.NotNil(t, results[0].ExpiresAt)
+		assert.True(t, now.Add(time.Hour).Equal(*results[0].ExpiresAt))
+		assert.Nil(t, results[0].LiftedAt)
+		assert.Nil(t, results[0].LiftedBy)
+	})
+
+	s.T().Run("update_existing_ban", func(t *testing.T) {
+		now := time.Now().UTC().Truncate(time.Second)
+
+		ban := &domain.PlayerBan{
+			ServerID:  2,
+			Name:      "Griefer",
+			Source:    domain.PlayerBanSourceImport,
+			CreatedAt: now.Add(-time.Hour),
+		}
+
+		require.NoError(t, s.repo.Save(ctx, ban))
+		originalID := ban.ID
+
+		ban.Reason = "griefing"
+		ban.LiftedAt = lo.ToPtr(now)
+		ban.LiftedBy = lo.ToPtr(uint(1))
+
+		require.NoError(t, s.repo.Save(ctx, ban))
+		assert.Equal(t, originalID, ban.ID)
+
+		results, err := s.repo.Find(ctx, &filters.FindPlayerBan{IDs: []uint{ban.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "griefing", results[0].Reason)
+		assert.Nil(t, results[0].IssuerID)
+		assert.Nil(t, results[0].ExpiresAt)
+		require.NotNil(t, results[0].LiftedAt)
+		assert.True(t, now.Equal(*results[0].LiftedAt))
+		assert.Equal(t, lo.ToPtr(uint(1)), results[0].LiftedBy)
+	})
+}
+
+func (s *PlayerBanRepositorySuite) TestPlayerBanRepositoryFind() {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	bans := []*domain.PlayerBan{
+		// Permanent
+		{ServerID: 1, UniqID: "u1", Name: "One", CreatedAt: now.Add(-4 * time.Hour)},
+		// Expired, not lifted yet
+		{ServerID: 1, UniqID: "u2", Name: "Two", CreatedAt: now.Add(-3 * time.Hour), ExpiresAt: lo.ToPtr(now.Add(-time.Hour))},
+		// Temporary, active
+		{ServerID: 1, UniqID: "u3", Name: "Three", CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: lo.ToPtr(now.Add(time.Hour))},
+		// Lifted
+		{ServerID: 2, UniqID: "u1", Name: "One", CreatedAt: now.Add(-time.Hour), LiftedAt: lo.ToPtr(now)},
+	}
+
+	for _, ban := range bans {
+		ban.Source = domain.PlayerBanSourcePanel
+		require.NoError(s.T(), s.repo.Save(ctx, ban))
+	}
+
+	s.T().Run("find_by_server", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPlayerBan{ServerIDs: []uint{1}}, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	s.T().Run("find_by_uniq_id_and_name", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPlayerBan{UniqIDs: []string{"u1"}}, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+
+		results, err = s.repo.Find(ctx, &filters.FindPlayerBan{Names: []string{"Three"}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "u3", results[0].UniqID)
+	})
+
+	s.T().Run("find_lifted", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPlayerBan{Lifted: lo.ToPtr(true)}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(2), results[0].ServerID)
+
+		results, err = s.repo.Find(ctx, &filters.FindPlayerBan{Lifted: lo.ToPtr(false)}, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	s.T().Run("find_active", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPlayerBan{ActiveAt: &now}, []filters.Sorting{
+			{Field: "id", Direction: filters.SortDirectionAsc},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "u1", results[0].UniqID)
+		assert.Equal(t, "u3", results[1].UniqID)
+	})
+
+	s.T().Run("find_expired", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPlayerBan{ExpiredAt: &now}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "u2", results[0].UniqID)
+	})
+
+	s.T().Run("order_and_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, []filters.Sorting{
+			{Field: "created_at", Direction: filters.SortDirectionDesc},
+		}, &filters.Pagination{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, uint(2), results[0].ServerID)
+		assert.Equal(t, "u3", results[1].UniqID)
+	})
+}
@@ -0,0 +1,174 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PlayerSessionRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.PlayerSessionRepository
+
+	fn func(t *testing.T) repositories.PlayerSessionRepository
+}
+
+func NewPlayerSessionRepositorySuite(
+	fn func(t *testing.T) repositories.PlayerSessionRepository,
+) *PlayerSessionRepositorySuite {
+	return &PlayerSessionRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *PlayerSessionRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *PlayerSessionRepositorySuite) TestPlayerSessionRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_session", func(t *testing.T) {
+		now := time.Now().UTC().Truncate(time.Second)
+
+		session := &domain.PlayerSession{
+			ServerID:   1,
+			UniqID:     "STEAM_0:1:12345",
+			Name:       "Player",
+			JoinedAt:   now.Add(-time.Hour),
+			LastSeenAt: now,
+		}
+
+		err := s.repo.Save(ctx, session)
+		require.NoError(t, err)
+		assert.NotZero(t, session.ID)
+
+		results, err := s.repo.Find(ctx, &filters.FindPlayerSession{IDs: []uint{session.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(1), results[0].ServerID)
+		assert.Equal(t, "STEAM_0:1:12345", results[0].UniqID)
+		assert.Equal(t, "Player", results[0].Name)
+		assert.True(t, session.JoinedAt.Equal(results[0].JoinedAt))
+		assert.True(t, session.LastSeenAt.Equal(results[0].LastSeenAt))
+		assert.Nil(t, results[0].LeftAt)
+	})
+
+	s.T().Run("update_existing_session", func(t *testing.T) {
+		now := time.Now().UTC().Truncate(time.Second)
+
+		session := &domain.PlayerSession{
+			ServerID:   2,
+			Name:       "Anonymous",
+			JoinedAt:   now.Add(-time.Hour),
+			LastSeenAt: now.Add(-time.Minute),
+		}
+
+		require.NoError(t, s.repo.Save(ctx, session))
+		originalID := session.ID
+
+		session.UniqID = "76561197960287930"
+		session.LastSeenAt = now
+		session.LeftAt = lo.ToPtr(now)
+
+		require.NoError(t, s.repo.Save(ctx, session))
+		assert.Equal(t, originalID, session.ID)
+
+		results, err := s.repo.Find(ctx, &filters.FindPlayerSession{IDs: []uint{session.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "76561197960287930", results[0].UniqID)
+		require.NotNil(t, results[0].LeftAt)
+		assert.True(t, now.Equal(*results[0].LeftAt))
+		assert.True(t, now.Equal(results[0].LastSeenAt))
+	})
+}
+
+func (s *PlayerSessionRepositorySuite) TestPlayerSessionRepositoryFind() {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Hour).Add(-24 * time.Hour)
+
+	sessions := []*domain.PlayerSession{
+		// Left before the range
+		{ServerID: 1, UniqID: "u1", Name: "One", JoinedAt: base, LeftAt: lo.ToPtr(base.Add(time.Hour))},
+		// Overlaps the range start
+		{ServerID: 1, UniqID: "u2", Name: "Two", JoinedAt: base.Add(time.Hour), LeftAt: lo.ToPtr(base.Add(3 * time.Hour))},
+		// Still active
+		{ServerID: 1, UniqID: "u1", Name: "One", JoinedAt: base.Add(4 * time.Hour)},
+		// Joined after the range
+		{ServerID: 2, UniqID: "u1", Name: "Renamed", JoinedAt: base.Add(10 * time.Hour)},
+	}
+
+	for _, session := range sessions {
+		session.LastSeenAt = session.JoinedAt
+		require.NoError(s.T(), s.repo.Save(ctx, session))
+	}
+
+	s.T().Run("find_by_server", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPlayerSession{ServerIDs: []uint{1}}, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	s.T().Run("find_by_uniq_id", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPlayerSession{UniqIDs: []string{"u1"}}, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	s.T().Run("find_by_name", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPlayerSession{Names: []string{"Renamed"}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(2), results[0].ServerID)
+	})
+
+	s.T().Run("find_active", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPlayerSession{
+			ServerIDs: []uint{1},
+			Active:    lo.ToPtr(true),
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, sessions[2].ID, results[0].ID)
+
+		results, err = s.repo.Find(ctx, &filters.FindPlayerSession{
+			ServerIDs: []uint{1},
+			Active:    lo.ToPtr(false),
+		}, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	s.T().Run("find_overlapping_time_range", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindPlayerSession{
+			ActiveFrom: lo.ToPtr(base.Add(2 * time.Hour)),
+			ActiveTo:   lo.ToPtr(base.Add(10 * time.Hour)),
+		}, []filters.Sorting{
+			{Field: "joined_at", Direction: filters.SortDirectionAsc},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, sessions[1].ID, results[0].ID)
+		assert.Equal(t, sessions[2].ID, results[1].ID)
+	})
+
+	s.T().Run("find_with_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, nil, []filters.Sorting{
+			{Field: "joined_at", Direction: filters.SortDirectionDesc},
+		}, &filters.Pagination{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, sessions[3].ID, results[0].ID)
+		assert.Equal(t, sessions[2].ID, results[1].ID)
+	})
+}
@@ -0,0 +1,197 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerStatRepositorySuite struct {
+	suite.Suite
+
+	repo repositories.ServerStatRepository
+
+	fn func(t *testing.T) repositories.ServerStatRepository
+}
+
+func NewServerStatRepositorySuite(fn func(t *testing.T) repositories.ServerStatRepository) *ServerStatRepositorySuite {
+	return &ServerStatRepositorySuite{
+		fn: fn,
+	}
+}
+
+func (s *ServerStatRepositorySuite) SetupTest() {
+	s.repo = s.fn(s.T())
+}
+
+func (s *ServerStatRepositorySuite) TestServerStatRepositorySave() {
+	ctx := context.Background()
+
+	s.T().Run("insert_new_stat", func(t *testing.T) {
+		stat := &domain.ServerStat{
+			ServerID:      1,
+			Time:          time.Now().UTC().Truncate(time.Second),
+			Online:        true,
+			PlayersNum:    5,
+			MaxPlayersNum: 32,
+			Kind:          domain.ServerStatKindQuery,
+		}
+
+		err := s.repo.Save(ctx, stat)
+		require.NoError(t, err)
+		assert.NotZero(t, stat.ID)
+
+		results, err := s.repo.Find(ctx, &filters.FindServerStat{IDs: []uint{stat.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, uint(1), results[0].ServerID)
+		assert.True(t, results[0].Online)
+		assert.Equal(t, 5, results[0].PlayersNum)
+		assert.Equal(t, 32, results[0].MaxPlayersNum)
+		assert.Equal(t, domain.ServerStatResolutionRaw, results[0].Resolution)
+		assert.Equal(t, domain.ServerStatKindQuery, results[0].Kind)
+		assert.True(t, stat.Time.Equal(results[0].Time))
+	})
+
+	s.T().Run("update_existing_stat", func(t *testing.T) {
+		stat := &domain.ServerStat{
+			ServerID:   2,
+			Time:       time.Now().UTC().Truncate(time.Hour),
+			Resolution: domain.ServerStatResolutionHourly,
+			Online:     true,
+			PlayersNum: 1,
+		}
+
+		require.NoError(t, s.repo.Save(ctx, stat))
+		originalID := stat.ID
+
+		stat.PlayersNum = 10
+		stat.Online = false
+
+		require.NoError(t, s.repo.Save(ctx, stat))
+		assert.Equal(t, originalID, stat.ID)
+
+		results, err := s.repo.Find(ctx, &filters.FindServerStat{IDs: []uint{stat.ID}}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 10, results[0].PlayersNum)
+		assert.False(t, results[0].Online)
+		assert.Equal(t, domain.ServerStatResolutionHourly, results[0].Resolution)
+	})
+}
+
+func (s *ServerStatRepositorySuite) TestServerStatRepositoryFind() {
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	stats := []*domain.ServerStat{
+		{ServerID: 10, Time: now.Add(-3 * time.Hour), Resolution: domain.ServerStatResolutionHourly, PlayersNum: 1},
+		{ServerID: 10, Time: now.Add(-2 * time.Hour), PlayersNum: 2},
+		{ServerID: 10, Time: now.Add(-time.Hour), PlayersNum: 3},
+		{ServerID: 11, Time: now.Add(-time.Hour), PlayersNum: 4},
+		{ServerID: 12, Time: now.Add(-time.Hour), RAM: "512", Kind: domain.ServerStatKindResources},
+		{ServerID: 12, Time: now.Add(-time.Hour), PlayersNum: 5, Kind: domain.ServerStatKindQuery},
+	}
+
+	for _, stat := range stats {
+		require.NoError(s.T(), s.repo.Save(ctx, stat))
+	}
+
+	s.T().Run("find_by_server_id", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerStat{ServerIDs: []uint{10}}, nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+
+	s.T().Run("find_by_resolution", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerStat{
+			ServerIDs:   []uint{10},
+			Resolutions: []domain.ServerStatResolution{domain.ServerStatResolutionRaw},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.ElementsMatch(t, []int{2, 3}, lo.Map(results, func(stat domain.ServerStat, _ int) int {
+			return stat.PlayersNum
+		}))
+	})
+
+	s.T().Run("find_by_kind", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerStat{
+			ServerIDs: []uint{12},
+			Kinds:     []domain.ServerStatKind{domain.ServerStatKindQuery},
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 5, results[0].PlayersNum)
+	})
+
+	s.T().Run("find_by_time_range", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerStat{
+			ServerIDs: []uint{10},
+			TimeFrom:  lo.ToPtr(now.Add(-2 * time.Hour)),
+			TimeTo:    lo.ToPtr(now.Add(-time.Hour)),
+		}, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 2, results[0].PlayersNum)
+	})
+
+	s.T().Run("find_with_order_by_time", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerStat{ServerIDs: []uint{10}}, []filters.Sorting{
+			{Field: "time", Direction: filters.SortDirectionDesc},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, 3, results[0].PlayersNum)
+		assert.Equal(t, 1, results[2].PlayersNum)
+	})
+
+	s.T().Run("find_with_pagination", func(t *testing.T) {
+		results, err := s.repo.Find(ctx, &filters.FindServerStat{ServerIDs: []uint{10}}, nil, &filters.Pagination{
+			Limit: 2,
+		})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+}
+
+func (s *ServerStatRepositorySuite) TestServerStatRepositoryDeleteMany() {
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	stats := []*domain.ServerStat{
+		{ServerID: 20, Time: now.Add(-50 * time.Hour)},
+		{ServerID: 20, Time: now.Add(-49 * time.Hour)},
+		{ServerID: 20, Time: now.Add(-time.Hour)},
+		{ServerID: 21, Time: now.Add(-50 * time.Hour)},
+	}
+
+	for _, stat := range stats {
+		require.NoError(s.T(), s.repo.Save(ctx, stat))
+	}
+
+	err := s.repo.DeleteMany(ctx, &filters.FindServerStat{
+		ServerIDs: []uint{20},
+		TimeTo:    lo.ToPtr(now.Add(-48 * time.Hour)),
+	})
+	require.NoError(s.T(), err)
+
+	results, err := s.repo.Find(ctx, &filters.FindServerStat{ServerIDs: []uint{20}}, nil, nil)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), results, 1)
+	assert.Equal(s.T(), stats[2].ID, results[0].ID)
+
+	results, err = s.repo.Find(ctx, &filters.FindServerStat{ServerIDs: []uint{21}}, nil, nil)
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), results, 1)
+}
@@ -0,0 +1,268 @@
+package gameadmins
+
+import (
+	"strconv"
+	"strings"
+)
+
+// amxAccountFlagsSteamID are the account flags of the entries added by the panel:
+// c - the auth is a SteamID, e - the password is not checked.
+const amxAccountFlagsSteamID = "ce"
+
+type adminEntry struct {
+	Admin
+
+	// password is the optional SourceMod password or the AMX Mod X password, kept as is.
+	password string
+
+	// accountFlags are the AMX Mod X account flags, kept as is.
+	accountFlags string
+
+	// comment is the trailing comment of the line including the leading whitespace.
+	comment string
+}
+
+// fileLine is a line of the admins file. Lines without an admin entry are comments,
+// blank lines or lines that can't be parsed, they are written back unchanged.
+type fileLine struct {
+	raw   string
+	entry *adminEntry
+}
+
+// adminsFile is a parsed admins_simple.ini or users.ini file.
+type adminsFile struct {
+	format  Format
+	lines   []fileLine
+	newline string
+}
+
+func parseAdminsFile(format Format, data []byte) *adminsFile {
+	content := string(data)
+
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+
+	rawLines := strings.Split(content, "\n")
+
+	file := &adminsFile{
+		format:  format,
+		lines:   make([]fileLine, 0, len(rawLines)),
+		newline: newline,
+	}
+
+	for _, raw := range rawLines {
+		file.lines = append(file.lines, fileLine{
+			raw:   raw,
+			entry: parseEntry(format, raw),
+		})
+	}
+
+	return file
+}
+
+func (f *adminsFile) Admins() []Admin {
+	admins := make([]Admin, 0)
+
+	for _, line := range f.lines {
+		if line.entry != nil {
+			admins = append(admins, line.entry.Admin)
+		}
+	}
+
+	return admins
+}
+
+// Set updates the flags and immunity of the admin with the same SteamID or adds a new admin
+// to the end of the file. It reports whether the admin was added.
+func (f *adminsFile) Set(admin Admin) bool {
+	for i := range f.lines {
+		entry := f.lines[i].entry
+		if entry == nil || !strings.EqualFold(entry.SteamID, admin.SteamID) {
+			continue
+		}
+
+		entry.Flags = admin.Flags
+		entry.Immunity = admin.Immunity
+		f.lines[i].raw = formatEntry(f.format, entry)
+
+		return false
+	}
+
+	entry := &adminEntry{Admin: admin}
+	if f.format == FormatAMXModX {
+		entry.accountFlags = amxAccountFlagsSteamID
+	}
+
+	line := fileLine{
+		raw:   formatEntry(f.format, entry),
+		entry: entry,
+	}
+
+	// Keep the trailing newline of the file after the new line.
+	insertAt := len(f.lines)
+	if insertAt > 0 && f.lines[insertAt-1].raw == "" {
+		insertAt--
+	}
+
+	f.lines = append(f.lines[:insertAt], append([]fileLine{line}, f.lines[insertAt:]...)...)
+
+	return true
+}
+
+// Remove removes the admin with the SteamID. It reports whether the admin was found.
+func (f *adminsFile) Remove(steamID string) bool {
+	found := false
+	lines := f.lines[:0]
+
+	for _, line := range f.lines {
+		if line.entry != nil && strings.EqualFold(line.entry.SteamID, steamID) {
+			found = true
+
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	f.lines = lines
+
+	return found
+}
+
+func (f *adminsFile) Bytes() []byte {
+	raws := make([]string, 0, len(f.lines))
+	for _, line := range f.lines {
+		raws = append(raws, line.raw)
+	}
+
+	return []byte(strings.Join(raws, f.newline))
+}
+
+// parseEntry parses the admin line, e.g.
+//
+//	"STEAM_0:1:16" "99:z" "password" // admins_simple.ini
+//	"STEAM_0:0:123456" "" "abcdefghijklmnopqrstu" "ce" ; users.ini
+//
+// It returns nil for comments, blank lines and malformed lines.
+func parseEntry(format Format, raw string) *adminEntry {
+	tokens, comment := tokenize(raw, format.commentPrefix())
+
+	switch format {
+	case FormatSourceMod:
+		return parseSourceModEntry(tokens, comment)
+	case FormatAMXModX:
+		return parseAMXModXEntry(tokens, comment)
+	default:
+		return nil
+	}
+}
+
+func parseSourceModEntry(tokens []string, comment string) *adminEntry {
+	if len(tokens) < 2 || len(tokens) > 3 || tokens[0] == "" {
+		return nil
+	}
+
+	entry := &adminEntry{
+		Admin:   Admin{SteamID: tokens[0], Flags: tokens[1]},
+		comment: comment,
+	}
+
+	if immunity, flags, ok := strings.Cut(tokens[1], ":"); ok {
+		if value, err := strconv.Atoi(immunity); err == nil {
+			entry.Immunity = value
+			entry.Flags = flags
+		}
+	}
+
+	if len(tokens) == 3 {
+		entry.password = tokens[2]
+	}
+
+	return entry
+}
+
+func parseAMXModXEntry(tokens []string, comment string) *adminEntry {
+	if len(tokens) != 4 || tokens[0] == "" {
+		return nil
+	}
+
+	return &adminEntry{
+		Admin:        Admin{SteamID: tokens[0], Flags: tokens[2]},
+		password:     tokens[1],
+		accountFlags: tokens[3],
+		comment:      comment,
+	}
+}
+
+func formatEntry(format Format, entry *adminEntry) string {
+	var tokens []string
+
+	switch format {
+	case FormatSourceMod:
+		flags := entry.Flags
+		if entry.Immunity > 0 {
+			flags = strconv.Itoa(entry.Immunity) + ":" + flags
+		}
+
+		tokens = []string{entry.SteamID, flags}
+		if entry.password != "" {
+			tokens = append(tokens, entry.password)
+		}
+	case FormatAMXModX:
+		tokens = []string{entry.SteamID, entry.password, entry.Flags, entry.accountFlags}
+	}
+
+	quoted := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		quoted = append(quoted, `"`+token+`"`)
+	}
+
+	return strings.Join(quoted, " ") + entry.comment
+}
+
+// tokenize splits the line into quoted or whitespace separated tokens.
+// The comment is the rest of the line starting with the whitespace before the comment prefix.
+func tokenize(raw, commentPrefix string) ([]string, string) {
+	var tokens []string
+
+	i := 0
+	for i < len(raw) {
+		start := i
+		for i < len(raw) && (raw[i] == ' ' || raw[i] == '\t') {
+			i++
+		}
+
+		if i == len(raw) {
+			break
+		}
+
+		if strings.HasPrefix(raw[i:], commentPrefix) {
+			return tokens, raw[start:]
+		}
+
+		if raw[i] == '"' {
+			end := strings.IndexByte(raw[i+1:], '"')
+			if end < 0 {
+				return nil, ""
+			}
+
+			tokens = append(tokens, raw[i+1:i+1+end])
+			i += end + 2
+
+			continue
+		}
+
+		end := i
+		for end < len(raw) && raw[end] != ' ' && raw[end] != '\t' && !strings.HasPrefix(raw[end:], commentPrefix) {
+			end++
+		}
+
+		tokens = append(tokens, raw[i:end])
+		i = end
+	}
+
+	return tokens, ""
+}
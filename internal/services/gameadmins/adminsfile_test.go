@@ -0,0 +1,148 @@
+package gameadmins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testAdminsSimple = `// Admins of the server
+"STEAM_0:1:16" "99:z" // owner
+"STEAM_0:0:42" "bce"
+"!127.0.0.1" "abc" "password"
+broken line with "quote
+
+"[U:1:100]" "@Full Admins"
+`
+
+const testUsersIni = "; Users configuration\r\n" +
+	"\"STEAM_0:0:123456\" \"\" \"abcdefghijklmnopqrstu\" \"ce\" ; owner\r\n" +
+	"\"loopback\" \"secret\" \"abc\" \"a\"\r\n"
+
+func TestParseAdminsFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		data   string
+		want   []Admin
+	}{
+		{
+			name:   "admins_simple",
+			format: FormatSourceMod,
+			data:   testAdminsSimple,
+			want: []Admin{
+				{SteamID: "STEAM_0:1:16", Flags: "z", Immunity: 99},
+				{SteamID: "STEAM_0:0:42", Flags: "bce"},
+				{SteamID: "!127.0.0.1", Flags: "abc"},
+				{SteamID: "[U:1:100]", Flags: "@Full Admins"},
+			},
+		},
+		{
+			name:   "users_ini",
+			format: FormatAMXModX,
+			data:   testUsersIni,
+			want: []Admin{
+				{SteamID: "STEAM_0:0:123456", Flags: "abcdefghijklmnopqrstu"},
+				{SteamID: "loopback", Flags: "abc"},
+			},
+		},
+		{
+			name:   "empty",
+			format: FormatSourceMod,
+			data:   "",
+			want:   []Admin{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := parseAdminsFile(tt.format, []byte(tt.data))
+
+			assert.Equal(t, tt.want, file.Admins())
+			assert.Equal(t, tt.data, string(file.Bytes()))
+		})
+	}
+}
+
+func TestAdminsFile_Set(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      Format
+		data        string
+		admin       Admin
+		wantCreated bool
+		want        string
+	}{
+		{
+			name:   "update sourcemod admin keeps comment",
+			format: FormatSourceMod,
+			data:   testAdminsSimple,
+			admin:  Admin{SteamID: "steam_0:1:16", Flags: "abc", Immunity: 50},
+			want: `// Admins of the server
+"STEAM_0:1:16" "50:abc" // owner
+"STEAM_0:0:42" "bce"
+"!127.0.0.1" "abc" "password"
+broken line with "quote
+
+"[U:1:100]" "@Full Admins"
+`,
+		},
+		{
+			name:        "add sourcemod admin",
+			format:      FormatSourceMod,
+			data:        "// Admins\n",
+			admin:       Admin{SteamID: "STEAM_0:1:7", Flags: "z"},
+			wantCreated: true,
+			want:        "// Admins\n\"STEAM_0:1:7\" \"z\"\n",
+		},
+		{
+			name:        "add to file without trailing newline",
+			format:      FormatSourceMod,
+			data:        "// Admins",
+			admin:       Admin{SteamID: "STEAM_0:1:7", Flags: "z", Immunity: 10},
+			wantCreated: true,
+			want:        "// Admins\n\"STEAM_0:1:7\" \"10:z\"",
+		},
+		{
+			name:   "update amx mod x admin keeps password and account flags",
+			format: FormatAMXModX,
+			data:   testUsersIni,
+			admin:  Admin{SteamID: "loopback", Flags: "bcd"},
+			want: "; Users configuration\r\n" +
+				"\"STEAM_0:0:123456\" \"\" \"abcdefghijklmnopqrstu\" \"ce\" ; owner\r\n" +
+				"\"loopback\" \"secret\" \"bcd\" \"a\"\r\n",
+		},
+		{
+			name:        "add amx mod x admin",
+			format:      FormatAMXModX,
+			data:        testUsersIni,
+			admin:       Admin{SteamID: "STEAM_0:1:5", Flags: "abc"},
+			wantCreated: true,
+			want: testUsersIni +
+				"\"STEAM_0:1:5\" \"\" \"abc\" \"ce\"\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := parseAdminsFile(tt.format, []byte(tt.data))
+
+			assert.Equal(t, tt.wantCreated, file.Set(tt.admin))
+			assert.Equal(t, tt.want, string(file.Bytes()))
+		})
+	}
+}
+
+func TestAdminsFile_Remove(t *testing.T) {
+	file := parseAdminsFile(FormatSourceMod, []byte(testAdminsSimple))
+
+	assert.True(t, file.Remove("STEAM_0:0:42"))
+	assert.False(t, file.Remove("STEAM_0:0:43"))
+	assert.Equal(t, `// Admins of the server
+"STEAM_0:1:16" "99:z" // owner
+"!127.0.0.1" "abc" "password"
+broken line with "quote
+
+"[U:1:100]" "@Full Admins"
+`, string(file.Bytes()))
+}
@@ -0,0 +1,336 @@
+// Package gameadmins manages the admins of SourceMod and AMX Mod X servers.
+// The admins are stored in addons/sourcemod/configs/admins_simple.ini and addons/amxmodx/configs/users.ini,
+// the files are edited through the daemon keeping comments and unknown lines. When the server is online,
+// the admins are reloaded with the sm_reloadadmins or amx_reloadadmins RCON command.
+package gameadmins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// MaxImmunity is the maximum SourceMod immunity level.
+const MaxImmunity = 99
+
+const adminsFilePerm = 0o644
+
+var (
+	ErrAdminsNotSupported   = errors.New("admin management is not supported for this game")
+	ErrInvalidSteamID       = errors.New("invalid steam id")
+	ErrInvalidFlags         = errors.New("invalid admin flags")
+	ErrInvalidImmunity      = errors.New("invalid immunity level")
+	ErrImmunityNotSupported = errors.New("immunity is not supported by amx mod x, use the a flag")
+	ErrAdminNotFound        = errors.New("admin not found")
+	ErrNodeNotFound         = errors.New("node not found")
+)
+
+var (
+	sourceModFlagsRegexp = regexp.MustCompile(`^[a-tz]+$`)
+	amxModXFlagsRegexp   = regexp.MustCompile(`^[a-uz]+$`)
+)
+
+// Format is the admins file format.
+type Format string
+
+const (
+	FormatSourceMod Format = "sourcemod"
+	FormatAMXModX   Format = "amxmodx"
+)
+
+func (f Format) fileName() string {
+	if f == FormatAMXModX {
+		return "addons/amxmodx/configs/users.ini"
+	}
+
+	return "addons/sourcemod/configs/admins_simple.ini"
+}
+
+func (f Format) commentPrefix() string {
+	if f == FormatAMXModX {
+		return ";"
+	}
+
+	return "//"
+}
+
+func (f Format) reloadCommand() string {
+	if f == FormatAMXModX {
+		return "amx_reloadadmins"
+	}
+
+	return "sm_reloadadmins"
+}
+
+type gameDir struct {
+	format Format
+	dir    string
+}
+
+// gameDirs are the game directories of the servers relative to the server directory.
+var gameDirs = map[string]gameDir{
+	"cs":        {format: FormatAMXModX, dir: "cstrike"},
+	"cstrike":   {format: FormatAMXModX, dir: "cstrike"},
+	"czero":     {format: FormatAMXModX, dir: "czero"},
+	"tfc":       {format: FormatAMXModX, dir: "tfc"},
+	"dod":       {format: FormatAMXModX, dir: "dod"},
+	"gearbox":   {format: FormatAMXModX, dir: "gearbox"},
+	"hl":        {format: FormatAMXModX, dir: "valve"},
+	"valve":     {format: FormatAMXModX, dir: "valve"},
+	"bms":       {format: FormatSourceMod, dir: "bms"},
+	"csgo":      {format: FormatSourceMod, dir: "csgo"},
+	"cssource":  {format: FormatSourceMod, dir: "cstrike"},
+	"cssv34":    {format: FormatSourceMod, dir: "cstrike"},
+	"dods":      {format: FormatSourceMod, dir: "dod"},
+	"garrysmod": {format: FormatSourceMod, dir: "garrysmod"},
+	"hl2mp":     {format: FormatSourceMod, dir: "hl2mp"},
+	"l4d":       {format: FormatSourceMod, dir: "left4dead"},
+	"l4d2":      {format: FormatSourceMod, dir: "left4dead2"},
+	"tf2":       {format: FormatSourceMod, dir: "tf"},
+}
+
+// AdminsFile returns the admins file format and path relative to the server directory.
+func AdminsFile(gameCode string) (Format, string, bool) {
+	dir, ok := gameDirs[gameCode]
+	if !ok {
+		return "", "", false
+	}
+
+	return dir.format, filepath.Join(dir.dir, dir.format.fileName()), true
+}
+
+type Admin struct {
+	SteamID string
+	Flags   string
+
+	// Immunity is the SourceMod immunity level, always zero for AMX Mod X.
+	Immunity int
+}
+
+type AdminList struct {
+	Format Format
+	Path   string
+	Admins []Admin
+}
+
+type ChangeResult struct {
+	// Created is set when a new admin was added to the file.
+	Created bool
+
+	// Reloaded is set when the admins were reloaded over RCON.
+	Reloaded bool
+
+	// Output is the output of the reload command.
+	Output string
+}
+
+type commandExecutor interface {
+	Execute(ctx context.Context, server *domain.Server, command string) (string, error)
+}
+
+type fileStorage interface {
+	ReadDir(ctx context.Context, node *domain.Node, directory string) ([]*daemon.FileInfo, error)
+	Download(ctx context.Context, node *domain.Node, filePath string) ([]byte, error)
+	Upload(ctx context.Context, node *domain.Node, filePath string, content []byte, perms os.FileMode) error
+}
+
+type Service struct {
+	nodeRepo repositories.NodeRepository
+	commands commandExecutor
+	files    fileStorage
+}
+
+func NewService(
+	nodeRepo repositories.NodeRepository,
+	commands commandExecutor,
+	files fileStorage,
+) *Service {
+	return &Service{
+		nodeRepo: nodeRepo,
+		commands: commands,
+		files:    files,
+	}
+}
+
+// ValidateAdmin checks the SteamID, flags and immunity of the admin for the file format.
+func ValidateAdmin(format Format, admin Admin) error {
+	if !players.IsValidSteamID(admin.SteamID) {
+		return ErrInvalidSteamID
+	}
+
+	switch format {
+	case FormatSourceMod:
+		if !sourceModFlagsRegexp.MatchString(admin.Flags) {
+			return ErrInvalidFlags
+		}
+
+		if admin.Immunity < 0 || admin.Immunity > MaxImmunity {
+			return ErrInvalidImmunity
+		}
+	case FormatAMXModX:
+		if !amxModXFlagsRegexp.MatchString(admin.Flags) {
+			return ErrInvalidFlags
+		}
+
+		if admin.Immunity != 0 {
+			return ErrImmunityNotSupported
+		}
+	}
+
+	return nil
+}
+
+// Admins returns the admins of the server admins file.
+func (s *Service) Admins(ctx context.Context, server *domain.Server) (*AdminList, error) {
+	format, filePath, ok := AdminsFile(server.GameID)
+	if !ok {
+		return nil, ErrAdminsNotSupported
+	}
+
+	node, err := s.findNode(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := s.readFile(ctx, node, server, format, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminList{
+		Format: format,
+		Path:   filePath,
+		Admins: file.Admins(),
+	}, nil
+}
+
+// Save adds the admin or updates the flags and immunity of the admin with the same SteamID.
+func (s *Service) Save(ctx context.Context, server *domain.Server, admin Admin) (*ChangeResult, error) {
+	format, filePath, ok := AdminsFile(server.GameID)
+	if !ok {
+		return nil, ErrAdminsNotSupported
+	}
+
+	if err := ValidateAdmin(format, admin); err != nil {
+		return nil, err
+	}
+
+	return s.change(ctx, server, format, filePath, func(file *adminsFile) (bool, error) {
+		return file.Set(admin), nil
+	})
+}
+
+// Remove removes the admin with the SteamID.
+func (s *Service) Remove(ctx context.Context, server *domain.Server, steamID string) (*ChangeResult, error) {
+	format, filePath, ok := AdminsFile(server.GameID)
+	if !ok {
+		return nil, ErrAdminsNotSupported
+	}
+
+	if !players.IsValidSteamID(steamID) {
+		return nil, ErrInvalidSteamID
+	}
+
+	return s.change(ctx, server, format, filePath, func(file *adminsFile) (bool, error) {
+		if !file.Remove(steamID) {
+			return false, ErrAdminNotFound
+		}
+
+		return false, nil
+	})
+}
+
+func (s *Service) change(
+	ctx context.Context,
+	server *domain.Server,
+	format Format,
+	filePath string,
+	apply func(file *adminsFile) (bool, error),
+) (*ChangeResult, error) {
+	node, err := s.findNode(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := s.readFile(ctx, node, server, format, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := apply(file)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.files.Upload(ctx, node, filepath.Join(node.WorkPath, server.Dir, filePath), file.Bytes(), adminsFilePerm)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to upload admins file")
+	}
+
+	result := &ChangeResult{Created: created}
+
+	if !server.IsOnline() {
+		return result, nil
+	}
+
+	result.Output, err = s.commands.Execute(ctx, server, format.reloadCommand())
+	if err != nil {
+		return nil, errors.WithMessage(err, "admins file saved, but failed to reload admins")
+	}
+
+	result.Reloaded = true
+
+	return result, nil
+}
+
+func (s *Service) findNode(ctx context.Context, server *domain.Server) (*domain.Node, error) {
+	nodes, err := s.nodeRepo.Find(ctx, filters.FindNodeByIDs(server.DSID), nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find node")
+	}
+
+	if len(nodes) == 0 {
+		return nil, ErrNodeNotFound
+	}
+
+	return &nodes[0], nil
+}
+
+func (s *Service) readFile(
+	ctx context.Context,
+	node *domain.Node,
+	server *domain.Server,
+	format Format,
+	filePath string,
+) (*adminsFile, error) {
+	fullPath := filepath.Join(node.WorkPath, server.Dir, filePath)
+
+	files, err := s.files.ReadDir(ctx, node, filepath.Dir(fullPath))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read admins file directory")
+	}
+
+	// A missing admins file is an empty list, it is created on the first save
+	exists := lo.ContainsBy(files, func(file *daemon.FileInfo) bool {
+		return file.Name == filepath.Base(fullPath) && file.Type != daemon.FileTypeDir
+	})
+	if !exists {
+		return parseAdminsFile(format, nil), nil
+	}
+
+	data, err := s.files.Download(ctx, node, fullPath)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to download admins file")
+	}
+
+	return parseAdminsFile(format, data), nil
+}
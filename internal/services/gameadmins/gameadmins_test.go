@@ -0,0 +1,314 @@
+package gameadmins
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAdminsSimplePath = "/srv/gameap/servers/test/tf/addons/sourcemod/configs/admins_simple.ini"
+	testUsersIniPath     = "/srv/gameap/servers/test/cstrike/addons/amxmodx/configs/users.ini"
+)
+
+type fakeExecutor struct {
+	commands []string
+	err      error
+}
+
+func (e *fakeExecutor) Execute(_ context.Context, _ *domain.Server, command string) (string, error) {
+	e.commands = append(e.commands, command)
+
+	return "Admin cache has been refreshed.", e.err
+}
+
+type fakeFiles struct {
+	files map[string][]byte
+}
+
+// ReadDir lists the files of the directory, the directories themselves are always found.
+func (f *fakeFiles) ReadDir(_ context.Context, _ *domain.Node, directory string) ([]*daemon.FileInfo, error) {
+	files := make([]*daemon.FileInfo, 0)
+
+	for filePath := range f.files {
+		if path.Dir(filePath) == directory {
+			files = append(files, &daemon.FileInfo{Name: path.Base(filePath), Type: daemon.FileTypeFile})
+		}
+	}
+
+	return files, nil
+}
+
+func (f *fakeFiles) Download(_ context.Context, _ *domain.Node, filePath string) ([]byte, error) {
+	data, ok := f.files[filePath]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+
+	return data, nil
+}
+
+func (f *fakeFiles) Upload(_ context.Context, _ *domain.Node, filePath string, content []byte, _ os.FileMode) error {
+	f.files[filePath] = content
+
+	return nil
+}
+
+type testEnv struct {
+	service  *Service
+	executor *fakeExecutor
+	files    *fakeFiles
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	nodeRepo := inmemory.NewNodeRepository()
+	require.NoError(t, nodeRepo.Save(context.Background(), &domain.Node{
+		ID:       1,
+		Enabled:  true,
+		Name:     "node",
+		WorkPath: "/srv/gameap",
+	}))
+
+	env := &testEnv{
+		executor: &fakeExecutor{},
+		files: &fakeFiles{files: map[string][]byte{
+			testAdminsSimplePath: []byte(testAdminsSimple),
+			testUsersIniPath:     []byte(testUsersIni),
+		}},
+	}
+
+	env.service = NewService(nodeRepo, env.executor, env.files)
+
+	return env
+}
+
+func newTestServer(gameID string, online bool) *domain.Server {
+	server := &domain.Server{
+		ID:            1,
+		Enabled:       true,
+		Installed:     domain.ServerInstalledStatusInstalled,
+		GameID:        gameID,
+		DSID:          1,
+		Dir:           "servers/test",
+		ProcessActive: online,
+	}
+
+	if online {
+		server.LastProcessCheck = lo.ToPtr(time.Now())
+	}
+
+	return server
+}
+
+func TestValidateAdmin(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		admin   Admin
+		wantErr error
+	}{
+		{
+			name:   "valid sourcemod admin",
+			format: FormatSourceMod,
+			admin:  Admin{SteamID: "STEAM_0:1:16", Flags: "z", Immunity: 99},
+		},
+		{
+			name:   "valid steam3 id",
+			format: FormatSourceMod,
+			admin:  Admin{SteamID: "[U:1:32]", Flags: "abc"},
+		},
+		{
+			name:    "invalid steam id",
+			format:  FormatSourceMod,
+			admin:   Admin{SteamID: "STEAM_0:1:16\" \"z", Flags: "z"},
+			wantErr: ErrInvalidSteamID,
+		},
+		{
+			name:    "invalid sourcemod flags",
+			format:  FormatSourceMod,
+			admin:   Admin{SteamID: "STEAM_0:1:16", Flags: "u"},
+			wantErr: ErrInvalidFlags,
+		},
+		{
+			name:    "empty flags",
+			format:  FormatAMXModX,
+			admin:   Admin{SteamID: "STEAM_0:1:16"},
+			wantErr: ErrInvalidFlags,
+		},
+		{
+			name:    "immunity too high",
+			format:  FormatSourceMod,
+			admin:   Admin{SteamID: "STEAM_0:1:16", Flags: "z", Immunity: 100},
+			wantErr: ErrInvalidImmunity,
+		},
+		{
+			name:    "amx mod x immunity",
+			format:  FormatAMXModX,
+			admin:   Admin{SteamID: "STEAM_0:1:16", Flags: "u", Immunity: 10},
+			wantErr: ErrImmunityNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAdmin(tt.format, tt.admin)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Admins(t *testing.T) {
+	env := newTestEnv(t)
+
+	list, err := env.service.Admins(context.Background(), newTestServer("cstrike", false))
+	require.NoError(t, err)
+
+	assert.Equal(t, FormatAMXModX, list.Format)
+	assert.Equal(t, "cstrike/addons/amxmodx/configs/users.ini", list.Path)
+	assert.Len(t, list.Admins, 2)
+
+	_, err = env.service.Admins(context.Background(), newTestServer("minecraft", false))
+	require.ErrorIs(t, err, ErrAdminsNotSupported)
+}
+
+func TestService_Admins_MissingFile(t *testing.T) {
+	env := newTestEnv(t)
+	delete(env.files.files, testUsersIniPath)
+
+	list, err := env.service.Admins(context.Background(), newTestServer("cstrike", false))
+	require.NoError(t, err)
+	assert.Empty(t, list.Admins)
+}
+
+func TestService_Save_MissingFile(t *testing.T) {
+	env := newTestEnv(t)
+	delete(env.files.files, testAdminsSimplePath)
+
+	result, err := env.service.Save(
+		context.Background(),
+		newTestServer("tf2", false),
+		Admin{SteamID: "STEAM_0:1:7", Flags: "z"},
+	)
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+	assert.Equal(t, "\"STEAM_0:1:7\" \"z\"\n", string(env.files.files[testAdminsSimplePath]))
+}
+
+func TestService_Save(t *testing.T) {
+	tests := []struct {
+		name         string
+		gameID       string
+		online       bool
+		admin        Admin
+		wantErr      error
+		wantCreated  bool
+		wantReloaded bool
+		wantCommands []string
+		wantFile     string
+		wantContent  string
+	}{
+		{
+			name:    "not supported",
+			gameID:  "minecraft",
+			admin:   Admin{SteamID: "STEAM_0:1:16", Flags: "z"},
+			wantErr: ErrAdminsNotSupported,
+		},
+		{
+			name:    "invalid admin",
+			gameID:  "tf2",
+			admin:   Admin{SteamID: "STEAM_0:1:16", Flags: "!"},
+			wantErr: ErrInvalidFlags,
+		},
+		{
+			name:         "add sourcemod admin and reload",
+			gameID:       "tf2",
+			online:       true,
+			admin:        Admin{SteamID: "STEAM_0:1:7", Flags: "bcd", Immunity: 5},
+			wantCreated:  true,
+			wantReloaded: true,
+			wantCommands: []string{"sm_reloadadmins"},
+			wantFile:     testAdminsSimplePath,
+			wantContent:  testAdminsSimple + "\"STEAM_0:1:7\" \"5:bcd\"\n",
+		},
+		{
+			name:     "update amx mod x admin offline",
+			gameID:   "cstrike",
+			admin:    Admin{SteamID: "STEAM_0:0:123456", Flags: "abc"},
+			wantFile: testUsersIniPath,
+			wantContent: "; Users configuration\r\n" +
+				"\"STEAM_0:0:123456\" \"\" \"abc\" \"ce\" ; owner\r\n" +
+				"\"loopback\" \"secret\" \"abc\" \"a\"\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+
+			result, err := env.service.Save(context.Background(), newTestServer(tt.gameID, tt.online), tt.admin)
+			assert.Equal(t, tt.wantCommands, env.executor.commands)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCreated, result.Created)
+			assert.Equal(t, tt.wantReloaded, result.Reloaded)
+			assert.Equal(t, tt.wantContent, string(env.files.files[tt.wantFile]))
+		})
+	}
+}
+
+func TestService_Remove(t *testing.T) {
+	env := newTestEnv(t)
+	server := newTestServer("cstrike", true)
+
+	result, err := env.service.Remove(context.Background(), server, "STEAM_0:0:123456")
+	require.NoError(t, err)
+	assert.True(t, result.Reloaded)
+	assert.Equal(t, []string{"amx_reloadadmins"}, env.executor.commands)
+	assert.Equal(t,
+		"; Users configuration\r\n\"loopback\" \"secret\" \"abc\" \"a\"\r\n",
+		string(env.files.files[testUsersIniPath]),
+	)
+
+	_, err = env.service.Remove(context.Background(), server, "STEAM_0:0:123456")
+	require.ErrorIs(t, err, ErrAdminNotFound)
+
+	_, err = env.service.Remove(context.Background(), server, "loopback")
+	require.ErrorIs(t, err, ErrInvalidSteamID)
+}
+
+func TestService_Save_ReloadFailed(t *testing.T) {
+	env := newTestEnv(t)
+	env.executor.err = errors.New("rcon connection refused")
+
+	_, err := env.service.Save(
+		context.Background(),
+		newTestServer("tf2", true),
+		Admin{SteamID: "STEAM_0:1:7", Flags: "z"},
+	)
+	require.ErrorContains(t, err, "failed to reload admins")
+	assert.Contains(t, string(env.files.files[testAdminsSimplePath]), `"STEAM_0:1:7" "z"`)
+}
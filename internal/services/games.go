@@ -48,7 +48,12 @@ func (s *GameUpgradeService) UpgradeGames(ctx context.Context) error {
 		for _, apiGame := range apiGames {
 			game := apiGame.ToDomainGame()
 
-			err := s.gameRepo.Save(ctx, game)
+			err := s.keepProtocolSettings(ctx, game)
+			if err != nil {
+				return err
+			}
+
+			err = s.gameRepo.Save(ctx, game)
 			if err != nil {
 				return errors.WithMessage(err, "failed to save game")
 			}
@@ -88,3 +93,24 @@ func (s *GameUpgradeService) UpgradeGames(ctx context.Context) error {
 
 	return err
 }
+
+// keepProtocolSettings copies protocol settings of the existing game,
+// they are configured by administrators and aren't provided by the global API.
+func (s *GameUpgradeService) keepProtocolSettings(ctx context.Context, game *domain.Game) error {
+	games, err := s.gameRepo.Find(ctx, filters.FindGameByCodes(game.Code), nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find game")
+	}
+
+	if len(games) == 0 {
+		return nil
+	}
+
+	game.QueryProtocol = games[0].QueryProtocol
+	game.RconProtocol = games[0].RconProtocol
+	game.PlayerManager = games[0].PlayerManager
+	game.QueryPortOffset = games[0].QueryPortOffset
+	game.RconPortOffset = games[0].RconPortOffset
+
+	return nil
+}
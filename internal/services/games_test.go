@@ -27,6 +27,7 @@ func TestGameUpgradeService_UpgradeGames(t *testing.T) {
 		name         string
 		apiGames     []domain.GlobalAPIGame
 		apiErr       error
+		setupGame    func(*inmemory.GameRepository)
 		setupGameMod func(*inmemory.GameModRepository)
 		wantErr      bool
 		errContains  string
@@ -232,6 +233,41 @@ func TestGameUpgradeService_UpgradeGames(t *testing.T) {
 				require.Len(t, cssMods, 1)
 			},
 		},
+		{
+			name: "keeps_game_protocol_settings",
+			apiGames: []domain.GlobalAPIGame{
+				{
+					Code:   "cstrike",
+					Name:   "Counter-Strike 1.6",
+					Engine: "GoldSource",
+				},
+			},
+			setupGame: func(repo *inmemory.GameRepository) {
+				_ = repo.Save(context.Background(), &domain.Game{
+					Code:           "cstrike",
+					Name:           "Counter-Strike",
+					Engine:         "GoldSource",
+					RconProtocol:   lo.ToPtr("source"),
+					PlayerManager:  lo.ToPtr("goldsrc"),
+					RconPortOffset: lo.ToPtr(1),
+				})
+			},
+			setupGameMod: func(_ *inmemory.GameModRepository) {},
+			wantErr:      false,
+			validate: func(t *testing.T, gameRepo *inmemory.GameRepository, _ *inmemory.GameModRepository) {
+				t.Helper()
+
+				games, err := gameRepo.FindAll(context.Background(), nil, nil)
+				require.NoError(t, err)
+				require.Len(t, games, 1)
+
+				assert.Equal(t, "Counter-Strike 1.6", games[0].Name)
+				assert.Equal(t, lo.ToPtr("source"), games[0].RconProtocol)
+				assert.Equal(t, lo.ToPtr("goldsrc"), games[0].PlayerManager)
+				assert.Equal(t, lo.ToPtr(1), games[0].RconPortOffset)
+				assert.Nil(t, games[0].QueryProtocol)
+			},
+		},
 		{
 			name:         "api_returns_error",
 			apiGames:     nil,
@@ -289,6 +325,9 @@ func TestGameUpgradeService_UpgradeGames(t *testing.T) {
 			gameRepo := inmemory.NewGameRepository()
 			gameModRepo := inmemory.NewGameModRepository()
 
+			if tt.setupGame != nil {
+				tt.setupGame(gameRepo)
+			}
 			tt.setupGameMod(gameModRepo)
 
 			mockAPI := &mockGlobalAPIService{
@@ -0,0 +1,375 @@
+// Package minecraftaccess manages the whitelist and operators of Minecraft servers.
+// Changes are made with RCON commands when the server is online, the server saves the files itself.
+// When the server is offline, whitelist.json and ops.json are edited through the daemon.
+package minecraftaccess
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// DefaultOperatorLevel is the permission level of operators added when the server is offline.
+const DefaultOperatorLevel = 4
+
+const listFilePerm = 0o644
+
+var (
+	ErrNotMinecraftServer = errors.New("server is not a minecraft server")
+	ErrInvalidPlayerName  = errors.New("invalid minecraft player name")
+	ErrEntryExists        = errors.New("player is already in the list")
+	ErrEntryNotFound      = errors.New("player is not in the list")
+	ErrNodeNotFound       = errors.New("node not found")
+	errInvalidList        = errors.New("invalid list file")
+)
+
+// List is the access list of the server.
+type List string
+
+const (
+	ListWhitelist List = "whitelist"
+	ListOperators List = "ops"
+)
+
+func (l List) IsValid() bool {
+	return l == ListWhitelist || l == ListOperators
+}
+
+// FileName returns the list file relative to the server directory.
+func (l List) FileName() string {
+	return string(l) + ".json"
+}
+
+func (l List) addCommand(name string) string {
+	if l == ListOperators {
+		return "op " + name
+	}
+
+	return "whitelist add " + name
+}
+
+func (l List) removeCommand(name string) string {
+	if l == ListOperators {
+		return "deop " + name
+	}
+
+	return "whitelist remove " + name
+}
+
+// Entry is a whitelisted player or an operator. Level and BypassesPlayerLimit are set for operators only.
+type Entry struct {
+	UUID                string `json:"uuid"`
+	Name                string `json:"name"`
+	Level               int    `json:"level,omitempty"`
+	BypassesPlayerLimit bool   `json:"bypassesPlayerLimit,omitempty"`
+}
+
+// operatorEntry keeps all fields of ops.json entries when the file is written.
+type operatorEntry struct {
+	UUID                string `json:"uuid"`
+	Name                string `json:"name"`
+	Level               int    `json:"level"`
+	BypassesPlayerLimit bool   `json:"bypassesPlayerLimit"`
+}
+
+// ChangeMethod is the way the list was changed.
+type ChangeMethod string
+
+const (
+	ChangeMethodRcon ChangeMethod = "rcon"
+	ChangeMethodFile ChangeMethod = "file"
+)
+
+type ChangeResult struct {
+	Method ChangeMethod
+
+	// Output is the RCON command output, empty for file changes.
+	Output string
+}
+
+type commandExecutor interface {
+	Execute(ctx context.Context, server *domain.Server, command string) (string, error)
+}
+
+type gameFinder interface {
+	FindServerGame(ctx context.Context, server *domain.Server) (*domain.Game, error)
+}
+
+type fileStorage interface {
+	ReadDir(ctx context.Context, node *domain.Node, directory string) ([]*daemon.FileInfo, error)
+	Download(ctx context.Context, node *domain.Node, filePath string) ([]byte, error)
+	Upload(ctx context.Context, node *domain.Node, filePath string, content []byte, perms os.FileMode) error
+}
+
+type Service struct {
+	nodeRepo repositories.NodeRepository
+	games    gameFinder
+	commands commandExecutor
+	files    fileStorage
+	profiles ProfileResolver
+}
+
+func NewService(
+	nodeRepo repositories.NodeRepository,
+	games gameFinder,
+	commands commandExecutor,
+	files fileStorage,
+	profiles ProfileResolver,
+) *Service {
+	return &Service{
+		nodeRepo: nodeRepo,
+		games:    games,
+		commands: commands,
+		files:    files,
+		profiles: profiles,
+	}
+}
+
+// Entries returns the entries of the list file.
+func (s *Service) Entries(ctx context.Context, server *domain.Server, list List) ([]Entry, error) {
+	if err := s.checkServer(ctx, server); err != nil {
+		return nil, err
+	}
+
+	node, err := s.findNode(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.readList(ctx, node, server, list)
+}
+
+// Add adds the player to the list. The player UUID is resolved when the list file is edited.
+func (s *Service) Add(ctx context.Context, server *domain.Server, list List, name string) (*ChangeResult, error) {
+	if err := s.checkChange(ctx, server, name); err != nil {
+		return nil, err
+	}
+
+	if server.IsOnline() {
+		return s.execute(ctx, server, list.addCommand(name))
+	}
+
+	node, err := s.findNode(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.readList(ctx, node, server, list)
+	if err != nil {
+		return nil, err
+	}
+
+	if lo.ContainsBy(entries, func(entry Entry) bool { return strings.EqualFold(entry.Name, name) }) {
+		return nil, ErrEntryExists
+	}
+
+	profile, err := s.profiles.ResolveProfile(ctx, name)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to resolve player %s", name)
+	}
+
+	if lo.ContainsBy(entries, func(entry Entry) bool { return strings.EqualFold(entry.UUID, profile.UUID) }) {
+		return nil, ErrEntryExists
+	}
+
+	entry := Entry{
+		UUID: profile.UUID,
+		Name: profile.Name,
+	}
+
+	if list == ListOperators {
+		entry.Level = DefaultOperatorLevel
+	}
+
+	if err = s.writeList(ctx, node, server, list, append(entries, entry)); err != nil {
+		return nil, err
+	}
+
+	return &ChangeResult{Method: ChangeMethodFile}, nil
+}
+
+// Remove removes the player from the list.
+func (s *Service) Remove(ctx context.Context, server *domain.Server, list List, name string) (*ChangeResult, error) {
+	if err := s.checkChange(ctx, server, name); err != nil {
+		return nil, err
+	}
+
+	if server.IsOnline() {
+		return s.execute(ctx, server, list.removeCommand(name))
+	}
+
+	node, err := s.findNode(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.readList(ctx, node, server, list)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := lo.Filter(entries, func(entry Entry, _ int) bool {
+		return !strings.EqualFold(entry.Name, name)
+	})
+
+	if len(remaining) == len(entries) {
+		return nil, ErrEntryNotFound
+	}
+
+	if err = s.writeList(ctx, node, server, list, remaining); err != nil {
+		return nil, err
+	}
+
+	return &ChangeResult{Method: ChangeMethodFile}, nil
+}
+
+func (s *Service) checkChange(ctx context.Context, server *domain.Server, name string) error {
+	if !players.IsValidMinecraftName(name) {
+		return ErrInvalidPlayerName
+	}
+
+	return s.checkServer(ctx, server)
+}
+
+func (s *Service) checkServer(ctx context.Context, server *domain.Server) error {
+	game, err := s.games.FindServerGame(ctx, server)
+	if err != nil {
+		return err
+	}
+
+	manager, err := players.NewPlayerManager(lo.FromPtr(game.PlayerManager), game.Code)
+	if err != nil {
+		return ErrNotMinecraftServer
+	}
+
+	if _, ok := manager.(*players.MinecraftPlayerManager); !ok {
+		return ErrNotMinecraftServer
+	}
+
+	return nil
+}
+
+func (s *Service) execute(ctx context.Context, server *domain.Server, command string) (*ChangeResult, error) {
+	output, err := s.commands.Execute(ctx, server, command)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChangeResult{
+		Method: ChangeMethodRcon,
+		Output: output,
+	}, nil
+}
+
+func (s *Service) findNode(ctx context.Context, server *domain.Server) (*domain.Node, error) {
+	nodes, err := s.nodeRepo.Find(ctx, filters.FindNodeByIDs(server.DSID), nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find node")
+	}
+
+	if len(nodes) == 0 {
+		return nil, ErrNodeNotFound
+	}
+
+	return &nodes[0], nil
+}
+
+func listPath(node *domain.Node, server *domain.Server, list List) string {
+	return filepath.Join(node.WorkPath, server.Dir, list.FileName())
+}
+
+func (s *Service) readList(
+	ctx context.Context,
+	node *domain.Node,
+	server *domain.Server,
+	list List,
+) ([]Entry, error) {
+	fullPath := listPath(node, server, list)
+
+	files, err := s.files.ReadDir(ctx, node, filepath.Dir(fullPath))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read server directory")
+	}
+
+	// A missing list file is an empty list, it is created on the first change
+	exists := lo.ContainsBy(files, func(file *daemon.FileInfo) bool {
+		return file.Name == list.FileName() && file.Type != daemon.FileTypeDir
+	})
+	if !exists {
+		return make([]Entry, 0), nil
+	}
+
+	data, err := s.files.Download(ctx, node, fullPath)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to download %s", list.FileName())
+	}
+
+	entries, err := parseList(data)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to parse %s", list.FileName())
+	}
+
+	return entries, nil
+}
+
+func (s *Service) writeList(
+	ctx context.Context,
+	node *domain.Node,
+	server *domain.Server,
+	list List,
+	entries []Entry,
+) error {
+	data, err := marshalList(list, entries)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to marshal %s", list.FileName())
+	}
+
+	err = s.files.Upload(ctx, node, listPath(node, server, list), data, listFilePerm)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to upload %s", list.FileName())
+	}
+
+	return nil
+}
+
+func parseList(data []byte) ([]Entry, error) {
+	entries := make([]Entry, 0)
+
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return entries, nil
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.WithMessage(errInvalidList, err.Error())
+	}
+
+	return entries, nil
+}
+
+// marshalList formats the list the same way the server does.
+func marshalList(list List, entries []Entry) ([]byte, error) {
+	var value any = entries
+
+	if list == ListOperators {
+		value = lo.Map(entries, func(entry Entry, _ int) operatorEntry {
+			return operatorEntry(entry)
+		})
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,384 @@
+package minecraftaccess
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testWhitelistPath = "/srv/gameap/servers/mc/whitelist.json"
+	testOpsPath       = "/srv/gameap/servers/mc/ops.json"
+)
+
+const testWhitelist = `[
+  {
+    "uuid": "069a79f4-44e9-4726-a5be-fca90e38aaf5",
+    "name": "Notch"
+  }
+]`
+
+const testOps = `[
+  {
+    "uuid": "853c80ef-3c37-49fd-aa49-938b674adae6",
+    "name": "jeb_",
+    "level": 3,
+    "bypassesPlayerLimit": true
+  }
+]`
+
+type fakeExecutor struct {
+	commands []string
+}
+
+func (e *fakeExecutor) Execute(_ context.Context, _ *domain.Server, command string) (string, error) {
+	e.commands = append(e.commands, command)
+
+	return "done", nil
+}
+
+type fakeGameFinder struct{}
+
+func (f *fakeGameFinder) FindServerGame(_ context.Context, server *domain.Server) (*domain.Game, error) {
+	return &domain.Game{Code: server.GameID}, nil
+}
+
+type fakeFiles struct {
+	files map[string][]byte
+}
+
+// ReadDir lists the files of the directory, the directories themselves are always found.
+func (f *fakeFiles) ReadDir(_ context.Context, _ *domain.Node, directory string) ([]*daemon.FileInfo, error) {
+	files := make([]*daemon.FileInfo, 0)
+
+	for filePath := range f.files {
+		if path.Dir(filePath) == directory {
+			files = append(files, &daemon.FileInfo{Name: path.Base(filePath), Type: daemon.FileTypeFile})
+		}
+	}
+
+	return files, nil
+}
+
+func (f *fakeFiles) Download(_ context.Context, _ *domain.Node, filePath string) ([]byte, error) {
+	data, ok := f.files[filePath]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+
+	return data, nil
+}
+
+func (f *fakeFiles) Upload(_ context.Context, _ *domain.Node, filePath string, content []byte, _ os.FileMode) error {
+	f.files[filePath] = content
+
+	return nil
+}
+
+type stubProfileResolver map[string]string
+
+func (r stubProfileResolver) ResolveProfile(_ context.Context, name string) (*Profile, error) {
+	id, ok := r[name]
+	if !ok {
+		return nil, ErrProfileNotFound
+	}
+
+	return &Profile{UUID: id, Name: name}, nil
+}
+
+type testEnv struct {
+	service  *Service
+	executor *fakeExecutor
+	files    *fakeFiles
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	nodeRepo := inmemory.NewNodeRepository()
+	require.NoError(t, nodeRepo.Save(context.Background(), &domain.Node{
+		ID:       1,
+		Enabled:  true,
+		Name:     "node",
+		WorkPath: "/srv/gameap",
+	}))
+
+	env := &testEnv{
+		executor: &fakeExecutor{},
+		files: &fakeFiles{files: map[string][]byte{
+			testWhitelistPath: []byte(testWhitelist),
+			testOpsPath:       []byte(testOps),
+		}},
+	}
+
+	env.service = NewService(
+		nodeRepo,
+		&fakeGameFinder{},
+		env.executor,
+		env.files,
+		stubProfileResolver{"Dinnerbone": "61699b2e-d327-4a01-9f1e-0ea8c3f06bc6"},
+	)
+
+	return env
+}
+
+func newTestServer(gameID string, online bool) *domain.Server {
+	server := &domain.Server{
+		ID:            1,
+		Enabled:       true,
+		Installed:     domain.ServerInstalledStatusInstalled,
+		GameID:        gameID,
+		DSID:          1,
+		Dir:           "servers/mc",
+		ProcessActive: online,
+	}
+
+	if online {
+		server.LastProcessCheck = lo.ToPtr(time.Now())
+	}
+
+	return server
+}
+
+func TestService_Entries(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	whitelist, err := env.service.Entries(ctx, newTestServer("minecraft", true), ListWhitelist)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{{UUID: "069a79f4-44e9-4726-a5be-fca90e38aaf5", Name: "Notch"}}, whitelist)
+
+	ops, err := env.service.Entries(ctx, newTestServer("minecraft", false), ListOperators)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{{
+		UUID:                "853c80ef-3c37-49fd-aa49-938b674adae6",
+		Name:                "jeb_",
+		Level:               3,
+		BypassesPlayerLimit: true,
+	}}, ops)
+
+	_, err = env.service.Entries(ctx, newTestServer("cstrike", false), ListWhitelist)
+	require.ErrorIs(t, err, ErrNotMinecraftServer)
+}
+
+func TestService_MissingListFile(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	server := newTestServer("minecraft", false)
+
+	delete(env.files.files, testWhitelistPath)
+
+	whitelist, err := env.service.Entries(ctx, server, ListWhitelist)
+	require.NoError(t, err)
+	assert.Empty(t, whitelist)
+
+	_, err = env.service.Remove(ctx, server, ListWhitelist, "Notch")
+	require.ErrorIs(t, err, ErrEntryNotFound)
+
+	result, err := env.service.Add(ctx, server, ListWhitelist, "Dinnerbone")
+	require.NoError(t, err)
+	assert.Equal(t, ChangeMethodFile, result.Method)
+	assert.Equal(t, `[
+  {
+    "uuid": "61699b2e-d327-4a01-9f1e-0ea8c3f06bc6",
+    "name": "Dinnerbone"
+  }
+]
+`, string(env.files.files[testWhitelistPath]))
+}
+
+func TestService_Add(t *testing.T) {
+	tests := []struct {
+		name         string
+		online       bool
+		list         List
+		player       string
+		wantErr      error
+		wantCommands []string
+		wantMethod   ChangeMethod
+		wantFile     string
+	}{
+		{
+			name:         "online_whitelist",
+			online:       true,
+			list:         ListWhitelist,
+			player:       "Dinnerbone",
+			wantCommands: []string{"whitelist add Dinnerbone"},
+			wantMethod:   ChangeMethodRcon,
+		},
+		{
+			name:         "online_operator",
+			online:       true,
+			list:         ListOperators,
+			player:       "Dinnerbone",
+			wantCommands: []string{"op Dinnerbone"},
+			wantMethod:   ChangeMethodRcon,
+		},
+		{
+			name:       "offline_whitelist",
+			list:       ListWhitelist,
+			player:     "Dinnerbone",
+			wantMethod: ChangeMethodFile,
+			wantFile: `[
+  {
+    "uuid": "069a79f4-44e9-4726-a5be-fca90e38aaf5",
+    "name": "Notch"
+  },
+  {
+    "uuid": "61699b2e-d327-4a01-9f1e-0ea8c3f06bc6",
+    "name": "Dinnerbone"
+  }
+]
+`,
+		},
+		{
+			name:       "offline_operator",
+			list:       ListOperators,
+			player:     "Dinnerbone",
+			wantMethod: ChangeMethodFile,
+			wantFile: `[
+  {
+    "uuid": "853c80ef-3c37-49fd-aa49-938b674adae6",
+    "name": "jeb_",
+    "level": 3,
+    "bypassesPlayerLimit": true
+  },
+  {
+    "uuid": "61699b2e-d327-4a01-9f1e-0ea8c3f06bc6",
+    "name": "Dinnerbone",
+    "level": 4,
+    "bypassesPlayerLimit": false
+  }
+]
+`,
+		},
+		{
+			name:    "offline_already_listed",
+			list:    ListWhitelist,
+			player:  "notch",
+			wantErr: ErrEntryExists,
+		},
+		{
+			name:    "offline_unknown_player",
+			list:    ListWhitelist,
+			player:  "Unknown",
+			wantErr: ErrProfileNotFound,
+		},
+		{
+			name:    "invalid_name",
+			online:  true,
+			list:    ListWhitelist,
+			player:  "Notch; stop",
+			wantErr: ErrInvalidPlayerName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+
+			result, err := env.service.Add(context.Background(), newTestServer("minecraft", tt.online), tt.list, tt.player)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, env.executor.commands)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMethod, result.Method)
+			assert.Equal(t, tt.wantCommands, env.executor.commands)
+
+			if tt.wantFile != "" {
+				filePath := testWhitelistPath
+				if tt.list == ListOperators {
+					filePath = testOpsPath
+				}
+
+				assert.Equal(t, tt.wantFile, string(env.files.files[filePath]))
+			}
+		})
+	}
+}
+
+func TestService_Remove(t *testing.T) {
+	tests := []struct {
+		name         string
+		online       bool
+		list         List
+		player       string
+		wantErr      error
+		wantCommands []string
+		wantFile     string
+	}{
+		{
+			name:         "online_whitelist",
+			online:       true,
+			list:         ListWhitelist,
+			player:       "Notch",
+			wantCommands: []string{"whitelist remove Notch"},
+		},
+		{
+			name:         "online_operator",
+			online:       true,
+			list:         ListOperators,
+			player:       "jeb_",
+			wantCommands: []string{"deop jeb_"},
+		},
+		{
+			name:     "offline_whitelist",
+			list:     ListWhitelist,
+			player:   "notch",
+			wantFile: "[]\n",
+		},
+		{
+			name:     "offline_operator",
+			list:     ListOperators,
+			player:   "jeb_",
+			wantFile: "[]\n",
+		},
+		{
+			name:    "offline_not_listed",
+			list:    ListOperators,
+			player:  "Notch",
+			wantErr: ErrEntryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+
+			_, err := env.service.Remove(context.Background(), newTestServer("minecraft", tt.online), tt.list, tt.player)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCommands, env.executor.commands)
+
+			if tt.wantFile != "" {
+				filePath := testWhitelistPath
+				if tt.list == ListOperators {
+					filePath = testOpsPath
+				}
+
+				assert.Equal(t, tt.wantFile, string(env.files.files[filePath]))
+			}
+		})
+	}
+}
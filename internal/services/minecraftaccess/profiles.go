@@ -0,0 +1,139 @@
+package minecraftaccess
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // offline UUIDs are MD5 based
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const DefaultMojangAPIURL = "https://api.mojang.com"
+
+// Profile resolver names, they are set with the MINECRAFT_PROFILE_RESOLVER option.
+const (
+	ResolverMojang  = "mojang"
+	ResolverOffline = "offline"
+)
+
+var ErrProfileNotFound = errors.New("minecraft player not found")
+
+// Profile is the Minecraft player account.
+type Profile struct {
+	UUID string
+	Name string
+}
+
+// ProfileResolver resolves player names to profiles.
+// It is used to write whitelist and operator entries when the server is offline.
+type ProfileResolver interface {
+	ResolveProfile(ctx context.Context, name string) (*Profile, error)
+}
+
+// NewProfileResolver returns the resolver by name, the Mojang resolver is used by default.
+func NewProfileResolver(name, mojangAPIURL string) ProfileResolver {
+	if name == ResolverOffline {
+		return NewOfflineProfileResolver()
+	}
+
+	return NewMojangProfileResolver(&http.Client{Timeout: 10 * time.Second}, mojangAPIURL)
+}
+
+// MojangProfileResolver resolves names of premium accounts with the Mojang API.
+type MojangProfileResolver struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewMojangProfileResolver(client *http.Client, baseURL string) *MojangProfileResolver {
+	if baseURL == "" {
+		baseURL = DefaultMojangAPIURL
+	}
+
+	return &MojangProfileResolver{
+		client:  client,
+		baseURL: baseURL,
+	}
+}
+
+type mojangProfile struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (r *MojangProfileResolver) ResolveProfile(ctx context.Context, name string) (*Profile, error) {
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, r.baseURL+"/users/profiles/minecraft/"+url.PathEscape(name), nil,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client.Do(req) //nolint:bodyclose
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			slog.Warn("failed to close response body", "error", err)
+		}
+	}(resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil, ErrProfileNotFound
+	default:
+		return nil, errors.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+
+	var profile mojangProfile
+	if err = json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response")
+	}
+
+	id, err := uuid.Parse(profile.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid profile id")
+	}
+
+	return &Profile{
+		UUID: id.String(),
+		Name: profile.Name,
+	}, nil
+}
+
+// OfflineProfileResolver resolves names the way servers with online-mode=false do:
+// the UUID is derived from the name.
+type OfflineProfileResolver struct{}
+
+func NewOfflineProfileResolver() *OfflineProfileResolver {
+	return &OfflineProfileResolver{}
+}
+
+func (r *OfflineProfileResolver) ResolveProfile(_ context.Context, name string) (*Profile, error) {
+	return &Profile{
+		UUID: OfflineUUID(name),
+		Name: name,
+	}, nil
+}
+
+// OfflineUUID returns the UUID of the player on servers with online-mode=false,
+// the same as Java UUID.nameUUIDFromBytes("OfflinePlayer:" + name).
+func OfflineUUID(name string) string {
+	sum := md5.Sum([]byte("OfflinePlayer:" + name)) //nolint:gosec
+
+	sum[6] = (sum[6] & 0x0f) | 0x30
+	sum[8] = (sum[8] & 0x3f) | 0x80
+
+	return uuid.UUID(sum).String()
+}
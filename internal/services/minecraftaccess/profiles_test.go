@@ -0,0 +1,42 @@
+package minecraftaccess
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMojangProfileResolver_ResolveProfile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/profiles/minecraft/notch":
+			_, _ = w.Write([]byte(`{"id":"069a79f444e94726a5befca90e38aaf5","name":"Notch"}`))
+		case "/users/profiles/minecraft/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewMojangProfileResolver(server.Client(), server.URL)
+
+	profile, err := resolver.ResolveProfile(context.Background(), "notch")
+	require.NoError(t, err)
+	assert.Equal(t, &Profile{UUID: "069a79f4-44e9-4726-a5be-fca90e38aaf5", Name: "Notch"}, profile)
+
+	_, err = resolver.ResolveProfile(context.Background(), "unknown")
+	require.ErrorIs(t, err, ErrProfileNotFound)
+
+	_, err = resolver.ResolveProfile(context.Background(), "broken")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestOfflineUUID(t *testing.T) {
+	assert.Equal(t, "b50ad385-829d-3141-a216-7e7d7539ba7f", OfflineUUID("Notch"))
+}
@@ -0,0 +1,39 @@
+package nodehealth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// StatusChangedEvent is raised when a node goes online or offline.
+type StatusChangedEvent struct {
+	NodeID uint
+	Online bool
+
+	// Error is the error of the failed check, empty when the node went online.
+	Error string
+	Time  time.Time
+}
+
+type EventHandler interface {
+	HandleStatusChanged(ctx context.Context, event StatusChangedEvent)
+}
+
+// LogEventHandler writes status changed events to the log.
+type LogEventHandler struct{}
+
+func (LogEventHandler) HandleStatusChanged(ctx context.Context, event StatusChangedEvent) {
+	if event.Online {
+		slog.InfoContext(ctx, "Node is online", slog.Uint64("node_id", uint64(event.NodeID)))
+
+		return
+	}
+
+	slog.WarnContext(
+		ctx,
+		"Node is offline",
+		slog.Uint64("node_id", uint64(event.NodeID)),
+		slog.String("error", event.Error),
+	)
+}
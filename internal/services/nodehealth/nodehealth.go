@@ -0,0 +1,209 @@
+// Package nodehealth periodically checks the daemon connection of nodes
+// and keeps the online state of nodes in the node_health table.
+package nodehealth
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultInterval = time.Minute
+	checkTimeout    = 15 * time.Second
+
+	// staleIntervals is the number of check intervals after which the state of a node is outdated,
+	// e.g. when the checker is disabled or the node has been disabled.
+	staleIntervals = 3
+)
+
+type versionChecker interface {
+	Version(ctx context.Context, node *domain.Node) (*daemon.NodeVersion, error)
+}
+
+// Checker requests the daemon version of every enabled node, saves the result
+// to the node health repository and raises events when nodes go online or offline.
+type Checker struct {
+	nodeRepo   repositories.NodeRepository
+	healthRepo repositories.NodeHealthRepository
+	status     versionChecker
+	interval   time.Duration
+	handlers   []EventHandler
+}
+
+func NewChecker(
+	nodeRepo repositories.NodeRepository,
+	healthRepo repositories.NodeHealthRepository,
+	status versionChecker,
+	interval time.Duration,
+	handlers ...EventHandler,
+) *Checker {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &Checker{
+		nodeRepo:   nodeRepo,
+		healthRepo: healthRepo,
+		status:     status,
+		interval:   interval,
+		handlers:   handlers,
+	}
+}
+
+// Run checks nodes until the context is canceled.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Check(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to check node health", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Node health checker stopped")
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check checks all enabled nodes once.
+func (c *Checker) Check(ctx context.Context) error {
+	nodes, err := c.nodeRepo.FindAll(ctx, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find nodes")
+	}
+
+	nodes = lo.Filter(nodes, func(node domain.Node, _ int) bool {
+		return node.Enabled
+	})
+
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	previous, err := c.healthRepo.Find(ctx, &filters.FindNodeHealth{
+		NodeIDs: lo.Map(nodes, func(node domain.Node, _ int) uint { return node.ID }),
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find node health")
+	}
+
+	previousByNode := lo.SliceToMap(previous, func(health domain.NodeHealth) (uint, domain.NodeHealth) {
+		return health.NodeID, health
+	})
+
+	wg := sync.WaitGroup{}
+
+	for i := range nodes {
+		node := &nodes[i]
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			var prev *domain.NodeHealth
+			if health, ok := previousByNode[node.ID]; ok {
+				prev = &health
+			}
+
+			err := c.checkNode(ctx, node, prev)
+			if err != nil && ctx.Err() == nil {
+				slog.WarnContext(
+					ctx,
+					"Failed to save node health",
+					slog.Uint64("node_id", uint64(node.ID)),
+					slog.String("error", err.Error()),
+				)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// Health returns the latest state of the node, nil when the node hasn't been checked
+// or the state is outdated.
+func (c *Checker) Health(ctx context.Context, nodeID uint) (*domain.NodeHealth, error) {
+	healths, err := c.healthRepo.Find(ctx, &filters.FindNodeHealth{NodeIDs: []uint{nodeID}}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find node health")
+	}
+
+	if len(healths) == 0 {
+		return nil, nil
+	}
+
+	if time.Since(healths[0].CheckedAt) > staleIntervals*c.interval {
+		return nil, nil
+	}
+
+	return &healths[0], nil
+}
+
+func (c *Checker) checkNode(ctx context.Context, node *domain.Node, prev *domain.NodeHealth) error {
+	checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	startedAt := time.Now()
+
+	_, err := c.status.Version(checkCtx, node)
+	if ctx.Err() != nil {
+		// The checker is stopping, the node state is unknown
+		return nil
+	}
+
+	health := &domain.NodeHealth{
+		NodeID:    node.ID,
+		CheckedAt: startedAt,
+	}
+
+	if prev != nil {
+		health.LastSeenAt = prev.LastSeenAt
+	}
+
+	if err != nil {
+		health.Error = err.Error()
+	} else {
+		health.Online = true
+		health.Latency = uint(time.Since(startedAt).Milliseconds()) //nolint:gosec // limited by the check timeout
+		health.LastSeenAt = &startedAt
+	}
+
+	if err = c.healthRepo.Save(ctx, health); err != nil {
+		return errors.WithMessage(err, "failed to save node health")
+	}
+
+	// The first check of a node only reports the node going offline
+	if (prev == nil && !health.Online) || (prev != nil && prev.Online != health.Online) {
+		c.raise(ctx, StatusChangedEvent{
+			NodeID: node.ID,
+			Online: health.Online,
+			Error:  health.Error,
+			Time:   startedAt,
+		})
+	}
+
+	return nil
+}
+
+func (c *Checker) raise(ctx context.Context, event StatusChangedEvent) {
+	for _, handler := range c.handlers {
+		handler.HandleStatusChanged(ctx, event)
+	}
+}
@@ -0,0 +1,164 @@
+package nodehealth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStatus struct {
+	mu      sync.Mutex
+	offline map[uint]bool
+	calls   []uint
+}
+
+func (s *fakeStatus) Version(_ context.Context, node *domain.Node) (*daemon.NodeVersion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, node.ID)
+
+	if s.offline[node.ID] {
+		return nil, errors.New("connection refused")
+	}
+
+	return &daemon.NodeVersion{Version: "3.2.0"}, nil
+}
+
+type recordingHandler struct {
+	mu     sync.Mutex
+	events []StatusChangedEvent
+}
+
+func (h *recordingHandler) HandleStatusChanged(_ context.Context, event StatusChangedEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.events = append(h.events, event)
+}
+
+func setupNodes(t *testing.T) *inmemory.NodeRepository {
+	t.Helper()
+
+	nodeRepo := inmemory.NewNodeRepository()
+
+	for _, node := range []*domain.Node{
+		{ID: 1, Enabled: true},
+		{ID: 2, Enabled: true},
+		{ID: 3, Enabled: false},
+	} {
+		require.NoError(t, nodeRepo.Save(context.Background(), node))
+	}
+
+	return nodeRepo
+}
+
+func TestChecker_Check(t *testing.T) {
+	ctx := context.Background()
+	nodeRepo := setupNodes(t)
+	healthRepo := inmemory.NewNodeHealthRepository()
+	status := &fakeStatus{offline: map[uint]bool{2: true}}
+	handler := &recordingHandler{}
+
+	checker := NewChecker(nodeRepo, healthRepo, status, 0, handler)
+
+	require.NoError(t, checker.Check(ctx))
+
+	assert.ElementsMatch(t, []uint{1, 2}, status.calls)
+
+	healths, err := healthRepo.Find(ctx, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, healths, 2)
+
+	assert.True(t, healths[0].Online)
+	assert.Empty(t, healths[0].Error)
+	require.NotNil(t, healths[0].LastSeenAt)
+
+	assert.False(t, healths[1].Online)
+	assert.Equal(t, "connection refused", healths[1].Error)
+	assert.Nil(t, healths[1].LastSeenAt)
+
+	// The first check only reports offline nodes
+	require.Len(t, handler.events, 1)
+	assert.Equal(t, uint(2), handler.events[0].NodeID)
+	assert.False(t, handler.events[0].Online)
+	assert.Equal(t, "connection refused", handler.events[0].Error)
+}
+
+func TestChecker_Check_Transitions(t *testing.T) {
+	ctx := context.Background()
+	nodeRepo := setupNodes(t)
+	healthRepo := inmemory.NewNodeHealthRepository()
+	status := &fakeStatus{offline: map[uint]bool{}}
+	handler := &recordingHandler{}
+
+	checker := NewChecker(nodeRepo, healthRepo, status, time.Minute, handler)
+
+	require.NoError(t, checker.Check(ctx))
+	assert.Empty(t, handler.events)
+
+	status.offline[1] = true
+	require.NoError(t, checker.Check(ctx))
+
+	// The state doesn't change, no new events
+	require.NoError(t, checker.Check(ctx))
+
+	healths, err := healthRepo.Find(ctx, &filters.FindNodeHealth{NodeIDs: []uint{1}}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, healths, 1)
+	assert.False(t, healths[0].Online)
+	assert.NotNil(t, healths[0].LastSeenAt, "last seen time is kept while the node is offline")
+
+	status.offline[1] = false
+	require.NoError(t, checker.Check(ctx))
+
+	require.Len(t, handler.events, 2)
+	assert.Equal(t, uint(1), handler.events[0].NodeID)
+	assert.False(t, handler.events[0].Online)
+	assert.Equal(t, uint(1), handler.events[1].NodeID)
+	assert.True(t, handler.events[1].Online)
+}
+
+func TestChecker_Health(t *testing.T) {
+	ctx := context.Background()
+	healthRepo := inmemory.NewNodeHealthRepository()
+	checker := NewChecker(inmemory.NewNodeRepository(), healthRepo, &fakeStatus{}, time.Minute)
+
+	require.NoError(t, healthRepo.Save(ctx, &domain.NodeHealth{NodeID: 1, CheckedAt: time.Now()}))
+	require.NoError(t, healthRepo.Save(ctx, &domain.NodeHealth{NodeID: 2, CheckedAt: time.Now().Add(-time.Hour)}))
+
+	tests := []struct {
+		name    string
+		nodeID  uint
+		wantNil bool
+	}{
+		{name: "recent_state", nodeID: 1},
+		{name: "outdated_state", nodeID: 2, wantNil: true},
+		{name: "unchecked_node", nodeID: 3, wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health, err := checker.Health(ctx, tt.nodeID)
+			require.NoError(t, err)
+
+			if tt.wantNil {
+				assert.Nil(t, health)
+
+				return
+			}
+
+			require.NotNil(t, health)
+			assert.Equal(t, tt.nodeID, health.NodeID)
+		})
+	}
+}
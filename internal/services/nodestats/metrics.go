@@ -0,0 +1,233 @@
+package nodestats
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const sectionPrefix = "::"
+
+const (
+	sectionLoadAvg = "loadavg"
+	sectionStat    = "stat"
+	sectionMemInfo = "meminfo"
+	sectionNetDev  = "netdev"
+	sectionDF      = "df"
+)
+
+// metricsScript prints procfs files and the disk usage of the work path ($0), each after a section marker.
+const metricsScript = "echo ::" + sectionLoadAvg + "; cat /proc/loadavg; " +
+	"echo ::" + sectionStat + "; head -n 1 /proc/stat; " +
+	"echo ::" + sectionMemInfo + "; cat /proc/meminfo; " +
+	"echo ::" + sectionNetDev + "; cat /proc/net/dev; " +
+	`echo ::` + sectionDF + `; df -P -k "$0"`
+
+var errNoMetrics = errors.New("no metrics in command output")
+
+// buildMetricsCommand builds the command collecting metrics of a Linux node.
+func buildMetricsCommand(workPath string) string {
+	return "sh -c " + shellQuote(metricsScript) + " " + shellQuote(workPath)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+type cpuTimes struct {
+	idle  uint64
+	total uint64
+}
+
+type metrics struct {
+	load         [3]float64
+	cpu          cpuTimes
+	memTotal     uint64
+	memAvailable uint64
+	netRX        uint64
+	netTX        uint64
+	diskTotal    uint64
+	diskUsed     uint64
+}
+
+// parseMetrics parses the output of the metrics command. Missing sections are left zero.
+func parseMetrics(output string) (*metrics, error) {
+	sections := splitSections(output)
+	if len(sections) == 0 {
+		return nil, errNoMetrics
+	}
+
+	m := &metrics{}
+
+	m.load = parseLoadAvg(sections[sectionLoadAvg])
+	m.cpu = parseCPUTimes(sections[sectionStat])
+	m.memTotal, m.memAvailable = parseMemInfo(sections[sectionMemInfo])
+	m.netRX, m.netTX = parseNetDev(sections[sectionNetDev])
+	m.diskTotal, m.diskUsed = parseDF(sections[sectionDF])
+
+	return m, nil
+}
+
+func splitSections(output string) map[string][]string {
+	sections := make(map[string][]string)
+	current := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if name, ok := strings.CutPrefix(line, sectionPrefix); ok {
+			current = name
+			sections[current] = []string{}
+
+			continue
+		}
+
+		if current == "" || line == "" {
+			continue
+		}
+
+		sections[current] = append(sections[current], line)
+	}
+
+	return sections
+}
+
+// parseLoadAvg parses /proc/loadavg: "0.52 0.58 0.59 1/467 12345".
+func parseLoadAvg(lines []string) [3]float64 {
+	var load [3]float64
+
+	if len(lines) == 0 {
+		return load
+	}
+
+	fields := strings.Fields(lines[0])
+	for i := 0; i < len(fields) && i < len(load); i++ {
+		load[i], _ = strconv.ParseFloat(fields[i], 64)
+	}
+
+	return load
+}
+
+// parseCPUTimes parses the aggregated line of /proc/stat:
+// "cpu user nice system idle iowait irq softirq steal guest guest_nice".
+// Guest time is already included in user time, so it is not counted.
+func parseCPUTimes(lines []string) cpuTimes {
+	times := cpuTimes{}
+
+	if len(lines) == 0 {
+		return times
+	}
+
+	fields := strings.Fields(lines[0])
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return times
+	}
+
+	for i, field := range fields[1:min(len(fields), 9)] {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return cpuTimes{}
+		}
+
+		times.total += value
+
+		// idle and iowait
+		if i == 3 || i == 4 {
+			times.idle += value
+		}
+	}
+
+	return times
+}
+
+// parseMemInfo parses /proc/meminfo and returns total and available memory in bytes.
+func parseMemInfo(lines []string) (uint64, uint64) {
+	var total, available uint64
+
+	for _, line := range lines {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+
+		kb, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "MemTotal":
+			total = kb * 1024
+		case "MemAvailable":
+			available = kb * 1024
+		}
+	}
+
+	return total, available
+}
+
+// parseNetDev parses /proc/net/dev and returns received and transmitted bytes of all interfaces except loopback.
+func parseNetDev(lines []string) (uint64, uint64) {
+	var rx, tx uint64
+
+	for _, line := range lines {
+		iface, counters, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(iface) == "lo" {
+			continue
+		}
+
+		// 8 receive counters followed by 8 transmit counters
+		fields := strings.Fields(counters)
+		if len(fields) < 16 {
+			continue
+		}
+
+		rxBytes, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		txBytes, err := strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		rx += rxBytes
+		tx += txBytes
+	}
+
+	return rx, tx
+}
+
+// parseDF parses the POSIX output of "df -P -k" and returns total and used disk space in bytes.
+func parseDF(lines []string) (uint64, uint64) {
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+
+		total, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			// Header line
+			continue
+		}
+
+		used, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		return total * 1024, used * 1024
+	}
+
+	return 0, 0
+}
@@ -0,0 +1,65 @@
+package nodestats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetricsOutput = `::loadavg
+0.52 0.58 0.59 1/467 12345
+::stat
+cpu  100 0 50 800 50 0 0 0 10 0
+::meminfo
+MemTotal:        2048000 kB
+MemFree:          512000 kB
+MemAvailable:    1024000 kB
+::netdev
+Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:    5000      50    0    0    0     0          0         0     5000      50    0    0    0     0       0          0
+  eth0: 1000000    1000    0    0    0     0          0         0   200000     900    0    0    0     0       0          0
+  eth1:    3000      30    0    0    0     0          0         0     4000      40    0    0    0     0       0          0
+::df
+Filesystem     1024-blocks     Used Available Capacity Mounted on
+/dev/sda1         10000000  2500000   7500000      25% /
+`
+
+func TestParseMetrics(t *testing.T) {
+	m, err := parseMetrics(testMetricsOutput)
+	require.NoError(t, err)
+
+	assert.Equal(t, [3]float64{0.52, 0.58, 0.59}, m.load)
+	assert.Equal(t, cpuTimes{idle: 850, total: 1000}, m.cpu)
+	assert.Equal(t, uint64(2048000*1024), m.memTotal)
+	assert.Equal(t, uint64(1024000*1024), m.memAvailable)
+	assert.Equal(t, uint64(1003000), m.netRX)
+	assert.Equal(t, uint64(204000), m.netTX)
+	assert.Equal(t, uint64(10000000*1024), m.diskTotal)
+	assert.Equal(t, uint64(2500000*1024), m.diskUsed)
+}
+
+func TestParseMetrics_MissingSections(t *testing.T) {
+	m, err := parseMetrics("::loadavg\n1.00 2.00 3.00 1/100 1\n::df\ndf: /srv/gameap: No such file or directory\n")
+	require.NoError(t, err)
+
+	assert.Equal(t, [3]float64{1, 2, 3}, m.load)
+	assert.Equal(t, cpuTimes{}, m.cpu)
+	assert.Zero(t, m.memTotal)
+	assert.Zero(t, m.diskTotal)
+}
+
+func TestParseMetrics_NoSections(t *testing.T) {
+	_, err := parseMetrics("sh: command not found")
+
+	require.ErrorIs(t, err, errNoMetrics)
+}
+
+func TestBuildMetricsCommand(t *testing.T) {
+	command := buildMetricsCommand("/srv/it's gameap")
+
+	assert.Contains(t, command, "sh -c '")
+	assert.Contains(t, command, `df -P -k "$0"`)
+	assert.Contains(t, command, `'/srv/it'\''s gameap'`)
+}
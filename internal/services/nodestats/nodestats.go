@@ -0,0 +1,199 @@
+// Package nodestats periodically collects resource usage of nodes into ds_stats.
+package nodestats
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	// Retention is how long node stats are kept.
+	Retention = 90 * 24 * time.Hour
+
+	defaultInterval = 5 * time.Minute
+	collectTimeout  = 30 * time.Second
+)
+
+type commandExecutor interface {
+	ExecuteCommand(
+		ctx context.Context,
+		node *domain.Node,
+		command string,
+		opts ...daemon.CommandServiceOption,
+	) (*daemon.CommandResult, error)
+}
+
+// Collector runs the metrics command on enabled Linux nodes through the daemon
+// and saves the results to the node stats repository.
+type Collector struct {
+	nodeRepo repositories.NodeRepository
+	statRepo repositories.NodeStatRepository
+	commands commandExecutor
+	interval time.Duration
+
+	// CPU usage is calculated from the difference with the previous sample of the node.
+	mu       sync.Mutex
+	cpuTimes map[uint]cpuTimes
+}
+
+func NewCollector(
+	nodeRepo repositories.NodeRepository,
+	statRepo repositories.NodeStatRepository,
+	commands commandExecutor,
+	interval time.Duration,
+) *Collector {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &Collector{
+		nodeRepo: nodeRepo,
+		statRepo: statRepo,
+		commands: commands,
+		interval: interval,
+		cpuTimes: make(map[uint]cpuTimes),
+	}
+}
+
+// Run collects stats until the context is canceled.
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Collect(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to collect node stats", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Node stats collector stopped")
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect collects stats of all enabled Linux nodes once and removes expired stats.
+func (c *Collector) Collect(ctx context.Context) error {
+	nodes, err := c.nodeRepo.FindAll(ctx, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find nodes")
+	}
+
+	wg := sync.WaitGroup{}
+
+	for i := range nodes {
+		node := &nodes[i]
+
+		if !node.Enabled || node.OS != domain.NodeOSLinux {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := c.collectNode(ctx, node)
+			if err != nil && ctx.Err() == nil {
+				slog.WarnContext(
+					ctx,
+					"Failed to collect node stats",
+					slog.Uint64("node_id", uint64(node.ID)),
+					slog.String("error", err.Error()),
+				)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	err = c.statRepo.DeleteMany(ctx, &filters.FindNodeStat{
+		TimeTo: lo.ToPtr(time.Now().Add(-Retention)),
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to delete expired node stats")
+	}
+
+	return nil
+}
+
+func (c *Collector) collectNode(ctx context.Context, node *domain.Node) error {
+	ctx, cancel := context.WithTimeout(ctx, collectTimeout)
+	defer cancel()
+
+	startedAt := time.Now()
+
+	result, err := c.commands.ExecuteCommand(ctx, node, buildMetricsCommand(node.WorkPath))
+	if err != nil {
+		return errors.WithMessage(err, "failed to execute metrics command")
+	}
+
+	ping := time.Since(startedAt)
+
+	m, err := parseMetrics(result.Output)
+	if err != nil {
+		return errors.WithMessage(err, "failed to parse metrics")
+	}
+
+	stat := &domain.NodeStat{
+		NodeID: node.ID,
+		Time:   startedAt,
+		Load:   m.load,
+		RAM: domain.NodeStatUsage{
+			Used:  m.memTotal - min(m.memAvailable, m.memTotal),
+			Total: m.memTotal,
+		},
+		CPU: c.cpuUsage(node.ID, m.cpu),
+		Network: domain.NodeStatTraffic{
+			RX: m.netRX,
+			TX: m.netTX,
+		},
+		Ping: uint(ping.Milliseconds()), //nolint:gosec // duration is limited by the collect timeout
+		Disk: domain.NodeStatUsage{
+			Used:  m.diskUsed,
+			Total: m.diskTotal,
+		},
+	}
+
+	err = c.statRepo.Save(ctx, stat)
+	if err != nil {
+		return errors.WithMessage(err, "failed to save node stat")
+	}
+
+	return nil
+}
+
+// cpuUsage returns CPU usage since the previous sample of the node.
+// The first sample gives the average usage since the node boot.
+func (c *Collector) cpuUsage(nodeID uint, current cpuTimes) domain.NodeStatPercent {
+	c.mu.Lock()
+	previous := c.cpuTimes[nodeID]
+	c.cpuTimes[nodeID] = current
+	c.mu.Unlock()
+
+	// Counters are reset after the node reboot
+	if current.total < previous.total || current.idle < previous.idle {
+		previous = cpuTimes{}
+	}
+
+	total := current.total - previous.total
+	if total == 0 {
+		return 0
+	}
+
+	idle := current.idle - previous.idle
+
+	return domain.NodeStatPercent(100 * float64(total-min(idle, total)) / float64(total))
+}
@@ -0,0 +1,116 @@
+package nodestats
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExecutor struct {
+	mu      sync.Mutex
+	outputs map[uint][]string
+	calls   map[uint]int
+}
+
+func (e *fakeExecutor) ExecuteCommand(
+	_ context.Context,
+	node *domain.Node,
+	_ string,
+	_ ...daemon.CommandServiceOption,
+) (*daemon.CommandResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	outputs, ok := e.outputs[node.ID]
+	if !ok {
+		return nil, errors.New("daemon is unavailable")
+	}
+
+	call := min(e.calls[node.ID], len(outputs)-1)
+	e.calls[node.ID]++
+
+	return &daemon.CommandResult{Output: outputs[call]}, nil
+}
+
+func TestCollector_Collect(t *testing.T) {
+	ctx := context.Background()
+	nodeRepo := inmemory.NewNodeRepository()
+	statRepo := inmemory.NewNodeStatRepository()
+
+	nodes := []*domain.Node{
+		{ID: 1, Enabled: true, OS: domain.NodeOSLinux, WorkPath: "/srv/gameap"},
+		{ID: 2, Enabled: false, OS: domain.NodeOSLinux, WorkPath: "/srv/gameap"},
+		{ID: 3, Enabled: true, OS: domain.NodeOSWindows, WorkPath: `C:\gameap`},
+		// Unavailable node doesn't break collection of other nodes
+		{ID: 4, Enabled: true, OS: domain.NodeOSLinux, WorkPath: "/srv/gameap"},
+	}
+	for _, node := range nodes {
+		require.NoError(t, nodeRepo.Save(ctx, node))
+	}
+
+	secondOutput := `::stat
+cpu  300 0 100 1500 100 0 0 0 0 0
+`
+
+	executor := &fakeExecutor{
+		outputs: map[uint][]string{
+			1: {testMetricsOutput, secondOutput},
+			2: {testMetricsOutput},
+			3: {testMetricsOutput},
+		},
+		calls: make(map[uint]int),
+	}
+
+	expired := &domain.NodeStat{NodeID: 1, Time: time.Now().Add(-Retention - time.Hour)}
+	require.NoError(t, statRepo.Save(ctx, expired))
+
+	collector := NewCollector(nodeRepo, statRepo, executor, 0)
+
+	require.NoError(t, collector.Collect(ctx))
+
+	stats, err := statRepo.Find(ctx, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+
+	stat := stats[0]
+	assert.Equal(t, uint(1), stat.NodeID)
+	assert.Equal(t, domain.NodeLoadAverage{0.52, 0.58, 0.59}, stat.Load)
+	assert.InDelta(t, 15, float64(stat.CPU), 0.001)
+	assert.Equal(t, domain.NodeStatUsage{Used: 1024000 * 1024, Total: 2048000 * 1024}, stat.RAM)
+	assert.Equal(t, domain.NodeStatUsage{Used: 2500000 * 1024, Total: 10000000 * 1024}, stat.Disk)
+	assert.Equal(t, domain.NodeStatTraffic{RX: 1003000, TX: 204000}, stat.Network)
+
+	// CPU usage of the second sample is calculated from the difference with the first one:
+	// 1000 jiffies total, 750 of them idle.
+	require.NoError(t, collector.Collect(ctx))
+
+	stats, err = statRepo.Find(ctx, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	var second domain.NodeStat
+	for _, s := range stats {
+		if s.ID != stat.ID {
+			second = s
+		}
+	}
+
+	assert.InDelta(t, 25, float64(second.CPU), 0.001)
+}
+
+func TestCollector_CPUUsage_CountersReset(t *testing.T) {
+	collector := NewCollector(nil, nil, nil, time.Minute)
+
+	collector.cpuUsage(1, cpuTimes{idle: 9000, total: 10000})
+	usage := collector.cpuUsage(1, cpuTimes{idle: 80, total: 100})
+
+	assert.InDelta(t, 20, float64(usage), 0.001)
+}
@@ -0,0 +1,265 @@
+package playerbans
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	minecraftBanTimeLayout = "2006-01-02 15:04:05 -0700"
+	minecraftBanForever    = "forever"
+)
+
+var (
+	ErrBanListPathRequired       = errors.New("ban list path is required for this game")
+	ErrUnsupportedBanListFormat  = errors.New("unsupported ban list format, json or cfg file expected")
+	ErrInvalidBanListPath        = errors.New("invalid ban list path")
+	ErrNodeNotFound              = errors.New("node not found")
+	errInvalidMinecraftBanList   = errors.New("invalid minecraft ban list")
+	errInvalidMinecraftBanExpiry = errors.New("invalid minecraft ban expiration time")
+)
+
+// defaultBanListPaths are the ban list files relative to the server directory.
+var defaultBanListPaths = map[string]string{
+	"minecraft": "banned-players.json",
+	"cs":        "cstrike/banned.cfg",
+	"cstrike":   "cstrike/banned.cfg",
+	"tfc":       "tfc/banned.cfg",
+	"dod":       "dod/banned.cfg",
+	"gearbox":   "gearbox/banned.cfg",
+	"hl":        "valve/banned.cfg",
+	"valve":     "valve/banned.cfg",
+}
+
+type ImportResult struct {
+	Path     string
+	Imported []domain.PlayerBan
+	Skipped  int
+}
+
+// DefaultBanListPath returns the ban list file of the game relative to the server directory.
+func DefaultBanListPath(gameCode string) (string, bool) {
+	p, ok := defaultBanListPaths[gameCode]
+
+	return p, ok
+}
+
+// Import registers bans from the ban list file of the game server.
+// The format is detected by the file extension: Minecraft banned-players.json (banned_players.json
+// in some server versions) or GoldSrc banned.cfg. An empty path selects the default file of the game.
+// Expired bans and players already banned in the panel are skipped.
+func (s *Service) Import(ctx context.Context, server *domain.Server, banListPath string) (*ImportResult, error) {
+	if banListPath == "" {
+		p, ok := DefaultBanListPath(server.GameID)
+		if !ok {
+			return nil, ErrBanListPathRequired
+		}
+
+		banListPath = p
+	}
+
+	if err := validatePath(banListPath); err != nil {
+		return nil, err
+	}
+
+	parse, err := banListParser(banListPath)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := s.nodeRepo.Find(ctx, filters.FindNodeByIDs(server.DSID), nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find node")
+	}
+	if len(nodes) == 0 {
+		return nil, ErrNodeNotFound
+	}
+
+	node := &nodes[0]
+
+	data, err := s.files.Download(ctx, node, filepath.Join(node.WorkPath, server.Dir, banListPath))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to download ban list")
+	}
+
+	now := time.Now()
+
+	entries, err := parse(data, now)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse ban list")
+	}
+
+	activeBans, err := s.ServerBans(ctx, server.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	banned := make(map[string]struct{}, len(activeBans))
+	for i := range activeBans {
+		banned[banKey(&activeBans[i])] = struct{}{}
+	}
+
+	result := &ImportResult{
+		Path:     banListPath,
+		Imported: make([]domain.PlayerBan, 0, len(entries)),
+	}
+
+	for i := range entries {
+		ban := &entries[i]
+
+		if _, exists := banned[banKey(ban)]; exists || !ban.IsActive(now) {
+			result.Skipped++
+
+			continue
+		}
+
+		ban.ServerID = server.ID
+		ban.Source = domain.PlayerBanSourceImport
+
+		if err = s.banRepo.Save(ctx, ban); err != nil {
+			return nil, errors.WithMessage(err, "failed to save player ban")
+		}
+
+		banned[banKey(ban)] = struct{}{}
+		result.Imported = append(result.Imported, *ban)
+	}
+
+	return result, nil
+}
+
+func banKey(ban *domain.PlayerBan) string {
+	if ban.UniqID != "" {
+		return "id:" + ban.UniqID
+	}
+
+	return "name:" + strings.ToLower(ban.Name)
+}
+
+func validatePath(p string) error {
+	if strings.Contains(p, "..") || path.IsAbs(p) || filepath.IsAbs(p) {
+		return ErrInvalidBanListPath
+	}
+
+	return nil
+}
+
+type banListParseFunc func(data []byte, now time.Time) ([]domain.PlayerBan, error)
+
+func banListParser(banListPath string) (banListParseFunc, error) {
+	switch strings.ToLower(filepath.Ext(banListPath)) {
+	case ".json":
+		return parseMinecraftBanList, nil
+	case ".cfg":
+		return parseBannedCfg, nil
+	default:
+		return nil, ErrUnsupportedBanListFormat
+	}
+}
+
+type minecraftBanEntry struct {
+	UUID    string `json:"uuid"`
+	Name    string `json:"name"`
+	Created string `json:"created"`
+	Source  string `json:"source"`
+	Expires string `json:"expires"`
+	Reason  string `json:"reason"`
+}
+
+func parseMinecraftBanList(data []byte, now time.Time) ([]domain.PlayerBan, error) {
+	var entries []minecraftBanEntry
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.WithMessage(errInvalidMinecraftBanList, err.Error())
+	}
+
+	bans := make([]domain.PlayerBan, 0, len(entries))
+
+	for _, entry := range entries {
+		// Entries are used in RCON commands, invalid ones are skipped
+		if !players.IsValidMinecraftName(entry.Name) || (entry.UUID != "" && !players.IsValidUniqID(entry.UUID)) {
+			continue
+		}
+
+		ban := domain.PlayerBan{
+			UniqID:    entry.UUID,
+			Name:      entry.Name,
+			Reason:    entry.Reason,
+			CreatedAt: now,
+		}
+
+		if created, err := time.Parse(minecraftBanTimeLayout, entry.Created); err == nil {
+			ban.CreatedAt = created
+		}
+
+		if entry.Expires != "" && entry.Expires != minecraftBanForever {
+			expires, err := time.Parse(minecraftBanTimeLayout, entry.Expires)
+			if err != nil {
+				return nil, errors.WithMessagef(errInvalidMinecraftBanExpiry, "player %s", entry.Name)
+			}
+
+			ban.ExpiresAt = &expires
+		}
+
+		bans = append(bans, ban)
+	}
+
+	return bans, nil
+}
+
+// parseBannedCfg parses the banned.cfg written by the writeid command, e.g.
+//
+//	banid 0 STEAM_0:1:12345
+//	banid 30.0 STEAM_0:0:54321
+//
+// The ban time is in minutes, zero means a permanent ban.
+func parseBannedCfg(data []byte, now time.Time) ([]domain.PlayerBan, error) {
+	bans := make([]domain.PlayerBan, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !strings.EqualFold(fields[0], "banid") {
+			continue
+		}
+
+		minutes, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+
+		uniqID := strings.TrimPrefix(fields[2], "#")
+		if !players.IsValidSteamID(uniqID) {
+			continue
+		}
+
+		ban := domain.PlayerBan{
+			UniqID:    uniqID,
+			CreatedAt: now,
+		}
+
+		if minutes > 0 {
+			ban.ExpiresAt = lo.ToPtr(now.Add(time.Duration(minutes * float64(time.Minute))))
+		}
+
+		bans = append(bans, ban)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WithMessage(err, "failed to read ban list")
+	}
+
+	return bans, nil
+}
@@ -0,0 +1,208 @@
+package playerbans
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMinecraftBanList = `[
+  {
+    "uuid": "069a79f4-44e9-4726-a5be-fca90e38aaf5",
+    "name": "Notch",
+    "created": "2025-01-10 12:00:00 +0000",
+    "source": "Server",
+    "expires": "forever",
+    "reason": "Banned by an operator."
+  },
+  {
+    "uuid": "853c80ef-3c37-49fd-aa49-938b674adae6",
+    "name": "jeb_",
+    "created": "2025-01-10 12:00:00 +0000",
+    "source": "Server",
+    "expires": "2020-01-01 00:00:00 +0000",
+    "reason": "expired"
+  },
+  {
+    "uuid": "61699b2e-d327-4a01-9f1e-0ea8c3f06bc6",
+    "name": "Dinnerbone",
+    "created": "2025-01-10 12:00:00 +0000",
+    "source": "Server",
+    "expires": "2999-01-01 00:00:00 +0000",
+    "reason": "temporary"
+  },
+  {
+    "uuid": "61699b2e-d327-4a01-9f1e-0ea8c3f06bc7",
+    "name": "Steve\nop Steve",
+    "created": "2025-01-10 12:00:00 +0000",
+    "source": "Server",
+    "expires": "forever",
+    "reason": "invalid name"
+  }
+]`
+
+const testBannedCfg = `banid 0 STEAM_0:1:111
+banid 30.0 STEAM_0:0:222
+
+// comment
+banid invalid STEAM_0:0:333
+banid 0 STEAM_0:0:444;quit
+`
+
+func TestParseMinecraftBanList(t *testing.T) {
+	now := time.Now()
+
+	bans, err := parseMinecraftBanList([]byte(testMinecraftBanList), now)
+	require.NoError(t, err)
+	require.Len(t, bans, 3)
+
+	assert.Equal(t, "069a79f4-44e9-4726-a5be-fca90e38aaf5", bans[0].UniqID)
+	assert.Equal(t, "Notch", bans[0].Name)
+	assert.Equal(t, "Banned by an operator.", bans[0].Reason)
+	assert.Equal(t, time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC), bans[0].CreatedAt.UTC())
+	assert.True(t, bans[0].IsPermanent())
+
+	require.NotNil(t, bans[2].ExpiresAt)
+	assert.Equal(t, 2999, bans[2].ExpiresAt.Year())
+
+	_, err = parseMinecraftBanList([]byte(`{"invalid"}`), now)
+	require.Error(t, err)
+}
+
+func TestParseBannedCfg(t *testing.T) {
+	now := time.Now()
+
+	bans, err := parseBannedCfg([]byte(testBannedCfg), now)
+	require.NoError(t, err)
+	require.Len(t, bans, 2)
+
+	assert.Equal(t, "STEAM_0:1:111", bans[0].UniqID)
+	assert.True(t, bans[0].IsPermanent())
+
+	assert.Equal(t, "STEAM_0:0:222", bans[1].UniqID)
+	assert.Equal(t, lo.ToPtr(now.Add(30*time.Minute)), bans[1].ExpiresAt)
+}
+
+func TestService_Import(t *testing.T) {
+	tests := []struct {
+		name           string
+		gameID         string
+		path           string
+		files          map[string]string
+		expectPath     string
+		expectImported []string
+		expectSkipped  int
+		expectError    error
+	}{
+		{
+			name:   "minecraft default path",
+			gameID: "minecraft",
+			files: map[string]string{
+				"/srv/gameap/servers/test/banned-players.json": testMinecraftBanList,
+			},
+			expectPath:     "banned-players.json",
+			expectImported: []string{"Notch", "Dinnerbone"},
+			expectSkipped:  1,
+		},
+		{
+			name:   "goldsrc default path",
+			gameID: "cstrike",
+			files: map[string]string{
+				"/srv/gameap/servers/test/cstrike/banned.cfg": testBannedCfg,
+			},
+			expectPath:     "cstrike/banned.cfg",
+			expectImported: []string{"STEAM_0:1:111", "STEAM_0:0:222"},
+		},
+		{
+			name:   "custom path",
+			gameID: "minecraft",
+			path:   "banned_players.json",
+			files: map[string]string{
+				"/srv/gameap/servers/test/banned_players.json": testMinecraftBanList,
+			},
+			expectPath:     "banned_players.json",
+			expectImported: []string{"Notch", "Dinnerbone"},
+			expectSkipped:  1,
+		},
+		{
+			name:        "no default path",
+			gameID:      "7d2d",
+			expectError: ErrBanListPathRequired,
+		},
+		{
+			name:        "directory traversal",
+			gameID:      "minecraft",
+			path:        "../other/banned-players.json",
+			expectError: ErrInvalidBanListPath,
+		},
+		{
+			name:        "unsupported format",
+			gameID:      "minecraft",
+			path:        "banned-players.txt",
+			expectError: ErrUnsupportedBanListFormat,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			server := env.addServer(t, 1, test.gameID, true)
+
+			for p, content := range test.files {
+				env.downloader.files[p] = []byte(content)
+			}
+
+			result, err := env.service.Import(context.Background(), server, test.path)
+
+			if test.expectError != nil {
+				require.ErrorIs(t, err, test.expectError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectPath, result.Path)
+			assert.Equal(t, test.expectSkipped, result.Skipped)
+			assert.Empty(t, env.executor.commands)
+
+			imported := lo.Map(env.findBans(t), func(ban domain.PlayerBan, _ int) string {
+				if ban.Name != "" {
+					return ban.Name
+				}
+
+				return ban.UniqID
+			})
+			assert.Equal(t, test.expectImported, imported)
+
+			for _, ban := range env.findBans(t) {
+				assert.Equal(t, domain.PlayerBanSourceImport, ban.Source)
+				assert.Nil(t, ban.IssuerID)
+			}
+		})
+	}
+}
+
+func TestService_Import_SkipsAlreadyBanned(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	server := env.addServer(t, 1, "cstrike", true)
+	env.downloader.files["/srv/gameap/servers/test/cstrike/banned.cfg"] = []byte(testBannedCfg)
+
+	require.NoError(t, env.service.Ban(ctx, server, &domain.PlayerBan{UniqID: "STEAM_0:1:111"}))
+
+	result, err := env.service.Import(ctx, server, "")
+	require.NoError(t, err)
+	assert.Len(t, result.Imported, 1)
+	assert.Equal(t, 1, result.Skipped)
+
+	// Importing again doesn't duplicate bans
+	result, err = env.service.Import(ctx, server, "")
+	require.NoError(t, err)
+	assert.Empty(t, result.Imported)
+	assert.Equal(t, 2, result.Skipped)
+}
@@ -0,0 +1,332 @@
+// Package playerbans manages the panel-side registry of player bans.
+// Bans are applied to game servers through the RCON player managers
+// and are lifted automatically with the unban command when they expire.
+package playerbans
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const defaultExpiryInterval = time.Minute
+
+var (
+	ErrBanNotFound  = errors.New("ban not found")
+	ErrBanNotActive = errors.New("ban is not active")
+)
+
+type commandExecutor interface {
+	Execute(ctx context.Context, server *domain.Server, command string) (string, error)
+}
+
+type gameFinder interface {
+	FindServerGame(ctx context.Context, server *domain.Server) (*domain.Game, error)
+}
+
+type fileDownloader interface {
+	Download(ctx context.Context, node *domain.Node, filePath string) ([]byte, error)
+}
+
+type Service struct {
+	banRepo        repositories.PlayerBanRepository
+	serverRepo     repositories.ServerRepository
+	nodeRepo       repositories.NodeRepository
+	games          gameFinder
+	commands       commandExecutor
+	files          fileDownloader
+	expiryInterval time.Duration
+}
+
+func NewService(
+	banRepo repositories.PlayerBanRepository,
+	serverRepo repositories.ServerRepository,
+	nodeRepo repositories.NodeRepository,
+	games gameFinder,
+	commands commandExecutor,
+	files fileDownloader,
+	expiryInterval time.Duration,
+) *Service {
+	if expiryInterval <= 0 {
+		expiryInterval = defaultExpiryInterval
+	}
+
+	return &Service{
+		banRepo:        banRepo,
+		serverRepo:     serverRepo,
+		nodeRepo:       nodeRepo,
+		games:          games,
+		commands:       commands,
+		files:          files,
+		expiryInterval: expiryInterval,
+	}
+}
+
+// ServerBans returns bans of the server, newest first.
+// When active is true, only bans which are not lifted and not expired are returned.
+func (s *Service) ServerBans(ctx context.Context, serverID uint, active bool) ([]domain.PlayerBan, error) {
+	filter := &filters.FindPlayerBan{
+		ServerIDs: []uint{serverID},
+	}
+
+	if active {
+		filter.ActiveAt = lo.ToPtr(time.Now())
+	}
+
+	bans, err := s.banRepo.Find(ctx, filter, []filters.Sorting{
+		{Field: "created_at", Direction: filters.SortDirectionDesc},
+		{Field: "id", Direction: filters.SortDirectionDesc},
+	}, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find player bans")
+	}
+
+	return bans, nil
+}
+
+// FindServerBan returns the ban of the server by id or ErrBanNotFound.
+func (s *Service) FindServerBan(ctx context.Context, serverID, banID uint) (*domain.PlayerBan, error) {
+	bans, err := s.banRepo.Find(ctx, &filters.FindPlayerBan{
+		IDs:       []uint{banID},
+		ServerIDs: []uint{serverID},
+	}, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find player ban")
+	}
+
+	if len(bans) == 0 {
+		return nil, ErrBanNotFound
+	}
+
+	return &bans[0], nil
+}
+
+// Ban bans the player on the server with the RCON ban command and registers the ban.
+// The ban is registered only when the command is executed successfully.
+func (s *Service) Ban(ctx context.Context, server *domain.Server, ban *domain.PlayerBan) error {
+	now := time.Now()
+
+	ban.ServerID = server.ID
+	ban.CreatedAt = now
+	ban.LiftedAt = nil
+	ban.LiftedBy = nil
+
+	if ban.Source == "" {
+		ban.Source = domain.PlayerBanSourcePanel
+	}
+
+	if err := s.applyBan(ctx, server, ban, now); err != nil {
+		return err
+	}
+
+	if err := s.banRepo.Save(ctx, ban); err != nil {
+		return errors.WithMessage(err, "failed to save player ban")
+	}
+
+	return nil
+}
+
+// Update changes the reason and the expiration time of an active ban.
+// The ban command is issued again when the expiration time is changed.
+func (s *Service) Update(
+	ctx context.Context,
+	server *domain.Server,
+	ban *domain.PlayerBan,
+	reason string,
+	expiresAt *time.Time,
+) error {
+	now := time.Now()
+
+	if !ban.IsActive(now) {
+		return ErrBanNotActive
+	}
+
+	expiryChanged := !lo.FromPtr(ban.ExpiresAt).Equal(lo.FromPtr(expiresAt))
+
+	ban.Reason = reason
+	ban.ExpiresAt = expiresAt
+
+	if expiryChanged {
+		if err := s.applyBan(ctx, server, ban, now); err != nil {
+			return err
+		}
+	}
+
+	if err := s.banRepo.Save(ctx, ban); err != nil {
+		return errors.WithMessage(err, "failed to save player ban")
+	}
+
+	return nil
+}
+
+// Lift issues the unban command and marks the ban as lifted by the user.
+func (s *Service) Lift(ctx context.Context, server *domain.Server, ban *domain.PlayerBan, userID uint) error {
+	now := time.Now()
+
+	if !ban.IsActive(now) && !ban.IsExpired(now) {
+		return ErrBanNotActive
+	}
+
+	if err := s.applyUnban(ctx, server, ban); err != nil {
+		return err
+	}
+
+	ban.LiftedAt = &now
+	ban.LiftedBy = &userID
+
+	if err := s.banRepo.Save(ctx, ban); err != nil {
+		return errors.WithMessage(err, "failed to save player ban")
+	}
+
+	return nil
+}
+
+// Run lifts expired bans until the context is canceled.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.expiryInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessExpired(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to process expired player bans", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Player bans expiry processor stopped")
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessExpired issues unban commands for bans expired at the given time and marks them as lifted.
+// Bans of offline servers and bans failed to lift are kept and retried on the next run.
+func (s *Service) ProcessExpired(ctx context.Context, now time.Time) error {
+	bans, err := s.banRepo.Find(ctx, &filters.FindPlayerBan{
+		ExpiredAt: &now,
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find expired player bans")
+	}
+
+	if len(bans) == 0 {
+		return nil
+	}
+
+	serverIDs := lo.Uniq(lo.Map(bans, func(ban domain.PlayerBan, _ int) uint {
+		return ban.ServerID
+	}))
+
+	servers, err := s.serverRepo.Find(ctx, filters.FindServerByIDs(serverIDs...), nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find servers")
+	}
+
+	serversByID := lo.SliceToMap(servers, func(server domain.Server) (uint, domain.Server) {
+		return server.ID, server
+	})
+
+	for i := range bans {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		ban := &bans[i]
+
+		server, exists := serversByID[ban.ServerID]
+		if exists && !server.IsOnline() {
+			continue
+		}
+
+		// The ban of a deleted server can't be lifted, it's only marked as lifted
+		if exists {
+			err = s.applyUnban(ctx, &server, ban)
+			if err != nil && !errors.Is(err, players.ErrPlayersManagementNotSupported) {
+				slog.WarnContext(
+					ctx,
+					"Failed to lift expired player ban",
+					slog.Uint64("ban_id", uint64(ban.ID)),
+					slog.Uint64("server_id", uint64(ban.ServerID)),
+					slog.String("error", err.Error()),
+				)
+
+				continue
+			}
+		}
+
+		ban.LiftedAt = &now
+
+		if err = s.banRepo.Save(ctx, ban); err != nil {
+			return errors.WithMessage(err, "failed to save player ban")
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) applyBan(ctx context.Context, server *domain.Server, ban *domain.PlayerBan, now time.Time) error {
+	manager, err := s.playerManager(ctx, server)
+	if err != nil {
+		return err
+	}
+
+	var duration time.Duration
+	if ban.ExpiresAt != nil {
+		duration = ban.ExpiresAt.Sub(now).Round(time.Second)
+	}
+
+	command, err := manager.BanCommand(banPlayer(ban), ban.Reason, duration)
+	if err != nil {
+		return errors.WithMessage(err, "failed to build ban command")
+	}
+
+	if _, err = s.commands.Execute(ctx, server, command); err != nil {
+		return errors.WithMessage(err, "failed to execute ban command")
+	}
+
+	return nil
+}
+
+func (s *Service) applyUnban(ctx context.Context, server *domain.Server, ban *domain.PlayerBan) error {
+	manager, err := s.playerManager(ctx, server)
+	if err != nil {
+		return err
+	}
+
+	command, err := manager.UnbanCommand(banPlayer(ban))
+	if err != nil {
+		return errors.WithMessage(err, "failed to build unban command")
+	}
+
+	if _, err = s.commands.Execute(ctx, server, command); err != nil {
+		return errors.WithMessage(err, "failed to execute unban command")
+	}
+
+	return nil
+}
+
+func (s *Service) playerManager(ctx context.Context, server *domain.Server) (players.PlayerManager, error) {
+	game, err := s.games.FindServerGame(ctx, server)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find game for server")
+	}
+
+	return players.NewPlayerManager(lo.FromPtr(game.PlayerManager), game.Code)
+}
+
+func banPlayer(ban *domain.PlayerBan) players.Player {
+	return players.Player{
+		ID:     ban.UniqID,
+		UniqID: ban.UniqID,
+		Name:   ban.Name,
+	}
+}
@@ -0,0 +1,336 @@
+package playerbans
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errExecute = errors.New("execute failed")
+
+type fakeExecutor struct {
+	mu       sync.Mutex
+	commands map[uint][]string
+	err      error
+}
+
+func (e *fakeExecutor) Execute(_ context.Context, server *domain.Server, command string) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err != nil {
+		return "", e.err
+	}
+
+	if e.commands == nil {
+		e.commands = make(map[uint][]string)
+	}
+	e.commands[server.ID] = append(e.commands[server.ID], command)
+
+	return "", nil
+}
+
+type fakeGameFinder struct {
+	playerManagers map[string]string
+}
+
+func (f *fakeGameFinder) FindServerGame(_ context.Context, server *domain.Server) (*domain.Game, error) {
+	game := &domain.Game{Code: server.GameID}
+
+	if manager, ok := f.playerManagers[server.GameID]; ok {
+		game.PlayerManager = &manager
+	}
+
+	return game, nil
+}
+
+type fakeDownloader struct {
+	files map[string][]byte
+}
+
+func (d *fakeDownloader) Download(_ context.Context, _ *domain.Node, filePath string) ([]byte, error) {
+	data, ok := d.files[filePath]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+
+	return data, nil
+}
+
+type testEnv struct {
+	service    *Service
+	banRepo    *inmemory.PlayerBanRepository
+	serverRepo *inmemory.ServerRepository
+	games      *fakeGameFinder
+	executor   *fakeExecutor
+	downloader *fakeDownloader
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		banRepo:    inmemory.NewPlayerBanRepository(),
+		serverRepo: inmemory.NewServerRepository(),
+		games:      &fakeGameFinder{playerManagers: map[string]string{}},
+		executor:   &fakeExecutor{},
+		downloader: &fakeDownloader{files: map[string][]byte{}},
+	}
+
+	nodeRepo := inmemory.NewNodeRepository()
+	require.NoError(t, nodeRepo.Save(context.Background(), &domain.Node{
+		ID:       1,
+		Enabled:  true,
+		Name:     "node",
+		WorkPath: "/srv/gameap",
+	}))
+
+	env.service = NewService(env.banRepo, env.serverRepo, nodeRepo, env.games, env.executor, env.downloader, 0)
+
+	return env
+}
+
+func (env *testEnv) addServer(t *testing.T, id uint, gameID string, online bool) *domain.Server {
+	t.Helper()
+
+	server := &domain.Server{
+		ID:            id,
+		UUID:          uuid.New(),
+		Enabled:       true,
+		Installed:     domain.ServerInstalledStatusInstalled,
+		Name:          "Test Server",
+		GameID:        gameID,
+		DSID:          1,
+		Dir:           "servers/test",
+		ServerIP:      "127.0.0.1",
+		ServerPort:    27015,
+		ProcessActive: online,
+	}
+
+	if online {
+		server.LastProcessCheck = lo.ToPtr(time.Now())
+	}
+
+	require.NoError(t, env.serverRepo.Save(context.Background(), server))
+
+	return server
+}
+
+func (env *testEnv) findBans(t *testing.T) []domain.PlayerBan {
+	t.Helper()
+
+	bans, err := env.banRepo.Find(context.Background(), nil, []filters.Sorting{
+		{Field: "id", Direction: filters.SortDirectionAsc},
+	}, nil)
+	require.NoError(t, err)
+
+	return bans
+}
+
+func TestService_Ban(t *testing.T) {
+	tests := []struct {
+		name          string
+		gameID        string
+		playerManager string
+		ban           domain.PlayerBan
+		executeErr    error
+		expectCommand string
+		expectError   string
+	}{
+		{
+			name:          "permanent valve ban",
+			gameID:        "cstrike",
+			ban:           domain.PlayerBan{UniqID: "STEAM_0:1:123", Reason: "cheater"},
+			expectCommand: `banid 0 STEAM_0:1:123; kick STEAM_0:1:123 "cheater"`,
+		},
+		{
+			name:          "minecraft ban",
+			gameID:        "minecraft",
+			ban:           domain.PlayerBan{Name: "alice"},
+			expectCommand: "ban alice",
+		},
+		{
+			name:          "game with configured player manager",
+			gameID:        "mcpaper",
+			playerManager: "minecraft",
+			ban:           domain.PlayerBan{Name: "alice"},
+			expectCommand: "ban alice",
+		},
+		{
+			name:        "player without required identifier",
+			gameID:      "cstrike",
+			ban:         domain.PlayerBan{Name: "alice"},
+			expectError: "failed to build ban command",
+		},
+		{
+			name:        "game without player management",
+			gameID:      "unknown",
+			ban:         domain.PlayerBan{UniqID: "STEAM_0:1:123"},
+			expectError: "players management is not supported",
+		},
+		{
+			name:        "command failed",
+			gameID:      "cstrike",
+			ban:         domain.PlayerBan{UniqID: "STEAM_0:1:123"},
+			executeErr:  errExecute,
+			expectError: "failed to execute ban command",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.executor.err = test.executeErr
+			if test.playerManager != "" {
+				env.games.playerManagers[test.gameID] = test.playerManager
+			}
+			server := env.addServer(t, 1, test.gameID, true)
+
+			ban := test.ban
+			err := env.service.Ban(context.Background(), server, &ban)
+
+			if test.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectError)
+				assert.Empty(t, env.findBans(t))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{test.expectCommand}, env.executor.commands[1])
+
+			bans := env.findBans(t)
+			require.Len(t, bans, 1)
+			assert.Equal(t, uint(1), bans[0].ServerID)
+			assert.Equal(t, domain.PlayerBanSourcePanel, bans[0].Source)
+			assert.True(t, bans[0].IsActive(time.Now()))
+		})
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	server := env.addServer(t, 1, "7d2d", true)
+
+	ban := &domain.PlayerBan{UniqID: "76561198000000001"}
+	require.NoError(t, env.service.Ban(ctx, server, ban))
+
+	t.Run("reason only", func(t *testing.T) {
+		require.NoError(t, env.service.Update(ctx, server, ban, "griefing", nil))
+
+		assert.Len(t, env.executor.commands[1], 1)
+		assert.Equal(t, "griefing", env.findBans(t)[0].Reason)
+	})
+
+	t.Run("expiration time reissues the ban", func(t *testing.T) {
+		expiresAt := time.Now().Add(2 * time.Hour)
+
+		require.NoError(t, env.service.Update(ctx, server, ban, "griefing", &expiresAt))
+
+		require.Len(t, env.executor.commands[1], 2)
+		assert.Regexp(t, `^ban add 76561198000000001 1(19|20) minutes`, env.executor.commands[1][1])
+		require.NotNil(t, env.findBans(t)[0].ExpiresAt)
+	})
+
+	t.Run("lifted ban", func(t *testing.T) {
+		lifted := *ban
+		lifted.LiftedAt = lo.ToPtr(time.Now())
+
+		err := env.service.Update(ctx, server, &lifted, "", nil)
+		require.ErrorIs(t, err, ErrBanNotActive)
+	})
+}
+
+func TestService_Lift(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	server := env.addServer(t, 1, "minecraft", true)
+
+	ban := &domain.PlayerBan{Name: "alice"}
+	require.NoError(t, env.service.Ban(ctx, server, ban))
+
+	require.NoError(t, env.service.Lift(ctx, server, ban, 5))
+	assert.Equal(t, []string{"ban alice", "pardon alice"}, env.executor.commands[1])
+
+	bans := env.findBans(t)
+	require.Len(t, bans, 1)
+	require.NotNil(t, bans[0].LiftedAt)
+	assert.Equal(t, lo.ToPtr(uint(5)), bans[0].LiftedBy)
+
+	err := env.service.Lift(ctx, server, ban, 5)
+	require.ErrorIs(t, err, ErrBanNotActive)
+}
+
+func TestService_ProcessExpired(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	now := time.Now()
+
+	env.addServer(t, 1, "minecraft", true)
+	env.addServer(t, 2, "minecraft", false)
+
+	bans := []domain.PlayerBan{
+		// Expired on an online server
+		{ServerID: 1, Name: "alice", CreatedAt: now.Add(-time.Hour), ExpiresAt: lo.ToPtr(now.Add(-time.Minute))},
+		// Not expired yet
+		{ServerID: 1, Name: "bob", CreatedAt: now.Add(-time.Hour), ExpiresAt: lo.ToPtr(now.Add(time.Hour))},
+		// Permanent
+		{ServerID: 1, Name: "carol", CreatedAt: now.Add(-time.Hour)},
+		// Expired on an offline server
+		{ServerID: 2, Name: "dave", CreatedAt: now.Add(-time.Hour), ExpiresAt: lo.ToPtr(now.Add(-time.Minute))},
+		// Expired on a deleted server
+		{ServerID: 3, Name: "eve", CreatedAt: now.Add(-time.Hour), ExpiresAt: lo.ToPtr(now.Add(-time.Minute))},
+	}
+	for i := range bans {
+		require.NoError(t, env.banRepo.Save(ctx, &bans[i]))
+	}
+
+	require.NoError(t, env.service.ProcessExpired(ctx, now))
+
+	assert.Equal(t, []string{"pardon alice"}, env.executor.commands[1])
+	assert.Empty(t, env.executor.commands[2])
+
+	lifted := lo.FilterMap(env.findBans(t), func(ban domain.PlayerBan, _ int) (string, bool) {
+		return ban.Name, ban.LiftedAt != nil
+	})
+	assert.Equal(t, []string{"alice", "eve"}, lifted)
+
+	for _, ban := range env.findBans(t) {
+		assert.Nil(t, ban.LiftedBy)
+	}
+}
+
+func TestService_ProcessExpired_CommandFailed(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	now := time.Now()
+
+	env.addServer(t, 1, "minecraft", true)
+	env.executor.err = errExecute
+
+	require.NoError(t, env.banRepo.Save(ctx, &domain.PlayerBan{
+		ServerID:  1,
+		Name:      "alice",
+		CreatedAt: now.Add(-time.Hour),
+		ExpiresAt: lo.ToPtr(now.Add(-time.Minute)),
+	}))
+
+	require.NoError(t, env.service.ProcessExpired(ctx, now))
+
+	// The ban is retried on the next run
+	bans := env.findBans(t)
+	require.Len(t, bans, 1)
+	assert.Nil(t, bans[0].LiftedAt)
+}
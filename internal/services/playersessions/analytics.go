@@ -0,0 +1,161 @@
+package playersessions
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+)
+
+// PlayerSummary is the activity of a player aggregated over sessions.
+type PlayerSummary struct {
+	// UniqID is empty for players known by name only.
+	UniqID string
+
+	// Names used by the player, in order of the first use.
+	Names     []string
+	ServerIDs []uint
+
+	SessionsCount int
+	Playtime      time.Duration
+	FirstSeenAt   time.Time
+	LastSeenAt    time.Time
+	Online        bool
+}
+
+// Summarize groups sessions by player: by UniqID, or by name for players without it.
+// Summaries are sorted by the last seen time, most recent first.
+func Summarize(sessions []domain.PlayerSession, now time.Time) []PlayerSummary {
+	type key struct {
+		uniqID string
+		name   string
+	}
+
+	summaries := make(map[key]*PlayerSummary)
+	order := make([]key, 0)
+
+	for i := range sessions {
+		session := &sessions[i]
+
+		k := key{uniqID: session.UniqID}
+		if k.uniqID == "" {
+			k.name = session.Name
+		}
+
+		summary, ok := summaries[k]
+		if !ok {
+			summary = &PlayerSummary{
+				UniqID:      session.UniqID,
+				FirstSeenAt: session.JoinedAt,
+			}
+			summaries[k] = summary
+			order = append(order, k)
+		}
+
+		summary.SessionsCount++
+		summary.Playtime += session.Duration(now)
+
+		if !slices.Contains(summary.ServerIDs, session.ServerID) {
+			summary.ServerIDs = append(summary.ServerIDs, session.ServerID)
+		}
+
+		if session.JoinedAt.Before(summary.FirstSeenAt) {
+			summary.FirstSeenAt = session.JoinedAt
+		}
+
+		lastSeen := session.LastSeenAt
+		if session.LeftAt != nil && session.LeftAt.After(lastSeen) {
+			lastSeen = *session.LeftAt
+		}
+
+		if lastSeen.After(summary.LastSeenAt) {
+			summary.LastSeenAt = lastSeen
+		}
+
+		if session.IsActive() {
+			summary.Online = true
+		}
+	}
+
+	// Names are collected in order of sessions start
+	byJoin := slices.Clone(sessions)
+	slices.SortStableFunc(byJoin, func(a, b domain.PlayerSession) int {
+		return a.JoinedAt.Compare(b.JoinedAt)
+	})
+
+	for i := range byJoin {
+		k := key{uniqID: byJoin[i].UniqID}
+		if k.uniqID == "" {
+			k.name = byJoin[i].Name
+		}
+
+		summary := summaries[k]
+		if !slices.Contains(summary.Names, byJoin[i].Name) {
+			summary.Names = append(summary.Names, byJoin[i].Name)
+		}
+	}
+
+	result := make([]PlayerSummary, 0, len(order))
+	for _, k := range order {
+		result = append(result, *summaries[k])
+	}
+
+	slices.SortStableFunc(result, func(a, b PlayerSummary) int {
+		return b.LastSeenAt.Compare(a.LastSeenAt)
+	})
+
+	return result
+}
+
+// PeakConcurrency returns the maximum number of simultaneous sessions within [from, to)
+// and the time it was first reached. Active sessions are counted until to.
+func PeakConcurrency(sessions []domain.PlayerSession, from, to time.Time) (int, time.Time) {
+	type event struct {
+		time  time.Time
+		delta int
+	}
+
+	events := make([]event, 0, len(sessions)*2)
+
+	for i := range sessions {
+		start := sessions[i].JoinedAt
+		if start.Before(from) {
+			start = from
+		}
+
+		end := to
+		if sessions[i].LeftAt != nil && sessions[i].LeftAt.Before(to) {
+			end = *sessions[i].LeftAt
+		}
+
+		if !start.Before(end) {
+			continue
+		}
+
+		events = append(events, event{time: start, delta: 1}, event{time: end, delta: -1})
+	}
+
+	// A player leaving at the same time another one joins doesn't overlap with them
+	slices.SortFunc(events, func(a, b event) int {
+		if c := a.time.Compare(b.time); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.delta, b.delta)
+	})
+
+	peak, current := 0, 0
+	var peakTime time.Time
+
+	for _, e := range events {
+		current += e.delta
+
+		if current > peak {
+			peak = current
+			peakTime = e.time
+		}
+	}
+
+	return peak, peakTime
+}
@@ -0,0 +1,112 @@
+package playersessions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeakConcurrency(t *testing.T) {
+	at := func(minutes int) time.Time {
+		return testStart.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	tests := []struct {
+		name       string
+		sessions   []domain.PlayerSession
+		expectPeak int
+		expectTime time.Time
+	}{
+		{
+			name:       "no sessions",
+			expectPeak: 0,
+		},
+		{
+			name: "overlapping sessions",
+			sessions: []domain.PlayerSession{
+				{JoinedAt: at(0), LeftAt: lo.ToPtr(at(30))},
+				{JoinedAt: at(10), LeftAt: lo.ToPtr(at(20))},
+				{JoinedAt: at(15)},
+			},
+			expectPeak: 3,
+			expectTime: at(15),
+		},
+		{
+			name: "leave and join at the same time don't overlap",
+			sessions: []domain.PlayerSession{
+				{JoinedAt: at(0), LeftAt: lo.ToPtr(at(10))},
+				{JoinedAt: at(10), LeftAt: lo.ToPtr(at(20))},
+			},
+			expectPeak: 1,
+			expectTime: at(0),
+		},
+		{
+			name: "sessions are clipped to the range",
+			sessions: []domain.PlayerSession{
+				{JoinedAt: at(-30), LeftAt: lo.ToPtr(at(5))},
+				{JoinedAt: at(-10), LeftAt: lo.ToPtr(at(-5))},
+			},
+			expectPeak: 1,
+			expectTime: at(0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			peak, peakTime := PeakConcurrency(test.sessions, at(0), at(60))
+
+			assert.Equal(t, test.expectPeak, peak)
+			assert.Equal(t, test.expectTime, peakTime)
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	now := testStart.Add(2 * time.Hour)
+
+	sessions := []domain.PlayerSession{
+		{
+			ServerID:   1,
+			UniqID:     "STEAM_1",
+			Name:       "alice2",
+			JoinedAt:   testStart.Add(time.Hour),
+			LastSeenAt: now,
+		},
+		{
+			ServerID:   1,
+			UniqID:     "STEAM_1",
+			Name:       "alice",
+			JoinedAt:   testStart,
+			LeftAt:     lo.ToPtr(testStart.Add(30 * time.Minute)),
+			LastSeenAt: testStart.Add(30 * time.Minute),
+		},
+		{
+			ServerID:   2,
+			Name:       "bob",
+			JoinedAt:   testStart,
+			LeftAt:     lo.ToPtr(testStart.Add(10 * time.Minute)),
+			LastSeenAt: testStart.Add(10 * time.Minute),
+		},
+	}
+
+	summaries := Summarize(sessions, now)
+	require.Len(t, summaries, 2)
+
+	assert.Equal(t, "STEAM_1", summaries[0].UniqID)
+	assert.Equal(t, []string{"alice", "alice2"}, summaries[0].Names)
+	assert.Equal(t, []uint{1}, summaries[0].ServerIDs)
+	assert.Equal(t, 2, summaries[0].SessionsCount)
+	assert.Equal(t, 90*time.Minute, summaries[0].Playtime)
+	assert.Equal(t, testStart, summaries[0].FirstSeenAt)
+	assert.Equal(t, now, summaries[0].LastSeenAt)
+	assert.True(t, summaries[0].Online)
+
+	assert.Empty(t, summaries[1].UniqID)
+	assert.Equal(t, []string{"bob"}, summaries[1].Names)
+	assert.Equal(t, 10*time.Minute, summaries[1].Playtime)
+	assert.False(t, summaries[1].Online)
+}
@@ -0,0 +1,284 @@
+// Package playersessions tracks join and leave times of players by diffing successive players lists
+// of game servers, taken from query results or RCON.
+package playersessions
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// MaxGap is the longest time a player may be unobserved (for example, the panel was stopped)
+// to continue the same session. After a longer gap the session is closed at the last observation.
+const MaxGap = 10 * time.Minute
+
+// lookupLimit limits the number of sessions returned by the players lookup.
+const lookupLimit = 500
+
+// Player is an entry of a players list.
+type Player struct {
+	// UniqID is empty when the source doesn't provide it (query protocols).
+	UniqID string
+	Name   string
+}
+
+type Service struct {
+	repo repositories.PlayerSessionRepository
+
+	// Players lists of the same server from the poller and RCON must not be diffed concurrently.
+	mu sync.Mutex
+}
+
+func NewService(repo repositories.PlayerSessionRepository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// Record tracks players from a query result. It implements serverquery.ResultRecorder.
+func (s *Service) Record(ctx context.Context, serverID uint, result *query.Result) error {
+	// Some servers report only the number of players, the list is unknown
+	if result.Online && len(result.Players) == 0 && result.PlayersNum > 0 {
+		return nil
+	}
+
+	list := make([]Player, 0, len(result.Players))
+	for _, p := range result.Players {
+		list = append(list, Player{Name: p.Name})
+	}
+
+	return s.Track(ctx, serverID, list, result.QueryTime)
+}
+
+// TrackRconPlayers tracks players parsed from the RCON players command.
+func (s *Service) TrackRconPlayers(ctx context.Context, serverID uint, list []players.Player) error {
+	return s.Track(ctx, serverID, lo.Map(list, func(p players.Player, _ int) Player {
+		return Player{UniqID: p.UniqID, Name: p.Name}
+	}), time.Now())
+}
+
+// Track updates sessions of the server with the current players list:
+// sessions of absent players are closed, new players get new sessions.
+//
+// Players are matched to active sessions by UniqID first, then by name, so lists without unique IDs
+// (query results) continue sessions opened from RCON lists. A player who changed the name
+// gets a new session, so all names used by a unique ID are kept.
+func (s *Service) Track(ctx context.Context, serverID uint, list []Player, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active, err := s.repo.Find(ctx, &filters.FindPlayerSession{
+		ServerIDs: []uint{serverID},
+		Active:    lo.ToPtr(true),
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find active player sessions")
+	}
+
+	list = lo.Filter(list, func(p Player, _ int) bool {
+		return p.UniqID != "" || p.Name != ""
+	})
+
+	matches := matchSessions(active, list)
+	continued := make(map[uint]struct{}, len(matches))
+
+	for i, player := range list {
+		session, ok := matches[i]
+		if ok && canContinue(session, &player, now) {
+			continued[session.ID] = struct{}{}
+
+			session.LastSeenAt = now
+			if session.UniqID == "" {
+				session.UniqID = player.UniqID
+			}
+
+			if err = s.repo.Save(ctx, session); err != nil {
+				return errors.WithMessage(err, "failed to save player session")
+			}
+
+			continue
+		}
+
+		err = s.repo.Save(ctx, &domain.PlayerSession{
+			ServerID:   serverID,
+			UniqID:     player.UniqID,
+			Name:       player.Name,
+			JoinedAt:   now,
+			LastSeenAt: now,
+		})
+		if err != nil {
+			return errors.WithMessage(err, "failed to save player session")
+		}
+	}
+
+	for i := range active {
+		session := &active[i]
+
+		if _, ok := continued[session.ID]; ok {
+			continue
+		}
+
+		session.LeftAt = lo.ToPtr(leaveTime(session, now))
+
+		if err = s.repo.Save(ctx, session); err != nil {
+			return errors.WithMessage(err, "failed to close player session")
+		}
+	}
+
+	return nil
+}
+
+// matchSessions returns active sessions matched to players by index.
+func matchSessions(active []domain.PlayerSession, list []Player) map[int]*domain.PlayerSession {
+	matches := make(map[int]*domain.PlayerSession, len(list))
+	used := make(map[uint]struct{}, len(active))
+
+	match := func(fn func(session *domain.PlayerSession, player *Player) bool) {
+		for i := range list {
+			if _, ok := matches[i]; ok {
+				continue
+			}
+
+			for j := range active {
+				session := &active[j]
+
+				if _, ok := used[session.ID]; ok || !fn(session, &list[i]) {
+					continue
+				}
+
+				matches[i] = session
+				used[session.ID] = struct{}{}
+
+				break
+			}
+		}
+	}
+
+	match(func(session *domain.PlayerSession, player *Player) bool {
+		return player.UniqID != "" && session.UniqID == player.UniqID
+	})
+
+	match(func(session *domain.PlayerSession, player *Player) bool {
+		return session.Name == player.Name && (session.UniqID == "" || player.UniqID == "")
+	})
+
+	return matches
+}
+
+// canContinue reports whether the player observation continues the session.
+// If not, the session is closed and a new one is started.
+func canContinue(session *domain.PlayerSession, player *Player, now time.Time) bool {
+	if player.Name != "" && session.Name != player.Name {
+		return false
+	}
+
+	return now.Sub(session.LastSeenAt) <= MaxGap
+}
+
+// leaveTime is the current time, or the last observation if the player wasn't observed for too long.
+func leaveTime(session *domain.PlayerSession, now time.Time) time.Time {
+	if now.Sub(session.LastSeenAt) > MaxGap || now.Before(session.LastSeenAt) {
+		return session.LastSeenAt
+	}
+
+	return now
+}
+
+// ServerSessions returns sessions of the server overlapping the time range, ordered by join time.
+func (s *Service) ServerSessions(
+	ctx context.Context,
+	serverID uint,
+	from, to time.Time,
+) ([]domain.PlayerSession, error) {
+	sessions, err := s.repo.Find(ctx, &filters.FindPlayerSession{
+		ServerIDs:  []uint{serverID},
+		ActiveFrom: &from,
+		ActiveTo:   &to,
+	}, []filters.Sorting{
+		{Field: "joined_at", Direction: filters.SortDirectionAsc},
+	}, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find player sessions")
+	}
+
+	return sessions, nil
+}
+
+// Lookup returns sessions on all servers of players with the given unique IDs or names
+// overlapping the time range, most recent first. Sessions found by name are extended
+// with sessions of the same unique IDs, so other names of the player are included too.
+func (s *Service) Lookup(
+	ctx context.Context,
+	uniqIDs, names []string,
+	from, to time.Time,
+) ([]domain.PlayerSession, error) {
+	order := []filters.Sorting{
+		{Field: "joined_at", Direction: filters.SortDirectionDesc},
+	}
+	pagination := filters.NewPagination(lookupLimit, 0)
+
+	result := make([]domain.PlayerSession, 0)
+	seen := make(map[uint]struct{})
+
+	appendSessions := func(sessions []domain.PlayerSession) {
+		for i := range sessions {
+			if _, ok := seen[sessions[i].ID]; ok {
+				continue
+			}
+
+			seen[sessions[i].ID] = struct{}{}
+			result = append(result, sessions[i])
+		}
+	}
+
+	if len(names) > 0 {
+		sessions, err := s.repo.Find(ctx, &filters.FindPlayerSession{
+			Names:      names,
+			ActiveFrom: &from,
+			ActiveTo:   &to,
+		}, order, pagination)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to find player sessions by name")
+		}
+
+		appendSessions(sessions)
+
+		for i := range sessions {
+			if sessions[i].UniqID != "" && !lo.Contains(uniqIDs, sessions[i].UniqID) {
+				uniqIDs = append(uniqIDs, sessions[i].UniqID)
+			}
+		}
+	}
+
+	if len(uniqIDs) > 0 {
+		sessions, err := s.repo.Find(ctx, &filters.FindPlayerSession{
+			UniqIDs:    uniqIDs,
+			ActiveFrom: &from,
+			ActiveTo:   &to,
+		}, order, pagination)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to find player sessions by unique id")
+		}
+
+		appendSessions(sessions)
+	}
+
+	slices.SortStableFunc(result, func(a, b domain.PlayerSession) int {
+		return b.JoinedAt.Compare(a.JoinedAt)
+	})
+
+	if len(result) > lookupLimit {
+		result = result[:lookupLimit]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,276 @@
+package playersessions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/gameap/gameap/pkg/quercon/rcon/players"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStart = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func findSessions(t *testing.T, repo *inmemory.PlayerSessionRepository) []domain.PlayerSession {
+	t.Helper()
+
+	sessions, err := repo.Find(context.Background(), nil, []filters.Sorting{
+		{Field: "id", Direction: filters.SortDirectionAsc},
+	}, nil)
+	require.NoError(t, err)
+
+	return sessions
+}
+
+func TestService_Track_JoinAndLeave(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewPlayerSessionRepository()
+	service := NewService(repo)
+
+	t1 := testStart
+	t2 := testStart.Add(time.Minute)
+	t3 := testStart.Add(2 * time.Minute)
+
+	require.NoError(t, service.Track(ctx, 1, []Player{{Name: "alice"}, {Name: "bob"}}, t1))
+	require.NoError(t, service.Track(ctx, 1, []Player{{Name: "alice"}, {Name: "carol"}}, t2))
+	require.NoError(t, service.Track(ctx, 1, []Player{}, t3))
+
+	sessions := findSessions(t, repo)
+	require.Len(t, sessions, 3)
+
+	assert.Equal(t, "alice", sessions[0].Name)
+	assert.Equal(t, t1, sessions[0].JoinedAt)
+	assert.Equal(t, t2, sessions[0].LastSeenAt)
+	assert.Equal(t, lo.ToPtr(t3), sessions[0].LeftAt)
+
+	assert.Equal(t, "bob", sessions[1].Name)
+	assert.Equal(t, lo.ToPtr(t2), sessions[1].LeftAt)
+
+	assert.Equal(t, "carol", sessions[2].Name)
+	assert.Equal(t, t2, sessions[2].JoinedAt)
+	assert.Equal(t, lo.ToPtr(t3), sessions[2].LeftAt)
+}
+
+func TestService_Track_NameChange(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewPlayerSessionRepository()
+	service := NewService(repo)
+
+	t1 := testStart
+	t2 := testStart.Add(time.Minute)
+
+	require.NoError(t, service.Track(ctx, 1, []Player{{UniqID: "STEAM_1", Name: "alice"}}, t1))
+	require.NoError(t, service.Track(ctx, 1, []Player{{UniqID: "STEAM_1", Name: "alice2"}}, t2))
+
+	sessions := findSessions(t, repo)
+	require.Len(t, sessions, 2)
+
+	assert.Equal(t, "alice", sessions[0].Name)
+	assert.Equal(t, lo.ToPtr(t2), sessions[0].LeftAt)
+
+	assert.Equal(t, "alice2", sessions[1].Name)
+	assert.Equal(t, "STEAM_1", sessions[1].UniqID)
+	assert.True(t, sessions[1].IsActive())
+}
+
+func TestService_Track_RconAndQueryLists(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewPlayerSessionRepository()
+	service := NewService(repo)
+
+	t1 := testStart
+	t2 := testStart.Add(time.Minute)
+	t3 := testStart.Add(2 * time.Minute)
+
+	// Query list without unique IDs, then RCON list fills the unique ID, then query list again
+	require.NoError(t, service.Track(ctx, 1, []Player{{Name: "alice"}}, t1))
+	require.NoError(t, service.Track(ctx, 1, []Player{{UniqID: "STEAM_1", Name: "alice"}}, t2))
+	require.NoError(t, service.Track(ctx, 1, []Player{{Name: "alice"}}, t3))
+
+	sessions := findSessions(t, repo)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "STEAM_1", sessions[0].UniqID)
+	assert.Equal(t, t1, sessions[0].JoinedAt)
+	assert.Equal(t, t3, sessions[0].LastSeenAt)
+	assert.True(t, sessions[0].IsActive())
+}
+
+func TestService_Track_Gap(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewPlayerSessionRepository()
+	service := NewService(repo)
+
+	t1 := testStart
+	t2 := testStart.Add(MaxGap + time.Minute)
+
+	require.NoError(t, service.Track(ctx, 1, []Player{{Name: "alice"}}, t1))
+	require.NoError(t, service.Track(ctx, 1, []Player{{Name: "alice"}}, t2))
+
+	sessions := findSessions(t, repo)
+	require.Len(t, sessions, 2)
+
+	// The first session is closed at the last observation
+	assert.Equal(t, lo.ToPtr(t1), sessions[0].LeftAt)
+	assert.Equal(t, t2, sessions[1].JoinedAt)
+	assert.True(t, sessions[1].IsActive())
+}
+
+func TestService_Track_ServersAreIndependent(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewPlayerSessionRepository()
+	service := NewService(repo)
+
+	require.NoError(t, service.Track(ctx, 1, []Player{{Name: "alice"}}, testStart))
+	require.NoError(t, service.Track(ctx, 2, []Player{}, testStart.Add(time.Minute)))
+
+	sessions := findSessions(t, repo)
+	require.Len(t, sessions, 1)
+	assert.True(t, sessions[0].IsActive())
+}
+
+func TestService_Record(t *testing.T) {
+	tests := []struct {
+		name           string
+		result         *query.Result
+		expectSessions int
+	}{
+		{
+			name: "players list",
+			result: &query.Result{
+				Online:     true,
+				QueryTime:  testStart,
+				PlayersNum: 2,
+				Players:    []query.ResultPlayer{{Name: "alice"}, {Name: "bob"}},
+			},
+			expectSessions: 2,
+		},
+		{
+			name: "unknown players list",
+			result: &query.Result{
+				Online:     true,
+				QueryTime:  testStart,
+				PlayersNum: 5,
+			},
+			expectSessions: 0,
+		},
+		{
+			name: "players without names are skipped",
+			result: &query.Result{
+				Online:     true,
+				QueryTime:  testStart,
+				PlayersNum: 2,
+				Players:    []query.ResultPlayer{{Name: ""}, {Name: "bob"}},
+			},
+			expectSessions: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := inmemory.NewPlayerSessionRepository()
+			service := NewService(repo)
+
+			err := service.Record(context.Background(), 1, test.result)
+			require.NoError(t, err)
+
+			sessions := findSessions(t, repo)
+			assert.Len(t, sessions, test.expectSessions)
+
+			for _, session := range sessions {
+				assert.Equal(t, testStart, session.JoinedAt)
+			}
+		})
+	}
+}
+
+func TestService_Record_ServerOffline(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewPlayerSessionRepository()
+	service := NewService(repo)
+
+	require.NoError(t, service.Record(ctx, 1, &query.Result{
+		Online:     true,
+		QueryTime:  testStart,
+		PlayersNum: 1,
+		Players:    []query.ResultPlayer{{Name: "alice"}},
+	}))
+	require.NoError(t, service.Record(ctx, 1, &query.Result{
+		Online:    false,
+		QueryTime: testStart.Add(time.Minute),
+	}))
+
+	sessions := findSessions(t, repo)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, lo.ToPtr(testStart.Add(time.Minute)), sessions[0].LeftAt)
+}
+
+func TestService_TrackRconPlayers(t *testing.T) {
+	repo := inmemory.NewPlayerSessionRepository()
+	service := NewService(repo)
+
+	err := service.TrackRconPlayers(context.Background(), 1, []players.Player{
+		{ID: "2", Name: "alice", UniqID: "STEAM_1"},
+	})
+	require.NoError(t, err)
+
+	sessions := findSessions(t, repo)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "STEAM_1", sessions[0].UniqID)
+	assert.Equal(t, "alice", sessions[0].Name)
+}
+
+func TestService_Lookup(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewPlayerSessionRepository()
+	service := NewService(repo)
+
+	require.NoError(t, service.Track(ctx, 1, []Player{{UniqID: "STEAM_1", Name: "alice"}}, testStart))
+	require.NoError(t, service.Track(ctx, 2, []Player{{UniqID: "STEAM_1", Name: "al"}}, testStart))
+	require.NoError(t, service.Track(ctx, 2, []Player{{Name: "bob"}}, testStart.Add(time.Minute)))
+
+	from := testStart.Add(-time.Hour)
+	to := testStart.Add(time.Hour)
+
+	t.Run("by name includes other names of the unique id", func(t *testing.T) {
+		sessions, err := service.Lookup(ctx, nil, []string{"alice"}, from, to)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+
+		names := lo.Map(sessions, func(s domain.PlayerSession, _ int) string { return s.Name })
+		assert.ElementsMatch(t, []string{"alice", "al"}, names)
+	})
+
+	t.Run("by unique id", func(t *testing.T) {
+		sessions, err := service.Lookup(ctx, []string{"STEAM_1"}, nil, from, to)
+		require.NoError(t, err)
+		assert.Len(t, sessions, 2)
+	})
+
+	t.Run("outside of range", func(t *testing.T) {
+		sessions, err := service.Lookup(ctx, nil, []string{"bob"}, from, testStart)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+}
+
+func TestService_ServerSessions(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewPlayerSessionRepository()
+	service := NewService(repo)
+
+	require.NoError(t, service.Track(ctx, 1, []Player{{Name: "alice"}}, testStart))
+	require.NoError(t, service.Track(ctx, 1, []Player{{Name: "bob"}}, testStart.Add(time.Minute)))
+	require.NoError(t, service.Track(ctx, 2, []Player{{Name: "carol"}}, testStart))
+
+	sessions, err := service.ServerSessions(ctx, 1, testStart, testStart.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "alice", sessions[0].Name)
+	assert.Equal(t, "bob", sessions[1].Name)
+}
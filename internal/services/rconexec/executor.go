@@ -0,0 +1,104 @@
+// Package rconexec executes RCON commands on game servers.
+// The RCON protocol is configured for the game or determined by the game engine and code.
+package rconexec
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/pkg/quercon/rcon"
+	"github.com/pkg/errors"
+)
+
+const defaultExecuteTimeout = 10 * time.Second
+
+var (
+	ErrServerOffline             = errors.New("server is offline")
+	ErrRconPasswordNotConfigured = errors.New("rcon password not configured for server")
+	ErrUnsupportedProtocol       = errors.New("unsupported game")
+	ErrAuthenticationFailed      = errors.New("rcon authentication failed")
+	ErrConnectionFailed          = errors.New("failed to connect to rcon")
+	ErrCommandFailed             = errors.New("failed to execute rcon command")
+)
+
+type gameFinder interface {
+	FindServerGame(ctx context.Context, server *domain.Server) (*domain.Game, error)
+}
+
+// Executor opens an RCON connection for every command.
+type Executor struct {
+	games   gameFinder
+	timeout time.Duration
+}
+
+func NewExecutor(games gameFinder) *Executor {
+	return &Executor{
+		games:   games,
+		timeout: defaultExecuteTimeout,
+	}
+}
+
+// Execute finds the game of the server, executes the command and returns the output.
+func (e *Executor) Execute(ctx context.Context, server *domain.Server, command string) (string, error) {
+	if !server.IsOnline() {
+		return "", ErrServerOffline
+	}
+
+	game, err := e.games.FindServerGame(ctx, server)
+	if err != nil {
+		return "", err
+	}
+
+	return e.ExecuteGame(ctx, server, game, command)
+}
+
+// ExecuteGame executes the command on the server of the game found by the caller.
+// Unlike Execute, it doesn't check that the server is online.
+func (e *Executor) ExecuteGame(
+	ctx context.Context,
+	server *domain.Server,
+	game *domain.Game,
+	command string,
+) (string, error) {
+	if server.Rcon == nil || *server.Rcon == "" {
+		return "", ErrRconPasswordNotConfigured
+	}
+
+	protocol, err := DetermineProtocol(*game)
+	if err != nil {
+		return "", errors.WithMessage(ErrUnsupportedProtocol, err.Error())
+	}
+
+	client, err := rcon.NewClient(rcon.Config{
+		Address:  fmt.Sprintf("%s:%d", server.ServerIP, server.ResolveRconPort(game)),
+		Password: *server.Rcon,
+		Protocol: protocol,
+		Timeout:  e.timeout,
+	})
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to create rcon client")
+	}
+
+	if err = client.Open(ctx); err != nil {
+		if errors.Is(err, rcon.ErrAuthenticationFailed) {
+			return "", ErrAuthenticationFailed
+		}
+
+		return "", errors.WithMessage(ErrConnectionFailed, err.Error())
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			slog.WarnContext(ctx, "failed to close rcon client", slog.String("error", err.Error()))
+		}
+	}()
+
+	output, err := client.Execute(ctx, command)
+	if err != nil {
+		return "", errors.WithMessage(ErrCommandFailed, err.Error())
+	}
+
+	return output, nil
+}
@@ -0,0 +1,112 @@
+package rconexec
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errGameNotFound = errors.New("game for server not found")
+
+type stubGameFinder struct {
+	game *domain.Game
+}
+
+func (f stubGameFinder) FindServerGame(_ context.Context, _ *domain.Server) (*domain.Game, error) {
+	if f.game == nil {
+		return nil, errGameNotFound
+	}
+
+	return f.game, nil
+}
+
+// closedTCPPort returns a local port nothing listens on.
+func closedTCPPort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	tcpAddr, ok := listener.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+	require.NoError(t, listener.Close())
+
+	return tcpAddr.Port
+}
+
+func TestExecutor_Execute_Errors(t *testing.T) {
+	sourceGame := &domain.Game{Code: "cstrike", Engine: "Source"}
+
+	tests := []struct {
+		name        string
+		server      domain.Server
+		game        *domain.Game
+		expectError error
+	}{
+		{
+			name: "server is offline",
+			server: domain.Server{
+				Rcon: lo.ToPtr("password"),
+			},
+			game:        sourceGame,
+			expectError: ErrServerOffline,
+		},
+		{
+			name: "game not found",
+			server: domain.Server{
+				Rcon:             lo.ToPtr("password"),
+				ProcessActive:    true,
+				LastProcessCheck: lo.ToPtr(time.Now()),
+			},
+			expectError: errGameNotFound,
+		},
+		{
+			name: "rcon password not configured",
+			server: domain.Server{
+				ProcessActive:    true,
+				LastProcessCheck: lo.ToPtr(time.Now()),
+			},
+			game:        sourceGame,
+			expectError: ErrRconPasswordNotConfigured,
+		},
+		{
+			name: "unsupported protocol",
+			server: domain.Server{
+				Rcon:             lo.ToPtr("password"),
+				ProcessActive:    true,
+				LastProcessCheck: lo.ToPtr(time.Now()),
+			},
+			game:        &domain.Game{Code: "unknown", Engine: "Unknown"},
+			expectError: ErrUnsupportedProtocol,
+		},
+		{
+			name: "connection failed",
+			server: domain.Server{
+				ServerIP:         "127.0.0.1",
+				ServerPort:       closedTCPPort(t),
+				Rcon:             lo.ToPtr("password"),
+				ProcessActive:    true,
+				LastProcessCheck: lo.ToPtr(time.Now()),
+			},
+			game:        sourceGame,
+			expectError: ErrConnectionFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			executor := NewExecutor(stubGameFinder{game: test.game})
+
+			_, err := executor.Execute(context.Background(), &test.server, "status")
+			require.Error(t, err)
+			assert.ErrorIs(t, err, test.expectError)
+		})
+	}
+}
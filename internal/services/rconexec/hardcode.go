@@ -0,0 +1,92 @@
+package rconexec
+
+import (
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/pkg/quercon/rcon"
+	"github.com/pkg/errors"
+)
+
+// mapProtocolByGameCode and mapProtocolByEngine are the defaults for games without a configured RCON protocol.
+var mapProtocolByGameCode = map[string]rcon.Protocol{
+	"7d2d":      rcon.ProtocolTelnet,  // 7 Days to Die
+	"bms":       rcon.ProtocolSource,  // Black Mesa: Source
+	"cod2":      rcon.ProtocolQuake3,  // Call of Duty 2
+	"cod4":      rcon.ProtocolQuake3,  // Call of Duty 4: Modern Warfare
+	"cs":        rcon.ProtocolGoldSrc, // Counter-Strike 1.6
+	"cs2":       rcon.ProtocolSource,  // Counter-Strike 2
+	"csgo":      rcon.ProtocolSource,  // Counter-Strike: Global Offensive
+	"cssource":  rcon.ProtocolSource,  // Counter-Strike: Source
+	"cssv34":    rcon.ProtocolSource,  // Counter-Strike: Source v34
+	"cstrike":   rcon.ProtocolGoldSrc, // Counter-Strike 1.6
+	"czero":     rcon.ProtocolSource,  // Counter-Strike: Condition Zero
+	"dmc":       rcon.ProtocolSource,  // Deathmatch Classic
+	"dod":       rcon.ProtocolGoldSrc, // Day of Defeat
+	"dods":      rcon.ProtocolSource,  // Day of Defeat: Source
+	"et":        rcon.ProtocolQuake3,  // Wolfenstein: Enemy Territory
+	"garrysmod": rcon.ProtocolSource,  // Garry's Mod
+	"gearbox":   rcon.ProtocolGoldSrc, // Half-Life: Opposing Force
+	"hl":        rcon.ProtocolGoldSrc, // Half-Life
+	"hl2mp":     rcon.ProtocolSource,  // Half-Life 2: Deathmatch
+	"l4d":       rcon.ProtocolSource,  // Left 4 Dead
+	"l4d2":      rcon.ProtocolSource,  // Left 4 Dead 2
+	"minecraft": rcon.ProtocolSource,  // Minecraft
+	"op4":       rcon.ProtocolGoldSrc, // Half-Life: Opposing Force
+	"q3":        rcon.ProtocolQuake3,  // Quake 3 Arena
+	"quake3":    rcon.ProtocolQuake3,  // Quake 3 Arena
+	"ricochet":  rcon.ProtocolGoldSrc, // Ricochet
+	"sdtd":      rcon.ProtocolTelnet,  // 7 Days to Die
+	"svencoop":  rcon.ProtocolGoldSrc, // Sven Co-op
+	"tf2":       rcon.ProtocolSource,  // Team Fortress 2
+	"tfc":       rcon.ProtocolGoldSrc, // Team Fortress Classic
+	"urt":       rcon.ProtocolQuake3,  // Urban Terror
+	"valve":     rcon.ProtocolGoldSrc, // Half-Life
+}
+
+var mapProtocolByEngine = map[string]rcon.Protocol{
+	"goldsource": rcon.ProtocolGoldSrc,
+	"goldsrc":    rcon.ProtocolGoldSrc,
+	"source":     rcon.ProtocolSource,
+	"minecraft":  rcon.ProtocolSource,
+	"quake3":     rcon.ProtocolQuake3,
+	"idtech3":    rcon.ProtocolQuake3,
+}
+
+// DetermineProtocol returns the RCON protocol configured for the game.
+// If it isn't configured, the protocol is determined by the game engine and then by the game code.
+func DetermineProtocol(game domain.Game) (rcon.Protocol, error) {
+	if game.RconProtocol != nil && *game.RconProtocol != "" {
+		protocol := rcon.Protocol(*game.RconProtocol)
+		if !rcon.IsProtocolSupported(protocol) {
+			return "", errors.Errorf("unsupported RCON protocol: %s", protocol)
+		}
+
+		return protocol, nil
+	}
+
+	protocol, err := DetermineProtocolByEngine(game.Engine)
+	if err == nil {
+		return protocol, nil
+	}
+
+	return DetermineProtocolByGameCode(game.Code)
+}
+
+func DetermineProtocolByEngine(engine string) (rcon.Protocol, error) {
+	engine = strings.ToLower(engine)
+
+	if protocol, ok := mapProtocolByEngine[engine]; ok {
+		return protocol, nil
+	}
+
+	return "", errors.Errorf("unable to determine RCON protocol for engine: %s", engine)
+}
+
+func DetermineProtocolByGameCode(gameCode string) (rcon.Protocol, error) {
+	if protocol, ok := mapProtocolByGameCode[gameCode]; ok {
+		return protocol, nil
+	}
+
+	return "", errors.Errorf("unable to determine RCON protocol for game code: %s", gameCode)
+}
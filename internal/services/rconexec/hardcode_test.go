@@ -0,0 +1,165 @@
+package rconexec
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetermineProtocolByEngine(t *testing.T) {
+	tests := []struct {
+		name     string
+		engine   string
+		want     string
+		wantErr  bool
+		errorMsg string
+	}{
+		{
+			name:    "source_engine",
+			engine:  "source",
+			want:    "source",
+			wantErr: false,
+		},
+		{
+			name:    "goldsource_engine",
+			engine:  "goldsource",
+			want:    "goldsource",
+			wantErr: false,
+		},
+		{
+			name:    "quake3_engine",
+			engine:  "Quake3",
+			want:    "quake3",
+			wantErr: false,
+		},
+		{
+			name:     "unsupported_engine",
+			engine:   "unreal",
+			wantErr:  true,
+			errorMsg: "unable to determine RCON protocol for engine",
+		},
+		{
+			name:     "empty_engine",
+			engine:   "",
+			wantErr:  true,
+			errorMsg: "unable to determine RCON protocol for engine",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protocol, err := DetermineProtocolByEngine(tt.engine)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(protocol))
+			}
+		})
+	}
+}
+
+func TestDetermineProtocolByGameCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameCode string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "goldsource_game",
+			gameCode: "cstrike",
+			want:     "goldsource",
+		},
+		{
+			name:     "source_game",
+			gameCode: "tf2",
+			want:     "source",
+		},
+		{
+			name:     "telnet_game",
+			gameCode: "7d2d",
+			want:     "telnet",
+		},
+		{
+			name:     "quake3_game",
+			gameCode: "urt",
+			want:     "quake3",
+		},
+		{
+			name:     "unknown_game",
+			gameCode: "unknown",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protocol, err := DetermineProtocolByGameCode(tt.gameCode)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "unable to determine RCON protocol for game code")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(protocol))
+			}
+		})
+	}
+}
+
+func TestDetermineProtocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		game     domain.Game
+		want     string
+		errorMsg string
+	}{
+		{
+			name: "configured_protocol",
+			game: domain.Game{Code: "rust", Engine: "unity", RconProtocol: lo.ToPtr("source")},
+			want: "source",
+		},
+		{
+			name: "configured_protocol_overrides_engine",
+			game: domain.Game{Code: "7d2d", Engine: "source", RconProtocol: lo.ToPtr("telnet")},
+			want: "telnet",
+		},
+		{
+			name: "empty_configured_protocol_falls_back_to_engine",
+			game: domain.Game{Code: "cs", Engine: "goldsource", RconProtocol: lo.ToPtr("")},
+			want: "goldsource",
+		},
+		{
+			name: "falls_back_to_game_code",
+			game: domain.Game{Code: "7d2d", Engine: "unity"},
+			want: "telnet",
+		},
+		{
+			name:     "unsupported_configured_protocol",
+			game:     domain.Game{Code: "cs", Engine: "goldsource", RconProtocol: lo.ToPtr("battleye")},
+			errorMsg: "unsupported RCON protocol",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			protocol, err := DetermineProtocol(tt.game)
+
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(protocol))
+		})
+	}
+}
@@ -0,0 +1,387 @@
+// Package servermaps manages the maps of GoldSrc and Source servers: the installed maps are found
+// in the maps directory of the game, the map rotation is stored in mapcycle.txt and maplist.txt.
+package servermaps
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/internal/services/serverquery"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const listFilePerm = 0o644
+
+var (
+	ErrMapsNotSupported = errors.New("map management is not supported for this game")
+	ErrInvalidMapName   = errors.New("invalid map name")
+	ErrMapNotInstalled  = errors.New("map is not installed")
+	ErrNodeNotFound     = errors.New("node not found")
+)
+
+var mapNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_\-.+]{1,64}$`)
+
+// List is a map rotation file in the game directory.
+type List string
+
+const (
+	// ListMapCycle is the map rotation of the server.
+	ListMapCycle List = "mapcycle"
+
+	// ListMapList is the list of maps offered in map votes.
+	ListMapList List = "maplist"
+)
+
+func (l List) IsValid() bool {
+	return l == ListMapCycle || l == ListMapList
+}
+
+func (l List) FileName() string {
+	return string(l) + ".txt"
+}
+
+type engineRules struct {
+	// extensions are the extensions of the map files in the maps directory.
+	extensions []string
+
+	// listDirs are the directories of the map list files relative to the game directory in the order
+	// the engine looks for them. New files are created in the first one.
+	listDirs []string
+}
+
+var (
+	goldSrcRules = engineRules{extensions: []string{".bsp"}, listDirs: []string{""}}
+
+	// Source engine games updated to SteamPipe read cfg/mapcycle.txt first,
+	// older ones (e.g. CS:GO) keep the file in the game directory.
+	sourceRules = engineRules{extensions: []string{".bsp"}, listDirs: []string{"cfg", ""}}
+)
+
+// mapRulesByEngine are the map file rules by the lowercase game engine.
+var mapRulesByEngine = map[string]engineRules{
+	"goldsource": goldSrcRules,
+	"goldsrc":    goldSrcRules,
+	"source":     sourceRules,
+}
+
+// gameDirs are the game directories of the servers relative to the server directory.
+var gameDirs = map[string]string{
+	"cs":        "cstrike",
+	"cstrike":   "cstrike",
+	"czero":     "czero",
+	"tfc":       "tfc",
+	"dod":       "dod",
+	"gearbox":   "gearbox",
+	"hl":        "valve",
+	"valve":     "valve",
+	"bms":       "bms",
+	"csgo":      "csgo",
+	"cssource":  "cstrike",
+	"cssv34":    "cstrike",
+	"dods":      "dod",
+	"garrysmod": "garrysmod",
+	"hl2mp":     "hl2mp",
+	"l4d":       "left4dead",
+	"l4d2":      "left4dead2",
+	"tf2":       "tf",
+}
+
+type MapsResult struct {
+	// CurrentMap is the map from the latest query result, empty when the server is offline or not queried.
+	CurrentMap string
+	Maps       []string
+}
+
+type gameFinder interface {
+	FindServerGame(ctx context.Context, server *domain.Server) (*domain.Game, error)
+}
+
+type fileStorage interface {
+	ReadDir(ctx context.Context, node *domain.Node, directory string) ([]*daemon.FileInfo, error)
+	Download(ctx context.Context, node *domain.Node, filePath string) ([]byte, error)
+	Upload(ctx context.Context, node *domain.Node, filePath string, content []byte, perms os.FileMode) error
+}
+
+type queryStore interface {
+	Get(serverID uint) (serverquery.State, bool)
+}
+
+type Service struct {
+	nodeRepo     repositories.NodeRepository
+	games        gameFinder
+	files        fileStorage
+	queryResults queryStore
+}
+
+func NewService(
+	nodeRepo repositories.NodeRepository,
+	games gameFinder,
+	files fileStorage,
+	queryResults queryStore,
+) *Service {
+	return &Service{
+		nodeRepo:     nodeRepo,
+		games:        games,
+		files:        files,
+		queryResults: queryResults,
+	}
+}
+
+// IsValidMapName reports whether the name can be used as a map name in commands and map lists.
+func IsValidMapName(name string) bool {
+	return mapNameRegexp.MatchString(name)
+}
+
+// Maps returns the installed maps sorted by name and the current map of the server.
+func (s *Service) Maps(ctx context.Context, server *domain.Server) (*MapsResult, error) {
+	maps, err := s.InstalledMaps(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MapsResult{
+		CurrentMap: s.CurrentMap(server),
+		Maps:       maps,
+	}, nil
+}
+
+// InstalledMaps returns the names of the map files in the maps directory of the game.
+func (s *Service) InstalledMaps(ctx context.Context, server *domain.Server) ([]string, error) {
+	gameDir, rules, err := s.findRules(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := s.findNode(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.files.ReadDir(ctx, node, filepath.Join(node.WorkPath, server.Dir, gameDir, "maps"))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read maps directory")
+	}
+
+	maps := make([]string, 0, len(files))
+
+	for _, file := range files {
+		if file.Type == daemon.FileTypeDir {
+			continue
+		}
+
+		ext := path.Ext(file.Name)
+		if !slices.Contains(rules.extensions, strings.ToLower(ext)) {
+			continue
+		}
+
+		maps = append(maps, strings.TrimSuffix(file.Name, ext))
+	}
+
+	slices.Sort(maps)
+
+	return slices.Compact(maps), nil
+}
+
+// CurrentMap returns the map from the latest query result of the server.
+func (s *Service) CurrentMap(server *domain.Server) string {
+	state, ok := s.queryResults.Get(server.ID)
+	if !ok || state.Stale || !state.Result.Online {
+		return ""
+	}
+
+	return state.Result.Map
+}
+
+// List returns the maps of the map list file, an empty list when the file doesn't exist.
+func (s *Service) List(ctx context.Context, server *domain.Server, list List) ([]string, error) {
+	gameDir, rules, err := s.findRules(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := s.findNode(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	_, data, err := s.readList(ctx, node, filepath.Join(node.WorkPath, server.Dir, gameDir), rules, list)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMapList(data), nil
+}
+
+// SaveList replaces the maps of the map list file. All maps must be installed.
+// The comments at the beginning of the existing file are kept.
+func (s *Service) SaveList(ctx context.Context, server *domain.Server, list List, maps []string) error {
+	for _, name := range maps {
+		if !IsValidMapName(name) {
+			return errors.WithMessage(ErrInvalidMapName, name)
+		}
+	}
+
+	installed, err := s.InstalledMaps(ctx, server)
+	if err != nil {
+		return err
+	}
+
+	if missing, ok := lo.Find(maps, func(name string) bool { return !slices.Contains(installed, name) }); ok {
+		return errors.WithMessage(ErrMapNotInstalled, missing)
+	}
+
+	gameDir, rules, err := s.findRules(ctx, server)
+	if err != nil {
+		return err
+	}
+
+	node, err := s.findNode(ctx, server)
+	if err != nil {
+		return err
+	}
+
+	filePath, existing, err := s.readList(ctx, node, filepath.Join(node.WorkPath, server.Dir, gameDir), rules, list)
+	if err != nil {
+		return err
+	}
+
+	err = s.files.Upload(ctx, node, filePath, formatMapList(existing, maps), listFilePerm)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to upload %s", list.FileName())
+	}
+
+	return nil
+}
+
+// IsInstalled reports whether the map is installed on the server.
+func (s *Service) IsInstalled(ctx context.Context, server *domain.Server, name string) (bool, error) {
+	installed, err := s.InstalledMaps(ctx, server)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(installed, name), nil
+}
+
+func (s *Service) findRules(ctx context.Context, server *domain.Server) (string, engineRules, error) {
+	game, err := s.games.FindServerGame(ctx, server)
+	if err != nil {
+		return "", engineRules{}, err
+	}
+
+	rules, ok := mapRulesByEngine[strings.ToLower(game.Engine)]
+	if !ok {
+		return "", engineRules{}, ErrMapsNotSupported
+	}
+
+	gameDir, ok := gameDirs[game.Code]
+	if !ok {
+		return "", engineRules{}, ErrMapsNotSupported
+	}
+
+	return gameDir, rules, nil
+}
+
+func (s *Service) findNode(ctx context.Context, server *domain.Server) (*domain.Node, error) {
+	nodes, err := s.nodeRepo.Find(ctx, filters.FindNodeByIDs(server.DSID), nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find node")
+	}
+
+	if len(nodes) == 0 {
+		return nil, ErrNodeNotFound
+	}
+
+	return &nodes[0], nil
+}
+
+// readList finds the map list file in the list directories of the engine and downloads it.
+// It returns the path of the file and its content, nil content when the file doesn't exist
+// and the path in the first list directory.
+// The daemon doesn't report missing files separately, so the directories are read first.
+func (s *Service) readList(
+	ctx context.Context,
+	node *domain.Node,
+	gameDir string,
+	rules engineRules,
+	list List,
+) (string, []byte, error) {
+	for _, listDir := range rules.listDirs {
+		dir := filepath.Join(gameDir, listDir)
+
+		files, err := s.files.ReadDir(ctx, node, dir)
+		if err != nil {
+			return "", nil, errors.WithMessagef(err, "failed to read %s directory", dir)
+		}
+
+		exists := lo.ContainsBy(files, func(file *daemon.FileInfo) bool {
+			return file.Name == list.FileName() && file.Type != daemon.FileTypeDir
+		})
+		if !exists {
+			continue
+		}
+
+		filePath := filepath.Join(dir, list.FileName())
+
+		data, err := s.files.Download(ctx, node, filePath)
+		if err != nil {
+			return "", nil, errors.WithMessagef(err, "failed to download %s", list.FileName())
+		}
+
+		return filePath, data, nil
+	}
+
+	return filepath.Join(gameDir, rules.listDirs[0], list.FileName()), nil, nil
+}
+
+// parseMapList returns the maps of the list, one map per line. Blank lines and // comments are skipped.
+func parseMapList(data []byte) []string {
+	maps := make([]string, 0)
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "//")
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		maps = append(maps, fields[0])
+	}
+
+	return maps
+}
+
+// formatMapList writes the maps one per line after the leading comments of the existing file.
+func formatMapList(existing []byte, maps []string) []byte {
+	var b strings.Builder
+
+	for _, line := range strings.Split(string(existing), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "//") {
+			break
+		}
+
+		if trimmed != "" {
+			b.WriteString(strings.TrimRight(line, "\r"))
+			b.WriteString("\n")
+		}
+	}
+
+	for _, name := range maps {
+		b.WriteString(name)
+		b.WriteString("\n")
+	}
+
+	return []byte(b.String())
+}
@@ -0,0 +1,307 @@
+package servermaps
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/internal/services/serverquery"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGameDir      = "/srv/gameap/servers/test/cstrike"
+	testMapsDir      = testGameDir + "/maps"
+	testMapCyclePath = testGameDir + "/mapcycle.txt"
+)
+
+const testMapCycle = `// Map cycle
+// edited by hand
+
+de_dust2
+de_inferno // classic
+cs_office
+`
+
+type fakeFiles struct {
+	dirs  map[string][]*daemon.FileInfo
+	files map[string][]byte
+}
+
+func (f *fakeFiles) ReadDir(_ context.Context, _ *domain.Node, directory string) ([]*daemon.FileInfo, error) {
+	files, ok := f.dirs[directory]
+	if !ok {
+		return nil, errors.New("directory not found")
+	}
+
+	return files, nil
+}
+
+func (f *fakeFiles) Download(_ context.Context, _ *domain.Node, filePath string) ([]byte, error) {
+	data, ok := f.files[filePath]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+
+	return data, nil
+}
+
+func (f *fakeFiles) Upload(_ context.Context, _ *domain.Node, filePath string, content []byte, _ os.FileMode) error {
+	f.files[filePath] = content
+
+	return nil
+}
+
+type fakeGameFinder struct{}
+
+func (f *fakeGameFinder) FindServerGame(_ context.Context, server *domain.Server) (*domain.Game, error) {
+	switch server.GameID {
+	case "cstrike":
+		return &domain.Game{Code: "cstrike", Engine: "GoldSource"}, nil
+	case "tf2":
+		return &domain.Game{Code: "tf2", Engine: "Source"}, nil
+	default:
+		return &domain.Game{Code: server.GameID, Engine: "Minecraft"}, nil
+	}
+}
+
+type testEnv struct {
+	service *Service
+	files   *fakeFiles
+	store   *serverquery.Store
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	nodeRepo := inmemory.NewNodeRepository()
+	require.NoError(t, nodeRepo.Save(context.Background(), &domain.Node{
+		ID:       1,
+		Enabled:  true,
+		Name:     "node",
+		WorkPath: "/srv/gameap",
+	}))
+
+	env := &testEnv{
+		files: &fakeFiles{
+			dirs: map[string][]*daemon.FileInfo{
+				testGameDir: {
+					{Name: "maps", Type: daemon.FileTypeDir},
+					{Name: "mapcycle.txt", Type: daemon.FileTypeFile},
+				},
+				testMapsDir: {
+					{Name: "de_inferno.bsp", Type: daemon.FileTypeFile},
+					{Name: "de_dust2.bsp", Type: daemon.FileTypeFile},
+					{Name: "de_dust2.res", Type: daemon.FileTypeFile},
+					{Name: "cs_office.BSP", Type: daemon.FileTypeFile},
+					{Name: "graphs", Type: daemon.FileTypeDir},
+				},
+			},
+			files: map[string][]byte{
+				testMapCyclePath: []byte(testMapCycle),
+			},
+		},
+		store: serverquery.NewStore(time.Minute),
+	}
+
+	env.service = NewService(nodeRepo, &fakeGameFinder{}, env.files, env.store)
+
+	return env
+}
+
+func newTestServer(gameID string) *domain.Server {
+	return &domain.Server{
+		ID:      1,
+		Enabled: true,
+		GameID:  gameID,
+		DSID:    1,
+		Dir:     "servers/test",
+	}
+}
+
+func TestService_Maps(t *testing.T) {
+	env := newTestEnv(t)
+	env.store.Set(1, query.Result{Online: true, Map: "de_dust2"})
+
+	result, err := env.service.Maps(context.Background(), newTestServer("cstrike"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "de_dust2", result.CurrentMap)
+	assert.Equal(t, []string{"cs_office", "de_dust2", "de_inferno"}, result.Maps)
+}
+
+func TestService_Maps_NotSupported(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.service.Maps(context.Background(), newTestServer("minecraft"))
+	require.ErrorIs(t, err, ErrMapsNotSupported)
+}
+
+func TestService_CurrentMap(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *query.Result
+		want   string
+	}{
+		{
+			name: "not queried",
+		},
+		{
+			name:   "offline",
+			result: &query.Result{Online: false, Map: "de_dust2"},
+		},
+		{
+			name:   "online",
+			result: &query.Result{Online: true, Map: "de_nuke"},
+			want:   "de_nuke",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			if tt.result != nil {
+				env.store.Set(1, *tt.result)
+			}
+
+			assert.Equal(t, tt.want, env.service.CurrentMap(newTestServer("cstrike")))
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	env := newTestEnv(t)
+	server := newTestServer("cstrike")
+
+	maps, err := env.service.List(context.Background(), server, ListMapCycle)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"de_dust2", "de_inferno", "cs_office"}, maps)
+
+	maps, err = env.service.List(context.Background(), server, ListMapList)
+	require.NoError(t, err)
+	assert.Empty(t, maps)
+}
+
+func TestService_SaveList(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     List
+		maps     []string
+		wantErr  error
+		wantPath string
+		want     string
+	}{
+		{
+			name:    "invalid map name",
+			list:    ListMapCycle,
+			maps:    []string{"de_dust2; quit"},
+			wantErr: ErrInvalidMapName,
+		},
+		{
+			name:    "map is not installed",
+			list:    ListMapCycle,
+			maps:    []string{"de_dust2", "de_nuke"},
+			wantErr: ErrMapNotInstalled,
+		},
+		{
+			name:     "keep leading comments",
+			list:     ListMapCycle,
+			maps:     []string{"cs_office", "de_dust2"},
+			wantPath: testMapCyclePath,
+			want:     "// Map cycle\n// edited by hand\ncs_office\nde_dust2\n",
+		},
+		{
+			name:     "create map list",
+			list:     ListMapList,
+			maps:     []string{"de_inferno"},
+			wantPath: testGameDir + "/maplist.txt",
+			want:     "de_inferno\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+
+			err := env.service.SaveList(context.Background(), newTestServer("cstrike"), tt.list, tt.maps)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, testMapCycle, string(env.files.files[testMapCyclePath]))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(env.files.files[tt.wantPath]))
+		})
+	}
+}
+
+func TestService_SaveList_Source(t *testing.T) {
+	const tfDir = "/srv/gameap/servers/test/tf"
+
+	tests := []struct {
+		name     string
+		rootDir  []*daemon.FileInfo
+		cfgDir   []*daemon.FileInfo
+		files    map[string][]byte
+		wantPath string
+		want     string
+	}{
+		{
+			name:     "create in cfg directory",
+			rootDir:  []*daemon.FileInfo{{Name: "cfg", Type: daemon.FileTypeDir}},
+			cfgDir:   []*daemon.FileInfo{{Name: "mapcycle_default.txt", Type: daemon.FileTypeFile}},
+			files:    map[string][]byte{},
+			wantPath: tfDir + "/cfg/mapcycle.txt",
+			want:     "ctf_2fort\n",
+		},
+		{
+			name:     "update in cfg directory",
+			rootDir:  []*daemon.FileInfo{{Name: "cfg", Type: daemon.FileTypeDir}},
+			cfgDir:   []*daemon.FileInfo{{Name: "mapcycle.txt", Type: daemon.FileTypeFile}},
+			files:    map[string][]byte{tfDir + "/cfg/mapcycle.txt": []byte("// cfg\ncp_dustbowl\n")},
+			wantPath: tfDir + "/cfg/mapcycle.txt",
+			want:     "// cfg\nctf_2fort\n",
+		},
+		{
+			name: "update in game directory",
+			rootDir: []*daemon.FileInfo{
+				{Name: "cfg", Type: daemon.FileTypeDir},
+				{Name: "mapcycle.txt", Type: daemon.FileTypeFile},
+			},
+			cfgDir:   []*daemon.FileInfo{},
+			files:    map[string][]byte{tfDir + "/mapcycle.txt": []byte("// root\ncp_dustbowl\n")},
+			wantPath: tfDir + "/mapcycle.txt",
+			want:     "// root\nctf_2fort\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.files.dirs[tfDir] = tt.rootDir
+			env.files.dirs[tfDir+"/cfg"] = tt.cfgDir
+			env.files.dirs[tfDir+"/maps"] = []*daemon.FileInfo{{Name: "ctf_2fort.bsp", Type: daemon.FileTypeFile}}
+			env.files.files = tt.files
+
+			err := env.service.SaveList(context.Background(), newTestServer("tf2"), ListMapCycle, []string{"ctf_2fort"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(env.files.files[tt.wantPath]))
+			assert.Len(t, env.files.files, 1)
+		})
+	}
+}
+
+func TestParseMapList(t *testing.T) {
+	assert.Equal(t, []string{"de_dust2", "de_inferno", "cs_office"}, parseMapList([]byte(testMapCycle)))
+	assert.Equal(t, []string{"ctf_2fort", "cp_dustbowl"}, parseMapList([]byte("ctf_2fort\r\n\r\ncp_dustbowl\r\n")))
+	assert.Empty(t, parseMapList(nil))
+}
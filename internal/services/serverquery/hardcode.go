@@ -0,0 +1,59 @@
+package serverquery
+
+import (
+	"strings"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/pkg/quercon/query"
+)
+
+// queryProtocolsByEngine are the defaults for games without a configured query protocol.
+var queryProtocolsByEngine = map[string]query.Protocol{
+	"source":     query.ProtocolSource,
+	"goldsource": query.ProtocolSource,
+	"goldsrc":    query.ProtocolSource,
+	"minecraft":  query.ProtocolMinecraft,
+
+	"unreal":     query.ProtocolGameSpy1,
+	"unreal2":    query.ProtocolGameSpy1,
+	"refractor":  query.ProtocolGameSpy1,
+	"halo":       query.ProtocolGameSpy2,
+	"refractor2": query.ProtocolGameSpy3,
+	"unreal3":    query.ProtocolGameSpy3,
+	"cryengine":  query.ProtocolGameSpy3,
+	"arma":       query.ProtocolGameSpy3,
+
+	"quake3":  query.ProtocolQuake3,
+	"idtech3": query.ProtocolQuake3,
+}
+
+// queryFallbackProtocols are used when the server doesn't answer the primary protocol.
+// The fallback protocol queries the game port instead of the query port.
+var queryFallbackProtocols = map[query.Protocol]query.Protocol{
+	// Most Minecraft servers run without enable-query=true,
+	// but Server List Ping is always available.
+	query.ProtocolMinecraft: query.ProtocolMinecraftPing,
+}
+
+func getQueryFallbackProtocol(protocol query.Protocol) (query.Protocol, bool) {
+	fallback, ok := queryFallbackProtocols[protocol]
+
+	return fallback, ok
+}
+
+// getQueryProtocol returns the query protocol configured for the game or the default one of the game engine.
+func getQueryProtocol(game *domain.Game) (query.Protocol, bool) {
+	if game.QueryProtocol != nil && *game.QueryProtocol != "" {
+		protocol := query.Protocol(*game.QueryProtocol)
+
+		return protocol, query.IsProtocolSupported(protocol)
+	}
+
+	return getQueryProtocolByEngine(strings.ToLower(game.Engine))
+}
+
+func getQueryProtocolByEngine(engine string) (query.Protocol, bool) {
+	protocol, ok := queryProtocolsByEngine[engine]
+
+	return protocol, ok
+}
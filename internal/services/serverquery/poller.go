@@ -0,0 +1,263 @@
+package serverquery
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultPollerInterval        = time.Minute
+	defaultPollerNodeConcurrency = 4
+	defaultPollerQueryTimeout    = 2 * time.Second
+)
+
+type QueryFunc func(ctx context.Context, server *domain.Server, game *domain.Game) (*query.Result, error)
+
+// ResultRecorder saves query results of polled servers, for example into the statistics history.
+type ResultRecorder interface {
+	Record(ctx context.Context, serverID uint, result *query.Result) error
+}
+
+// ResultRecorders passes query results to several recorders. All recorders are called
+// even if some of them fail, the first error is returned.
+type ResultRecorders []ResultRecorder
+
+func (r ResultRecorders) Record(ctx context.Context, serverID uint, result *query.Result) error {
+	var firstErr error
+
+	for _, recorder := range r {
+		if err := recorder.Record(ctx, serverID, result); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+type PollerConfig struct {
+	// Interval between polling rounds.
+	Interval time.Duration
+
+	// NodeConcurrency limits the number of simultaneous queries to servers of the same node.
+	NodeConcurrency int
+
+	// QueryTimeout limits a single server query.
+	QueryTimeout time.Duration
+}
+
+// Poller periodically queries all enabled and installed servers and saves results to the store.
+type Poller struct {
+	serverRepo  repositories.ServerRepository
+	gameRepo    repositories.GameRepository
+	gameModRepo repositories.GameModRepository
+	store       *Store
+	recorder    ResultRecorder
+	config      PollerConfig
+	queryFunc   QueryFunc
+}
+
+func NewPoller(
+	serverRepo repositories.ServerRepository,
+	gameRepo repositories.GameRepository,
+	gameModRepo repositories.GameModRepository,
+	store *Store,
+	recorder ResultRecorder,
+	config PollerConfig,
+) *Poller {
+	if config.Interval <= 0 {
+		config.Interval = defaultPollerInterval
+	}
+
+	if config.NodeConcurrency <= 0 {
+		config.NodeConcurrency = defaultPollerNodeConcurrency
+	}
+
+	if config.QueryTimeout <= 0 {
+		config.QueryTimeout = defaultPollerQueryTimeout
+	}
+
+	return &Poller{
+		serverRepo:  serverRepo,
+		gameRepo:    gameRepo,
+		gameModRepo: gameModRepo,
+		store:       store,
+		recorder:    recorder,
+		config:      config,
+		queryFunc:   Query,
+	}
+}
+
+// Run polls servers until the context is canceled.
+func (p *Poller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Poll(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to poll servers", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Server query poller stopped")
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll queries all enabled and installed servers once.
+func (p *Poller) Poll(ctx context.Context) error {
+	servers, err := p.serverRepo.Find(ctx, &filters.FindServer{
+		Enabled: lo.ToPtr(true),
+		Blocked: lo.ToPtr(false),
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find servers")
+	}
+
+	games, err := p.gameRepo.FindAll(ctx, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find games")
+	}
+
+	gameMods, err := p.gameModRepo.FindAll(ctx, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find game mods")
+	}
+
+	gamesByCode := lo.SliceToMap(games, func(game domain.Game) (string, domain.Game) {
+		return game.Code, game
+	})
+
+	gameModsByID := lo.SliceToMap(gameMods, func(gameMod domain.GameMod) (uint, domain.GameMod) {
+		return gameMod.ID, gameMod
+	})
+
+	serversByNode := make(map[uint][]*domain.Server)
+	serverGames := make(map[uint]domain.Game, len(servers))
+	polledIDs := make([]uint, 0, len(servers))
+
+	for i := range servers {
+		server := &servers[i]
+
+		if server.Installed != domain.ServerInstalledStatusInstalled {
+			continue
+		}
+
+		game, ok := gamesByCode[server.GameID]
+		if !ok {
+			continue
+		}
+
+		if gameMod, ok := gameModsByID[server.GameModID]; ok {
+			game.ApplyGameMod(&gameMod)
+		}
+
+		if !IsQuerySupported(&game) {
+			continue
+		}
+
+		serversByNode[server.DSID] = append(serversByNode[server.DSID], server)
+		serverGames[server.ID] = game
+		polledIDs = append(polledIDs, server.ID)
+	}
+
+	wg := sync.WaitGroup{}
+
+	for _, nodeServers := range serversByNode {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			p.pollNodeServers(ctx, nodeServers, serverGames)
+		}()
+	}
+
+	wg.Wait()
+
+	p.store.Retain(polledIDs)
+
+	return nil
+}
+
+func (p *Poller) pollNodeServers(ctx context.Context, servers []*domain.Server, serverGames map[uint]domain.Game) {
+	semaphore := make(chan struct{}, p.config.NodeConcurrency)
+	wg := sync.WaitGroup{}
+
+	for _, server := range servers {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+
+			return
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			game := serverGames[server.ID]
+
+			p.pollServer(ctx, server, &game)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (p *Poller) pollServer(ctx context.Context, server *domain.Server, game *domain.Game) {
+	queryCtx, cancel := context.WithTimeout(ctx, p.config.QueryTimeout)
+	defer cancel()
+
+	result, err := p.queryFunc(queryCtx, server, game)
+	if err != nil && (result == nil || !result.Online) {
+		if ctx.Err() != nil {
+			return
+		}
+
+		slog.DebugContext(
+			ctx,
+			"Server query failed",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.String("error", err.Error()),
+		)
+
+		result = &query.Result{
+			Online:    false,
+			QueryTime: time.Now(),
+		}
+	}
+
+	p.store.Set(server.ID, *result)
+
+	if p.recorder == nil {
+		return
+	}
+
+	err = p.recorder.Record(ctx, server.ID, result)
+	if err != nil && ctx.Err() == nil {
+		slog.ErrorContext(
+			ctx,
+			"Failed to record server query result",
+			slog.Uint64("server_id", uint64(server.ID)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,275 @@
+package serverquery
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPollerRepos(t *testing.T, servers []domain.Server) (
+	*inmemory.ServerRepository,
+	*inmemory.GameRepository,
+) {
+	t.Helper()
+
+	serverRepo := inmemory.NewServerRepository()
+	gameRepo := inmemory.NewGameRepository()
+
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "cstrike",
+		Name:   "Counter-Strike",
+		Engine: "GoldSource",
+	}))
+	require.NoError(t, gameRepo.Save(context.Background(), &domain.Game{
+		Code:   "unknown",
+		Name:   "Unknown Game",
+		Engine: "unknown",
+	}))
+
+	for i := range servers {
+		servers[i].UUID = uuid.New()
+		require.NoError(t, serverRepo.Save(context.Background(), &servers[i]))
+	}
+
+	return serverRepo, gameRepo
+}
+
+func TestPoller_Poll(t *testing.T) {
+	serverRepo, gameRepo := setupPollerRepos(t, []domain.Server{
+		{ID: 1, Enabled: true, Installed: domain.ServerInstalledStatusInstalled, GameID: "cstrike", DSID: 1},
+		{ID: 2, Enabled: true, Installed: domain.ServerInstalledStatusInstalled, GameID: "cstrike", DSID: 2},
+		{ID: 3, Enabled: false, Installed: domain.ServerInstalledStatusInstalled, GameID: "cstrike", DSID: 1},
+		{ID: 4, Enabled: true, Installed: domain.ServerInstalledStatusNotInstalled, GameID: "cstrike", DSID: 1},
+		{ID: 5, Enabled: true, Installed: domain.ServerInstalledStatusInstalled, GameID: "unknown", DSID: 1},
+		{ID: 6, Enabled: true, Installed: domain.ServerInstalledStatusInstalled, Blocked: true, GameID: "cstrike", DSID: 1},
+	})
+
+	store := NewStore(time.Minute)
+	// State of a deleted server must be removed
+	store.Set(100, query.Result{Online: true})
+
+	poller := NewPoller(serverRepo, gameRepo, inmemory.NewGameModRepository(), store, nil, PollerConfig{})
+	poller.queryFunc = func(_ context.Context, server *domain.Server, _ *domain.Game) (*query.Result, error) {
+		if server.ID == 2 {
+			return &query.Result{Online: false}, errors.New("timeout")
+		}
+
+		return &query.Result{Online: true, Map: "de_dust2", PlayersNum: 3}, nil
+	}
+
+	require.NoError(t, poller.Poll(context.Background()))
+
+	states := store.GetMany([]uint{1, 2, 3, 4, 5, 6, 100})
+	require.Len(t, states, 2)
+
+	assert.True(t, states[1].Result.Online)
+	assert.Equal(t, "de_dust2", states[1].Result.Map)
+	assert.Equal(t, 3, states[1].Result.PlayersNum)
+
+	assert.False(t, states[2].Result.Online)
+	assert.False(t, states[2].UpdatedAt.IsZero())
+}
+
+func TestPoller_Poll_GameModProtocolSettings(t *testing.T) {
+	serverRepo, gameRepo := setupPollerRepos(t, []domain.Server{
+		{ID: 1, Enabled: true, Installed: domain.ServerInstalledStatusInstalled, GameID: "unknown", GameModID: 1, DSID: 1},
+		{ID: 2, Enabled: true, Installed: domain.ServerInstalledStatusInstalled, GameID: "unknown", GameModID: 2, DSID: 1},
+	})
+
+	gameModRepo := inmemory.NewGameModRepository()
+	require.NoError(t, gameModRepo.Save(context.Background(), &domain.GameMod{
+		ID:              1,
+		GameCode:        "unknown",
+		Name:            "With query",
+		QueryProtocol:   lo.ToPtr("gamespy3"),
+		QueryPortOffset: lo.ToPtr(1),
+	}))
+	require.NoError(t, gameModRepo.Save(context.Background(), &domain.GameMod{
+		ID:       2,
+		GameCode: "unknown",
+		Name:     "Without query",
+	}))
+
+	queried := make(chan *domain.Game, 2)
+
+	poller := NewPoller(serverRepo, gameRepo, gameModRepo, NewStore(0), nil, PollerConfig{})
+	poller.queryFunc = func(_ context.Context, _ *domain.Server, game *domain.Game) (*query.Result, error) {
+		queried <- game
+
+		return &query.Result{Online: true}, nil
+	}
+
+	require.NoError(t, poller.Poll(context.Background()))
+	close(queried)
+
+	games := make([]*domain.Game, 0, 1)
+	for game := range queried {
+		games = append(games, game)
+	}
+
+	require.Len(t, games, 1)
+	assert.Equal(t, lo.ToPtr("gamespy3"), games[0].QueryProtocol)
+	assert.Equal(t, lo.ToPtr(1), games[0].QueryPortOffset)
+}
+
+func TestPoller_Poll_NodeConcurrency(t *testing.T) {
+	servers := make([]domain.Server, 0, 20)
+	for i := range 20 {
+		servers = append(servers, domain.Server{
+			ID:        uint(i + 1),
+			Enabled:   true,
+			Installed: domain.ServerInstalledStatusInstalled,
+			GameID:    "cstrike",
+			DSID:      uint(i%2 + 1),
+		})
+	}
+
+	serverRepo, gameRepo := setupPollerRepos(t, servers)
+	store := NewStore(time.Minute)
+
+	poller := NewPoller(serverRepo, gameRepo, inmemory.NewGameModRepository(), store, nil, PollerConfig{NodeConcurrency: 2})
+
+	mu := sync.Mutex{}
+	activeByNode := make(map[uint]int)
+	maxActiveByNode := make(map[uint]int)
+	var calls atomic.Int32
+
+	poller.queryFunc = func(_ context.Context, server *domain.Server, _ *domain.Game) (*query.Result, error) {
+		calls.Add(1)
+
+		mu.Lock()
+		activeByNode[server.DSID]++
+		maxActiveByNode[server.DSID] = max(maxActiveByNode[server.DSID], activeByNode[server.DSID])
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		activeByNode[server.DSID]--
+		mu.Unlock()
+
+		return &query.Result{Online: true}, nil
+	}
+
+	require.NoError(t, poller.Poll(context.Background()))
+
+	assert.Equal(t, int32(20), calls.Load())
+	assert.LessOrEqual(t, maxActiveByNode[1], 2)
+	assert.LessOrEqual(t, maxActiveByNode[2], 2)
+	assert.Len(t, store.GetMany([]uint{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}), 10)
+}
+
+type recorderFunc func(ctx context.Context, serverID uint, result *query.Result) error
+
+func (f recorderFunc) Record(ctx context.Context, serverID uint, result *query.Result) error {
+	return f(ctx, serverID, result)
+}
+
+func TestPoller_Poll_Recorder(t *testing.T) {
+	serverRepo, gameRepo := setupPollerRepos(t, []domain.Server{
+		{ID: 1, Enabled: true, Installed: domain.ServerInstalledStatusInstalled, GameID: "cstrike", DSID: 1},
+		{ID: 2, Enabled: true, Installed: domain.ServerInstalledStatusInstalled, GameID: "cstrike", DSID: 1},
+	})
+
+	mu := sync.Mutex{}
+	recorded := make(map[uint]query.Result)
+
+	recorder := recorderFunc(func(_ context.Context, serverID uint, result *query.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		recorded[serverID] = *result
+
+		return nil
+	})
+
+	poller := NewPoller(serverRepo, gameRepo, inmemory.NewGameModRepository(), NewStore(0), recorder, PollerConfig{})
+	poller.queryFunc = func(_ context.Context, server *domain.Server, _ *domain.Game) (*query.Result, error) {
+		if server.ID == 2 {
+			return nil, errors.New("timeout")
+		}
+
+		return &query.Result{Online: true, PlayersNum: 5}, nil
+	}
+
+	require.NoError(t, poller.Poll(context.Background()))
+
+	require.Len(t, recorded, 2)
+	assert.True(t, recorded[1].Online)
+	assert.Equal(t, 5, recorded[1].PlayersNum)
+	assert.False(t, recorded[2].Online)
+	assert.False(t, recorded[2].QueryTime.IsZero())
+}
+
+func TestResultRecorders_Record(t *testing.T) {
+	calls := make([]string, 0, 3)
+
+	recorders := ResultRecorders{
+		recorderFunc(func(_ context.Context, _ uint, _ *query.Result) error {
+			calls = append(calls, "first")
+
+			return errors.New("first failed")
+		}),
+		recorderFunc(func(_ context.Context, _ uint, _ *query.Result) error {
+			calls = append(calls, "second")
+
+			return errors.New("second failed")
+		}),
+		recorderFunc(func(_ context.Context, _ uint, _ *query.Result) error {
+			calls = append(calls, "third")
+
+			return nil
+		}),
+	}
+
+	err := recorders.Record(context.Background(), 1, &query.Result{})
+
+	require.EqualError(t, err, "first failed")
+	assert.Equal(t, []string{"first", "second", "third"}, calls)
+}
+
+func TestPoller_Run_StopsOnContextCancel(t *testing.T) {
+	serverRepo, gameRepo := setupPollerRepos(t, []domain.Server{
+		{ID: 1, Enabled: true, Installed: domain.ServerInstalledStatusInstalled, GameID: "cstrike", DSID: 1},
+	})
+
+	poller := NewPoller(serverRepo, gameRepo, inmemory.NewGameModRepository(), NewStore(0), nil, PollerConfig{Interval: 10 * time.Millisecond})
+
+	var calls atomic.Int32
+	poller.queryFunc = func(_ context.Context, _ *domain.Server, _ *domain.Game) (*query.Result, error) {
+		calls.Add(1)
+
+		return &query.Result{Online: true}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		poller.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		return calls.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("poller didn't stop")
+	}
+}
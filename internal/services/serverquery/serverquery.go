@@ -0,0 +1,69 @@
+// Package serverquery queries game servers and keeps the latest query results.
+package serverquery
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/pkg/errors"
+)
+
+var ErrUnsupportedEngine = errors.New("unsupported game engine for query")
+
+// Query queries the game server with the protocol configured for the game or determined by the game engine.
+// If the server doesn't answer and the protocol has a fallback, the fallback protocol is used.
+// The primary protocol gets half of the context deadline, so the fallback has time left.
+func Query(ctx context.Context, server *domain.Server, game *domain.Game) (*query.Result, error) {
+	protocol, ok := getQueryProtocol(game)
+	if !ok {
+		return nil, ErrUnsupportedEngine
+	}
+
+	fallbackProtocol, hasFallback := getQueryFallbackProtocol(protocol)
+	if !hasFallback {
+		return query.Query(ctx, server.ServerIP, server.ResolveQueryPort(game), protocol)
+	}
+
+	primaryCtx, cancel := withPrimaryDeadline(ctx)
+	defer cancel()
+
+	result, err := query.Query(primaryCtx, server.ServerIP, server.ResolveQueryPort(game), protocol)
+	if err != nil && isQueryUnreachable(err) && ctx.Err() == nil {
+		return query.Query(ctx, server.ServerIP, server.ServerPort, fallbackProtocol)
+	}
+
+	return result, err
+}
+
+// withPrimaryDeadline limits the context to half of the time left before its deadline.
+// Without a deadline, each protocol uses its own default timeout.
+func withPrimaryDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, time.Until(deadline)/2)
+}
+
+// IsQuerySupported checks if the game has a configured or a known query protocol.
+func IsQuerySupported(game *domain.Game) bool {
+	_, ok := getQueryProtocol(game)
+
+	return ok
+}
+
+// isQueryUnreachable checks if the server didn't answer the query:
+// the request timed out or the query port is closed.
+func isQueryUnreachable(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
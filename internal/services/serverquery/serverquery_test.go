@@ -0,0 +1,101 @@
+package serverquery
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startSilentUDPListener starts a UDP listener which reads requests and never replies.
+func startSilentUDPListener(t *testing.T) int {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			if _, _, err := conn.ReadFrom(buf); err != nil {
+				return
+			}
+		}
+	}()
+
+	udpAddr, ok := conn.LocalAddr().(*net.UDPAddr)
+	require.True(t, ok)
+
+	return udpAddr.Port
+}
+
+// startMinecraftPingListener starts a TCP server which answers the Server List Ping with the given JSON status.
+func startMinecraftPingListener(t *testing.T, status string) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		// Handshake and status request, the content is not checked
+		_, _ = conn.Read(make([]byte, 1024))
+
+		// Packet ID (0x00) and the JSON string prefixed with the length, lengths are single byte VarInts
+		body := append([]byte{0x00, byte(len(status))}, status...)
+		_, _ = conn.Write(append([]byte{byte(len(body))}, body...))
+	}()
+
+	tcpAddr, ok := listener.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+
+	return tcpAddr.Port
+}
+
+func TestQuery_FallbackAfterQueryTimeout(t *testing.T) {
+	queryPort := startSilentUDPListener(t)
+	gamePort := startMinecraftPingListener(t,
+		`{"version":{"name":"1.20.4"},"players":{"max":20,"online":3},"description":"Minecraft Server"}`,
+	)
+
+	server := &domain.Server{
+		ID:         1,
+		ServerIP:   "127.0.0.1",
+		ServerPort: gamePort,
+		QueryPort:  &queryPort,
+	}
+	game := &domain.Game{Code: "minecraft", Engine: "Minecraft"}
+
+	// The same budget the poller gives a single query
+	ctx, cancel := context.WithTimeout(context.Background(), defaultPollerQueryTimeout)
+	defer cancel()
+
+	start := time.Now()
+	result, err := Query(ctx, server, game)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.True(t, result.Online)
+	assert.Equal(t, "Minecraft Server", result.Name)
+	assert.Equal(t, 3, result.PlayersNum)
+	assert.Less(t, time.Since(start), defaultPollerQueryTimeout)
+}
@@ -0,0 +1,99 @@
+package serverquery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/pkg/quercon/query"
+)
+
+// State is the latest known query result of a server.
+type State struct {
+	Result    query.Result
+	UpdatedAt time.Time
+
+	// Stale is true when the result wasn't refreshed for too long,
+	// for example the poller is stopped or the server is not polled anymore.
+	Stale bool
+}
+
+// Store keeps the latest query results in memory.
+type Store struct {
+	mu         sync.RWMutex
+	states     map[uint]State
+	staleAfter time.Duration
+}
+
+// NewStore creates a store. Results older than staleAfter are marked as stale,
+// zero staleAfter disables the check.
+func NewStore(staleAfter time.Duration) *Store {
+	return &Store{
+		states:     make(map[uint]State),
+		staleAfter: staleAfter,
+	}
+}
+
+func (s *Store) Get(serverID uint) (State, bool) {
+	s.mu.RLock()
+	state, ok := s.states[serverID]
+	s.mu.RUnlock()
+
+	if !ok {
+		return State{}, false
+	}
+
+	state.Stale = s.isStale(state)
+
+	return state, true
+}
+
+// GetMany returns states of the given servers. Servers without state are omitted.
+func (s *Store) GetMany(serverIDs []uint) map[uint]State {
+	result := make(map[uint]State, len(serverIDs))
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, id := range serverIDs {
+		state, ok := s.states[id]
+		if !ok {
+			continue
+		}
+
+		state.Stale = s.isStale(state)
+		result[id] = state
+	}
+
+	return result
+}
+
+func (s *Store) Set(serverID uint, result query.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[serverID] = State{
+		Result:    result,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// Retain removes states of all servers except the given ones.
+func (s *Store) Retain(serverIDs []uint) {
+	keep := make(map[uint]struct{}, len(serverIDs))
+	for _, id := range serverIDs {
+		keep[id] = struct{}{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id := range s.states {
+		if _, ok := keep[id]; !ok {
+			delete(s.states, id)
+		}
+	}
+}
+
+func (s *Store) isStale(state State) bool {
+	return s.staleAfter > 0 && time.Since(state.UpdatedAt) > s.staleAfter
+}
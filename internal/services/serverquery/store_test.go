@@ -0,0 +1,70 @@
+package serverquery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_SetGet(t *testing.T) {
+	store := NewStore(time.Minute)
+
+	_, ok := store.Get(1)
+	assert.False(t, ok)
+
+	store.Set(1, query.Result{Online: true, Map: "de_dust2"})
+
+	state, ok := store.Get(1)
+	require.True(t, ok)
+	assert.True(t, state.Result.Online)
+	assert.Equal(t, "de_dust2", state.Result.Map)
+	assert.False(t, state.Stale)
+	assert.WithinDuration(t, time.Now(), state.UpdatedAt, time.Second)
+}
+
+func TestStore_Stale(t *testing.T) {
+	store := NewStore(time.Minute)
+
+	store.states[1] = State{
+		Result:    query.Result{Online: true},
+		UpdatedAt: time.Now().Add(-2 * time.Minute),
+	}
+	store.states[2] = State{
+		Result:    query.Result{Online: true},
+		UpdatedAt: time.Now(),
+	}
+
+	states := store.GetMany([]uint{1, 2, 3})
+
+	require.Len(t, states, 2)
+	assert.True(t, states[1].Stale)
+	assert.False(t, states[2].Stale)
+}
+
+func TestStore_StaleDisabled(t *testing.T) {
+	store := NewStore(0)
+
+	store.states[1] = State{UpdatedAt: time.Now().Add(-24 * time.Hour)}
+
+	state, ok := store.Get(1)
+	require.True(t, ok)
+	assert.False(t, state.Stale)
+}
+
+func TestStore_Retain(t *testing.T) {
+	store := NewStore(time.Minute)
+
+	store.Set(1, query.Result{})
+	store.Set(2, query.Result{})
+	store.Set(3, query.Result{})
+
+	store.Retain([]uint{2})
+
+	assert.Len(t, store.GetMany([]uint{1, 2, 3}), 1)
+
+	_, ok := store.Get(2)
+	assert.True(t, ok)
+}
@@ -0,0 +1,43 @@
+package serverresources
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Resource string
+
+const (
+	ResourceCPU Resource = "cpu"
+	ResourceRAM Resource = "ram"
+	ResourceNet Resource = "net"
+)
+
+// LimitExceededEvent is raised when the resource usage of a server crosses its limit.
+// It isn't raised again until the usage gets back under the limit.
+type LimitExceededEvent struct {
+	ServerID uint
+	Resource Resource
+	Usage    float64
+	Limit    float64
+	Time     time.Time
+}
+
+type EventHandler interface {
+	HandleLimitExceeded(ctx context.Context, event LimitExceededEvent)
+}
+
+// LogEventHandler writes limit exceeded events to the log.
+type LogEventHandler struct{}
+
+func (LogEventHandler) HandleLimitExceeded(ctx context.Context, event LimitExceededEvent) {
+	slog.WarnContext(
+		ctx,
+		"Server resource usage exceeded the limit",
+		slog.Uint64("server_id", uint64(event.ServerID)),
+		slog.String("resource", string(event.Resource)),
+		slog.Float64("usage", event.Usage),
+		slog.Float64("limit", event.Limit),
+	)
+}
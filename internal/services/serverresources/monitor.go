@@ -0,0 +1,307 @@
+// Package serverresources collects resource usage of game servers with the node stats script
+// and reports usage exceeding the server limits.
+package serverresources
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultInterval = time.Minute
+	scriptTimeout   = 10 * time.Second
+)
+
+type commandExecutor interface {
+	ExecuteCommand(
+		ctx context.Context,
+		node *domain.Node,
+		command string,
+		opts ...daemon.CommandServiceOption,
+	) (*daemon.CommandResult, error)
+}
+
+// Monitor periodically executes the stats script of nodes for every running server,
+// keeps the latest usage in memory and raises events when usage crosses the server limits.
+type Monitor struct {
+	serverRepo repositories.ServerRepository
+	nodeRepo   repositories.NodeRepository
+	commands   commandExecutor
+	interval   time.Duration
+	handlers   []EventHandler
+
+	mu       sync.RWMutex
+	usages   map[uint]Usage
+	exceeded map[uint]map[Resource]bool
+}
+
+func NewMonitor(
+	serverRepo repositories.ServerRepository,
+	nodeRepo repositories.NodeRepository,
+	commands commandExecutor,
+	interval time.Duration,
+	handlers ...EventHandler,
+) *Monitor {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &Monitor{
+		serverRepo: serverRepo,
+		nodeRepo:   nodeRepo,
+		commands:   commands,
+		interval:   interval,
+		handlers:   handlers,
+		usages:     make(map[uint]Usage),
+		exceeded:   make(map[uint]map[Resource]bool),
+	}
+}
+
+// Usage returns the latest usage of the server.
+func (m *Monitor) Usage(serverID uint) (Usage, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	usage, ok := m.usages[serverID]
+
+	return usage, ok
+}
+
+// Run collects usage until the context is canceled.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Collect(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to collect server resource usage", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Server resources monitor stopped")
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect collects usage of all running servers on nodes with the stats script once.
+func (m *Monitor) Collect(ctx context.Context) error {
+	servers, err := m.serverRepo.Find(ctx, &filters.FindServer{
+		Enabled: lo.ToPtr(true),
+		Blocked: lo.ToPtr(false),
+	}, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find servers")
+	}
+
+	nodes, err := m.nodeRepo.FindAll(ctx, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find nodes")
+	}
+
+	nodesByID := make(map[uint]*domain.Node, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+
+		if node.Enabled && node.ScriptStats != nil && *node.ScriptStats != "" {
+			nodesByID[node.ID] = node
+		}
+	}
+
+	serversByNode := make(map[uint][]*domain.Server)
+	collectedIDs := make([]uint, 0, len(servers))
+
+	for i := range servers {
+		server := &servers[i]
+
+		if _, ok := nodesByID[server.DSID]; !ok {
+			continue
+		}
+
+		if server.Installed != domain.ServerInstalledStatusInstalled || !server.IsOnline() {
+			continue
+		}
+
+		serversByNode[server.DSID] = append(serversByNode[server.DSID], server)
+		collectedIDs = append(collectedIDs, server.ID)
+	}
+
+	wg := sync.WaitGroup{}
+
+	// Servers of the same node are collected one by one to not overload the daemon
+	for nodeID, nodeServers := range serversByNode {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for _, server := range nodeServers {
+				if ctx.Err() != nil {
+					return
+				}
+
+				m.collectServer(ctx, nodesByID[nodeID], server)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	m.retain(collectedIDs)
+
+	return nil
+}
+
+func (m *Monitor) collectServer(ctx context.Context, node *domain.Node, server *domain.Server) {
+	usage, err := m.executeScript(ctx, node, server)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.DebugContext(
+				ctx,
+				"Failed to collect server resource usage",
+				slog.Uint64("server_id", uint64(server.ID)),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		return
+	}
+
+	m.update(ctx, server, usage)
+}
+
+func (m *Monitor) executeScript(ctx context.Context, node *domain.Node, server *domain.Server) (Usage, error) {
+	ctx, cancel := context.WithTimeout(ctx, scriptTimeout)
+	defer cancel()
+
+	cmd := server.ReplaceServerShortcodes(node, *node.ScriptStats, nil)
+
+	result, err := m.commands.ExecuteCommand(ctx, node, cmd)
+	if err != nil {
+		return Usage{}, errors.WithMessage(err, "failed to execute stats script")
+	}
+
+	usage, err := parseUsage(result.Output)
+	if err != nil {
+		return Usage{}, errors.WithMessage(err, "failed to parse stats script output")
+	}
+
+	usage.Time = time.Now()
+
+	return usage, nil
+}
+
+func (m *Monitor) update(ctx context.Context, server *domain.Server, usage Usage) {
+	m.mu.Lock()
+
+	if previous, ok := m.usages[server.ID]; ok {
+		usage.NetRate = netRate(&previous, &usage)
+	}
+
+	m.usages[server.ID] = usage
+
+	events := m.checkLimits(server, &usage)
+
+	m.mu.Unlock()
+
+	for _, event := range events {
+		for _, handler := range m.handlers {
+			handler.HandleLimitExceeded(ctx, event)
+		}
+	}
+}
+
+// checkLimits returns events for resources which usage crossed the limit since the previous sample.
+// Must be called with the lock held.
+func (m *Monitor) checkLimits(server *domain.Server, usage *Usage) []LimitExceededEvent {
+	limits := ServerLimits(server)
+
+	exceeded := m.exceeded[server.ID]
+	if exceeded == nil {
+		exceeded = make(map[Resource]bool)
+		m.exceeded[server.ID] = exceeded
+	}
+
+	var events []LimitExceededEvent
+
+	check := func(resource Resource, value float64, limit *float64) {
+		if limit == nil || value <= *limit {
+			exceeded[resource] = false
+
+			return
+		}
+
+		if exceeded[resource] {
+			return
+		}
+
+		exceeded[resource] = true
+		events = append(events, LimitExceededEvent{
+			ServerID: server.ID,
+			Resource: resource,
+			Usage:    value,
+			Limit:    *limit,
+			Time:     usage.Time,
+		})
+	}
+
+	var ramLimit *float64
+	if limits.RAM != nil {
+		ramLimit = lo.ToPtr(float64(*limits.RAM))
+	}
+
+	check(ResourceCPU, usage.CPU, limits.CPU)
+	check(ResourceRAM, float64(usage.RSS), ramLimit)
+
+	if usage.NetRate != nil {
+		check(ResourceNet, *usage.NetRate, limits.Net)
+	}
+
+	return events
+}
+
+// retain removes usage of all servers except the given ones.
+func (m *Monitor) retain(serverIDs []uint) {
+	keep := make(map[uint]struct{}, len(serverIDs))
+	for _, id := range serverIDs {
+		keep[id] = struct{}{}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id := range m.usages {
+		if _, ok := keep[id]; !ok {
+			delete(m.usages, id)
+			delete(m.exceeded, id)
+		}
+	}
+}
+
+func netRate(previous, current *Usage) *float64 {
+	seconds := current.Time.Sub(previous.Time).Seconds()
+	if seconds <= 0 {
+		return nil
+	}
+
+	// Counters are reset after the server restart
+	if current.NetRX < previous.NetRX || current.NetTX < previous.NetTX {
+		return nil
+	}
+
+	rate := float64(current.NetRX-previous.NetRX+current.NetTX-previous.NetTX) / seconds
+
+	return &rate
+}
@@ -0,0 +1,169 @@
+package serverresources
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/daemon"
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExecutor struct {
+	mu       sync.Mutex
+	commands []string
+	outputs  map[string]string
+}
+
+func (e *fakeExecutor) ExecuteCommand(
+	_ context.Context,
+	_ *domain.Node,
+	command string,
+	_ ...daemon.CommandServiceOption,
+) (*daemon.CommandResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.commands = append(e.commands, command)
+
+	return &daemon.CommandResult{Output: e.outputs[command]}, nil
+}
+
+type recordingHandler struct {
+	mu     sync.Mutex
+	events []LimitExceededEvent
+}
+
+func (h *recordingHandler) HandleLimitExceeded(_ context.Context, event LimitExceededEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.events = append(h.events, event)
+}
+
+func setupMonitorRepos(t *testing.T) (*inmemory.ServerRepository, *inmemory.NodeRepository) {
+	t.Helper()
+
+	ctx := context.Background()
+	serverRepo := inmemory.NewServerRepository()
+	nodeRepo := inmemory.NewNodeRepository()
+
+	require.NoError(t, nodeRepo.Save(ctx, &domain.Node{
+		ID:          1,
+		Enabled:     true,
+		WorkPath:    "/srv/gameap",
+		ScriptStats: lo.ToPtr("stats.sh {dir}"),
+	}))
+	require.NoError(t, nodeRepo.Save(ctx, &domain.Node{
+		ID:       2,
+		Enabled:  true,
+		WorkPath: "/srv/gameap",
+	}))
+
+	lastCheck := time.Now()
+
+	servers := []domain.Server{
+		{ID: 1, DSID: 1, Dir: "servers/one", RAMLimit: lo.ToPtr(100), CPULimit: lo.ToPtr(50)},
+		// Stopped server
+		{ID: 2, DSID: 1, Dir: "servers/two", ProcessActive: false},
+		// Node without stats script
+		{ID: 3, DSID: 2, Dir: "servers/three"},
+	}
+
+	for i := range servers {
+		servers[i].UUID = uuid.New()
+		servers[i].Enabled = true
+		servers[i].Installed = domain.ServerInstalledStatusInstalled
+		servers[i].LastProcessCheck = &lastCheck
+
+		if servers[i].ID != 2 {
+			servers[i].ProcessActive = true
+		}
+
+		require.NoError(t, serverRepo.Save(ctx, &servers[i]))
+	}
+
+	return serverRepo, nodeRepo
+}
+
+func TestMonitor_Collect(t *testing.T) {
+	ctx := context.Background()
+	serverRepo, nodeRepo := setupMonitorRepos(t)
+
+	executor := &fakeExecutor{
+		outputs: map[string]string{
+			"stats.sh servers/one": "cpu=60\nrss=1048576\nnet_rx=100\nnet_tx=100\n",
+		},
+	}
+	handler := &recordingHandler{}
+
+	monitor := NewMonitor(serverRepo, nodeRepo, executor, 0, handler)
+
+	require.NoError(t, monitor.Collect(ctx))
+
+	assert.Equal(t, []string{"stats.sh servers/one"}, executor.commands)
+
+	usage, ok := monitor.Usage(1)
+	require.True(t, ok)
+	assert.InDelta(t, 60, usage.CPU, 0.001)
+	assert.Equal(t, uint64(1048576), usage.RSS)
+	assert.Nil(t, usage.NetRate)
+
+	_, ok = monitor.Usage(2)
+	assert.False(t, ok)
+
+	require.Len(t, handler.events, 1)
+	assert.Equal(t, uint(1), handler.events[0].ServerID)
+	assert.Equal(t, ResourceCPU, handler.events[0].Resource)
+	assert.InDelta(t, 60, handler.events[0].Usage, 0.001)
+	assert.InDelta(t, 50, handler.events[0].Limit, 0.001)
+
+	// The event isn't raised again while usage stays above the limit
+	require.NoError(t, monitor.Collect(ctx))
+
+	assert.Len(t, handler.events, 1)
+
+	usage, ok = monitor.Usage(1)
+	require.True(t, ok)
+	assert.NotNil(t, usage.NetRate)
+}
+
+func TestMonitor_Collect_LimitCrossedAgain(t *testing.T) {
+	ctx := context.Background()
+	serverRepo, nodeRepo := setupMonitorRepos(t)
+
+	executor := &fakeExecutor{outputs: map[string]string{}}
+	handler := &recordingHandler{}
+	monitor := NewMonitor(serverRepo, nodeRepo, executor, time.Minute, handler)
+
+	for _, rss := range []string{"209715200", "1048576", "209715200"} {
+		executor.outputs["stats.sh servers/one"] = "cpu=10\nrss=" + rss + "\n"
+
+		require.NoError(t, monitor.Collect(ctx))
+	}
+
+	require.Len(t, handler.events, 2)
+	for _, event := range handler.events {
+		assert.Equal(t, ResourceRAM, event.Resource)
+		assert.InDelta(t, 100*1024*1024, event.Limit, 0.001)
+	}
+}
+
+func TestNetRate(t *testing.T) {
+	now := time.Now()
+
+	previous := &Usage{Time: now.Add(-10 * time.Second), NetRX: 1000, NetTX: 1000}
+
+	rate := netRate(previous, &Usage{Time: now, NetRX: 6000, NetTX: 2000})
+	require.NotNil(t, rate)
+	assert.InDelta(t, 600, *rate, 0.001)
+
+	// Counters reset
+	assert.Nil(t, netRate(previous, &Usage{Time: now, NetRX: 10, NetTX: 10}))
+}
@@ -0,0 +1,126 @@
+package serverresources
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/pkg/errors"
+)
+
+const (
+	bytesInMegabyte = 1024 * 1024
+	bytesInKilobyte = 1024
+)
+
+var errNoUsage = errors.New("no resource usage in stats script output")
+
+// Usage is the resource usage of a server process.
+type Usage struct {
+	Time time.Time
+
+	// CPU usage in percent of one core, may exceed 100 on multicore nodes.
+	CPU float64
+
+	// RSS is the resident memory size in bytes.
+	RSS uint64
+
+	// NetRX and NetTX are received and transmitted bytes counters.
+	NetRX uint64
+	NetTX uint64
+
+	// NetRate is received and transmitted bytes per second since the previous sample.
+	// It is nil for the first sample and after the counters reset.
+	NetRate *float64
+}
+
+// parseUsage parses the output of the node stats script (Node.ScriptStats).
+//
+// The script prints "key=value" lines, unknown keys, empty lines and "#" comments are ignored:
+//
+//	cpu=12.5        # CPU usage in percent of one core
+//	rss=104857600   # resident memory in bytes
+//	net_rx=1048576  # received bytes counter
+//	net_tx=524288   # transmitted bytes counter
+func parseUsage(output string) (Usage, error) {
+	usage := Usage{}
+	found := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		var err error
+
+		switch key {
+		case "cpu":
+			usage.CPU, err = strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		case "rss":
+			usage.RSS, err = strconv.ParseUint(value, 10, 64)
+		case "net_rx":
+			usage.NetRX, err = strconv.ParseUint(value, 10, 64)
+		case "net_tx":
+			usage.NetTX, err = strconv.ParseUint(value, 10, 64)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return Usage{}, errors.WithMessagef(err, "invalid %s value", key)
+		}
+
+		found = true
+	}
+
+	if !found {
+		return Usage{}, errNoUsage
+	}
+
+	return usage, nil
+}
+
+// Limits are the resource limits of a server in units of Usage.
+// Nil means the resource is not limited.
+type Limits struct {
+	// CPU in percent of one core.
+	CPU *float64
+
+	// RAM in bytes.
+	RAM *uint64
+
+	// Net in bytes per second.
+	Net *float64
+}
+
+// ServerLimits converts the server limits: CPULimit is in percent of one core,
+// RAMLimit in megabytes and NetLimit in kilobytes per second. Zero or negative values mean no limit.
+func ServerLimits(server *domain.Server) Limits {
+	limits := Limits{}
+
+	if server.CPULimit != nil && *server.CPULimit > 0 {
+		cpu := float64(*server.CPULimit)
+		limits.CPU = &cpu
+	}
+
+	if server.RAMLimit != nil && *server.RAMLimit > 0 {
+		ram := uint64(*server.RAMLimit) * bytesInMegabyte
+		limits.RAM = &ram
+	}
+
+	if server.NetLimit != nil && *server.NetLimit > 0 {
+		net := float64(*server.NetLimit) * bytesInKilobyte
+		limits.Net = &net
+	}
+
+	return limits
+}
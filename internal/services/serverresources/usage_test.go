@@ -0,0 +1,72 @@
+package serverresources
+
+import (
+	"testing"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUsage(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		want      Usage
+		wantError string
+	}{
+		{
+			name:   "all values",
+			output: "cpu=12.5\nrss=104857600\nnet_rx=1048576\nnet_tx=524288\n",
+			want:   Usage{CPU: 12.5, RSS: 104857600, NetRX: 1048576, NetTX: 524288},
+		},
+		{
+			name:   "spaces, comments, percent sign and unknown keys",
+			output: "# gameap stats\n CPU = 150% \nrss=2048 # bytes\nthreads=12\n\n",
+			want:   Usage{CPU: 150, RSS: 2048},
+		},
+		{
+			name:      "invalid value",
+			output:    "cpu=12.5\nrss=big\n",
+			wantError: "invalid rss value",
+		},
+		{
+			name:      "no known keys",
+			output:    "Process not found\n",
+			wantError: errNoUsage.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, err := parseUsage(tt.output)
+
+			if tt.wantError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, usage)
+		})
+	}
+}
+
+func TestServerLimits(t *testing.T) {
+	limits := ServerLimits(&domain.Server{
+		CPULimit: lo.ToPtr(200),
+		RAMLimit: lo.ToPtr(512),
+		NetLimit: lo.ToPtr(0),
+	})
+
+	require.NotNil(t, limits.CPU)
+	assert.InDelta(t, 200, *limits.CPU, 0.001)
+	require.NotNil(t, limits.RAM)
+	assert.Equal(t, uint64(512*1024*1024), *limits.RAM)
+	assert.Nil(t, limits.Net)
+
+	assert.Equal(t, Limits{}, ServerLimits(&domain.Server{}))
+}
@@ -0,0 +1,267 @@
+// Package serverstats keeps the history of server query samples in servers_stats.
+// The samples are stored with the query kind, other rows of the table are never read or removed.
+//
+// Raw samples are kept for RawRetention, after that they are downsampled into hourly samples,
+// which are kept for HourlyRetention.
+package serverstats
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	RawRetention    = 48 * time.Hour
+	HourlyRetention = 90 * 24 * time.Hour
+
+	downsampleInterval = time.Hour
+)
+
+type Service struct {
+	repo repositories.ServerStatRepository
+}
+
+func NewService(repo repositories.ServerStatRepository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// Record saves the query result as a raw sample.
+func (s *Service) Record(ctx context.Context, serverID uint, result *query.Result) error {
+	stat := &domain.ServerStat{
+		ServerID:      serverID,
+		Time:          result.QueryTime,
+		Resolution:    domain.ServerStatResolutionRaw,
+		Online:        result.Online,
+		PlayersNum:    result.PlayersNum,
+		MaxPlayersNum: result.MaxPlayersNum,
+		Kind:          domain.ServerStatKindQuery,
+	}
+
+	if stat.Time.IsZero() {
+		stat.Time = time.Now()
+	}
+
+	err := s.repo.Save(ctx, stat)
+	if err != nil {
+		return errors.WithMessage(err, "failed to save server stat")
+	}
+
+	return nil
+}
+
+// Series returns samples of the server in the [from, to) range.
+// With the raw resolution only raw samples are returned,
+// with the hourly resolution raw samples are aggregated into hours together with the hourly samples.
+func (s *Service) Series(
+	ctx context.Context,
+	serverID uint,
+	from, to time.Time,
+	resolution domain.ServerStatResolution,
+) ([]domain.ServerStat, error) {
+	filter := &filters.FindServerStat{
+		ServerIDs: []uint{serverID},
+		Kinds:     []domain.ServerStatKind{domain.ServerStatKindQuery},
+		TimeFrom:  &from,
+		TimeTo:    &to,
+	}
+
+	if resolution == domain.ServerStatResolutionRaw {
+		filter.Resolutions = []domain.ServerStatResolution{domain.ServerStatResolutionRaw}
+	}
+
+	stats, err := s.repo.Find(ctx, filter, []filters.Sorting{
+		{Field: "time", Direction: filters.SortDirectionAsc},
+	}, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find server stats")
+	}
+
+	if resolution == domain.ServerStatResolutionRaw {
+		return stats, nil
+	}
+
+	return Aggregate(stats, resolution), nil
+}
+
+// Run downsamples stats periodically until the context is canceled.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(downsampleInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Downsample(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to downsample server stats", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Server stats downsampler stopped")
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Downsample aggregates raw samples older than RawRetention into hourly samples
+// and removes hourly samples older than HourlyRetention.
+//
+// Hours are processed one by one, the raw samples of an hour are removed
+// only after the hourly samples are saved, so an interrupted run is continued by the next one.
+func (s *Service) Downsample(ctx context.Context, now time.Time) error {
+	boundary := now.Add(-RawRetention).Truncate(time.Hour)
+
+	var lastHour time.Time
+
+	for {
+		oldest, err := s.repo.Find(ctx, &filters.FindServerStat{
+			Resolutions: []domain.ServerStatResolution{domain.ServerStatResolutionRaw},
+			Kinds:       []domain.ServerStatKind{domain.ServerStatKindQuery},
+			TimeTo:      &boundary,
+		}, []filters.Sorting{
+			{Field: "time", Direction: filters.SortDirectionAsc},
+		}, &filters.Pagination{Limit: 1})
+		if err != nil {
+			return errors.WithMessage(err, "failed to find oldest raw stat")
+		}
+
+		if len(oldest) == 0 {
+			break
+		}
+
+		hour := oldest[0].Time.Truncate(time.Hour)
+		if hour.Equal(lastHour) {
+			return errors.Errorf("raw stats of %s were not downsampled", hour.Format(time.RFC3339))
+		}
+
+		err = s.downsampleHour(ctx, hour)
+		if err != nil {
+			return err
+		}
+
+		lastHour = hour
+	}
+
+	err := s.repo.DeleteMany(ctx, &filters.FindServerStat{
+		Resolutions: []domain.ServerStatResolution{domain.ServerStatResolutionHourly},
+		Kinds:       []domain.ServerStatKind{domain.ServerStatKindQuery},
+		TimeTo:      lo.ToPtr(now.Add(-HourlyRetention)),
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to delete expired stats")
+	}
+
+	return nil
+}
+
+func (s *Service) downsampleHour(ctx context.Context, hour time.Time) error {
+	rawFilter := &filters.FindServerStat{
+		Resolutions: []domain.ServerStatResolution{domain.ServerStatResolutionRaw},
+		Kinds:       []domain.ServerStatKind{domain.ServerStatKindQuery},
+		TimeFrom:    &hour,
+		TimeTo:      lo.ToPtr(hour.Add(time.Hour)),
+	}
+
+	raw, err := s.repo.Find(ctx, rawFilter, nil, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to find raw stats")
+	}
+
+	aggregated := Aggregate(raw, domain.ServerStatResolutionHourly)
+
+	// Remove hourly samples left by an interrupted run
+	err = s.repo.DeleteMany(ctx, &filters.FindServerStat{
+		ServerIDs: lo.Map(aggregated, func(stat domain.ServerStat, _ int) uint {
+			return stat.ServerID
+		}),
+		Resolutions: []domain.ServerStatResolution{domain.ServerStatResolutionHourly},
+		Kinds:       rawFilter.Kinds,
+		TimeFrom:    rawFilter.TimeFrom,
+		TimeTo:      rawFilter.TimeTo,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "failed to delete hourly stats")
+	}
+
+	for i := range aggregated {
+		err = s.repo.Save(ctx, &aggregated[i])
+		if err != nil {
+			return errors.WithMessage(err, "failed to save hourly stat")
+		}
+	}
+
+	err = s.repo.DeleteMany(ctx, rawFilter)
+	if err != nil {
+		return errors.WithMessage(err, "failed to delete raw stats")
+	}
+
+	return nil
+}
+
+type bucketKey struct {
+	serverID uint
+	time     time.Time
+}
+
+type bucket struct {
+	stat       domain.ServerStat
+	playersSum int
+	samples    int
+}
+
+// Aggregate merges samples into buckets of the given resolution.
+// A bucket has the average players number, the highest max players number
+// and is online if the server was online at least once.
+// Buckets keep the order of the samples.
+func Aggregate(stats []domain.ServerStat, resolution domain.ServerStatResolution) []domain.ServerStat {
+	buckets := make(map[bucketKey]*bucket)
+	keys := make([]bucketKey, 0)
+
+	for _, stat := range stats {
+		key := bucketKey{
+			serverID: stat.ServerID,
+			time:     stat.Time.Truncate(resolution.Duration()),
+		}
+
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{
+				stat: domain.ServerStat{
+					ServerID:   stat.ServerID,
+					Time:       key.time,
+					Resolution: resolution,
+					Kind:       domain.ServerStatKindQuery,
+				},
+			}
+			buckets[key] = b
+			keys = append(keys, key)
+		}
+
+		b.playersSum += stat.PlayersNum
+		b.samples++
+		b.stat.Online = b.stat.Online || stat.Online
+		b.stat.MaxPlayersNum = max(b.stat.MaxPlayersNum, stat.MaxPlayersNum)
+	}
+
+	result := make([]domain.ServerStat, 0, len(keys))
+
+	for _, key := range keys {
+		b := buckets[key]
+		b.stat.PlayersNum = int(math.Round(float64(b.playersSum) / float64(b.samples)))
+
+		result = append(result, b.stat)
+	}
+
+	return result
+}
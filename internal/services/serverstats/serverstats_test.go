@@ -0,0 +1,184 @@
+package serverstats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gameap/gameap/internal/domain"
+	"github.com/gameap/gameap/internal/filters"
+	"github.com/gameap/gameap/internal/repositories/inmemory"
+	"github.com/gameap/gameap/pkg/quercon/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Record(t *testing.T) {
+	repo := inmemory.NewServerStatRepository()
+	service := NewService(repo)
+
+	queryTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	err := service.Record(context.Background(), 1, &query.Result{
+		QueryTime:     queryTime,
+		Online:        true,
+		PlayersNum:    7,
+		MaxPlayersNum: 16,
+	})
+	require.NoError(t, err)
+
+	stats, err := repo.Find(context.Background(), nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, uint(1), stats[0].ServerID)
+	assert.Equal(t, queryTime, stats[0].Time)
+	assert.Equal(t, domain.ServerStatResolutionRaw, stats[0].Resolution)
+	assert.Equal(t, domain.ServerStatKindQuery, stats[0].Kind)
+	assert.True(t, stats[0].Online)
+	assert.Equal(t, 7, stats[0].PlayersNum)
+	assert.Equal(t, 16, stats[0].MaxPlayersNum)
+}
+
+func TestService_Downsample(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewServerStatRepository()
+	service := NewService(repo)
+
+	now := time.Date(2025, 6, 10, 12, 30, 0, 0, time.UTC)
+	oldHour := now.Add(-50 * time.Hour).Truncate(time.Hour)
+
+	stats := []domain.ServerStat{
+		// Raw samples older than 48 hours, aggregated into one hourly sample per server
+		{ServerID: 1, Time: oldHour.Add(10 * time.Minute), Online: true, PlayersNum: 4, MaxPlayersNum: 10},
+		{ServerID: 1, Time: oldHour.Add(20 * time.Minute), Online: true, PlayersNum: 6, MaxPlayersNum: 12},
+		{ServerID: 1, Time: oldHour.Add(30 * time.Minute), Online: false},
+		{ServerID: 2, Time: oldHour.Add(5 * time.Minute), Online: false},
+		{ServerID: 1, Time: oldHour.Add(time.Hour + time.Minute), Online: true, PlayersNum: 1, MaxPlayersNum: 10},
+		// Recent raw sample is kept
+		{ServerID: 1, Time: now.Add(-time.Hour), Online: true, PlayersNum: 2, MaxPlayersNum: 10},
+		// Expired hourly sample
+		{
+			ServerID:   1,
+			Time:       now.Add(-HourlyRetention - time.Hour),
+			Resolution: domain.ServerStatResolutionHourly,
+			Online:     true,
+		},
+		// Resource usage rows are never downsampled or removed
+		{ServerID: 1, Time: oldHour, RAM: "512", Kind: domain.ServerStatKindResources},
+		{ServerID: 1, Time: now.Add(-HourlyRetention - time.Hour), RAM: "256", Kind: domain.ServerStatKindResources},
+	}
+
+	for i := range stats {
+		// Rows without a kind are query samples
+		if stats[i].Kind == "" {
+			stats[i].Kind = domain.ServerStatKindQuery
+		}
+
+		require.NoError(t, repo.Save(ctx, &stats[i]))
+	}
+
+	err := service.Downsample(ctx, now)
+	require.NoError(t, err)
+
+	raw, err := repo.Find(ctx, &filters.FindServerStat{
+		Resolutions: []domain.ServerStatResolution{domain.ServerStatResolutionRaw},
+		Kinds:       []domain.ServerStatKind{domain.ServerStatKindQuery},
+	}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, raw, 1)
+	assert.Equal(t, now.Add(-time.Hour), raw[0].Time)
+
+	hourly, err := repo.Find(ctx, &filters.FindServerStat{
+		Resolutions: []domain.ServerStatResolution{domain.ServerStatResolutionHourly},
+	}, []filters.Sorting{
+		{Field: "server_id", Direction: filters.SortDirectionAsc},
+		{Field: "time", Direction: filters.SortDirectionAsc},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, hourly, 3)
+
+	assert.Equal(t, domain.ServerStat{
+		ID:            hourly[0].ID,
+		ServerID:      1,
+		Time:          oldHour,
+		Resolution:    domain.ServerStatResolutionHourly,
+		Online:        true,
+		PlayersNum:    3,
+		MaxPlayersNum: 12,
+		Kind:          domain.ServerStatKindQuery,
+	}, hourly[0])
+	assert.Equal(t, oldHour.Add(time.Hour), hourly[1].Time)
+	assert.Equal(t, 1, hourly[1].PlayersNum)
+	assert.Equal(t, uint(2), hourly[2].ServerID)
+	assert.False(t, hourly[2].Online)
+
+	// Running again changes nothing
+	require.NoError(t, service.Downsample(ctx, now))
+
+	all, err := repo.Find(ctx, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 6)
+
+	resources, err := repo.Find(ctx, &filters.FindServerStat{
+		Kinds: []domain.ServerStatKind{domain.ServerStatKindResources},
+	}, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+}
+
+func TestService_Series(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewServerStatRepository()
+	service := NewService(repo)
+
+	hour := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	stats := []domain.ServerStat{
+		{ServerID: 1, Time: hour.Add(-time.Hour), Resolution: domain.ServerStatResolutionHourly, PlayersNum: 8},
+		{ServerID: 1, Time: hour.Add(time.Minute), Online: true, PlayersNum: 2},
+		{ServerID: 1, Time: hour.Add(2 * time.Minute), Online: true, PlayersNum: 4},
+		{ServerID: 2, Time: hour.Add(time.Minute), Online: true, PlayersNum: 10},
+		{ServerID: 1, Time: hour.Add(3 * time.Minute), RAM: "512", Kind: domain.ServerStatKindResources},
+	}
+
+	for i := range stats {
+		// Rows without a kind are query samples
+		if stats[i].Kind == "" {
+			stats[i].Kind = domain.ServerStatKindQuery
+		}
+
+		require.NoError(t, repo.Save(ctx, &stats[i]))
+	}
+
+	t.Run("raw", func(t *testing.T) {
+		series, err := service.Series(
+			ctx, 1, hour.Add(-2*time.Hour), hour.Add(time.Hour), domain.ServerStatResolutionRaw,
+		)
+		require.NoError(t, err)
+		require.Len(t, series, 2)
+		assert.Equal(t, 2, series[0].PlayersNum)
+		assert.Equal(t, 4, series[1].PlayersNum)
+	})
+
+	t.Run("hourly", func(t *testing.T) {
+		series, err := service.Series(
+			ctx, 1, hour.Add(-2*time.Hour), hour.Add(time.Hour), domain.ServerStatResolutionHourly,
+		)
+		require.NoError(t, err)
+		require.Len(t, series, 2)
+		assert.Equal(t, hour.Add(-time.Hour), series[0].Time)
+		assert.Equal(t, 8, series[0].PlayersNum)
+		assert.Equal(t, hour, series[1].Time)
+		assert.Equal(t, 3, series[1].PlayersNum)
+		assert.True(t, series[1].Online)
+	})
+
+	t.Run("range", func(t *testing.T) {
+		series, err := service.Series(
+			ctx, 1, hour, hour.Add(2*time.Minute), domain.ServerStatResolutionRaw,
+		)
+		require.NoError(t, err)
+		require.Len(t, series, 1)
+		assert.Equal(t, 2, series[0].PlayersNum)
+	})
+}
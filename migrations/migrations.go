@@ -39,6 +39,14 @@ type migration struct {
 // List of SQLite-specific migrations in Go.
 var sqliteMigrationsList = []migration{
 	{version: 1, upFN: sqlite.Up001, downFN: sqlite.Down001},
+	{version: 2, upFN: sqlite.Up002, downFN: sqlite.Down002},
+	{version: 3, upFN: sqlite.Up003, downFN: sqlite.Down003},
+	{version: 4, upFN: sqlite.Up004, downFN: sqlite.Down004},
+	{version: 5, upFN: sqlite.Up005, downFN: sqlite.Down005},
+	{version: 6, upFN: sqlite.Up006, downFN: sqlite.Down006},
+	{version: 7, upFN: sqlite.Up007, downFN: sqlite.Down007},
+	{version: 8, upFN: sqlite.Up008, downFN: sqlite.Down008},
+	{version: 9, upFN: sqlite.Up009, downFN: sqlite.Down009},
 }
 
 // SqliteMigrations returns the list of SQLite-specific migrations in Go.
@@ -78,6 +86,14 @@ func PostgresMigrations(_ context.Context, _ container) (goose.Migrations, error
 // List of MySQL-specific migrations in Go.
 var mysqlMigrationsList = []migration{
 	{version: 1, upFN: mysql.Up001, downFN: mysql.Down001},
+	{version: 2, upFN: mysql.Up002, downFN: mysql.Down002},
+	{version: 3, upFN: mysql.Up003, downFN: mysql.Down003},
+	{version: 4, upFN: mysql.Up004, downFN: mysql.Down004},
+	{version: 5, upFN: mysql.Up005, downFN: mysql.Down005},
+	{version: 6, upFN: mysql.Up006, downFN: mysql.Down006},
+	{version: 7, upFN: mysql.Up007, downFN: mysql.Down007},
+	{version: 8, upFN: mysql.Up008, downFN: mysql.Down008},
+	{version: 9, upFN: mysql.Up009, downFN: mysql.Down009},
 }
 
 func MySQLMigrations(_ context.Context, _ container) (goose.Migrations, error) {
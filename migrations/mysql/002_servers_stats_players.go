@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up002 adds query samples (online status and players) to servers_stats.
+func Up002(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE servers_stats
+			ADD COLUMN resolution int(10) unsigned NOT NULL DEFAULT 0,
+			ADD COLUMN online tinyint(1) NOT NULL DEFAULT 0,
+			ADD COLUMN players_num int(10) unsigned NOT NULL DEFAULT 0,
+			ADD COLUMN max_players_num int(10) unsigned NOT NULL DEFAULT 0`,
+		`CREATE INDEX servers_stats_server_id_time_index ON servers_stats (server_id, time)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down002(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`DROP INDEX servers_stats_server_id_time_index ON servers_stats`,
+		`ALTER TABLE servers_stats
+			DROP COLUMN resolution,
+			DROP COLUMN online,
+			DROP COLUMN players_num,
+			DROP COLUMN max_players_num`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up003 adds an index for time-range queries of node stats.
+func Up003(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`CREATE INDEX ds_stats_dedicated_server_id_time_index ON ds_stats (dedicated_server_id, time)`,
+	)
+
+	return err
+}
+
+func Down003(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP INDEX ds_stats_dedicated_server_id_time_index ON ds_stats`)
+
+	return err
+}
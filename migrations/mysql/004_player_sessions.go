@@ -0,0 +1,31 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up004 creates the player_sessions table.
+func Up004(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE player_sessions (
+			id bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+			server_id int(10) unsigned NOT NULL,
+			uniq_id varchar(255) NOT NULL DEFAULT '',
+			name varchar(255) NOT NULL,
+			joined_at timestamp NOT NULL,
+			left_at timestamp NULL DEFAULT NULL,
+			last_seen_at timestamp NOT NULL,
+			PRIMARY KEY (id),
+			KEY player_sessions_server_id_joined_at_index (server_id, joined_at),
+			KEY player_sessions_uniq_id_index (uniq_id),
+			KEY player_sessions_name_index (name)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down004(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE player_sessions`)
+
+	return err
+}
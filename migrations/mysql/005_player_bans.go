@@ -0,0 +1,35 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up005 creates the player_bans table.
+func Up005(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE player_bans (
+			id bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+			server_id int(10) unsigned NOT NULL,
+			uniq_id varchar(255) NOT NULL DEFAULT '',
+			name varchar(255) NOT NULL DEFAULT '',
+			reason varchar(1024) NOT NULL DEFAULT '',
+			source varchar(32) NOT NULL,
+			issuer_id int(10) unsigned NULL DEFAULT NULL,
+			created_at timestamp NOT NULL,
+			expires_at timestamp NULL DEFAULT NULL,
+			lifted_at timestamp NULL DEFAULT NULL,
+			lifted_by int(10) unsigned NULL DEFAULT NULL,
+			PRIMARY KEY (id),
+			KEY player_bans_server_id_index (server_id),
+			KEY player_bans_uniq_id_index (uniq_id),
+			KEY player_bans_lifted_at_expires_at_index (lifted_at, expires_at)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down005(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE player_bans`)
+
+	return err
+}
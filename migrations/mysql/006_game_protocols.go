@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up006 adds protocol settings to games and game mods.
+func Up006(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE games
+			ADD COLUMN query_protocol varchar(32) NULL DEFAULT NULL,
+			ADD COLUMN rcon_protocol varchar(32) NULL DEFAULT NULL,
+			ADD COLUMN player_manager varchar(32) NULL DEFAULT NULL,
+			ADD COLUMN query_port_offset int(11) NULL DEFAULT NULL,
+			ADD COLUMN rcon_port_offset int(11) NULL DEFAULT NULL`,
+		`ALTER TABLE game_mods
+			ADD COLUMN query_protocol varchar(32) NULL DEFAULT NULL,
+			ADD COLUMN rcon_protocol varchar(32) NULL DEFAULT NULL,
+			ADD COLUMN player_manager varchar(32) NULL DEFAULT NULL,
+			ADD COLUMN query_port_offset int(11) NULL DEFAULT NULL,
+			ADD COLUMN rcon_port_offset int(11) NULL DEFAULT NULL`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down006(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE games
+			DROP COLUMN query_protocol,
+			DROP COLUMN rcon_protocol,
+			DROP COLUMN player_manager,
+			DROP COLUMN query_port_offset,
+			DROP COLUMN rcon_port_offset`,
+		`ALTER TABLE game_mods
+			DROP COLUMN query_protocol,
+			DROP COLUMN rcon_protocol,
+			DROP COLUMN player_manager,
+			DROP COLUMN query_port_offset,
+			DROP COLUMN rcon_port_offset`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up007 creates the command_policies table.
+func Up007(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE command_policies (
+			id int(10) unsigned NOT NULL AUTO_INCREMENT,
+			server_id int(10) unsigned NULL DEFAULT NULL,
+			role varchar(255) NULL DEFAULT NULL,
+			target varchar(32) NOT NULL,
+			action varchar(32) NOT NULL,
+			pattern_type varchar(32) NOT NULL,
+			pattern varchar(512) NOT NULL,
+			description varchar(1024) NOT NULL DEFAULT '',
+			created_at timestamp NULL DEFAULT NULL,
+			updated_at timestamp NULL DEFAULT NULL,
+			PRIMARY KEY (id),
+			KEY command_policies_server_id_index (server_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down007(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE command_policies`)
+
+	return err
+}
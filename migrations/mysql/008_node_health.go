@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up008 creates the node_health table.
+func Up008(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE node_health (
+			node_id int(10) unsigned NOT NULL,
+			online tinyint(1) NOT NULL DEFAULT 0,
+			latency int(10) unsigned NOT NULL DEFAULT 0,
+			error varchar(1024) NOT NULL DEFAULT '',
+			last_seen_at timestamp NULL DEFAULT NULL,
+			checked_at timestamp NOT NULL,
+			PRIMARY KEY (node_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`)
+
+	return err
+}
+
+func Down008(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE node_health`)
+
+	return err
+}
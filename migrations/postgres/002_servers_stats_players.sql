@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Query samples (online status and players) stored in servers_stats.
+-- resolution is the number of seconds covered by the row, 0 for raw samples.
+ALTER TABLE servers_stats
+    ADD COLUMN resolution INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN online BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN players_num INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN max_players_num INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX servers_stats_server_id_time_index ON servers_stats (server_id, time);
+
+-- +goose Down
+
+DROP INDEX servers_stats_server_id_time_index;
+ALTER TABLE servers_stats
+    DROP COLUMN resolution,
+    DROP COLUMN online,
+    DROP COLUMN players_num,
+    DROP COLUMN max_players_num;
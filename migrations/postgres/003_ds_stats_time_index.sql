@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Index for time-range queries of node stats.
+CREATE INDEX ds_stats_dedicated_server_id_time_index ON ds_stats (dedicated_server_id, time);
+
+-- +goose Down
+
+DROP INDEX ds_stats_dedicated_server_id_time_index;
@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Sessions of players on game servers. uniq_id is empty when the player is known by name only.
+CREATE TABLE player_sessions (
+    id BIGSERIAL PRIMARY KEY,
+    server_id INTEGER NOT NULL,
+    uniq_id VARCHAR(255) NOT NULL DEFAULT '',
+    name VARCHAR(255) NOT NULL,
+    joined_at TIMESTAMPTZ NOT NULL,
+    left_at TIMESTAMPTZ NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX player_sessions_server_id_joined_at_index ON player_sessions (server_id, joined_at);
+CREATE INDEX player_sessions_uniq_id_index ON player_sessions (uniq_id);
+CREATE INDEX player_sessions_name_index ON player_sessions (name);
+
+-- +goose Down
+
+DROP TABLE player_sessions;
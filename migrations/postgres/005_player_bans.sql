@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- Bans of players on game servers registered in the panel.
+-- lifted_at is set when the ban is lifted or expired and the unban command was issued.
+CREATE TABLE player_bans (
+    id BIGSERIAL PRIMARY KEY,
+    server_id INTEGER NOT NULL,
+    uniq_id VARCHAR(255) NOT NULL DEFAULT '',
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    reason VARCHAR(1024) NOT NULL DEFAULT '',
+    source VARCHAR(32) NOT NULL,
+    issuer_id INTEGER NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NULL,
+    lifted_at TIMESTAMPTZ NULL,
+    lifted_by INTEGER NULL
+);
+CREATE INDEX player_bans_server_id_index ON player_bans (server_id);
+CREATE INDEX player_bans_uniq_id_index ON player_bans (uniq_id);
+CREATE INDEX player_bans_lifted_at_expires_at_index ON player_bans (lifted_at, expires_at);
+
+-- +goose Down
+
+DROP TABLE player_bans;
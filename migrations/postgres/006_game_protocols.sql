@@ -0,0 +1,31 @@
+-- +goose Up
+
+-- Protocol settings of games, game mods override them.
+-- Empty values fall back to the defaults by the game engine and the game code.
+ALTER TABLE games
+    ADD COLUMN query_protocol VARCHAR(32) NULL,
+    ADD COLUMN rcon_protocol VARCHAR(32) NULL,
+    ADD COLUMN player_manager VARCHAR(32) NULL,
+    ADD COLUMN query_port_offset INTEGER NULL,
+    ADD COLUMN rcon_port_offset INTEGER NULL;
+ALTER TABLE game_mods
+    ADD COLUMN query_protocol VARCHAR(32) NULL,
+    ADD COLUMN rcon_protocol VARCHAR(32) NULL,
+    ADD COLUMN player_manager VARCHAR(32) NULL,
+    ADD COLUMN query_port_offset INTEGER NULL,
+    ADD COLUMN rcon_port_offset INTEGER NULL;
+
+-- +goose Down
+
+ALTER TABLE games
+    DROP COLUMN query_protocol,
+    DROP COLUMN rcon_protocol,
+    DROP COLUMN player_manager,
+    DROP COLUMN query_port_offset,
+    DROP COLUMN rcon_port_offset;
+ALTER TABLE game_mods
+    DROP COLUMN query_protocol,
+    DROP COLUMN rcon_protocol,
+    DROP COLUMN player_manager,
+    DROP COLUMN query_port_offset,
+    DROP COLUMN rcon_port_offset;
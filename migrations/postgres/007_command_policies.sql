@@ -0,0 +1,21 @@
+-- +goose Up
+
+-- Rules allowing or denying RCON and console commands.
+-- server_id and role are NULL for policies applied to all servers and all users.
+CREATE TABLE command_policies (
+    id SERIAL PRIMARY KEY,
+    server_id INTEGER NULL,
+    role VARCHAR(255) NULL,
+    target VARCHAR(32) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    pattern_type VARCHAR(32) NOT NULL,
+    pattern VARCHAR(512) NOT NULL,
+    description VARCHAR(1024) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NULL,
+    updated_at TIMESTAMPTZ NULL
+);
+CREATE INDEX command_policies_server_id_index ON command_policies (server_id);
+
+-- +goose Down
+
+DROP TABLE command_policies;
@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- The latest result of the daemon health check of each node.
+CREATE TABLE node_health (
+    node_id INTEGER PRIMARY KEY,
+    online BOOLEAN NOT NULL DEFAULT FALSE,
+    latency INTEGER NOT NULL DEFAULT 0,
+    error VARCHAR(1024) NOT NULL DEFAULT '',
+    last_seen_at TIMESTAMPTZ NULL,
+    checked_at TIMESTAMPTZ NOT NULL
+);
+
+-- +goose Down
+
+DROP TABLE node_health;
@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- kind separates query samples from the resource usage rows of servers_stats.
+-- Rows without resource usage are query samples added by 002.
+ALTER TABLE servers_stats ADD COLUMN kind VARCHAR(16) NOT NULL DEFAULT 'resources';
+UPDATE servers_stats SET kind = 'query'
+    WHERE resolution > 0 OR (ram = '' AND cpu = '' AND netstat = '' AND drvspace = '');
+
+-- +goose Down
+
+ALTER TABLE servers_stats DROP COLUMN kind;
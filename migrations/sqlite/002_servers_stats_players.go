@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up002 adds query samples (online status and players) to servers_stats.
+func Up002(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE servers_stats ADD COLUMN resolution INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE servers_stats ADD COLUMN online INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE servers_stats ADD COLUMN players_num INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE servers_stats ADD COLUMN max_players_num INTEGER NOT NULL DEFAULT 0`,
+		`CREATE INDEX servers_stats_server_id_time_index ON servers_stats(server_id, time)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down002(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`DROP INDEX servers_stats_server_id_time_index`,
+		`ALTER TABLE servers_stats DROP COLUMN resolution`,
+		`ALTER TABLE servers_stats DROP COLUMN online`,
+		`ALTER TABLE servers_stats DROP COLUMN players_num`,
+		`ALTER TABLE servers_stats DROP COLUMN max_players_num`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
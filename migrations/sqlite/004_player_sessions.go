@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up004 creates the player_sessions table.
+func Up004(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE player_sessions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			server_id INTEGER NOT NULL,
+			uniq_id TEXT NOT NULL DEFAULT '',
+			name TEXT NOT NULL,
+			joined_at TEXT NOT NULL,
+			left_at TEXT NULL,
+			last_seen_at TEXT NOT NULL
+		)`,
+		`CREATE INDEX player_sessions_server_id_joined_at_index ON player_sessions(server_id, joined_at)`,
+		`CREATE INDEX player_sessions_uniq_id_index ON player_sessions(uniq_id)`,
+		`CREATE INDEX player_sessions_name_index ON player_sessions(name)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down004(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE player_sessions`)
+
+	return err
+}
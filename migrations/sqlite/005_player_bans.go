@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up005 creates the player_bans table.
+func Up005(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE player_bans (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			server_id INTEGER NOT NULL,
+			uniq_id TEXT NOT NULL DEFAULT '',
+			name TEXT NOT NULL DEFAULT '',
+			reason TEXT NOT NULL DEFAULT '',
+			source TEXT NOT NULL,
+			issuer_id INTEGER NULL,
+			created_at TEXT NOT NULL,
+			expires_at TEXT NULL,
+			lifted_at TEXT NULL,
+			lifted_by INTEGER NULL
+		)`,
+		`CREATE INDEX player_bans_server_id_index ON player_bans(server_id)`,
+		`CREATE INDEX player_bans_uniq_id_index ON player_bans(uniq_id)`,
+		`CREATE INDEX player_bans_lifted_at_expires_at_index ON player_bans(lifted_at, expires_at)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down005(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE player_bans`)
+
+	return err
+}
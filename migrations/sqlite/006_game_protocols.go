@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up006 adds protocol settings to games and game mods.
+func Up006(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE games ADD COLUMN query_protocol TEXT NULL`,
+		`ALTER TABLE games ADD COLUMN rcon_protocol TEXT NULL`,
+		`ALTER TABLE games ADD COLUMN player_manager TEXT NULL`,
+		`ALTER TABLE games ADD COLUMN query_port_offset INTEGER NULL`,
+		`ALTER TABLE games ADD COLUMN rcon_port_offset INTEGER NULL`,
+		`ALTER TABLE game_mods ADD COLUMN query_protocol TEXT NULL`,
+		`ALTER TABLE game_mods ADD COLUMN rcon_protocol TEXT NULL`,
+		`ALTER TABLE game_mods ADD COLUMN player_manager TEXT NULL`,
+		`ALTER TABLE game_mods ADD COLUMN query_port_offset INTEGER NULL`,
+		`ALTER TABLE game_mods ADD COLUMN rcon_port_offset INTEGER NULL`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down006(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE games DROP COLUMN query_protocol`,
+		`ALTER TABLE games DROP COLUMN rcon_protocol`,
+		`ALTER TABLE games DROP COLUMN player_manager`,
+		`ALTER TABLE games DROP COLUMN query_port_offset`,
+		`ALTER TABLE games DROP COLUMN rcon_port_offset`,
+		`ALTER TABLE game_mods DROP COLUMN query_protocol`,
+		`ALTER TABLE game_mods DROP COLUMN rcon_protocol`,
+		`ALTER TABLE game_mods DROP COLUMN player_manager`,
+		`ALTER TABLE game_mods DROP COLUMN query_port_offset`,
+		`ALTER TABLE game_mods DROP COLUMN rcon_port_offset`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up007 creates the command_policies table.
+func Up007(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`CREATE TABLE command_policies (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			server_id INTEGER NULL,
+			role TEXT NULL,
+			target TEXT NOT NULL,
+			action TEXT NOT NULL,
+			pattern_type TEXT NOT NULL,
+			pattern TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			created_at TEXT NULL,
+			updated_at TEXT NULL
+		)`,
+		`CREATE INDEX command_policies_server_id_index ON command_policies(server_id)`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down007(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE command_policies`)
+
+	return err
+}
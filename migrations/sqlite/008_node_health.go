@@ -0,0 +1,26 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up008 creates the node_health table.
+func Up008(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE node_health (
+			node_id INTEGER PRIMARY KEY,
+			online INTEGER NOT NULL DEFAULT 0,
+			latency INTEGER NOT NULL DEFAULT 0,
+			error TEXT NOT NULL DEFAULT '',
+			last_seen_at TEXT NULL,
+			checked_at TEXT NOT NULL
+		)`)
+
+	return err
+}
+
+func Down008(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `DROP TABLE node_health`)
+
+	return err
+}
@@ -0,0 +1,30 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Up009 adds the kind which separates query samples from the resource usage rows of servers_stats.
+// Rows without resource usage are query samples added by Up002.
+func Up009(ctx context.Context, tx *sql.Tx) error {
+	queries := []string{
+		`ALTER TABLE servers_stats ADD COLUMN kind TEXT NOT NULL DEFAULT 'resources'`,
+		`UPDATE servers_stats SET kind = 'query'
+			WHERE resolution > 0 OR (ram = '' AND cpu = '' AND netstat = '' AND drvspace = '')`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Down009(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `ALTER TABLE servers_stats DROP COLUMN kind`)
+
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -123,3 +124,28 @@ func (r *QueryReader) ReadUintList(key string) ([]uint, error) {
 
 	return res, nil
 }
+
+// ReadTime reads a time in RFC 3339 format or a unix timestamp. It returns nil if the key is missing.
+func (r *QueryReader) ReadTime(key string) (*time.Time, error) {
+	value, err := r.ReadString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	if timestamp, err := strconv.ParseInt(value, 10, 64); err == nil {
+		t := time.Unix(timestamp, 0)
+
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse time")
+	}
+
+	return &t, nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -410,3 +411,61 @@ func TestQueryReader_ReadUintList(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryReader_ReadTime(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       map[string][]string
+		expected    *time.Time
+		expectError bool
+	}{
+		{
+			name:     "rfc3339",
+			query:    map[string][]string{"from": {"2025-01-02T03:04:05Z"}},
+			expected: timePtr(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		},
+		{
+			name:     "unix_timestamp",
+			query:    map[string][]string{"from": {"1735787045"}},
+			expected: timePtr(time.Unix(1735787045, 0)),
+		},
+		{
+			name:     "missing_key",
+			query:    map[string][]string{},
+			expected: nil,
+		},
+		{
+			name:        "invalid_value",
+			query:       map[string][]string{"from": {"yesterday"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &QueryReader{query: tt.query}
+
+			result, err := reader.ReadTime("from")
+
+			if tt.expectError {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.expected == nil {
+				assert.Nil(t, result)
+
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.True(t, tt.expected.Equal(*result))
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
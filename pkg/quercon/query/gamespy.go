@@ -0,0 +1,44 @@
+package query
+
+import "strconv"
+
+const gameSpyMaxPacketSize = 8192
+
+// applyGameSpyRules fills the result with common fields of GameSpy server rules.
+func applyGameSpyRules(rules map[string]string, result *Result) {
+	result.Rules = rules
+
+	for key, value := range rules {
+		switch key {
+		case "hostname":
+			result.Name = value
+		case "mapname":
+			result.Map = value
+		case "numplayers":
+			result.PlayersNum, _ = strconv.Atoi(value)
+		case "maxplayers":
+			result.MaxPlayersNum, _ = strconv.Atoi(value)
+		case "gamever":
+			result.Version = value
+		case "password":
+			result.Password = value == "1" || value == "True" || value == "true"
+		}
+	}
+}
+
+// gameSpyPlayer converts player fields (name, score, ...) into a result player.
+// Returns false if the player has no name.
+func gameSpyPlayer(fields map[string]string) (ResultPlayer, bool) {
+	player := ResultPlayer{}
+
+	for key, value := range fields {
+		switch key {
+		case "player", "playername", "name":
+			player.Name = value
+		case "score", "frags":
+			player.Score, _ = strconv.Atoi(value)
+		}
+	}
+
+	return player, player.Name != ""
+}
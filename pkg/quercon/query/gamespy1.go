@@ -0,0 +1,177 @@
+package query
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	gameSpy1StatusPacket = `\status\`
+	gameSpy1MaxPackets   = 32
+)
+
+// queryGameSpy1 queries servers with the GameSpy 1 protocol (Unreal Tournament, UT2004, Battlefield 1942).
+func queryGameSpy1(ctx context.Context, host string, port int) (*Result, error) {
+	result := &Result{
+		Online:    false,
+		QueryTime: time.Now(),
+	}
+
+	conn, err := dialUDP(ctx, host, port)
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, err = conn.Write([]byte(gameSpy1StatusPacket))
+	if err != nil {
+		return result, errors.Wrap(err, "failed to send status packet")
+	}
+
+	packets := make([]gameSpy1Packet, 0, 1)
+	total := 0
+	buffer := make([]byte, gameSpyMaxPacketSize)
+
+	for total == 0 || len(packets) < total {
+		if len(packets) >= gameSpy1MaxPackets {
+			return result, errors.New("too many response packets")
+		}
+
+		n, err := conn.Read(buffer)
+		if err != nil {
+			return result, errors.Wrap(err, "failed to read status response")
+		}
+
+		packet := parseGameSpy1Packet(string(buffer[:n]))
+		if packet.final {
+			total = packet.index
+		}
+
+		packets = append(packets, packet)
+	}
+
+	sort.Slice(packets, func(i, j int) bool {
+		return packets[i].index < packets[j].index
+	})
+
+	sb := strings.Builder{}
+	for _, packet := range packets {
+		sb.WriteString(packet.payload)
+	}
+
+	parseGameSpy1Response(sb.String(), result)
+
+	result.Online = true
+
+	return result, nil
+}
+
+type gameSpy1Packet struct {
+	payload string
+	index   int
+	final   bool
+}
+
+// parseGameSpy1Packet extracts the payload and the packet number from the "\queryid\<id>.<index>" key.
+// A response without queryid is treated as a single packet.
+func parseGameSpy1Packet(data string) gameSpy1Packet {
+	packet := gameSpy1Packet{
+		payload: data,
+		index:   1,
+	}
+
+	if idx := strings.Index(packet.payload, `\final\`); idx != -1 {
+		packet.final = true
+		packet.payload = packet.payload[:idx] + packet.payload[idx+len(`\final\`):]
+	}
+
+	if idx := strings.Index(packet.payload, `\queryid\`); idx != -1 {
+		rest := packet.payload[idx+len(`\queryid\`):]
+
+		queryID := rest
+		after := ""
+		if end := strings.Index(rest, `\`); end != -1 {
+			queryID = rest[:end]
+			after = rest[end:]
+		}
+
+		if dot := strings.LastIndex(queryID, "."); dot != -1 {
+			if index, err := strconv.Atoi(queryID[dot+1:]); err == nil {
+				packet.index = index
+			}
+		}
+
+		packet.payload = packet.payload[:idx] + after
+	}
+
+	return packet
+}
+
+// parseGameSpy1Response parses backslash delimited key/value pairs.
+// Player fields are suffixed with the player number: \player_0\Name\frags_0\10\ping_0\50.
+func parseGameSpy1Response(data string, result *Result) {
+	parts := strings.Split(strings.TrimPrefix(data, `\`), `\`)
+
+	rules := make(map[string]string, len(parts)/2)
+	playerFields := make(map[int]map[string]string)
+
+	for i := 0; i+1 < len(parts); i += 2 {
+		key, value := parts[i], parts[i+1]
+		if key == "" {
+			continue
+		}
+
+		if name, index, ok := splitGameSpy1PlayerKey(key); ok {
+			if playerFields[index] == nil {
+				playerFields[index] = make(map[string]string)
+			}
+
+			playerFields[index][name] = value
+
+			continue
+		}
+
+		rules[key] = value
+	}
+
+	applyGameSpyRules(rules, result)
+
+	indexes := make([]int, 0, len(playerFields))
+	for index := range playerFields {
+		indexes = append(indexes, index)
+	}
+
+	sort.Ints(indexes)
+
+	for _, index := range indexes {
+		if player, ok := gameSpyPlayer(playerFields[index]); ok {
+			result.Players = append(result.Players, player)
+		}
+	}
+}
+
+func splitGameSpy1PlayerKey(key string) (string, int, bool) {
+	idx := strings.LastIndex(key, "_")
+	if idx == -1 || idx == len(key)-1 {
+		return "", 0, false
+	}
+
+	index, err := strconv.Atoi(key[idx+1:])
+	if err != nil {
+		return "", 0, false
+	}
+
+	switch name := key[:idx]; name {
+	case "player", "playername", "frags", "score", "ping", "team", "deaths", "kills":
+		return name, index, true
+	default:
+		return "", 0, false
+	}
+}
@@ -0,0 +1,183 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Rows count in a GameSpy 2 field block is always small,
+	// a larger value means that the server omitted the count byte.
+	gameSpy2MaxRowsCount = 64
+)
+
+var (
+	gameSpy2Header         = []byte{0xFE, 0xFD, 0x00}
+	gameSpy2SessionID      = []byte{0x04, 0x05, 0x06, 0x07}
+	gameSpy2InfoRequest    = []byte{0xFF, 0x00, 0x00}
+	gameSpy2PlayersRequest = []byte{0x00, 0xFF, 0x00}
+)
+
+// queryGameSpy2 queries servers with the GameSpy 2 protocol (Battlefield Vietnam, Halo, America's Army).
+func queryGameSpy2(ctx context.Context, host string, port int) (*Result, error) {
+	result := &Result{
+		Online:    false,
+		QueryTime: time.Now(),
+	}
+
+	conn, err := dialUDP(ctx, host, port)
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	info, err := gameSpy2Request(conn, gameSpy2InfoRequest)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to query info")
+	}
+
+	applyGameSpyRules(parseGameSpy2Info(info), result)
+	result.Online = true
+
+	players, err := gameSpy2Request(conn, gameSpy2PlayersRequest)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to query players")
+	}
+
+	for _, fields := range parseGameSpy2Rows(players) {
+		if player, ok := gameSpyPlayer(fields); ok {
+			result.Players = append(result.Players, player)
+		}
+	}
+
+	return result, nil
+}
+
+func gameSpy2Request(conn net.Conn, request []byte) ([]byte, error) {
+	packet := make([]byte, 0, len(gameSpy2Header)+len(gameSpy2SessionID)+len(request))
+	packet = append(packet, gameSpy2Header...)
+	packet = append(packet, gameSpy2SessionID...)
+	packet = append(packet, request...)
+
+	_, err := conn.Write(packet)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send packet")
+	}
+
+	buffer := make([]byte, gameSpyMaxPacketSize)
+
+	n, err := conn.Read(buffer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+
+	return parseGameSpy2Packet(buffer[:n])
+}
+
+// parseGameSpy2Packet validates the response header (0x00 + session ID) and returns the payload.
+func parseGameSpy2Packet(packet []byte) ([]byte, error) {
+	if len(packet) < 5 {
+		return nil, errors.New("response too short")
+	}
+
+	if packet[0] != 0x00 {
+		return nil, errors.Errorf("invalid response type: expected 0x00, got 0x%02x", packet[0])
+	}
+
+	if !bytes.Equal(packet[1:5], gameSpy2SessionID) {
+		return nil, errors.New("session ID mismatch")
+	}
+
+	return packet[5:], nil
+}
+
+// parseGameSpy2Info parses null terminated key/value pairs, terminated by an empty key.
+func parseGameSpy2Info(data []byte) map[string]string {
+	reader := bytes.NewReader(data)
+	rules := make(map[string]string)
+
+	for {
+		key, err := readNullTerminatedString(reader)
+		if err != nil || key == "" {
+			break
+		}
+
+		value, err := readNullTerminatedString(reader)
+		if err != nil {
+			break
+		}
+
+		rules[key] = value
+	}
+
+	return rules
+}
+
+// parseGameSpy2Rows parses a field block: 0x00, rows count, field names
+// terminated by an empty name, then values row by row.
+// Field names have a trailing underscore (player_, score_), it is removed.
+func parseGameSpy2Rows(data []byte) []map[string]string {
+	reader := bytes.NewReader(data)
+
+	if _, err := reader.ReadByte(); err != nil {
+		return nil
+	}
+
+	count, err := reader.ReadByte()
+	if err != nil {
+		return nil
+	}
+
+	if count > gameSpy2MaxRowsCount {
+		_ = reader.UnreadByte()
+	}
+
+	fields := make([]string, 0, 4)
+
+	for {
+		field, err := readNullTerminatedString(reader)
+		if err != nil || field == "" {
+			break
+		}
+
+		fields = append(fields, trimGameSpyFieldName(field))
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	rows := make([]map[string]string, 0, count)
+
+	for reader.Len() > 0 {
+		row := make(map[string]string, len(fields))
+
+		for i, field := range fields {
+			value, err := readNullTerminatedString(reader)
+			if err != nil || (i == 0 && value == "") {
+				return rows
+			}
+
+			row[field] = value
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// trimGameSpyFieldName removes the trailing underscore: "player_" -> "player".
+func trimGameSpyFieldName(field string) string {
+	if len(field) > 1 && field[len(field)-1] == '_' {
+		return field[:len(field)-1]
+	}
+
+	return field
+}
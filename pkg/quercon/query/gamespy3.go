@@ -0,0 +1,267 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	gameSpy3TypeChallenge byte = 0x09
+	gameSpy3TypeQuery     byte = 0x00
+
+	// "splitnum\x00" + packet number byte + unknown byte.
+	gameSpy3SplitHeaderSize = 11
+	gameSpy3LastPacketFlag  = 0x80
+	gameSpy3MaxPackets      = 32
+)
+
+var (
+	gameSpy3SessionID    = []byte{0x01, 0x02, 0x03, 0x04}
+	gameSpy3FullRequest  = []byte{0xFF, 0xFF, 0xFF, 0x01}
+	gameSpy3PacketHeader = []byte{0xFE, 0xFD}
+)
+
+// queryGameSpy3 queries servers with the GameSpy 3 protocol (Battlefield 2, Arma, Crysis, Unreal Tournament 3).
+func queryGameSpy3(ctx context.Context, host string, port int) (*Result, error) {
+	result := &Result{
+		Online:    false,
+		QueryTime: time.Now(),
+	}
+
+	conn, err := dialUDP(ctx, host, port)
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	challenge, err := gameSpy3Challenge(conn)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to get challenge")
+	}
+
+	request := make([]byte, 0, 16)
+	request = append(request, gameSpy3PacketHeader...)
+	request = append(request, gameSpy3TypeQuery)
+	request = append(request, gameSpy3SessionID...)
+	request = append(request, challenge...)
+	request = append(request, gameSpy3FullRequest...)
+
+	_, err = conn.Write(request)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to send query packet")
+	}
+
+	packets, err := gameSpy3ReadPackets(conn)
+	if err != nil {
+		return result, err
+	}
+
+	parseGameSpy3Response(packets, result)
+
+	result.Online = true
+
+	return result, nil
+}
+
+// gameSpy3Challenge requests the challenge number and encodes it as a 4-byte big endian integer.
+func gameSpy3Challenge(conn net.Conn) ([]byte, error) {
+	request := make([]byte, 0, 7)
+	request = append(request, gameSpy3PacketHeader...)
+	request = append(request, gameSpy3TypeChallenge)
+	request = append(request, gameSpy3SessionID...)
+
+	_, err := conn.Write(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send challenge packet")
+	}
+
+	buffer := make([]byte, gameSpyMaxPacketSize)
+
+	n, err := conn.Read(buffer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read challenge response")
+	}
+
+	payload, err := parseGameSpy3Packet(buffer[:n], gameSpy3TypeChallenge)
+	if err != nil {
+		return nil, err
+	}
+
+	challengeStr := strings.TrimRight(string(payload), "\x00")
+
+	challengeNum, err := strconv.ParseInt(challengeStr, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse challenge number")
+	}
+
+	challenge := make([]byte, 4)
+	// #nosec G115 - challengeNum is from server response, overflow is acceptable
+	binary.BigEndian.PutUint32(challenge, uint32(challengeNum))
+
+	return challenge, nil
+}
+
+// gameSpy3ReadPackets reads split response packets and returns their payloads in order.
+func gameSpy3ReadPackets(conn net.Conn) ([][]byte, error) {
+	packets := make(map[int][]byte)
+	total := 0
+	buffer := make([]byte, gameSpyMaxPacketSize)
+
+	for total == 0 || len(packets) < total {
+		if len(packets) >= gameSpy3MaxPackets {
+			return nil, errors.New("too many response packets")
+		}
+
+		n, err := conn.Read(buffer)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read query response")
+		}
+
+		payload, err := parseGameSpy3Packet(buffer[:n], gameSpy3TypeQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		index, last, body, err := splitGameSpy3Payload(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if last {
+			total = index + 1
+		}
+
+		packets[index] = bytes.Clone(body)
+	}
+
+	result := make([][]byte, 0, total)
+
+	for i := range total {
+		packet, ok := packets[i]
+		if !ok {
+			return nil, errors.Errorf("missing response packet %d", i)
+		}
+
+		result = append(result, packet)
+	}
+
+	return result, nil
+}
+
+// parseGameSpy3Packet validates the packet type and session ID and returns the payload.
+func parseGameSpy3Packet(packet []byte, packetType byte) ([]byte, error) {
+	if len(packet) < 5 {
+		return nil, errors.New("response too short")
+	}
+
+	if packet[0] != packetType {
+		return nil, errors.Errorf("invalid response type: expected 0x%02x, got 0x%02x", packetType, packet[0])
+	}
+
+	if !bytes.Equal(packet[1:5], gameSpy3SessionID) {
+		return nil, errors.New("session ID mismatch")
+	}
+
+	return packet[5:], nil
+}
+
+func splitGameSpy3Payload(payload []byte) (int, bool, []byte, error) {
+	if len(payload) < gameSpy3SplitHeaderSize {
+		return 0, false, nil, errors.New("query response too short")
+	}
+
+	number := payload[gameSpy3SplitHeaderSize-2]
+	last := number&gameSpy3LastPacketFlag != 0
+	index := int(number &^ gameSpy3LastPacketFlag)
+
+	return index, last, payload[gameSpy3SplitHeaderSize:], nil
+}
+
+// parseGameSpy3Response parses the server key/values from the first packet
+// and player field blocks from all packets.
+//
+// A field block is a field name ("player_", "score_", "team_t"), an offset byte
+// and null terminated values terminated by an empty value.
+// A block may continue in the next packet starting from the given offset.
+func parseGameSpy3Response(packets [][]byte, result *Result) {
+	rules := make(map[string]string)
+	playerFields := make([]map[string]string, 0)
+
+	for i, packet := range packets {
+		reader := bytes.NewReader(packet)
+
+		if i == 0 {
+			for {
+				key, err := readNullTerminatedString(reader)
+				if err != nil || key == "" {
+					break
+				}
+
+				value, err := readNullTerminatedString(reader)
+				if err != nil {
+					break
+				}
+
+				rules[key] = value
+			}
+		}
+
+		for reader.Len() > 0 {
+			b, _ := reader.ReadByte()
+			if b <= 0x02 {
+				// Section delimiters
+				continue
+			}
+
+			_ = reader.UnreadByte()
+
+			field, err := readNullTerminatedString(reader)
+			if err != nil || field == "" {
+				break
+			}
+
+			offset, err := reader.ReadByte()
+			if err != nil {
+				break
+			}
+
+			// Only player fields ("player_", "score_") are collected, team fields end with "_t"
+			isPlayerField := strings.HasSuffix(field, "_")
+			name := trimGameSpyFieldName(field)
+
+			for index := int(offset); ; index++ {
+				value, err := readNullTerminatedString(reader)
+				if err != nil || value == "" {
+					break
+				}
+
+				if !isPlayerField {
+					continue
+				}
+
+				for len(playerFields) <= index {
+					playerFields = append(playerFields, make(map[string]string))
+				}
+
+				playerFields[index][name] = value
+			}
+		}
+	}
+
+	applyGameSpyRules(rules, result)
+
+	for _, fields := range playerFields {
+		if player, ok := gameSpyPlayer(fields); ok {
+			result.Players = append(result.Players, player)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeUDPServer answers every received packet with the responses returned by the handler.
+func startFakeUDPServer(t *testing.T, handler func(request []byte) [][]byte) int {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	go func() {
+		buffer := make([]byte, 2048)
+
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+
+			for _, response := range handler(buffer[:n]) {
+				_, _ = conn.WriteTo(response, addr)
+			}
+		}
+	}()
+
+	udpAddr, ok := conn.LocalAddr().(*net.UDPAddr)
+	require.True(t, ok)
+
+	return udpAddr.Port
+}
+
+func testQueryContext(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+func TestParseGameSpy1Response(t *testing.T) {
+	data := `\gamename\ut\gamever\451\hostname\Unreal Tournament Server\mapname\DM-Deck16][\numplayers\2` +
+		`\maxplayers\16\gametype\DeathMatchPlus\player_0\Player\frags_0\12\ping_0\ 45` +
+		`\team_0\0\player_1\Another One\frags_1\-1\ping_1\ 80\team_1\255`
+
+	result := &Result{}
+	parseGameSpy1Response(data, result)
+
+	assert.Equal(t, "Unreal Tournament Server", result.Name)
+	assert.Equal(t, "DM-Deck16][", result.Map)
+	assert.Equal(t, 2, result.PlayersNum)
+	assert.Equal(t, 16, result.MaxPlayersNum)
+	assert.Equal(t, []ResultPlayer{
+		{Name: "Player", Score: 12},
+		{Name: "Another One", Score: -1},
+	}, result.Players)
+	assert.Equal(t, "DeathMatchPlus", result.Rules["gametype"])
+	assert.Equal(t, "ut", result.Rules["gamename"])
+	assert.NotContains(t, result.Rules, "player_0")
+}
+
+func TestParseGameSpy1Packet(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected gameSpy1Packet
+	}{
+		{
+			name:     "single_final_packet",
+			data:     `\hostname\Test\queryid\57.1\final\`,
+			expected: gameSpy1Packet{payload: `\hostname\Test`, index: 1, final: true},
+		},
+		{
+			name:     "first_of_several",
+			data:     `\hostname\Test\queryid\57.1`,
+			expected: gameSpy1Packet{payload: `\hostname\Test`, index: 1, final: false},
+		},
+		{
+			name:     "last_of_several",
+			data:     `\player_0\Name\queryid\57.2\final\`,
+			expected: gameSpy1Packet{payload: `\player_0\Name`, index: 2, final: true},
+		},
+		{
+			name:     "without_query_id",
+			data:     `\hostname\Test\final\`,
+			expected: gameSpy1Packet{payload: `\hostname\Test`, index: 1, final: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseGameSpy1Packet(tt.data))
+		})
+	}
+}
+
+func TestQueryGameSpy1_SplitResponse(t *testing.T) {
+	port := startFakeUDPServer(t, func(request []byte) [][]byte {
+		if string(request) != `\status\` {
+			return nil
+		}
+
+		// Packets arrive out of order
+		return [][]byte{
+			[]byte(`\player_0\Player\frags_0\3\queryid\12.2\final\`),
+			[]byte(`\hostname\UT2004 Server\mapname\DM-Rankin\numplayers\1\maxplayers\10\queryid\12.1`),
+		}
+	})
+
+	result, err := Query(testQueryContext(t), "127.0.0.1", port, ProtocolGameSpy1)
+	require.NoError(t, err)
+
+	assert.True(t, result.Online)
+	assert.Equal(t, "UT2004 Server", result.Name)
+	assert.Equal(t, "DM-Rankin", result.Map)
+	assert.Equal(t, 1, result.PlayersNum)
+	assert.Equal(t, 10, result.MaxPlayersNum)
+	assert.Equal(t, []ResultPlayer{{Name: "Player", Score: 3}}, result.Players)
+}
+
+func TestParseGameSpy2(t *testing.T) {
+	info := []byte("hostname\x00Halo Server\x00gamever\x0001.00.09.0620\x00mapname\x00bloodgulch\x00" +
+		"numplayers\x002\x00maxplayers\x0016\x00password\x000\x00\x00")
+
+	rules := parseGameSpy2Info(info)
+	assert.Equal(t, map[string]string{
+		"hostname":   "Halo Server",
+		"gamever":    "01.00.09.0620",
+		"mapname":    "bloodgulch",
+		"numplayers": "2",
+		"maxplayers": "16",
+		"password":   "0",
+	}, rules)
+
+	players := []byte("\x00\x02player_\x00score_\x00ping_\x00\x00" +
+		"Chief\x0025\x0050\x00" +
+		"Arbiter\x0013\x00120\x00")
+
+	assert.Equal(t, []map[string]string{
+		{"player": "Chief", "score": "25", "ping": "50"},
+		{"player": "Arbiter", "score": "13", "ping": "120"},
+	}, parseGameSpy2Rows(players))
+
+	// America's Army omits the rows count byte
+	withoutCount := []byte("\x00player_\x00score_\x00\x00Soldier\x007\x00")
+
+	assert.Equal(t, []map[string]string{
+		{"player": "Soldier", "score": "7"},
+	}, parseGameSpy2Rows(withoutCount))
+}
+
+func TestQueryGameSpy2(t *testing.T) {
+	port := startFakeUDPServer(t, func(request []byte) [][]byte {
+		if len(request) != 10 || !bytes.Equal(request[:3], gameSpy2Header) {
+			return nil
+		}
+
+		header := append([]byte{0x00}, request[3:7]...)
+
+		switch {
+		case bytes.Equal(request[7:], gameSpy2InfoRequest):
+			return [][]byte{append(header,
+				[]byte("hostname\x00BF Vietnam\x00mapname\x00Hue\x00numplayers\x001\x00maxplayers\x0064\x00\x00")...,
+			)}
+		case bytes.Equal(request[7:], gameSpy2PlayersRequest):
+			return [][]byte{append(header,
+				[]byte("\x00\x01player_\x00score_\x00\x00Grunt\x0042\x00")...,
+			)}
+		}
+
+		return nil
+	})
+
+	result, err := Query(testQueryContext(t), "127.0.0.1", port, ProtocolGameSpy2)
+	require.NoError(t, err)
+
+	assert.True(t, result.Online)
+	assert.Equal(t, "BF Vietnam", result.Name)
+	assert.Equal(t, "Hue", result.Map)
+	assert.Equal(t, 1, result.PlayersNum)
+	assert.Equal(t, 64, result.MaxPlayersNum)
+	assert.Equal(t, []ResultPlayer{{Name: "Grunt", Score: 42}}, result.Players)
+}
+
+func TestParseGameSpy3Response(t *testing.T) {
+	packets := [][]byte{
+		[]byte("hostname\x00BF2 Server\x00gamename\x00battlefield2\x00mapname\x00Strike At Karkand\x00" +
+			"numplayers\x003\x00maxplayers\x0064\x00\x00" +
+			"\x01player_\x00\x00Alpha\x00Bravo\x00\x00" +
+			"score_\x00\x0010\x0020\x00\x00"),
+		[]byte("\x01player_\x00\x02Charlie\x00\x00score_\x00\x0230\x00\x00" +
+			"\x02team_t\x00\x00MEC\x00USMC\x00\x00"),
+	}
+
+	result := &Result{}
+	parseGameSpy3Response(packets, result)
+
+	assert.Equal(t, "BF2 Server", result.Name)
+	assert.Equal(t, "Strike At Karkand", result.Map)
+	assert.Equal(t, 3, result.PlayersNum)
+	assert.Equal(t, 64, result.MaxPlayersNum)
+	assert.Equal(t, "battlefield2", result.Rules["gamename"])
+	assert.Equal(t, []ResultPlayer{
+		{Name: "Alpha", Score: 10},
+		{Name: "Bravo", Score: 20},
+		{Name: "Charlie", Score: 30},
+	}, result.Players)
+}
+
+func TestQueryGameSpy3(t *testing.T) {
+	port := startFakeUDPServer(t, func(request []byte) [][]byte {
+		if len(request) < 7 || !bytes.Equal(request[:2], gameSpy3PacketHeader) {
+			return nil
+		}
+
+		sessionID := request[3:7]
+
+		switch request[2] {
+		case gameSpy3TypeChallenge:
+			return [][]byte{append(append([]byte{0x09}, sessionID...), []byte("-1234567\x00")...)}
+		case gameSpy3TypeQuery:
+			// Challenge -1234567 encoded as big endian int32
+			if !bytes.Equal(request[7:11], []byte{0xFF, 0xED, 0x29, 0x79}) {
+				return nil
+			}
+
+			header := append([]byte{0x00}, sessionID...)
+
+			return [][]byte{
+				append(append(append([]byte{}, header...), []byte("splitnum\x00\x81\x00")...),
+					[]byte("\x01player_\x00\x01Second\x00\x00")...),
+				append(append(append([]byte{}, header...), []byte("splitnum\x00\x00\x00")...),
+					[]byte("hostname\x00Arma Server\x00mapname\x00Sahrani\x00numplayers\x002\x00maxplayers\x0032\x00\x00"+
+						"\x01player_\x00\x00First\x00\x00")...),
+			}
+		}
+
+		return nil
+	})
+
+	result, err := Query(testQueryContext(t), "127.0.0.1", port, ProtocolGameSpy3)
+	require.NoError(t, err)
+
+	assert.True(t, result.Online)
+	assert.Equal(t, "Arma Server", result.Name)
+	assert.Equal(t, "Sahrani", result.Map)
+	assert.Equal(t, 2, result.PlayersNum)
+	assert.Equal(t, 32, result.MaxPlayersNum)
+	assert.Equal(t, []ResultPlayer{{Name: "First"}, {Name: "Second"}}, result.Players)
+}
@@ -0,0 +1,270 @@
+package query
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	minecraftPingPacketStatus = 0x00
+	minecraftPingNextState    = 0x01
+	// -1 tells the server that the client doesn't care about the protocol version.
+	minecraftPingProtocolVersion = -1
+	minecraftPingMaxResponseSize = 1 << 20
+	minecraftPingMaxVarIntBytes  = 5
+)
+
+var errMinecraftVarIntTooBig = errors.New("varint is too big")
+
+// minecraftPingStatus is the JSON status returned by the Server List Ping.
+type minecraftPingStatus struct {
+	Version struct {
+		Name     string `json:"name"`
+		Protocol int    `json:"protocol"`
+	} `json:"version"`
+	Players struct {
+		Max    int `json:"max"`
+		Online int `json:"online"`
+		Sample []struct {
+			Name string `json:"name"`
+			ID   string `json:"id"`
+		} `json:"sample"`
+	} `json:"players"`
+	Description json.RawMessage `json:"description"`
+}
+
+// minecraftChatComponent is a text component used in the server description (MOTD).
+type minecraftChatComponent struct {
+	Text  string            `json:"text"`
+	Extra []json.RawMessage `json:"extra"`
+}
+
+// queryMinecraftPing queries Minecraft servers with the Server List Ping over TCP.
+// Unlike the UDP query it works without enable-query=true, the game port is used.
+func queryMinecraftPing(ctx context.Context, host string, port int) (*Result, error) {
+	result := &Result{
+		Online:    false,
+		QueryTime: time.Now(),
+	}
+
+	address := fmt.Sprintf("%s:%d", host, port)
+
+	dialer := &net.Dialer{Timeout: defaultTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create TCP connection")
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to set deadline")
+	}
+
+	request := buildMinecraftPingHandshake(host, port)
+	request = append(request, buildMinecraftPingPacket(minecraftPingPacketStatus, nil)...)
+
+	_, err = conn.Write(request)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to send status request")
+	}
+
+	data, err := readMinecraftPingResponse(bufio.NewReader(conn))
+	if err != nil {
+		return result, errors.Wrap(err, "failed to read status response")
+	}
+
+	err = parseMinecraftPingResponse(data, result)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to parse status response")
+	}
+
+	result.Online = true
+
+	return result, nil
+}
+
+// buildMinecraftPingHandshake builds the handshake packet:
+// protocol version, server address, server port and the next state (status).
+func buildMinecraftPingHandshake(host string, port int) []byte {
+	data := make([]byte, 0, len(host)+16)
+	data = appendVarInt(data, minecraftPingProtocolVersion)
+	data = appendVarInt(data, int32(len(host))) // #nosec G115 - host length is small
+	data = append(data, host...)
+	data = binary.BigEndian.AppendUint16(data, uint16(port)) // #nosec G115 - port fits in uint16
+	data = appendVarInt(data, minecraftPingNextState)
+
+	return buildMinecraftPingPacket(0x00, data)
+}
+
+// buildMinecraftPingPacket frames the packet: length (VarInt), packet ID (VarInt), data.
+func buildMinecraftPingPacket(packetID int32, data []byte) []byte {
+	body := appendVarInt(make([]byte, 0, len(data)+minecraftPingMaxVarIntBytes), packetID)
+	body = append(body, data...)
+
+	packet := appendVarInt(make([]byte, 0, len(body)+minecraftPingMaxVarIntBytes), int32(len(body))) // #nosec G115
+	packet = append(packet, body...)
+
+	return packet
+}
+
+// readMinecraftPingResponse reads the status response packet and returns the JSON string.
+func readMinecraftPingResponse(reader io.ByteReader) ([]byte, error) {
+	length, err := readVarInt(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read packet length")
+	}
+
+	if length <= 0 || length > minecraftPingMaxResponseSize {
+		return nil, errors.Errorf("invalid packet length: %d", length)
+	}
+
+	packetID, err := readVarInt(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read packet id")
+	}
+
+	if packetID != minecraftPingPacketStatus {
+		return nil, errors.Errorf("invalid packet id: expected 0x00, got 0x%02x", packetID)
+	}
+
+	jsonLength, err := readVarInt(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response length")
+	}
+
+	if jsonLength < 0 || jsonLength > length {
+		return nil, errors.Errorf("invalid response length: %d", jsonLength)
+	}
+
+	data := make([]byte, jsonLength)
+	for i := range data {
+		data[i], err = reader.ReadByte()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read response")
+		}
+	}
+
+	return data, nil
+}
+
+func parseMinecraftPingResponse(data []byte, result *Result) error {
+	status := minecraftPingStatus{}
+
+	err := json.Unmarshal(data, &status)
+	if err != nil {
+		return err
+	}
+
+	result.Name = stripMinecraftFormatting(parseMinecraftDescription(status.Description))
+	result.Version = status.Version.Name
+	result.PlayersNum = status.Players.Online
+	result.MaxPlayersNum = status.Players.Max
+
+	for _, player := range status.Players.Sample {
+		if player.Name == "" {
+			continue
+		}
+
+		result.Players = append(result.Players, ResultPlayer{Name: player.Name})
+	}
+
+	return nil
+}
+
+// parseMinecraftDescription converts the description into plain text.
+// The description is either a string or a chat component with nested "extra" components.
+func parseMinecraftDescription(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	component := minecraftChatComponent{}
+	if err := json.Unmarshal(raw, &component); err != nil {
+		return ""
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(component.Text)
+
+	for _, extra := range component.Extra {
+		sb.WriteString(parseMinecraftDescription(extra))
+	}
+
+	return sb.String()
+}
+
+// stripMinecraftFormatting removes legacy formatting codes (§a, §l) from a string.
+func stripMinecraftFormatting(s string) string {
+	if !strings.ContainsRune(s, '§') {
+		return strings.TrimSpace(s)
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(len(s))
+
+	skip := false
+	for _, r := range s {
+		switch {
+		case skip:
+			skip = false
+		case r == '§':
+			skip = true
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+func appendVarInt(data []byte, value int32) []byte {
+	v := uint32(value) // #nosec G115 - negative values are encoded as two's complement
+
+	for v >= 0x80 {
+		data = append(data, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(data, byte(v))
+}
+
+func readVarInt(reader io.ByteReader) (int32, error) {
+	var value uint32
+
+	for i := range minecraftPingMaxVarIntBytes {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		value |= uint32(b&0x7F) << (7 * i)
+
+		if b&0x80 == 0 {
+			return int32(value), nil // #nosec G115 - two's complement
+		}
+	}
+
+	return 0, errMinecraftVarIntTooBig
+}
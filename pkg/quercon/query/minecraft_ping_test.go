@@ -0,0 +1,146 @@
+package query
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarInt(t *testing.T) {
+	tests := []struct {
+		value   int32
+		encoded []byte
+	}{
+		{value: 0, encoded: []byte{0x00}},
+		{value: 1, encoded: []byte{0x01}},
+		{value: 127, encoded: []byte{0x7f}},
+		{value: 128, encoded: []byte{0x80, 0x01}},
+		{value: 25565, encoded: []byte{0xdd, 0xc7, 0x01}},
+		{value: 2147483647, encoded: []byte{0xff, 0xff, 0xff, 0xff, 0x07}},
+		{value: -1, encoded: []byte{0xff, 0xff, 0xff, 0xff, 0x0f}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.encoded, appendVarInt(nil, tt.value))
+
+		decoded, err := readVarInt(bytes.NewReader(tt.encoded))
+		require.NoError(t, err)
+		assert.Equal(t, tt.value, decoded)
+	}
+
+	_, err := readVarInt(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0x01}))
+	require.ErrorIs(t, err, errMinecraftVarIntTooBig)
+}
+
+func TestParseMinecraftPingResponse(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		expectedName    string
+		expectedVersion string
+		expectedOnline  int
+		expectedMax     int
+		expectedPlayers []ResultPlayer
+	}{
+		{
+			name: "string_description",
+			data: `{"version":{"name":"1.20.4","protocol":765},` +
+				`"players":{"max":20,"online":2,"sample":[{"name":"Steve","id":"1"},{"name":"Alex","id":"2"}]},` +
+				`"description":"§aMy §lServer"}`,
+			expectedName:    "My Server",
+			expectedVersion: "1.20.4",
+			expectedOnline:  2,
+			expectedMax:     20,
+			expectedPlayers: []ResultPlayer{{Name: "Steve"}, {Name: "Alex"}},
+		},
+		{
+			name: "chat_component_description",
+			data: `{"version":{"name":"Paper 1.21","protocol":767},"players":{"max":100,"online":0},` +
+				`"description":{"text":"","extra":[{"text":"Survival ","color":"green"},` +
+				`{"text":"Server","extra":[{"text":" #1"}]}]}}`,
+			expectedName:    "Survival Server #1",
+			expectedVersion: "Paper 1.21",
+			expectedOnline:  0,
+			expectedMax:     100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &Result{}
+
+			err := parseMinecraftPingResponse([]byte(tt.data), result)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedName, result.Name)
+			assert.Equal(t, tt.expectedVersion, result.Version)
+			assert.Equal(t, tt.expectedOnline, result.PlayersNum)
+			assert.Equal(t, tt.expectedMax, result.MaxPlayersNum)
+			assert.Equal(t, tt.expectedPlayers, result.Players)
+		})
+	}
+}
+
+func TestQueryMinecraftPing(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	status := map[string]any{
+		"version":     map[string]any{"name": "1.20.1", "protocol": 763},
+		"players":     map[string]any{"max": 50, "online": 1, "sample": []map[string]string{{"name": "Notch"}}},
+		"description": map[string]any{"text": "Hello"},
+	}
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		reader := bufio.NewReader(conn)
+
+		// Handshake and status request
+		for range 2 {
+			length, err := readVarInt(reader)
+			if err != nil {
+				return
+			}
+
+			_, err = reader.Discard(int(length))
+			if err != nil {
+				return
+			}
+		}
+
+		payload, _ := json.Marshal(status)
+		data := appendVarInt(nil, int32(len(payload)))
+		data = append(data, payload...)
+
+		_, _ = conn.Write(buildMinecraftPingPacket(minecraftPingPacketStatus, data))
+	}()
+
+	tcpAddr, ok := listener.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+
+	result, err := Query(testQueryContext(t), "127.0.0.1", tcpAddr.Port, ProtocolMinecraftPing)
+	require.NoError(t, err)
+
+	assert.True(t, result.Online)
+	assert.Equal(t, "Hello", result.Name)
+	assert.Equal(t, "1.20.1", result.Version)
+	assert.Equal(t, 1, result.PlayersNum)
+	assert.Equal(t, 50, result.MaxPlayersNum)
+	assert.Equal(t, []ResultPlayer{{Name: "Notch"}}, result.Players)
+}
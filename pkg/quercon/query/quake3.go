@@ -0,0 +1,165 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	quake3Header         = "\xff\xff\xff\xff"
+	quake3StatusRequest  = quake3Header + "getstatus\n"
+	quake3StatusResponse = "statusResponse"
+)
+
+// queryQuake3 queries id Tech 3 based servers (Quake 3, Urban Terror, Call of Duty 2/4, Wolfenstein: Enemy Territory).
+func queryQuake3(ctx context.Context, host string, port int) (*Result, error) {
+	result := &Result{
+		Online:    false,
+		QueryTime: time.Now(),
+	}
+
+	conn, err := dialUDP(ctx, host, port)
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, err = conn.Write([]byte(quake3StatusRequest))
+	if err != nil {
+		return result, errors.Wrap(err, "failed to send getstatus packet")
+	}
+
+	buffer := make([]byte, defaultMaxPacketSize)
+
+	n, err := conn.Read(buffer)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to read status response")
+	}
+
+	err = parseQuake3Response(buffer[:n], result)
+	if err != nil {
+		return result, err
+	}
+
+	result.Online = true
+
+	return result, nil
+}
+
+// parseQuake3Response parses the getstatus response:
+//
+//	\xff\xff\xff\xffstatusResponse
+//	\sv_hostname\Server\mapname\q3dm17\sv_maxclients\16
+//	12 50 "^1Player"
+func parseQuake3Response(data []byte, result *Result) error {
+	data = bytes.TrimPrefix(data, []byte(quake3Header))
+
+	lines := strings.Split(strings.TrimRight(string(data), "\x00\n"), "\n")
+	if len(lines) < 2 || strings.TrimSpace(lines[0]) != quake3StatusResponse {
+		return errors.New("invalid status response")
+	}
+
+	rules := parseQuake3Info(lines[1])
+	result.Rules = rules
+
+	for key, value := range rules {
+		switch key {
+		case "sv_hostname", "hostname":
+			result.Name = stripQuake3Colors(value)
+		case "mapname":
+			result.Map = value
+		case "sv_maxclients":
+			result.MaxPlayersNum, _ = strconv.Atoi(value)
+		case "g_needpass", "pswrd":
+			result.Password = value == "1"
+		}
+	}
+
+	// Call of Duty reports the version in "shortversion"
+	result.Version = rules["version"]
+	if result.Version == "" {
+		result.Version = rules["shortversion"]
+	}
+
+	for _, line := range lines[2:] {
+		if player, ok := parseQuake3Player(line); ok {
+			result.Players = append(result.Players, player)
+		}
+	}
+
+	result.PlayersNum = len(result.Players)
+
+	return nil
+}
+
+// parseQuake3Info parses backslash delimited key/value pairs.
+func parseQuake3Info(line string) map[string]string {
+	parts := strings.Split(strings.TrimPrefix(line, `\`), `\`)
+	rules := make(map[string]string, len(parts)/2)
+
+	for i := 0; i+1 < len(parts); i += 2 {
+		if parts[i] == "" {
+			continue
+		}
+
+		rules[parts[i]] = parts[i+1]
+	}
+
+	return rules
+}
+
+// parseQuake3Player parses a player line: <score> <ping> "<name>".
+// Some games (Wolfenstein: Enemy Territory) have extra numeric fields before the name.
+func parseQuake3Player(line string) (ResultPlayer, bool) {
+	nameStart := strings.Index(line, `"`)
+	nameEnd := strings.LastIndex(line, `"`)
+
+	if nameStart == -1 || nameEnd <= nameStart {
+		return ResultPlayer{}, false
+	}
+
+	fields := strings.Fields(line[:nameStart])
+	if len(fields) == 0 {
+		return ResultPlayer{}, false
+	}
+
+	score, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return ResultPlayer{}, false
+	}
+
+	return ResultPlayer{
+		Name:  stripQuake3Colors(line[nameStart+1 : nameEnd]),
+		Score: score,
+	}, true
+}
+
+// stripQuake3Colors removes color codes (^1, ^7, ^a) from a string.
+// A caret followed by another caret is kept as is.
+func stripQuake3Colors(s string) string {
+	if !strings.Contains(s, "^") {
+		return s
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(len(s))
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '^' && i+1 < len(s) && s[i+1] != '^' {
+			i++
+
+			continue
+		}
+
+		sb.WriteByte(s[i])
+	}
+
+	return sb.String()
+}
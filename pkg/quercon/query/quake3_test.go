@@ -0,0 +1,96 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuake3Response(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		expectedName    string
+		expectedMap     string
+		expectedMax     int
+		expectedPlayers []ResultPlayer
+	}{
+		{
+			name: "quake3",
+			data: "\xff\xff\xff\xffstatusResponse\n" +
+				`\sv_hostname\^1Red ^7Arena\mapname\q3dm17\sv_maxclients\16\g_gametype\0` + "\n" +
+				`15 48 "^2Sarge"` + "\n" +
+				`-2 999 "^^7Bot^7"` + "\n",
+			expectedName: "Red Arena",
+			expectedMap:  "q3dm17",
+			expectedMax:  16,
+			expectedPlayers: []ResultPlayer{
+				{Name: "Sarge", Score: 15},
+				{Name: "^Bot", Score: -2},
+			},
+		},
+		{
+			name: "enemy_territory_extra_fields",
+			data: "\xff\xff\xff\xffstatusResponse\n" +
+				`\sv_hostname\^aET ^oServer\mapname\oasis\sv_maxclients\32` + "\n" +
+				`120 40 0 "^1Medic"` + "\n",
+			expectedName:    "ET Server",
+			expectedMap:     "oasis",
+			expectedMax:     32,
+			expectedPlayers: []ResultPlayer{{Name: "Medic", Score: 120}},
+		},
+		{
+			name: "no_players",
+			data: "\xff\xff\xff\xffstatusResponse\n" +
+				`\sv_hostname\CoD4 Server\mapname\mp_crash\sv_maxclients\24` + "\n",
+			expectedName: "CoD4 Server",
+			expectedMap:  "mp_crash",
+			expectedMax:  24,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &Result{}
+
+			err := parseQuake3Response([]byte(tt.data), result)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedName, result.Name)
+			assert.Equal(t, tt.expectedMap, result.Map)
+			assert.Equal(t, tt.expectedMax, result.MaxPlayersNum)
+			assert.Equal(t, len(tt.expectedPlayers), result.PlayersNum)
+			assert.Equal(t, tt.expectedPlayers, result.Players)
+		})
+	}
+}
+
+func TestParseQuake3Response_Invalid(t *testing.T) {
+	err := parseQuake3Response([]byte("\xff\xff\xff\xffprint\nunknown command\n"), &Result{})
+
+	require.Error(t, err)
+}
+
+func TestQueryQuake3(t *testing.T) {
+	port := startFakeUDPServer(t, func(request []byte) [][]byte {
+		if string(request) != quake3StatusRequest {
+			return nil
+		}
+
+		return [][]byte{[]byte("\xff\xff\xff\xffstatusResponse\n" +
+			`\sv_hostname\^4Urban ^7Terror\mapname\ut4_turnpike\sv_maxclients\12` + "\n" +
+			`7 30 "Player"` + "\n")}
+	})
+
+	result, err := Query(testQueryContext(t), "127.0.0.1", port, ProtocolQuake3)
+	require.NoError(t, err)
+
+	assert.True(t, result.Online)
+	assert.Equal(t, "Urban Terror", result.Name)
+	assert.Equal(t, "ut4_turnpike", result.Map)
+	assert.Equal(t, 1, result.PlayersNum)
+	assert.Equal(t, 12, result.MaxPlayersNum)
+	assert.Equal(t, []ResultPlayer{{Name: "Player", Score: 7}}, result.Players)
+	assert.Equal(t, "ut4_turnpike", result.Rules["mapname"])
+}
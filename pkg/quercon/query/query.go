@@ -2,7 +2,11 @@ package query
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 const (
@@ -13,8 +17,13 @@ const (
 type Protocol string
 
 const (
-	ProtocolSource    Protocol = "source"
-	ProtocolMinecraft Protocol = "minecraft"
+	ProtocolSource        Protocol = "source"
+	ProtocolMinecraft     Protocol = "minecraft"
+	ProtocolMinecraftPing Protocol = "minecraft_ping"
+	ProtocolGameSpy1      Protocol = "gamespy1"
+	ProtocolGameSpy2      Protocol = "gamespy2"
+	ProtocolGameSpy3      Protocol = "gamespy3"
+	ProtocolQuake3        Protocol = "quake3"
 )
 
 type Result struct {
@@ -25,6 +34,17 @@ type Result struct {
 	PlayersNum    int            `json:"players_num,omitempty"`
 	MaxPlayersNum int            `json:"max_players_num,omitempty"`
 	Players       []ResultPlayer `json:"players,omitempty"`
+
+	Version string `json:"version,omitempty"`
+	// Password is true if the server requires a password to join.
+	Password bool `json:"password,omitempty"`
+	// Secure is true if the server is protected by an anti-cheat (VAC).
+	Secure bool     `json:"secure,omitempty"`
+	Bots   int      `json:"bots,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+
+	// Rules contains raw server key/values (cvars, server info) returned by the protocol.
+	Rules map[string]string `json:"rules,omitempty"`
 }
 
 type ResultPlayer struct {
@@ -33,8 +53,13 @@ type ResultPlayer struct {
 }
 
 var queryProtocolFuncsMap = map[Protocol]func(ctx context.Context, host string, port int) (*Result, error){
-	"source":    querySource,
-	"minecraft": queryMinecraft,
+	"source":         querySource,
+	"minecraft":      queryMinecraft,
+	"minecraft_ping": queryMinecraftPing,
+	"gamespy1":       queryGameSpy1,
+	"gamespy2":       queryGameSpy2,
+	"gamespy3":       queryGameSpy3,
+	"quake3":         queryQuake3,
 }
 
 func Query(ctx context.Context, host string, port int, protocol Protocol) (*Result, error) {
@@ -45,3 +70,34 @@ func Query(ctx context.Context, host string, port int, protocol Protocol) (*Resu
 
 	return queryFunc(ctx, host, port)
 }
+
+func IsProtocolSupported(protocol Protocol) bool {
+	_, ok := queryProtocolFuncsMap[protocol]
+
+	return ok
+}
+
+// dialUDP creates a UDP connection with the deadline taken from the context.
+func dialUDP(ctx context.Context, host string, port int) (net.Conn, error) {
+	address := fmt.Sprintf("%s:%d", host, port)
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create UDP connection")
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, errors.Wrap(err, "failed to set deadline")
+	}
+
+	return conn, nil
+}
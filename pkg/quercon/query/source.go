@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -45,6 +46,14 @@ func querySource(_ context.Context, host string, port int) (*Result, error) {
 	result.Map = info.Map
 	result.PlayersNum = int(info.Players)
 	result.MaxPlayersNum = int(info.MaxPlayers)
+	result.Version = info.Version
+	result.Password = info.Visibility
+	result.Secure = info.VAC
+	result.Bots = int(info.Bots)
+
+	if info.ExtendedServerInfo != nil {
+		result.Tags = parseSourceKeywords(info.ExtendedServerInfo.Keywords)
+	}
 
 	players, err := client.QueryPlayer()
 	if err != nil {
@@ -62,5 +71,36 @@ func querySource(_ context.Context, host string, port int) (*Result, error) {
 		}
 	}
 
+	// Many servers don't answer A2S_RULES (CS:GO and CS2 have host_rules_show 0 by default),
+	// so rules are optional.
+	rules, err := client.QueryRules()
+	if err != nil {
+		slog.Debug("failed to query rules", "address", address, "error", err)
+
+		return result, nil
+	}
+
+	if rules != nil {
+		result.Rules = rules.Rules
+	}
+
 	return result, nil
 }
+
+// parseSourceKeywords splits the comma separated server tags (sv_tags).
+func parseSourceKeywords(keywords string) []string {
+	if keywords == "" {
+		return nil
+	}
+
+	parts := strings.Split(keywords, ",")
+	tags := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		if tag := strings.TrimSpace(part); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,112 @@
+package query
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSourceChallenge = []byte{0x4B, 0xA1, 0xD5, 0x22}
+
+// fakeSourceServer answers A2S_INFO, A2S_PLAYER and A2S_RULES requests after a challenge,
+// the rules response is split into two packets.
+func fakeSourceServer(request []byte) [][]byte {
+	if len(request) < 5 || !bytes.Equal(request[:4], []byte{0xFF, 0xFF, 0xFF, 0xFF}) {
+		return nil
+	}
+
+	challengeResponse := append([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x41}, testSourceChallenge...)
+
+	if !bytes.HasSuffix(request, testSourceChallenge) {
+		return [][]byte{challengeResponse}
+	}
+
+	switch request[4] {
+	case 0x54:
+		info := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x49, 0x11}
+		info = append(info, "Test Server\x00de_dust2\x00csgo\x00Counter-Strike: Global Offensive\x00"...)
+		info = append(info, 0xDA, 0x02) // AppID 730
+		info = append(info, 5, 32, 2)   // players, max players, bots
+		info = append(info, 'd', 'l')   // server type, OS
+		info = append(info, 1, 1)       // password, VAC
+		info = append(info, "1.38.7.9\x00"...)
+		info = append(info, 0x20) // EDF: keywords
+		info = append(info, "secure, competitive,,increased_maxplayers\x00"...)
+
+		return [][]byte{info}
+	case 0x55:
+		players := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x44, 1, 0}
+		players = append(players, "Player\x00"...)
+		players = binary.LittleEndian.AppendUint32(players, 12)
+		players = append(players, 0, 0, 0, 0)
+
+		return [][]byte{players}
+	case 0x56:
+		rules := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x45, 3, 0}
+		rules = append(rules, "mp_timelimit\x0030\x00sv_tags\x00competitive\x00sm_version\x001.11\x00"...)
+
+		split := len(rules) / 2
+
+		// Packets are sent in reverse order
+		return [][]byte{
+			sourceSplitPacket(1, 2, rules[split:]),
+			sourceSplitPacket(0, 2, rules[:split]),
+		}
+	}
+
+	return nil
+}
+
+func sourceSplitPacket(number, total byte, payload []byte) []byte {
+	packet := []byte{0xFE, 0xFF, 0xFF, 0xFF}
+	packet = binary.LittleEndian.AppendUint32(packet, 1)
+	packet = append(packet, total, number)
+	packet = binary.LittleEndian.AppendUint16(packet, 1248)
+
+	return append(packet, payload...)
+}
+
+func TestQuerySource(t *testing.T) {
+	port := startFakeUDPServer(t, fakeSourceServer)
+
+	result, err := Query(testQueryContext(t), "127.0.0.1", port, ProtocolSource)
+	require.NoError(t, err)
+
+	assert.True(t, result.Online)
+	assert.Equal(t, "Test Server", result.Name)
+	assert.Equal(t, "de_dust2", result.Map)
+	assert.Equal(t, 5, result.PlayersNum)
+	assert.Equal(t, 32, result.MaxPlayersNum)
+	assert.Equal(t, "1.38.7.9", result.Version)
+	assert.True(t, result.Password)
+	assert.True(t, result.Secure)
+	assert.Equal(t, 2, result.Bots)
+	assert.Equal(t, []string{"secure", "competitive", "increased_maxplayers"}, result.Tags)
+	assert.Equal(t, []ResultPlayer{{Name: "Player", Score: 12}}, result.Players)
+	assert.Equal(t, map[string]string{
+		"mp_timelimit": "30",
+		"sv_tags":      "competitive",
+		"sm_version":   "1.11",
+	}, result.Rules)
+}
+
+func TestQuerySource_RulesNotAvailable(t *testing.T) {
+	port := startFakeUDPServer(t, func(request []byte) [][]byte {
+		// Server doesn't answer A2S_RULES
+		if len(request) > 4 && request[4] == 0x56 {
+			return nil
+		}
+
+		return fakeSourceServer(request)
+	})
+
+	result, err := Query(testQueryContext(t), "127.0.0.1", port, ProtocolSource)
+	require.NoError(t, err)
+
+	assert.True(t, result.Online)
+	assert.Equal(t, "Test Server", result.Name)
+	assert.Nil(t, result.Rules)
+}
@@ -6,6 +6,22 @@ var (
 	ErrPlayersManagementNotSupported = errors.New("players management is not supported for this game")
 )
 
+// Player manager names, they can be set for games and game mods to override the defaults.
+const (
+	ManagerGoldSrc   = "goldsrc"
+	ManagerSource    = "source"
+	ManagerMinecraft = "minecraft"
+	ManagerSevenDays = "7d2d"
+)
+
+var mapPlayerManagersByName = map[string]func() PlayerManager{
+	ManagerGoldSrc:   NewValvePlayers,
+	ManagerSource:    NewSourcePlayers,
+	ManagerMinecraft: NewMinecraftPlayers,
+	ManagerSevenDays: NewSevenDaysPlayers,
+}
+
+// mapPlayerManagersByGameCode are the defaults for games without a configured player manager.
 var mapPlayerManagersByGameCode = map[string]func() PlayerManager{
 	"cs":        NewValvePlayers,
 	"cstrike":   NewValvePlayers,
@@ -15,6 +31,33 @@ var mapPlayerManagersByGameCode = map[string]func() PlayerManager{
 	"hl":        NewValvePlayers,
 	"valve":     NewValvePlayers,
 	"minecraft": NewMinecraftPlayers,
+	"7d2d":      NewSevenDaysPlayers,
+	"sdtd":      NewSevenDaysPlayers,
+	"bms":       NewSourcePlayers,
+	"cs2":       NewSourcePlayers,
+	"csgo":      NewSourcePlayers,
+	"cssource":  NewSourcePlayers,
+	"cssv34":    NewSourcePlayers,
+	"dods":      NewSourcePlayers,
+	"garrysmod": NewSourcePlayers,
+	"hl2mp":     NewSourcePlayers,
+	"l4d":       NewSourcePlayers,
+	"l4d2":      NewSourcePlayers,
+	"tf2":       NewSourcePlayers,
+}
+
+// NewPlayerManager returns the player manager by name.
+// If the name is empty, the default manager of the game code is returned.
+func NewPlayerManager(name, gameCode string) (PlayerManager, error) {
+	if name == "" {
+		return NewPlayerManagerByGameCode(gameCode)
+	}
+
+	if constructor, ok := mapPlayerManagersByName[name]; ok {
+		return constructor(), nil
+	}
+
+	return nil, ErrPlayersManagementNotSupported
 }
 
 func NewPlayerManagerByGameCode(gameCode string) (PlayerManager, error) {
@@ -30,3 +73,10 @@ func IsPlayerManagementSupported(gameCode string) bool {
 
 	return ok
 }
+
+// IsPlayerManagerSupported checks if the player manager name is known.
+func IsPlayerManagerSupported(name string) bool {
+	_, ok := mapPlayerManagersByName[name]
+
+	return ok
+}
@@ -0,0 +1,68 @@
+package players
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlayerManager(t *testing.T) {
+	tests := []struct {
+		name        string
+		manager     string
+		gameCode    string
+		expected    PlayerManager
+		expectedErr error
+	}{
+		{
+			name:     "manager_by_name",
+			manager:  ManagerSource,
+			gameCode: "cs",
+			expected: &SourcePlayerManager{},
+		},
+		{
+			name:     "manager_by_name_for_unknown_game",
+			manager:  ManagerMinecraft,
+			gameCode: "my_minecraft_fork",
+			expected: &MinecraftPlayerManager{},
+		},
+		{
+			name:     "default_manager_of_game",
+			gameCode: "cstrike",
+			expected: &ValvePlayerManager{},
+		},
+		{
+			name:        "unknown_manager",
+			manager:     "unknown",
+			gameCode:    "cstrike",
+			expectedErr: ErrPlayersManagementNotSupported,
+		},
+		{
+			name:        "unknown_game",
+			gameCode:    "unknown",
+			expectedErr: ErrPlayersManagementNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr, err := NewPlayerManager(tt.manager, tt.gameCode)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.IsType(t, tt.expected, mgr)
+		})
+	}
+}
+
+func TestIsPlayerManagerSupported(t *testing.T) {
+	assert.True(t, IsPlayerManagerSupported(ManagerGoldSrc))
+	assert.True(t, IsPlayerManagerSupported(ManagerSevenDays))
+	assert.False(t, IsPlayerManagerSupported("cstrike"))
+	assert.False(t, IsPlayerManagerSupported(""))
+}
@@ -65,8 +65,10 @@ func (mgr *MinecraftPlayerManager) PlayersCommand() string {
 	return "list uuids"
 }
 
+// KickCommand kicks the player by name. Minecraft commands take the rest of the line
+// as the reason, so it isn't quoted.
 func (mgr *MinecraftPlayerManager) KickCommand(player Player, reason string) (string, error) {
-	if err := player.ValidateName(); err != nil {
+	if err := mgr.validateName(player); err != nil {
 		return "", err
 	}
 
@@ -76,7 +78,7 @@ func (mgr *MinecraftPlayerManager) KickCommand(player Player, reason string) (st
 	sb.WriteString("kick ")
 	sb.WriteString(player.Name)
 
-	if reason != "" {
+	if reason = EscapeArgument(reason); reason != "" {
 		sb.WriteString(" ")
 		sb.WriteString(reason)
 	}
@@ -85,7 +87,7 @@ func (mgr *MinecraftPlayerManager) KickCommand(player Player, reason string) (st
 }
 
 func (mgr *MinecraftPlayerManager) BanCommand(player Player, reason string, _ time.Duration) (string, error) {
-	if err := player.ValidateName(); err != nil {
+	if err := mgr.validateName(player); err != nil {
 		return "", err
 	}
 
@@ -95,10 +97,55 @@ func (mgr *MinecraftPlayerManager) BanCommand(player Player, reason string, _ ti
 	sb.WriteString("ban ")
 	sb.WriteString(player.Name)
 
-	if reason != "" {
+	if reason = EscapeArgument(reason); reason != "" {
 		sb.WriteString(" ")
 		sb.WriteString(reason)
 	}
 
 	return sb.String(), nil
 }
+
+func (mgr *MinecraftPlayerManager) UnbanCommand(player Player) (string, error) {
+	if err := mgr.validateName(player); err != nil {
+		return "", err
+	}
+
+	return "pardon " + player.Name, nil
+}
+
+func (mgr *MinecraftPlayerManager) validateName(player Player) error {
+	if err := player.ValidateName(); err != nil {
+		return err
+	}
+
+	if !IsValidMinecraftName(player.Name) {
+		return ErrInvalidPlayerName
+	}
+
+	return nil
+}
+
+// MessageCommand uses the tell command, the target is the player name or UUID.
+// Minecraft commands take the rest of the line as the message, so it isn't quoted.
+func (mgr *MinecraftPlayerManager) MessageCommand(player Player, message string) (string, error) {
+	target := strings.ReplaceAll(EscapeArgument(firstNonEmpty(player.Name, player.UniqID)), " ", "")
+	if target == "" {
+		return "", ErrPlayerNameRequired
+	}
+
+	message, err := validateMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	return "tell " + target + " " + message, nil
+}
+
+func (mgr *MinecraftPlayerManager) BroadcastCommand(message string) (string, error) {
+	message, err := validateMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	return "say " + message, nil
+}
@@ -179,6 +179,17 @@ func TestMinecraftPlayerManager_BanCommand(t *testing.T) {
 			duration: 7 * 24 * time.Hour,
 			expected: "ban Notch spam",
 		},
+		{
+			name:     "ban_reason_with_line_break",
+			player:   Player{Name: "Steve"},
+			reason:   "hacking\nop Steve",
+			expected: "ban Steve hacking op Steve",
+		},
+		{
+			name:        "ban_with_injected_name",
+			player:      Player{Name: "Steve\nop Alex"},
+			expectedErr: ErrInvalidPlayerName,
+		},
 		{
 			name:        "ban_with_empty_name",
 			player:      Player{Name: ""},
@@ -203,3 +214,106 @@ func TestMinecraftPlayerManager_BanCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestMinecraftPlayerManager_UnbanCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "unban_by_name",
+			player:   Player{Name: "Steve", UniqID: "069a79f4-44e9-4726-a5be-fca90e38aaf5"},
+			expected: "pardon Steve",
+		},
+		{
+			name:        "unban_with_invalid_name",
+			player:      Player{Name: "Steve Alex"},
+			expectedErr: ErrInvalidPlayerName,
+		},
+		{
+			name:        "unban_with_empty_name",
+			player:      Player{UniqID: "069a79f4-44e9-4726-a5be-fca90e38aaf5"},
+			expectedErr: ErrPlayerNameRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewMinecraftPlayers()
+			result, err := mgr.UnbanCommand(tt.player)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMinecraftPlayerManager_MessageCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		message     string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "message_by_name",
+			player:   Player{Name: "Steve", UniqID: "069a79f4-44e9-4726-a5be-fca90e38aaf5"},
+			message:  "hello",
+			expected: "tell Steve hello",
+		},
+		{
+			name:     "message_by_uuid",
+			player:   Player{ID: "069a79f4-44e9-4726-a5be-fca90e38aaf5", UniqID: "069a79f4-44e9-4726-a5be-fca90e38aaf5"},
+			message:  "hello",
+			expected: "tell 069a79f4-44e9-4726-a5be-fca90e38aaf5 hello",
+		},
+		{
+			name:     "message_is_single_line",
+			player:   Player{Name: "Steve"},
+			message:  "hello\nop Steve",
+			expected: "tell Steve hello op Steve",
+		},
+		{
+			name:        "message_without_player",
+			message:     "hello",
+			expectedErr: ErrPlayerNameRequired,
+		},
+		{
+			name:        "empty_message",
+			player:      Player{Name: "Steve"},
+			expectedErr: ErrMessageRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewMinecraftPlayers()
+			result, err := mgr.MessageCommand(tt.player, tt.message)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMinecraftPlayerManager_BroadcastCommand(t *testing.T) {
+	mgr := NewMinecraftPlayers()
+
+	result, err := mgr.BroadcastCommand("server\nrestarts")
+	assert.NoError(t, err)
+	assert.Equal(t, "say server restarts", result)
+
+	_, err = mgr.BroadcastCommand("")
+	assert.ErrorIs(t, err, ErrMessageRequired)
+}
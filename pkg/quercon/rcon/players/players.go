@@ -2,12 +2,28 @@ package players
 
 import (
 	"errors"
+	"regexp"
+	"strings"
 	"time"
+	"unicode"
 )
 
 var (
 	ErrPlayerNameRequired   = errors.New("player name is required")
 	ErrPlayerUniqIDRequired = errors.New("player unique ID is required")
+	ErrMessageRequired      = errors.New("message is required")
+	ErrInvalidPlayerUniqID  = errors.New("invalid player unique ID")
+	ErrInvalidPlayerName    = errors.New("invalid player name")
+)
+
+var (
+	steamIDRegexp = regexp.MustCompile(`^(STEAM_[0-5]:[01]:\d+|\[U:1:\d+\])$`)
+
+	// uniqIDRegexp matches SteamIDs, UUIDs and platform IDs, they never contain spaces,
+	// quotes or command separators.
+	uniqIDRegexp = regexp.MustCompile(`^[A-Za-z0-9_:.\-\[\]]{1,64}$`)
+
+	minecraftNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_]{1,16}$`)
 )
 
 type Player struct {
@@ -37,6 +53,21 @@ func (p Player) ValidateUniqID() error {
 	return nil
 }
 
+// IsValidSteamID reports whether the value is a SteamID in the STEAM_X:Y:Z or [U:1:Z] format.
+func IsValidSteamID(steamID string) bool {
+	return steamIDRegexp.MatchString(steamID)
+}
+
+// IsValidUniqID reports whether the value can be a unique player ID of any supported game.
+func IsValidUniqID(uniqID string) bool {
+	return uniqIDRegexp.MatchString(uniqID)
+}
+
+// IsValidMinecraftName reports whether the value is a valid Minecraft player name.
+func IsValidMinecraftName(name string) bool {
+	return minecraftNameRegexp.MatchString(name)
+}
+
 type PlayerManager interface {
 	// ParsePlayers takes the raw response from the server and parses it into a slice of Player structs.
 	ParsePlayers(data string) ([]Player, error)
@@ -49,4 +80,56 @@ type PlayerManager interface {
 
 	// BanCommand returns the command string to ban a player with the given reason.
 	BanCommand(player Player, reason string, time time.Duration) (string, error)
+
+	// UnbanCommand returns the command string to lift the ban of a player.
+	UnbanCommand(player Player) (string, error)
+
+	// MessageCommand returns the command string to send a private chat message to a player.
+	MessageCommand(player Player, message string) (string, error)
+
+	// BroadcastCommand returns the command string to send a chat message to all players.
+	BroadcastCommand(message string) (string, error)
+}
+
+// EscapeArgument escapes the user input inserted into a console command, so it stays
+// a single argument of a single command. Game consoles have no escape sequences:
+// control characters are replaced with spaces, double quotes with single ones
+// and command separators with commas.
+func EscapeArgument(s string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsControl(r):
+			return ' '
+		case r == '"':
+			return '\''
+		case r == ';':
+			return ','
+		default:
+			return r
+		}
+	}, s))
+}
+
+// quoteArgument wraps the escaped argument in double quotes.
+func quoteArgument(s string) string {
+	return `"` + EscapeArgument(s) + `"`
+}
+
+func validateMessage(message string) (string, error) {
+	message = EscapeArgument(message)
+	if message == "" {
+		return "", ErrMessageRequired
+	}
+
+	return message, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
 }
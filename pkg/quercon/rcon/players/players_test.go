@@ -85,3 +85,38 @@ func TestPlayer_ValidateUniqID(t *testing.T) {
 		})
 	}
 }
+
+func TestEscapeArgument(t *testing.T) {
+	tests := []struct {
+		name     string
+		argument string
+		expected string
+	}{
+		{
+			name:     "plain_text",
+			argument: "hello world",
+			expected: "hello world",
+		},
+		{
+			name:     "double_quotes",
+			argument: `say "hi"`,
+			expected: "say 'hi'",
+		},
+		{
+			name:     "command_separators",
+			argument: "bye; quit",
+			expected: "bye, quit",
+		},
+		{
+			name:     "control_characters",
+			argument: "hello\nquit\r\n",
+			expected: "hello quit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, EscapeArgument(tt.argument))
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package players
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const sevenDaysPermanentBanYears = 100
+
+var (
+	// 0. id=171, Steve, pos=(-1.5, 61.1, 45.7), rot=(-18.3, 14.5, 0.0), remote=True, health=84, ...
+	sevenDaysPlayerLineRegexp = regexp.MustCompile(`^\d+\.\s+id=(\d+),\s+(.*?),\s+pos=\(`)
+	sevenDaysKeyValueRegexp   = regexp.MustCompile(`(\w+)=(\([^)]*\)|[^,]*)`)
+)
+
+// SevenDaysPlayerManager handles players of 7 Days to Die servers via the telnet console.
+type SevenDaysPlayerManager struct{}
+
+func NewSevenDaysPlayers() PlayerManager {
+	return &SevenDaysPlayerManager{}
+}
+
+func (mgr *SevenDaysPlayerManager) ParsePlayers(data string) ([]Player, error) {
+	lines := strings.Split(data, "\n")
+	players := make([]Player, 0, len(lines))
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		player, ok := mgr.parsePlayer(line)
+		if !ok {
+			continue
+		}
+
+		players = append(players, player)
+	}
+
+	return players, nil
+}
+
+func (mgr *SevenDaysPlayerManager) parsePlayer(line string) (Player, bool) {
+	matches := sevenDaysPlayerLineRegexp.FindStringSubmatch(line)
+	if matches == nil {
+		return Player{}, false
+	}
+
+	player := Player{
+		ID:   matches[1],
+		Name: matches[2],
+	}
+
+	for _, kv := range sevenDaysKeyValueRegexp.FindAllStringSubmatch(line[len(matches[0])-1:], -1) {
+		value := strings.TrimSpace(kv[2])
+
+		switch kv[1] {
+		case "score":
+			player.Score = value
+		case "ping":
+			player.Ping = value
+		case "ip":
+			player.Addr = value
+		case "pltfmid", "steamid":
+			if player.UniqID == "" {
+				player.UniqID = value
+			}
+		}
+	}
+
+	if player.UniqID == "" {
+		player.UniqID = player.ID
+	}
+
+	return player, true
+}
+
+func (mgr *SevenDaysPlayerManager) PlayersCommand() string {
+	return "lp"
+}
+
+func (mgr *SevenDaysPlayerManager) KickCommand(player Player, reason string) (string, error) {
+	if err := player.ValidateUniqID(); err != nil {
+		return "", err
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(64)
+
+	sb.WriteString("kick ")
+	sb.WriteString(player.UniqID)
+
+	if reason != "" {
+		sb.WriteString(" ")
+		sb.WriteString(quoteArgument(reason))
+	}
+
+	return sb.String(), nil
+}
+
+func (mgr *SevenDaysPlayerManager) BanCommand(player Player, reason string, duration time.Duration) (string, error) {
+	if err := mgr.validateUniqID(player); err != nil {
+		return "", err
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(64)
+
+	sb.WriteString("ban add ")
+	sb.WriteString(player.UniqID)
+
+	minutes := int(duration.Minutes())
+	if minutes <= 0 {
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Itoa(sevenDaysPermanentBanYears))
+		sb.WriteString(" years")
+	} else {
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Itoa(minutes))
+		sb.WriteString(" minutes")
+	}
+
+	if reason != "" {
+		sb.WriteString(" ")
+		sb.WriteString(quoteArgument(reason))
+	}
+
+	return sb.String(), nil
+}
+
+func (mgr *SevenDaysPlayerManager) UnbanCommand(player Player) (string, error) {
+	if err := mgr.validateUniqID(player); err != nil {
+		return "", err
+	}
+
+	return "ban remove " + player.UniqID, nil
+}
+
+func (mgr *SevenDaysPlayerManager) validateUniqID(player Player) error {
+	if err := player.ValidateUniqID(); err != nil {
+		return err
+	}
+
+	if !IsValidUniqID(player.UniqID) {
+		return ErrInvalidPlayerUniqID
+	}
+
+	return nil
+}
+
+func (mgr *SevenDaysPlayerManager) MessageCommand(player Player, message string) (string, error) {
+	target := firstNonEmpty(player.ID, player.UniqID, player.Name)
+	if target == "" {
+		return "", ErrPlayerUniqIDRequired
+	}
+
+	message, err := validateMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	return "sayplayer " + quoteArgument(target) + " " + quoteArgument(message), nil
+}
+
+func (mgr *SevenDaysPlayerManager) BroadcastCommand(message string) (string, error) {
+	message, err := validateMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	return "say " + quoteArgument(message), nil
+}
@@ -0,0 +1,257 @@
+package players
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSevenDaysPlayerManager_ParsePlayers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Player
+	}{
+		{
+			name: "multiple_players_with_platform_ids",
+			input: `0. id=171, Steve, pos=(-1234.5, 61.1, 456.7), rot=(-18.3, 1462.5, 0.0), remote=True, health=84, deaths=0, zombies=2, players=0, score=2, level=1, pltfmid=Steam_76561198000000001, crossid=EOS_0002aaaa, ip=192.0.2.10, ping=24
+1. id=245, Alex, the Builder, pos=(10.0, 50.0, 10.0), rot=(0.0, 0.0, 0.0), remote=True, health=100, deaths=3, zombies=14, players=1, score=11, level=7, pltfmid=Steam_76561198000000002, crossid=EOS_0002bbbb, ip=192.0.2.11, ping=57
+Total of 2 in the game`,
+			expected: []Player{
+				{ID: "171", Name: "Steve", Score: "2", Ping: "24", Addr: "192.0.2.10", UniqID: "Steam_76561198000000001"},
+				{ID: "245", Name: "Alex, the Builder", Score: "11", Ping: "57", Addr: "192.0.2.11", UniqID: "Steam_76561198000000002"},
+			},
+		},
+		{
+			name:  "legacy_steamid_format",
+			input: `1. id=171, Steve, pos=(1.0, 2.0, 3.0), rot=(0.0, 0.0, 0.0), remote=True, health=100, deaths=0, zombies=0, players=0, score=0, level=1, steamid=76561198000000001, ip=192.0.2.10, ping=12`,
+			expected: []Player{
+				{ID: "171", Name: "Steve", Score: "0", Ping: "12", Addr: "192.0.2.10", UniqID: "76561198000000001"},
+			},
+		},
+		{
+			name:     "empty_server",
+			input:    "Total of 0 in the game",
+			expected: []Player{},
+		},
+		{
+			name:     "empty_input",
+			input:    "",
+			expected: []Player{},
+		},
+		{
+			name: "log_lines_are_ignored",
+			input: `2024-01-01T10:00:00 123.456 INF Executing command 'lp' by Telnet from 127.0.0.1:5555
+0. id=171, Steve, pos=(1.0, 2.0, 3.0), rot=(0.0, 0.0, 0.0), remote=True, health=100, deaths=0, zombies=0, players=0, score=5, level=1, ip=192.0.2.10, ping=12
+Total of 1 in the game`,
+			expected: []Player{
+				{ID: "171", Name: "Steve", Score: "5", Ping: "12", Addr: "192.0.2.10", UniqID: "171"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewSevenDaysPlayers()
+			result, err := mgr.ParsePlayers(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSevenDaysPlayerManager_PlayersCommand(t *testing.T) {
+	mgr := NewSevenDaysPlayers()
+
+	assert.Equal(t, "lp", mgr.PlayersCommand())
+}
+
+func TestSevenDaysPlayerManager_KickCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		reason      string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "kick_with_reason",
+			player:   Player{UniqID: "Steam_76561198000000001"},
+			reason:   "stop griefing",
+			expected: `kick Steam_76561198000000001 "stop griefing"`,
+		},
+		{
+			name:     "kick_without_reason",
+			player:   Player{UniqID: "171"},
+			expected: "kick 171",
+		},
+		{
+			name:     "kick_reason_with_quotes",
+			player:   Player{UniqID: "171"},
+			reason:   `say "bye"`,
+			expected: `kick 171 "say 'bye'"`,
+		},
+		{
+			name:        "kick_without_uniq_id",
+			player:      Player{Name: "Steve"},
+			expectedErr: ErrPlayerUniqIDRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewSevenDaysPlayers()
+			result, err := mgr.KickCommand(tt.player, tt.reason)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSevenDaysPlayerManager_BanCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		reason      string
+		duration    time.Duration
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "ban_with_duration",
+			player:   Player{UniqID: "Steam_76561198000000001"},
+			reason:   "cheating",
+			duration: 2 * time.Hour,
+			expected: `ban add Steam_76561198000000001 120 minutes "cheating"`,
+		},
+		{
+			name:     "permanent_ban",
+			player:   Player{UniqID: "171"},
+			expected: "ban add 171 100 years",
+		},
+		{
+			name:        "ban_with_injected_uniq_id",
+			player:      Player{UniqID: "171\nshutdown"},
+			expectedErr: ErrInvalidPlayerUniqID,
+		},
+		{
+			name:        "ban_without_uniq_id",
+			player:      Player{Name: "Steve"},
+			duration:    time.Hour,
+			expectedErr: ErrPlayerUniqIDRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewSevenDaysPlayers()
+			result, err := mgr.BanCommand(tt.player, tt.reason, tt.duration)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSevenDaysPlayerManager_UnbanCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "unban_by_platform_id",
+			player:   Player{UniqID: "Steam_76561198000000000", Name: "Steve"},
+			expected: "ban remove Steam_76561198000000000",
+		},
+		{
+			name:        "unban_with_empty_uniq_id",
+			player:      Player{Name: "Steve"},
+			expectedErr: ErrPlayerUniqIDRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewSevenDaysPlayers()
+			result, err := mgr.UnbanCommand(tt.player)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSevenDaysPlayerManager_MessageCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		message     string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "message_by_entity_id",
+			player:   Player{ID: "171", UniqID: "Steam_76561198000000000", Name: "Steve"},
+			message:  `say "hi"`,
+			expected: `sayplayer "171" "say 'hi'"`,
+		},
+		{
+			name:     "message_by_name",
+			player:   Player{Name: "Steve"},
+			message:  "hi",
+			expected: `sayplayer "Steve" "hi"`,
+		},
+		{
+			name:        "message_without_player",
+			message:     "hi",
+			expectedErr: ErrPlayerUniqIDRequired,
+		},
+		{
+			name:        "empty_message",
+			player:      Player{ID: "171"},
+			expectedErr: ErrMessageRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewSevenDaysPlayers()
+			result, err := mgr.MessageCommand(tt.player, tt.message)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSevenDaysPlayerManager_BroadcastCommand(t *testing.T) {
+	mgr := NewSevenDaysPlayers()
+
+	result, err := mgr.BroadcastCommand("horde\ntonight")
+	assert.NoError(t, err)
+	assert.Equal(t, `say "horde tonight"`, result)
+
+	_, err = mgr.BroadcastCommand("")
+	assert.ErrorIs(t, err, ErrMessageRequired)
+}
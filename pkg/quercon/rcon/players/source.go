@@ -0,0 +1,259 @@
+package players
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sourceBotUniqID      = "BOT"
+	sourceCS2PlayersMark = "---------players--------"
+	// sourceChallengingID is the user ID of connections which are not players yet in CS2.
+	sourceChallengingID = "65535"
+)
+
+// STEAM_1:0:12345 (CS:GO, L4D2, GMod) or [U:1:24690] (TF2, CS:S, HL2DM).
+// SourcePlayerManager handles players of Source engine servers (CS:GO, CS2, TF2, Garry's Mod, L4D2 etc.).
+// Players are identified by the user ID from the status command, bans are issued by Steam ID.
+type SourcePlayerManager struct{}
+
+func NewSourcePlayers() PlayerManager {
+	return &SourcePlayerManager{}
+}
+
+// ParsePlayers parses the status command output. Two formats are supported:
+//
+//	# userid name                uniqueid            connected ping loss state  adr
+//	#      3 "Player"            [U:1:24690]         12:34       67    0 active 192.0.2.10:27005
+//
+// and the CS2 one, where the player list has no unique IDs:
+//
+//	id     time ping loss      state   rate adr name
+//	  2    03:05   27    0     active 786432 192.0.2.10:27005 'Player'
+func (mgr *SourcePlayerManager) ParsePlayers(data string) ([]Player, error) {
+	lines := strings.Split(data, "\n")
+	players := make([]Player, 0, 32)
+
+	cs2Players := false
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if line == sourceCS2PlayersMark {
+			cs2Players = true
+
+			continue
+		}
+
+		var (
+			player Player
+			ok     bool
+		)
+
+		if cs2Players {
+			player, ok = mgr.parseCS2Player(line)
+		} else {
+			player, ok = mgr.parsePlayer(line)
+		}
+
+		if !ok {
+			continue
+		}
+
+		players = append(players, player)
+	}
+
+	return players, nil
+}
+
+func (mgr *SourcePlayerManager) parsePlayer(line string) (Player, bool) {
+	if !strings.HasPrefix(line, "#") {
+		return Player{}, false
+	}
+
+	startQuote := strings.Index(line, `"`)
+	endQuote := strings.LastIndex(line, `"`)
+	if startQuote == -1 || startQuote == endQuote {
+		return Player{}, false
+	}
+
+	// CS:GO and L4D2 print the slot after the user ID, bots have no slot
+	prefix := strings.Fields(strings.TrimPrefix(line[:startQuote], "#"))
+	fields := strings.Fields(line[endQuote+1:])
+	if len(prefix) == 0 || len(fields) == 0 {
+		return Player{}, false
+	}
+
+	player := Player{
+		ID:     prefix[0],
+		Name:   line[startQuote+1 : endQuote],
+		UniqID: fields[0],
+	}
+
+	if player.UniqID == sourceBotUniqID {
+		return player, true
+	}
+
+	// uniqueid connected ping loss state [rate] adr
+	if len(fields) >= 5 {
+		player.Ping = fields[2]
+	}
+
+	if len(fields) >= 6 {
+		player.Addr = sourceAddressHost(fields[len(fields)-1])
+	}
+
+	return player, true
+}
+
+func (mgr *SourcePlayerManager) parseCS2Player(line string) (Player, bool) {
+	startQuote := strings.Index(line, "'")
+	endQuote := strings.LastIndex(line, "'")
+	if startQuote == -1 || startQuote == endQuote {
+		return Player{}, false
+	}
+
+	// id time ping loss state rate [adr]
+	fields := strings.Fields(line[:startQuote])
+	if len(fields) < 6 || fields[0] == sourceChallengingID {
+		return Player{}, false
+	}
+
+	if _, err := strconv.Atoi(fields[0]); err != nil {
+		return Player{}, false
+	}
+
+	player := Player{
+		ID:   fields[0],
+		Name: line[startQuote+1 : endQuote],
+	}
+
+	if fields[1] == sourceBotUniqID {
+		player.UniqID = sourceBotUniqID
+
+		return player, true
+	}
+
+	player.Ping = fields[2]
+
+	if len(fields) >= 7 {
+		player.Addr = sourceAddressHost(fields[6])
+	}
+
+	return player, true
+}
+
+func sourceAddressHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+
+	return addr
+}
+
+func (mgr *SourcePlayerManager) PlayersCommand() string {
+	return "status"
+}
+
+// KickCommand kicks the player by the user ID, the Steam ID is used when the user ID is unknown.
+func (mgr *SourcePlayerManager) KickCommand(player Player, reason string) (string, error) {
+	target := mgr.userID(player)
+	if target == "" {
+		target = mgr.steamID(player)
+	}
+
+	if target == "" {
+		return "", ErrPlayerUniqIDRequired
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(64)
+
+	sb.WriteString("kickid ")
+	sb.WriteString(target)
+
+	if reason != "" {
+		sb.WriteString(" ")
+		sb.WriteString(quoteArgument(reason))
+	}
+
+	return sb.String(), nil
+}
+
+// BanCommand bans the Steam ID and kicks the player. The banid command has no reason argument.
+// Bans by the user ID only last until the map change, so they're used when the Steam ID is unknown.
+func (mgr *SourcePlayerManager) BanCommand(player Player, _ string, duration time.Duration) (string, error) {
+	target := mgr.steamID(player)
+	if target == "" {
+		target = mgr.userID(player)
+	}
+
+	if target == "" {
+		return "", ErrPlayerUniqIDRequired
+	}
+
+	minutes := 0
+	if duration > 0 {
+		minutes = max(int(duration.Round(time.Minute).Minutes()), 1)
+	}
+
+	return "banid " + strconv.Itoa(minutes) + " " + target + " kick", nil
+}
+
+func (mgr *SourcePlayerManager) UnbanCommand(player Player) (string, error) {
+	steamID := mgr.steamID(player)
+	if steamID == "" {
+		return "", ErrPlayerUniqIDRequired
+	}
+
+	return "removeid " + steamID, nil
+}
+
+// MessageCommand uses the sm_psay command of SourceMod, the target is the user ID or the name.
+func (mgr *SourcePlayerManager) MessageCommand(player Player, message string) (string, error) {
+	target := firstNonEmpty(mgr.steamID(player), player.Name)
+	if userID := mgr.userID(player); userID != "" {
+		target = "#" + userID
+	}
+
+	if target == "" {
+		return "", ErrPlayerUniqIDRequired
+	}
+
+	message, err := validateMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	return "sm_psay " + quoteArgument(target) + " " + quoteArgument(message), nil
+}
+
+func (mgr *SourcePlayerManager) BroadcastCommand(message string) (string, error) {
+	message, err := validateMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	return "say " + quoteArgument(message), nil
+}
+
+func (mgr *SourcePlayerManager) userID(player Player) string {
+	if _, err := strconv.ParseUint(player.ID, 10, 32); err != nil {
+		return ""
+	}
+
+	return player.ID
+}
+
+func (mgr *SourcePlayerManager) steamID(player Player) string {
+	if IsValidSteamID(player.UniqID) {
+		return player.UniqID
+	}
+
+	return ""
+}
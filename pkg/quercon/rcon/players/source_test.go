@@ -0,0 +1,280 @@
+package players
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourcePlayerManager_ParsePlayers_Fixtures(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected []Player
+	}{
+		{
+			fixture: "csgo.txt",
+			expected: []Player{
+				{ID: "2", Name: "GOTV", UniqID: "BOT"},
+				{ID: "3", Name: "s1mple fan", Ping: "45", Addr: "192.0.2.10", UniqID: "STEAM_1:0:100001"},
+				{ID: "4", Name: `Player "Two"`, Ping: "120", Addr: "192.0.2.11", UniqID: "STEAM_1:1:100002"},
+				{ID: "5", Name: "Chet", UniqID: "BOT"},
+			},
+		},
+		{
+			fixture: "cssource.txt",
+			expected: []Player{
+				{ID: "2", Name: "SourceTV", UniqID: "BOT"},
+				{ID: "3", Name: "Gordon", Ping: "32", Addr: "192.0.2.12", UniqID: "[U:1:100003]"},
+				{ID: "4", Name: "Alyx", Ping: "210", Addr: "192.0.2.13", UniqID: "[U:1:100004]"},
+			},
+		},
+		{
+			fixture: "tf2.txt",
+			expected: []Player{
+				{ID: "2", Name: "Heavy Weapons Guy", Ping: "54", Addr: "192.0.2.14", UniqID: "[U:1:100005]"},
+				{ID: "3", Name: "Scout", Ping: "78", Addr: "192.0.2.15", UniqID: "[U:1:100006]"},
+				{ID: "4", Name: "Soldier Bot", UniqID: "BOT"},
+			},
+		},
+		{
+			fixture: "garrysmod.txt",
+			expected: []Player{
+				{ID: "12", Name: "Builder", Ping: "63", Addr: "192.0.2.16", UniqID: "STEAM_0:1:100007"},
+				{ID: "13", Name: "Admin [GAP]", Ping: "22", Addr: "192.0.2.17", UniqID: "STEAM_0:0:100008"},
+			},
+		},
+		{
+			fixture: "l4d2.txt",
+			expected: []Player{
+				{ID: "2", Name: "Coach", UniqID: "BOT"},
+				{ID: "3", Name: "Ellis", UniqID: "BOT"},
+				{ID: "5", Name: "Survivor", Ping: "58", Addr: "192.0.2.18", UniqID: "STEAM_1:0:100009"},
+			},
+		},
+		{
+			fixture: "cs2.txt",
+			expected: []Player{
+				{ID: "2", Name: "ZywOo fan", Ping: "27", Addr: "192.0.2.19"},
+				{ID: "3", Name: "Player 'Quoted'", Ping: "35", Addr: "192.0.2.20"},
+				{ID: "4", Name: "Bot Ivan", UniqID: "BOT"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "source", tt.fixture))
+			require.NoError(t, err)
+
+			result, err := NewSourcePlayers().ParsePlayers(string(data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSourcePlayerManager_ParsePlayers_Empty(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "empty_input",
+			input: "",
+		},
+		{
+			name: "no_players",
+			input: `hostname: Empty
+players : 0 humans, 0 bots (24 max)
+# userid name                uniqueid            connected ping loss state  adr
+#end`,
+		},
+		{
+			name: "cs2_no_players",
+			input: `---------players--------
+  id     time ping loss      state   rate adr name
+65535 [NoChan]    0    0 challenging      0unknown ''
+#end`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewSourcePlayers().ParsePlayers(tt.input)
+			require.NoError(t, err)
+			assert.Empty(t, result)
+		})
+	}
+}
+
+func TestSourcePlayerManager_PlayersCommand(t *testing.T) {
+	assert.Equal(t, "status", NewSourcePlayers().PlayersCommand())
+}
+
+func TestSourcePlayerManager_KickCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		reason      string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "kick_by_user_id",
+			player:   Player{ID: "3", UniqID: "[U:1:100003]"},
+			expected: "kickid 3",
+		},
+		{
+			name:     "kick_with_reason",
+			player:   Player{ID: "3"},
+			reason:   `bad "words"; quit`,
+			expected: `kickid 3 "bad 'words', quit"`,
+		},
+		{
+			name:     "kick_by_steam_id",
+			player:   Player{UniqID: "STEAM_1:0:100001"},
+			expected: "kickid STEAM_1:0:100001",
+		},
+		{
+			name:        "no_identifier",
+			player:      Player{ID: "3; quit", Name: "Gordon"},
+			expectedErr: ErrPlayerUniqIDRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewSourcePlayers().KickCommand(tt.player, tt.reason)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSourcePlayerManager_BanCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		duration    time.Duration
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "permanent_ban_by_steam_id",
+			player:   Player{ID: "3", UniqID: "[U:1:100003]"},
+			expected: "banid 0 [U:1:100003] kick",
+		},
+		{
+			name:     "temporary_ban",
+			player:   Player{ID: "3", UniqID: "STEAM_1:0:100001"},
+			duration: 2 * time.Hour,
+			expected: "banid 120 STEAM_1:0:100001 kick",
+		},
+		{
+			name:     "short_ban_is_at_least_a_minute",
+			player:   Player{UniqID: "STEAM_1:0:100001"},
+			duration: 10 * time.Second,
+			expected: "banid 1 STEAM_1:0:100001 kick",
+		},
+		{
+			name:     "ban_by_user_id_without_steam_id",
+			player:   Player{ID: "4", UniqID: "4"},
+			expected: "banid 0 4 kick",
+		},
+		{
+			name:        "bot",
+			player:      Player{UniqID: "BOT", Name: "Chet"},
+			expectedErr: ErrPlayerUniqIDRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewSourcePlayers().BanCommand(tt.player, "reason", tt.duration)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSourcePlayerManager_UnbanCommand(t *testing.T) {
+	mgr := NewSourcePlayers()
+
+	result, err := mgr.UnbanCommand(Player{UniqID: "[U:1:100003]"})
+	require.NoError(t, err)
+	assert.Equal(t, "removeid [U:1:100003]", result)
+
+	_, err = mgr.UnbanCommand(Player{ID: "3", UniqID: "3"})
+	require.ErrorIs(t, err, ErrPlayerUniqIDRequired)
+}
+
+func TestSourcePlayerManager_MessageCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		message     string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "by_user_id",
+			player:   Player{ID: "3", UniqID: "[U:1:100003]"},
+			message:  "hello",
+			expected: `sm_psay "#3" "hello"`,
+		},
+		{
+			name:     "by_name_with_escaping",
+			player:   Player{Name: `Gordon "Free"man`},
+			message:  "line\nbreak",
+			expected: `sm_psay "Gordon 'Free'man" "line break"`,
+		},
+		{
+			name:        "empty_message",
+			player:      Player{ID: "3"},
+			message:     " ",
+			expectedErr: ErrMessageRequired,
+		},
+		{
+			name:        "no_target",
+			player:      Player{},
+			message:     "hello",
+			expectedErr: ErrPlayerUniqIDRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewSourcePlayers().MessageCommand(tt.player, tt.message)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSourcePlayerManager_BroadcastCommand(t *testing.T) {
+	result, err := NewSourcePlayers().BroadcastCommand(`map "changes" soon`)
+	require.NoError(t, err)
+	assert.Equal(t, `say "map 'changes' soon"`, result)
+}
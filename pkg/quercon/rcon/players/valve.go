@@ -163,26 +163,86 @@ func (mgr *ValvePlayerManager) KickCommand(player Player, reason string) (string
 	return sb.String(), nil
 }
 
-func (mgr *ValvePlayerManager) BanCommand(player Player, reason string, time time.Duration) (string, error) {
+// BanCommand bans the player by the Steam ID or by the user ID from the status command.
+// The banid command takes the ban time in minutes, zero is a permanent ban.
+// It has no reason argument, so the banned player is kicked with the reason by the kick command.
+func (mgr *ValvePlayerManager) BanCommand(player Player, reason string, duration time.Duration) (string, error) {
+	target, err := mgr.banTarget(player)
+	if err != nil {
+		return "", err
+	}
+
+	minutes := 0
+	if duration > 0 {
+		minutes = max(int(duration.Round(time.Minute).Minutes()), 1)
+	}
+
+	ban := "banid " + strconv.Itoa(minutes) + " " + target
+
+	if reason == "" {
+		return ban + " kick", nil
+	}
+
+	return ban + "; kick " + target + " " + quoteArgument(reason), nil
+}
+
+func (mgr *ValvePlayerManager) UnbanCommand(player Player) (string, error) {
 	if err := player.ValidateUniqID(); err != nil {
 		return "", err
 	}
 
-	sb := strings.Builder{}
-	sb.Grow(64)
+	if !IsValidSteamID(player.UniqID) {
+		return "", ErrInvalidPlayerUniqID
+	}
 
-	sb.WriteString("banid ")
+	return "removeid " + player.UniqID, nil
+}
 
-	seconds := int(time.Seconds())
-	sb.WriteString(strconv.Itoa(seconds))
+func (mgr *ValvePlayerManager) banTarget(player Player) (string, error) {
+	if err := player.ValidateUniqID(); err != nil {
+		return "", err
+	}
 
-	sb.WriteString(" #")
-	sb.WriteString(player.UniqID)
+	if IsValidSteamID(player.UniqID) {
+		return player.UniqID, nil
+	}
 
-	if reason != "" {
-		sb.WriteString(" ")
-		sb.WriteString(reason)
+	if _, err := strconv.ParseUint(player.UniqID, 10, 32); err == nil {
+		return "#" + player.UniqID, nil
 	}
 
-	return sb.String(), nil
+	return "", ErrInvalidPlayerUniqID
+}
+
+// MessageCommand uses the amx_psay command of AMX Mod X, the target is the user ID
+// from the status command, the unique ID or the name.
+func (mgr *ValvePlayerManager) MessageCommand(player Player, message string) (string, error) {
+	target := firstNonEmpty(player.UniqID, player.Name)
+	if player.ID != "" {
+		target = player.ID
+
+		if _, err := strconv.Atoi(player.ID); err == nil {
+			target = "#" + player.ID
+		}
+	}
+
+	if target == "" {
+		return "", ErrPlayerUniqIDRequired
+	}
+
+	message, err := validateMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	return "amx_psay " + quoteArgument(target) + " " + quoteArgument(message), nil
+}
+
+func (mgr *ValvePlayerManager) BroadcastCommand(message string) (string, error) {
+	message, err := validateMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	return "say " + quoteArgument(message), nil
 }
@@ -381,21 +381,47 @@ func TestValvePlayerManager_BanCommand(t *testing.T) {
 			player:   Player{UniqID: "STEAM_0:0:12345678"},
 			reason:   "hacking",
 			duration: 24 * time.Hour,
-			expected: "banid 86400 #STEAM_0:0:12345678 hacking",
+			expected: `banid 1440 STEAM_0:0:12345678; kick STEAM_0:0:12345678 "hacking"`,
 		},
 		{
 			name:     "ban_without_reason",
 			player:   Player{UniqID: "STEAM_0:1:87654321"},
 			reason:   "",
 			duration: time.Hour,
-			expected: "banid 3600 #STEAM_0:1:87654321",
+			expected: "banid 60 STEAM_0:1:87654321 kick",
 		},
 		{
 			name:     "ban_permanent",
 			player:   Player{UniqID: "STEAM_0:0:11111111"},
 			reason:   "permanent ban",
 			duration: 0,
-			expected: "banid 0 #STEAM_0:0:11111111 permanent ban",
+			expected: `banid 0 STEAM_0:0:11111111; kick STEAM_0:0:11111111 "permanent ban"`,
+		},
+		{
+			name:     "ban_by_user_id",
+			player:   Player{UniqID: "12"},
+			duration: 90 * time.Second,
+			expected: "banid 2 #12 kick",
+		},
+		{
+			name:     "ban_reason_with_separators",
+			player:   Player{UniqID: "STEAM_0:0:12345678"},
+			reason:   "bye\"; quit\nexit",
+			duration: time.Minute,
+			expected: `banid 1 STEAM_0:0:12345678; kick STEAM_0:0:12345678 "bye', quit exit"`,
+		},
+		{
+			name:     "ban_by_user_id_with_reason",
+			player:   Player{UniqID: "12"},
+			reason:   "teamkill",
+			duration: time.Hour,
+			expected: `banid 60 #12; kick #12 "teamkill"`,
+		},
+		{
+			name:        "ban_with_injected_uniq_id",
+			player:      Player{UniqID: "STEAM_0:0:1; quit"},
+			duration:    time.Hour,
+			expectedErr: ErrInvalidPlayerUniqID,
 		},
 		{
 			name:        "ban_with_empty_uniq_id",
@@ -429,3 +455,140 @@ func TestValvePlayerManager_BanCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestValvePlayerManager_UnbanCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "unban_by_uniq_id",
+			player:   Player{UniqID: "STEAM_0:0:12345678", Name: "Player"},
+			expected: "removeid STEAM_0:0:12345678",
+		},
+		{
+			name:        "unban_with_empty_uniq_id",
+			player:      Player{Name: "Player"},
+			expectedErr: ErrPlayerUniqIDRequired,
+		},
+		{
+			name:        "unban_with_injected_uniq_id",
+			player:      Player{UniqID: "STEAM_0:0:1\nquit"},
+			expectedErr: ErrInvalidPlayerUniqID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewValvePlayers()
+			result, err := mgr.UnbanCommand(tt.player)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestValvePlayerManager_MessageCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      Player
+		message     string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "message_by_user_id",
+			player:   Player{ID: "5", UniqID: "STEAM_0:0:12345678", Name: "Player"},
+			message:  "hello",
+			expected: `amx_psay "#5" "hello"`,
+		},
+		{
+			name:     "message_by_uniq_id",
+			player:   Player{UniqID: "STEAM_0:0:12345678"},
+			message:  "hello",
+			expected: `amx_psay "STEAM_0:0:12345678" "hello"`,
+		},
+		{
+			name:     "message_by_name",
+			player:   Player{Name: "Player"},
+			message:  "hello",
+			expected: `amx_psay "Player" "hello"`,
+		},
+		{
+			name:     "message_is_escaped",
+			player:   Player{ID: "5"},
+			message:  "say \"hi\"\nquit",
+			expected: `amx_psay "#5" "say 'hi' quit"`,
+		},
+		{
+			name:        "message_without_player",
+			message:     "hello",
+			expectedErr: ErrPlayerUniqIDRequired,
+		},
+		{
+			name:        "empty_message",
+			player:      Player{ID: "5"},
+			message:     " \n ",
+			expectedErr: ErrMessageRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewValvePlayers()
+			result, err := mgr.MessageCommand(tt.player, tt.message)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestValvePlayerManager_BroadcastCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "broadcast",
+			message:  "server restarts in 5 minutes",
+			expected: `say "server restarts in 5 minutes"`,
+		},
+		{
+			name:     "broadcast_is_escaped",
+			message:  "\"; quit\r\nrcon_password x",
+			expected: `say "', quit  rcon_password x"`,
+		},
+		{
+			name:        "empty_message",
+			expectedErr: ErrMessageRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewValvePlayers()
+			result, err := mgr.BroadcastCommand(tt.message)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
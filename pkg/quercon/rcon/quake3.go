@@ -0,0 +1,146 @@
+package rcon
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	quake3Header             = "\xff\xff\xff\xff"
+	quake3PrintResponse      = "print\n"
+	quake3ReadBufferSize     = 16384
+	defaultQuake3IdleTimeout = 200 * time.Millisecond
+	defaultQuake3MaxResponse = 1 << 20
+)
+
+// quake3AuthFailures are lowercased responses of id Tech 3 servers to a wrong rcon password.
+var quake3AuthFailures = []string{
+	"bad rconpassword",
+	"invalid password",
+	"no rconpassword set",
+}
+
+// Quake3 is a client for the id Tech 3 remote console
+// (Quake 3, Urban Terror, Call of Duty 2/4, Wolfenstein: Enemy Territory).
+//
+// The protocol is connectionless: every command is sent as "rcon <password> <command>"
+// in a single UDP packet and the output comes back in one or more "print" packets.
+type Quake3 struct {
+	address         string
+	password        string
+	timeout         time.Duration
+	idleTimeout     time.Duration
+	maxResponseSize int
+	connection      net.Conn
+}
+
+func NewQuake3(config Config) (*Quake3, error) {
+	adapter := &Quake3{
+		address:         config.Address,
+		password:        config.Password,
+		timeout:         config.Timeout,
+		idleTimeout:     defaultQuake3IdleTimeout,
+		maxResponseSize: config.MaxResponseSize,
+	}
+
+	if adapter.maxResponseSize <= 0 {
+		adapter.maxResponseSize = defaultQuake3MaxResponse
+	}
+
+	return adapter, nil
+}
+
+func (q *Quake3) Open(ctx context.Context) error {
+	dialer := &net.Dialer{
+		Timeout: q.timeout,
+	}
+
+	conn, err := dialer.DialContext(ctx, "udp", q.address)
+	if err != nil {
+		return errors.WithMessage(err, "unable to connect")
+	}
+
+	q.connection = conn
+
+	return nil
+}
+
+func (q *Quake3) Close() error {
+	if q.connection != nil {
+		err := q.connection.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (q *Quake3) Execute(_ context.Context, command string) (string, error) {
+	if q.connection == nil {
+		return "", errors.New("connection not established")
+	}
+
+	if err := q.connection.SetWriteDeadline(time.Now().Add(q.timeout)); err != nil {
+		return "", errors.WithMessage(err, "unable to set deadline")
+	}
+
+	packet := quake3Header + "rcon " + q.password + " " + command
+
+	if _, err := q.connection.Write([]byte(packet)); err != nil {
+		return "", errors.WithMessage(err, "unable to send command")
+	}
+
+	response, err := q.readResponse()
+	if err != nil {
+		return "", err
+	}
+
+	if containsAny(strings.ToLower(strings.TrimSpace(response)), quake3AuthFailures) {
+		return "", ErrAuthenticationFailed
+	}
+
+	return strings.TrimSpace(response), nil
+}
+
+// readResponse collects "print" packets until nothing arrives for the idle timeout.
+func (q *Quake3) readResponse() (string, error) {
+	result := strings.Builder{}
+	buf := make([]byte, quake3ReadBufferSize)
+	received := false
+
+	wait := q.timeout
+
+	for {
+		if err := q.connection.SetReadDeadline(time.Now().Add(wait)); err != nil {
+			return "", errors.WithMessage(err, "unable to set deadline")
+		}
+
+		n, err := q.connection.Read(buf)
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) && received {
+				return result.String(), nil
+			}
+
+			return "", errors.WithMessage(err, "unable to read response")
+		}
+
+		payload := bytes.TrimPrefix(buf[:n], []byte(quake3Header))
+		payload = bytes.TrimPrefix(payload, []byte(quake3PrintResponse))
+
+		result.Write(bytes.TrimRight(payload, "\x00"))
+
+		if result.Len() > q.maxResponseSize {
+			return "", ErrResponseTooLarge
+		}
+
+		received = true
+		wait = q.idleTimeout
+	}
+}
@@ -0,0 +1,119 @@
+package rcon
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeQuake3Server answers "rcon <password> <command>" packets with print packets.
+func startFakeQuake3Server(t *testing.T, password string, responses map[string][]string) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	go func() {
+		buffer := make([]byte, 2048)
+
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+
+			request := strings.TrimPrefix(string(buffer[:n]), quake3Header+"rcon ")
+			pass, command, _ := strings.Cut(request, " ")
+
+			if pass != password {
+				_, _ = conn.WriteTo([]byte(quake3Header+"print\nBad rconpassword.\n"), addr)
+
+				continue
+			}
+
+			for _, part := range responses[command] {
+				_, _ = conn.WriteTo([]byte(quake3Header+"print\n"+part), addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func newTestQuake3(t *testing.T, address, password string) *Quake3 {
+	t.Helper()
+
+	client, err := NewQuake3(Config{
+		Address:  address,
+		Password: password,
+		Protocol: ProtocolQuake3,
+		Timeout:  time.Second,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Open(context.Background()))
+
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	return client
+}
+
+func TestQuake3_Execute(t *testing.T) {
+	address := startFakeQuake3Server(t, "secret", map[string][]string{
+		"status": {
+			"map: q3dm17\nnum score ping name            lastmsg address               qport rate\n",
+			"  0    12   48 Sarge^7             0 127.0.0.1:27960       1234 25000\n",
+		},
+		"say hello": {"broadcast: print \"server: hello\\n\"\n"},
+	})
+
+	client := newTestQuake3(t, address, "secret")
+
+	response, err := client.Execute(context.Background(), "status")
+	require.NoError(t, err)
+	assert.Equal(t, "map: q3dm17\nnum score ping name            lastmsg address               qport rate\n"+
+		"  0    12   48 Sarge^7             0 127.0.0.1:27960       1234 25000", response)
+
+	response, err = client.Execute(context.Background(), "say hello")
+	require.NoError(t, err)
+	assert.Equal(t, `broadcast: print "server: hello\n"`, response)
+}
+
+func TestQuake3_Execute_BadPassword(t *testing.T) {
+	address := startFakeQuake3Server(t, "secret", nil)
+
+	client := newTestQuake3(t, address, "wrong")
+
+	_, err := client.Execute(context.Background(), "status")
+	require.ErrorIs(t, err, ErrAuthenticationFailed)
+}
+
+func TestQuake3_Execute_NoResponse(t *testing.T) {
+	address := startFakeQuake3Server(t, "secret", nil)
+
+	client, err := NewQuake3(Config{
+		Address:  address,
+		Password: "secret",
+		Protocol: ProtocolQuake3,
+		Timeout:  100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Open(context.Background()))
+	defer func() {
+		_ = client.Close()
+	}()
+
+	_, err = client.Execute(context.Background(), "unknown")
+	require.Error(t, err)
+}
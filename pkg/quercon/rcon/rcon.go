@@ -10,6 +10,7 @@ import (
 
 var (
 	ErrUnsupportedProtocol = errors.New("unsupported protocol")
+	ErrResponseTooLarge    = errors.New("response too large")
 )
 
 type Protocol string
@@ -17,6 +18,8 @@ type Protocol string
 const (
 	ProtocolSource  Protocol = "source"
 	ProtocolGoldSrc Protocol = "goldsource"
+	ProtocolTelnet  Protocol = "telnet"
+	ProtocolQuake3  Protocol = "quake3"
 )
 
 type Config struct {
@@ -24,6 +27,10 @@ type Config struct {
 	Password string
 	Protocol Protocol
 	Timeout  time.Duration
+
+	// MaxResponseSize limits the size of a command response in bytes.
+	// Zero means the protocol default.
+	MaxResponseSize int
 }
 
 type Player struct {
@@ -49,6 +56,10 @@ func NewClient(config Config) (Client, error) {
 		return NewGoldSource(config)
 	case ProtocolSource:
 		return NewSource(config)
+	case ProtocolTelnet:
+		return NewTelnet(config)
+	case ProtocolQuake3:
+		return NewQuake3(config)
 	}
 
 	return nil, ErrUnsupportedProtocol
@@ -56,7 +67,7 @@ func NewClient(config Config) (Client, error) {
 
 func IsProtocolSupported(protocol Protocol) bool {
 	switch protocol {
-	case ProtocolGoldSrc, ProtocolSource:
+	case ProtocolGoldSrc, ProtocolSource, ProtocolTelnet, ProtocolQuake3:
 		return true
 	default:
 		return false
@@ -20,7 +20,11 @@ const (
 
 	// Packet structure constants.
 	minPacketSize = 10 // 4 (size) + 4 (id) + 4 (type) + 2 (empty strings)
-	maxPacketSize = 4096
+	// Source servers send packets up to 4096 bytes,
+	// Minecraft sends up to 4096 bytes of body in a single packet.
+	maxPacketSize = 4096 + minPacketSize
+
+	defaultSourceMaxResponseSize = 1 << 20
 )
 
 var (
@@ -29,19 +33,25 @@ var (
 )
 
 type Source struct {
-	address    string
-	password   string
-	timeout    time.Duration
-	connection net.Conn
-	requestID  int32
+	address         string
+	password        string
+	timeout         time.Duration
+	maxResponseSize int
+	connection      net.Conn
+	requestID       int32
 }
 
 func NewSource(config Config) (*Source, error) {
 	adapter := &Source{
-		address:   config.Address,
-		password:  config.Password,
-		timeout:   config.Timeout,
-		requestID: 1,
+		address:         config.Address,
+		password:        config.Password,
+		timeout:         config.Timeout,
+		maxResponseSize: config.MaxResponseSize,
+		requestID:       1,
+	}
+
+	if adapter.maxResponseSize <= 0 {
+		adapter.maxResponseSize = defaultSourceMaxResponseSize
 	}
 
 	return adapter, nil
@@ -85,35 +95,67 @@ func (s *Source) Close() error {
 	return nil
 }
 
+// Execute sends the command and reassembles a response split into several packets.
+//
+// The command packet is followed by an empty SERVERDATA_RESPONSE_VALUE packet.
+// Servers process packets in order and mirror the empty packet back,
+// so every packet received before the mirrored one belongs to the command response.
+// Minecraft doesn't mirror the packet, but answers it with an "Unknown request" packet
+// carrying the same ID, which works as the end marker too.
 func (s *Source) Execute(_ context.Context, command string) (string, error) {
 	if err := s.connection.SetDeadline(time.Now().Add(s.timeout)); err != nil {
 		return "", errors.WithMessage(err, "unable to set deadline")
 	}
 
+	commandID := s.nextRequestID()
+	sentinelID := s.nextRequestID()
+
 	// Send command packet
-	packet := s.buildPacket(s.requestID, serverDataExecCommand, command)
+	packet := s.buildPacket(commandID, serverDataExecCommand, command)
 	if _, err := s.connection.Write(packet); err != nil {
 		return "", errors.WithMessage(err, "unable to send command")
 	}
 
-	// Read response
-	responseID, responseType, responseBody, err := s.readPacket()
-	if err != nil {
-		return "", err
+	// Send sentinel packet
+	packet = s.buildPacket(sentinelID, serverDataResponseValue, "")
+	if _, err := s.connection.Write(packet); err != nil {
+		return "", errors.WithMessage(err, "unable to send command")
 	}
 
-	// Validate response
-	if responseID != s.requestID {
-		return "", errors.New("response ID mismatch")
-	}
+	response := bytes.Buffer{}
+
+	for {
+		responseID, responseType, responseBody, err := s.readPacket()
+		if err != nil {
+			return "", err
+		}
 
-	if responseType != serverDataResponseValue {
-		return "", errors.Errorf("unexpected response type: %d", responseType)
+		switch responseID {
+		case sentinelID:
+			return response.String(), nil
+		case commandID:
+			if responseType != serverDataResponseValue {
+				return "", errors.Errorf("unexpected response type: %d", responseType)
+			}
+
+			if response.Len()+len(responseBody) > s.maxResponseSize {
+				return "", ErrResponseTooLarge
+			}
+
+			response.WriteString(responseBody)
+		default:
+			// Packet left from a previous command,
+			// e.g. srcds follows the mirrored packet with an extra one.
+			continue
+		}
 	}
+}
 
+func (s *Source) nextRequestID() int32 {
+	id := s.requestID
 	s.requestID++
 
-	return responseBody, nil
+	return id
 }
 
 func (s *Source) authenticate() error {
@@ -123,10 +165,20 @@ func (s *Source) authenticate() error {
 		return errors.WithMessage(err, "unable to send auth packet")
 	}
 
-	// Read auth response
-	responseID, responseType, _, err := s.readPacket()
-	if err != nil {
-		return err
+	// Read auth response, srcds sends an empty SERVERDATA_RESPONSE_VALUE packet before it
+	var responseID, responseType int32
+
+	for {
+		var err error
+
+		responseID, responseType, _, err = s.readPacket()
+		if err != nil {
+			return err
+		}
+
+		if responseType != serverDataResponseValue {
+			break
+		}
 	}
 
 	// Check if authentication was successful
@@ -0,0 +1,304 @@
+package rcon
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSourceFlavor int
+
+const (
+	fakeSourceFlavorSrcds fakeSourceFlavor = iota
+	fakeSourceFlavorMinecraft
+)
+
+type fakeSourceServer struct {
+	password  string
+	flavor    fakeSourceFlavor
+	chunkSize int
+	responses map[string]string
+}
+
+type fakeSourcePacket struct {
+	id         int32
+	packetType int32
+	body       string
+}
+
+func startFakeSourceServer(t *testing.T, server *fakeSourceServer) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go server.serve(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func (f *fakeSourceServer) serve(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	for {
+		packet, err := readFakeSourcePacket(conn)
+		if err != nil {
+			return
+		}
+
+		switch packet.packetType {
+		case serverDataAuth:
+			f.handleAuth(conn, packet)
+		case serverDataExecCommand:
+			f.handleCommand(conn, packet)
+		case serverDataResponseValue:
+			f.handleResponseValue(conn, packet)
+		}
+	}
+}
+
+func (f *fakeSourceServer) handleAuth(conn net.Conn, packet fakeSourcePacket) {
+	id := packet.id
+	if packet.body != f.password {
+		id = -1
+	}
+
+	if f.flavor == fakeSourceFlavorSrcds {
+		writeFakeSourcePacket(conn, packet.id, serverDataResponseValue, "")
+	}
+
+	writeFakeSourcePacket(conn, id, serverDataAuthResponse, "")
+}
+
+func (f *fakeSourceServer) handleCommand(conn net.Conn, packet fakeSourcePacket) {
+	response := f.responses[packet.body]
+
+	if response == "" {
+		writeFakeSourcePacket(conn, packet.id, serverDataResponseValue, "")
+
+		return
+	}
+
+	for len(response) > 0 {
+		size := min(f.chunkSize, len(response))
+		writeFakeSourcePacket(conn, packet.id, serverDataResponseValue, response[:size])
+		response = response[size:]
+	}
+}
+
+func (f *fakeSourceServer) handleResponseValue(conn net.Conn, packet fakeSourcePacket) {
+	switch f.flavor {
+	case fakeSourceFlavorSrcds:
+		writeFakeSourcePacket(conn, packet.id, serverDataResponseValue, "")
+		writeFakeSourcePacket(conn, packet.id, serverDataResponseValue, "\x00\x01\x00\x00")
+	case fakeSourceFlavorMinecraft:
+		writeFakeSourcePacket(conn, packet.id, serverDataResponseValue, "Unknown request 0")
+	}
+}
+
+func readFakeSourcePacket(r io.Reader) (fakeSourcePacket, error) {
+	var size int32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return fakeSourcePacket{}, err
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return fakeSourcePacket{}, err
+	}
+
+	return fakeSourcePacket{
+		// #nosec G115 -- test data
+		id: int32(binary.LittleEndian.Uint32(data[0:4])),
+		// #nosec G115 -- test data
+		packetType: int32(binary.LittleEndian.Uint32(data[4:8])),
+		body:       string(data[8 : size-2]),
+	}, nil
+}
+
+func writeFakeSourcePacket(w io.Writer, id int32, packetType int32, body string) {
+	buf := new(bytes.Buffer)
+	// #nosec G115 -- test data
+	_ = binary.Write(buf, binary.LittleEndian, int32(len(body)+10))
+	_ = binary.Write(buf, binary.LittleEndian, id)
+	_ = binary.Write(buf, binary.LittleEndian, packetType)
+	buf.WriteString(body)
+	buf.Write([]byte{0, 0})
+
+	_, _ = w.Write(buf.Bytes())
+}
+
+func openTestSourceClient(t *testing.T, address, password string, maxResponseSize int) Client {
+	t.Helper()
+
+	client, err := NewClient(Config{
+		Address:         address,
+		Password:        password,
+		Protocol:        ProtocolSource,
+		Timeout:         2 * time.Second,
+		MaxResponseSize: maxResponseSize,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Open(context.Background()))
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	return client
+}
+
+func TestSource_Execute_SinglePacket(t *testing.T) {
+	address := startFakeSourceServer(t, &fakeSourceServer{
+		password:  "secret",
+		flavor:    fakeSourceFlavorSrcds,
+		chunkSize: 4086,
+		responses: map[string]string{
+			"status": "hostname: Test Server\nmap     : de_dust2\n",
+		},
+	})
+
+	client := openTestSourceClient(t, address, "secret", 0)
+
+	output, err := client.Execute(context.Background(), "status")
+	require.NoError(t, err)
+	assert.Equal(t, "hostname: Test Server\nmap     : de_dust2\n", output)
+}
+
+func TestSource_Execute_FragmentedResponse(t *testing.T) {
+	cvarlist := strings.Repeat("sv_cheats                                : 0        : , \"sv\", \"rep\", \"nf\"\n", 300)
+
+	address := startFakeSourceServer(t, &fakeSourceServer{
+		password:  "secret",
+		flavor:    fakeSourceFlavorSrcds,
+		chunkSize: 4086,
+		responses: map[string]string{
+			"cvarlist": cvarlist,
+			"echo ok":  "ok\n",
+		},
+	})
+
+	client := openTestSourceClient(t, address, "secret", 0)
+
+	output, err := client.Execute(context.Background(), "cvarlist")
+	require.NoError(t, err)
+	assert.Equal(t, cvarlist, output)
+
+	// The extra packet sent by srcds after the mirrored one must not leak into the next response
+	output, err = client.Execute(context.Background(), "echo ok")
+	require.NoError(t, err)
+	assert.Equal(t, "ok\n", output)
+}
+
+func TestSource_Execute_EmptyResponse(t *testing.T) {
+	address := startFakeSourceServer(t, &fakeSourceServer{
+		password:  "secret",
+		flavor:    fakeSourceFlavorSrcds,
+		chunkSize: 4086,
+	})
+
+	client := openTestSourceClient(t, address, "secret", 0)
+
+	output, err := client.Execute(context.Background(), "sv_cheats 0")
+	require.NoError(t, err)
+	assert.Empty(t, output)
+}
+
+func TestSource_Execute_Minecraft(t *testing.T) {
+	help := strings.Repeat("/advancement (grant|revoke)\n/attribute <target> <attribute>\n", 200)
+
+	address := startFakeSourceServer(t, &fakeSourceServer{
+		password:  "secret",
+		flavor:    fakeSourceFlavorMinecraft,
+		chunkSize: 4096,
+		responses: map[string]string{
+			"help":       help,
+			"list uuids": "There are 0 of a max of 20 players online: ",
+		},
+	})
+
+	client := openTestSourceClient(t, address, "secret", 0)
+
+	output, err := client.Execute(context.Background(), "help")
+	require.NoError(t, err)
+	assert.Equal(t, help, output)
+
+	output, err = client.Execute(context.Background(), "list uuids")
+	require.NoError(t, err)
+	assert.Equal(t, "There are 0 of a max of 20 players online: ", output)
+}
+
+func TestSource_Execute_MaxResponseSize(t *testing.T) {
+	address := startFakeSourceServer(t, &fakeSourceServer{
+		password:  "secret",
+		flavor:    fakeSourceFlavorSrcds,
+		chunkSize: 1000,
+		responses: map[string]string{
+			"cvarlist": strings.Repeat("a", 5000),
+		},
+	})
+
+	client := openTestSourceClient(t, address, "secret", 2048)
+
+	_, err := client.Execute(context.Background(), "cvarlist")
+	require.ErrorIs(t, err, ErrResponseTooLarge)
+}
+
+func TestSource_Open_AuthenticationFailed(t *testing.T) {
+	tests := []struct {
+		name   string
+		flavor fakeSourceFlavor
+	}{
+		{
+			name:   "srcds",
+			flavor: fakeSourceFlavorSrcds,
+		},
+		{
+			name:   "minecraft",
+			flavor: fakeSourceFlavorMinecraft,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address := startFakeSourceServer(t, &fakeSourceServer{
+				password:  "secret",
+				flavor:    tt.flavor,
+				chunkSize: 4086,
+			})
+
+			client, err := NewSource(Config{
+				Address:  address,
+				Password: "wrong",
+				Timeout:  2 * time.Second,
+			})
+			require.NoError(t, err)
+
+			err = client.Open(context.Background())
+			require.ErrorIs(t, err, ErrAuthenticationFailed)
+		})
+	}
+}
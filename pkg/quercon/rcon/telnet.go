@@ -0,0 +1,368 @@
+package rcon
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Telnet negotiation bytes (RFC 854).
+	telnetIAC  byte = 255
+	telnetDONT byte = 254
+	telnetDO   byte = 253
+	telnetWONT byte = 252
+	telnetWILL byte = 251
+	telnetSB   byte = 250
+	telnetSE   byte = 240
+
+	telnetReadBufferSize     = 4096
+	defaultTelnetTimeout     = 5 * time.Second
+	defaultTelnetIdleTimeout = 300 * time.Millisecond
+	defaultTelnetMaxResponse = 1 << 20
+	telnetDrainTimeout       = 10 * time.Millisecond
+)
+
+// telnetPasswordPrompts are lowercased fragments of login prompts sent by telnet consoles.
+var telnetPasswordPrompts = []string{
+	"enter password",
+	"password:",
+}
+
+// telnetAuthFailures are lowercased fragments which indicate a rejected password.
+var telnetAuthFailures = []string{
+	"password incorrect",
+	"incorrect password",
+	"wrong password",
+	"authentication failed",
+	"access denied",
+}
+
+type TelnetOption func(t *Telnet)
+
+// WithTelnetIdleTimeout sets how long the client waits for more data
+// before it considers the response complete.
+func WithTelnetIdleTimeout(timeout time.Duration) TelnetOption {
+	return func(t *Telnet) {
+		t.idleTimeout = timeout
+	}
+}
+
+// WithTelnetSentinel sets a string which marks the end of a response.
+// When the sentinel is found the client stops reading without waiting for the idle timeout.
+func WithTelnetSentinel(sentinel string) TelnetOption {
+	return func(t *Telnet) {
+		t.sentinel = sentinel
+	}
+}
+
+// Telnet is a client for line-based telnet consoles (7 Days to Die, some Unreal titles).
+type Telnet struct {
+	address         string
+	password        string
+	timeout         time.Duration
+	idleTimeout     time.Duration
+	sentinel        string
+	maxResponseSize int
+	connection      net.Conn
+
+	// pending is an incomplete IAC sequence at the end of the last read.
+	pending []byte
+}
+
+func NewTelnet(config Config, opts ...TelnetOption) (*Telnet, error) {
+	adapter := &Telnet{
+		address:         config.Address,
+		password:        config.Password,
+		timeout:         config.Timeout,
+		idleTimeout:     defaultTelnetIdleTimeout,
+		maxResponseSize: config.MaxResponseSize,
+	}
+
+	if adapter.timeout <= 0 {
+		adapter.timeout = defaultTelnetTimeout
+	}
+
+	if adapter.maxResponseSize <= 0 {
+		adapter.maxResponseSize = defaultTelnetMaxResponse
+	}
+
+	for _, opt := range opts {
+		opt(adapter)
+	}
+
+	return adapter, nil
+}
+
+func (t *Telnet) Open(ctx context.Context) error {
+	dialer := &net.Dialer{
+		Timeout: t.timeout,
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", t.address)
+	if err != nil {
+		return errors.WithMessage(err, "unable to connect")
+	}
+
+	t.connection = conn
+	t.pending = nil
+
+	if err := t.login(ctx); err != nil {
+		_ = t.Close()
+
+		return err
+	}
+
+	return nil
+}
+
+func (t *Telnet) Close() error {
+	if t.connection != nil {
+		err := t.connection.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *Telnet) Execute(ctx context.Context, command string) (string, error) {
+	if t.connection == nil {
+		return "", errors.New("connection not established")
+	}
+
+	// Drop anything the server pushed between commands (log lines, notifications).
+	if _, err := t.readUntilIdle(ctx, 0); err != nil {
+		return "", err
+	}
+
+	if err := t.writeLine(ctx, command); err != nil {
+		return "", errors.WithMessage(err, "unable to send command")
+	}
+
+	response, err := t.readUntilIdle(ctx, t.timeout)
+	if err != nil {
+		return "", err
+	}
+
+	return stripTelnetEcho(string(response), command), nil
+}
+
+func (t *Telnet) login(ctx context.Context) error {
+	banner, err := t.readUntilIdle(ctx, t.timeout)
+	if err != nil {
+		return errors.WithMessage(err, "unable to read banner")
+	}
+
+	if !containsAny(strings.ToLower(string(banner)), telnetPasswordPrompts) {
+		// Console doesn't ask for a password
+		return nil
+	}
+
+	if err := t.writeLine(ctx, t.password); err != nil {
+		return errors.WithMessage(err, "unable to send password")
+	}
+
+	response, err := t.readUntilIdle(ctx, t.timeout)
+	if err != nil {
+		return errors.WithMessage(err, "unable to read login response")
+	}
+
+	lowered := strings.ToLower(string(response))
+	if containsAny(lowered, telnetAuthFailures) || containsAny(lowered, telnetPasswordPrompts) {
+		return ErrAuthenticationFailed
+	}
+
+	return nil
+}
+
+// deadline returns the time after the timeout, limited by the context deadline.
+func (t *Telnet) deadline(ctx context.Context, timeout time.Duration) time.Time {
+	deadline := time.Now().Add(timeout)
+
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+
+	return deadline
+}
+
+func (t *Telnet) writeLine(ctx context.Context, line string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := t.connection.SetWriteDeadline(t.deadline(ctx, t.timeout)); err != nil {
+		return errors.WithMessage(err, "unable to set deadline")
+	}
+
+	_, err := t.connection.Write([]byte(line + "\r\n"))
+
+	return err
+}
+
+// readUntilIdle reads data until nothing arrives for the idle timeout or the sentinel is found.
+// firstByteTimeout limits the wait for the beginning of the response,
+// zero means that only already buffered data is consumed. The context deadline limits the whole read.
+func (t *Telnet) readUntilIdle(ctx context.Context, firstByteTimeout time.Duration) ([]byte, error) {
+	result := bytes.Buffer{}
+	buf := make([]byte, telnetReadBufferSize)
+
+	wait := firstByteTimeout
+	if wait == 0 {
+		wait = telnetDrainTimeout
+	} else if wait < t.idleTimeout {
+		wait = t.idleTimeout
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if err := t.connection.SetReadDeadline(t.deadline(ctx, wait)); err != nil {
+			return nil, errors.WithMessage(err, "unable to set deadline")
+		}
+
+		n, err := t.connection.Read(buf)
+		if n > 0 {
+			result.Write(t.filterNegotiation(buf[:n]))
+
+			if result.Len()+len(t.pending) > t.maxResponseSize {
+				return nil, ErrResponseTooLarge
+			}
+
+			if t.sentinel != "" && bytes.Contains(result.Bytes(), []byte(t.sentinel)) {
+				return result.Bytes(), nil
+			}
+
+			wait = t.idleTimeout
+		}
+
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				if ctxDeadline, ok := ctx.Deadline(); ok && !time.Now().Before(ctxDeadline) {
+					return nil, context.DeadlineExceeded
+				}
+
+				return result.Bytes(), nil
+			}
+
+			return nil, errors.WithMessage(err, "unable to read response")
+		}
+	}
+}
+
+// filterNegotiation removes telnet IAC sequences from the data and refuses all requested options.
+// An incomplete sequence at the end of the data is kept until the next read.
+func (t *Telnet) filterNegotiation(data []byte) []byte {
+	if len(t.pending) > 0 {
+		data = append(t.pending, data...)
+		t.pending = nil
+	}
+
+	if bytes.IndexByte(data, telnetIAC) == -1 {
+		return data
+	}
+
+	out := make([]byte, 0, len(data))
+	replies := make([]byte, 0)
+
+	for i := 0; i < len(data); i++ {
+		if data[i] != telnetIAC {
+			out = append(out, data[i])
+
+			continue
+		}
+
+		if !isCompleteTelnetSequence(data[i:]) {
+			t.pending = bytes.Clone(data[i:])
+
+			break
+		}
+
+		cmd := data[i+1]
+
+		switch cmd {
+		case telnetIAC:
+			out = append(out, telnetIAC)
+			i++
+		case telnetDO, telnetDONT, telnetWILL, telnetWONT:
+			option := data[i+2]
+			if cmd == telnetDO {
+				replies = append(replies, telnetIAC, telnetWONT, option)
+			} else if cmd == telnetWILL {
+				replies = append(replies, telnetIAC, telnetDONT, option)
+			}
+			i += 2
+		case telnetSB:
+			i += bytes.Index(data[i:], []byte{telnetIAC, telnetSE}) + 1
+		default:
+			i++
+		}
+	}
+
+	if len(replies) > 0 {
+		_, _ = t.connection.Write(replies)
+	}
+
+	return out
+}
+
+// isCompleteTelnetSequence reports whether the data starting with IAC contains the whole sequence.
+func isCompleteTelnetSequence(data []byte) bool {
+	if len(data) < 2 {
+		return false
+	}
+
+	switch data[1] {
+	case telnetDO, telnetDONT, telnetWILL, telnetWONT:
+		return len(data) >= 3
+	case telnetSB:
+		return bytes.Contains(data, []byte{telnetIAC, telnetSE})
+	default:
+		return true
+	}
+}
+
+// stripTelnetEcho removes the echoed command and the "Executing command" notice
+// from the response and normalizes line endings.
+func stripTelnetEcho(response, command string) string {
+	response = strings.ReplaceAll(response, "\r\n", "\n")
+	lines := strings.Split(response, "\n")
+	command = strings.TrimSpace(command)
+
+	result := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == command {
+			continue
+		}
+
+		if strings.Contains(trimmed, "Executing command '"+command+"'") {
+			continue
+		}
+
+		result = append(result, strings.TrimRight(line, "\r"))
+	}
+
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
+
+func containsAny(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+
+	return false
+}
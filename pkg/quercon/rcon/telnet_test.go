@@ -0,0 +1,292 @@
+package rcon
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTelnetPassword = "secret"
+
+// startFakeTelnetServer starts a 7 Days to Die like telnet console.
+func startFakeTelnetServer(t *testing.T, password string, responses map[string]string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go serveFakeTelnet(conn, password, responses)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func serveFakeTelnet(conn net.Conn, password string, responses map[string]string) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	reader := bufio.NewReader(conn)
+
+	// IAC WILL ECHO negotiation, which must be filtered out by the client
+	_, _ = conn.Write([]byte{telnetIAC, telnetWILL, 1})
+	_, _ = conn.Write([]byte("*** Connected with 7DTD server.\r\n"))
+
+	if password != "" {
+		_, _ = conn.Write([]byte("Please enter password:\r\n"))
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			// Skip negotiation replies
+			if strings.TrimSpace(stripFakeNegotiation(line)) == password {
+				break
+			}
+
+			_, _ = conn.Write([]byte("Password incorrect, please enter password:\r\n"))
+		}
+	}
+
+	_, _ = conn.Write([]byte("Logon successful.\r\n\r\n"))
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.TrimSpace(stripFakeNegotiation(line))
+
+		_, _ = conn.Write([]byte(
+			"2024-01-01T10:00:00 1.000 INF Executing command '" + command + "' by Telnet from 127.0.0.1:5555\r\n",
+		))
+		_, _ = conn.Write([]byte(responses[command]))
+	}
+}
+
+// stripFakeNegotiation removes the client's reply to the IAC WILL ECHO sent by the fake server.
+func stripFakeNegotiation(line string) string {
+	return strings.ReplaceAll(line, string([]byte{telnetIAC, telnetDONT, 1}), "")
+}
+
+func TestTelnet_Execute(t *testing.T) {
+	address := startFakeTelnetServer(t, testTelnetPassword, map[string]string{
+		"lp":      "0. id=171, Steve, pos=(1.0, 2.0, 3.0), rot=(0.0, 0.0, 0.0)\r\nTotal of 1 in the game\r\n",
+		"version": "Game version: V 1.0 (b333)\r\n",
+	})
+
+	client, err := NewClient(Config{
+		Address:  address,
+		Password: testTelnetPassword,
+		Protocol: ProtocolTelnet,
+		Timeout:  2 * time.Second,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Open(context.Background()))
+	defer func() {
+		_ = client.Close()
+	}()
+
+	output, err := client.Execute(context.Background(), "lp")
+	require.NoError(t, err)
+	assert.Equal(t, "0. id=171, Steve, pos=(1.0, 2.0, 3.0), rot=(0.0, 0.0, 0.0)\nTotal of 1 in the game", output)
+
+	output, err = client.Execute(context.Background(), "version")
+	require.NoError(t, err)
+	assert.Equal(t, "Game version: V 1.0 (b333)", output)
+}
+
+func TestTelnet_Execute_Sentinel(t *testing.T) {
+	address := startFakeTelnetServer(t, testTelnetPassword, map[string]string{
+		"lp": "Total of 0 in the game\r\n",
+	})
+
+	client, err := NewTelnet(
+		Config{
+			Address:  address,
+			Password: testTelnetPassword,
+			Timeout:  2 * time.Second,
+		},
+		WithTelnetIdleTimeout(time.Minute),
+		WithTelnetSentinel("in the game"),
+	)
+	require.NoError(t, err)
+
+	// Login waits for the idle timeout, so open the connection with a short one
+	client.idleTimeout = 100 * time.Millisecond
+	require.NoError(t, client.Open(context.Background()))
+	defer func() {
+		_ = client.Close()
+	}()
+	client.idleTimeout = time.Minute
+
+	start := time.Now()
+	output, err := client.Execute(context.Background(), "lp")
+	require.NoError(t, err)
+	assert.Equal(t, "Total of 0 in the game", output)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestTelnet_Open_WrongPassword(t *testing.T) {
+	address := startFakeTelnetServer(t, testTelnetPassword, nil)
+
+	client, err := NewTelnet(Config{
+		Address:  address,
+		Password: "wrong",
+		Timeout:  2 * time.Second,
+	})
+	require.NoError(t, err)
+
+	err = client.Open(context.Background())
+	require.ErrorIs(t, err, ErrAuthenticationFailed)
+}
+
+func TestTelnet_Open_WithoutPassword(t *testing.T) {
+	address := startFakeTelnetServer(t, "", map[string]string{
+		"gettime": "Day 7, 22:15\r\n",
+	})
+
+	client, err := NewTelnet(Config{
+		Address: address,
+		Timeout: 2 * time.Second,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Open(context.Background()))
+	defer func() {
+		_ = client.Close()
+	}()
+
+	output, err := client.Execute(context.Background(), "gettime")
+	require.NoError(t, err)
+	assert.Equal(t, "Day 7, 22:15", output)
+}
+
+func TestTelnet_Execute_DefaultTimeout(t *testing.T) {
+	address := startFakeTelnetServer(t, testTelnetPassword, map[string]string{
+		"version": "Game version: V 1.0 (b333)\r\n",
+	})
+
+	client, err := NewTelnet(Config{
+		Address:  address,
+		Password: testTelnetPassword,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Open(context.Background()))
+	defer func() {
+		_ = client.Close()
+	}()
+
+	output, err := client.Execute(context.Background(), "version")
+	require.NoError(t, err)
+	assert.Equal(t, "Game version: V 1.0 (b333)", output)
+}
+
+func TestTelnet_Execute_ContextDeadline(t *testing.T) {
+	address := startFakeTelnetServer(t, "", nil)
+
+	client, err := NewTelnet(Config{
+		Address: address,
+		Timeout: time.Minute,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Open(context.Background()))
+	defer func() {
+		_ = client.Close()
+	}()
+
+	// The response never completes within the idle timeout, the context deadline must stop the read
+	client.idleTimeout = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = client.Execute(ctx, "gettime")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestTelnet_FilterNegotiation_SplitSequence(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer func() {
+		_ = clientConn.Close()
+		_ = serverConn.Close()
+	}()
+
+	replies := make(chan []byte, 1)
+	go func() {
+		buf := make([]byte, 16)
+		n, _ := serverConn.Read(buf)
+		replies <- buf[:n]
+	}()
+
+	client := &Telnet{connection: clientConn}
+
+	assert.Equal(t, []byte("abc"), client.filterNegotiation([]byte{'a', 'b', 'c', telnetIAC}))
+	assert.Empty(t, client.filterNegotiation([]byte{telnetDO}))
+	assert.Equal(t, []byte("def"), client.filterNegotiation([]byte{1, 'd', 'e', 'f'}))
+	assert.Equal(t, []byte{telnetIAC, telnetWONT, 1}, <-replies)
+
+	assert.Equal(t, []byte("x"), client.filterNegotiation([]byte{'x', telnetIAC, telnetSB, 24, 1}))
+	assert.Equal(t, []byte("y"), client.filterNegotiation([]byte{telnetIAC, telnetSE, 'y'}))
+	assert.Empty(t, client.pending)
+}
+
+func TestStripTelnetEcho(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		command  string
+		expected string
+	}{
+		{
+			name:     "echoed_command",
+			response: "status\r\nplayers: 1\r\n",
+			command:  "status",
+			expected: "players: 1",
+		},
+		{
+			name:     "executing_command_notice",
+			response: "2024-01-01T10:00:00 1.000 INF Executing command 'lp' by Telnet from 127.0.0.1:5555\r\nTotal of 0 in the game\r\n",
+			command:  "lp",
+			expected: "Total of 0 in the game",
+		},
+		{
+			name:     "no_echo",
+			response: "line1\r\nline2",
+			command:  "cmd",
+			expected: "line1\nline2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, stripTelnetEcho(tt.response, tt.command))
+		})
+	}
+}
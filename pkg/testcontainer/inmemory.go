@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	serversbase "github.com/gameap/gameap/internal/api/servers/base"
 	"github.com/gameap/gameap/internal/cache"
 	"github.com/gameap/gameap/internal/certificates"
 	"github.com/gameap/gameap/internal/config"
@@ -17,7 +18,17 @@ import (
 	"github.com/gameap/gameap/internal/repositories/base"
 	"github.com/gameap/gameap/internal/repositories/inmemory"
 	"github.com/gameap/gameap/internal/services"
+	"github.com/gameap/gameap/internal/services/gameadmins"
+	"github.com/gameap/gameap/internal/services/minecraftaccess"
+	"github.com/gameap/gameap/internal/services/nodehealth"
+	"github.com/gameap/gameap/internal/services/playerbans"
+	"github.com/gameap/gameap/internal/services/playersessions"
+	"github.com/gameap/gameap/internal/services/rconexec"
 	"github.com/gameap/gameap/internal/services/servercontrol"
+	"github.com/gameap/gameap/internal/services/servermaps"
+	"github.com/gameap/gameap/internal/services/serverquery"
+	"github.com/gameap/gameap/internal/services/serverresources"
+	"github.com/gameap/gameap/internal/services/serverstats"
 	pkgapi "github.com/gameap/gameap/pkg/api"
 	"github.com/gameap/gameap/pkg/auth"
 	"github.com/samber/lo"
@@ -40,6 +51,9 @@ type InmemoryContainer struct {
 	serverSettingRepo     repositories.ServerSettingRepository
 	nodeRepo              repositories.NodeRepository
 	clientCertificateRepo repositories.ClientCertificateRepository
+	nodeStatRepo          repositories.NodeStatRepository
+	commandPolicyRepo     repositories.CommandPolicyRepository
+	nodeHealthRepo        repositories.NodeHealthRepository
 	rbacService           *rbac.RBAC
 	serverControlService  *servercontrol.Service
 	gameUpgradeService    *services.GameUpgradeService
@@ -47,6 +61,17 @@ type InmemoryContainer struct {
 	cacheService          cache.Cache
 	certificatesService   *certificates.Service
 	globalAPIService      *services.GlobalAPIService
+	serverQueryStore      *serverquery.Store
+	serverStatsService    *serverstats.Service
+	playerSessions        *playersessions.Service
+	playerBans            *playerbans.Service
+	minecraftAccess       *minecraftaccess.Service
+	gameAdmins            *gameadmins.Service
+	serverMaps            *servermaps.Service
+	serverResources       *serverresources.Monitor
+	nodeHealthChecker     *nodehealth.Checker
+	daemonBreakers        *daemon.Breakers
+	daemonPools           *daemon.PoolRegistry
 	daemonStatusService   *daemon.StatusService
 	daemonFilesService    *daemon.FileService
 	daemonCommandsService *daemon.CommandService
@@ -88,14 +113,53 @@ func (c *InmemoryContainer) NodeRepository() repositories.NodeRepository { retur
 func (c *InmemoryContainer) ClientCertificateRepository() repositories.ClientCertificateRepository {
 	return c.clientCertificateRepo
 }
+func (c *InmemoryContainer) NodeStatRepository() repositories.NodeStatRepository {
+	return c.nodeStatRepo
+}
+func (c *InmemoryContainer) CommandPolicyRepository() repositories.CommandPolicyRepository {
+	return c.commandPolicyRepo
+}
+func (c *InmemoryContainer) NodeHealthRepository() repositories.NodeHealthRepository {
+	return c.nodeHealthRepo
+}
 func (c *InmemoryContainer) RBAC() *rbac.RBAC                             { return c.rbacService }
 func (c *InmemoryContainer) FileManager() files.FileManager               { return c.fileManager }
 func (c *InmemoryContainer) Cache() cache.Cache                           { return c.cacheService }
 func (c *InmemoryContainer) CertificatesService() *certificates.Service   { return c.certificatesService }
 func (c *InmemoryContainer) GlobalAPIService() *services.GlobalAPIService { return c.globalAPIService }
-func (c *InmemoryContainer) DaemonStatus() *daemon.StatusService          { return c.daemonStatusService }
-func (c *InmemoryContainer) DaemonFiles() *daemon.FileService             { return c.daemonFilesService }
-func (c *InmemoryContainer) DaemonCommands() *daemon.CommandService       { return c.daemonCommandsService }
+func (c *InmemoryContainer) ServerQueryStore() *serverquery.Store         { return c.serverQueryStore }
+func (c *InmemoryContainer) ServerStatsService() *serverstats.Service     { return c.serverStatsService }
+func (c *InmemoryContainer) PlayerSessionsService() *playersessions.Service {
+	return c.playerSessions
+}
+func (c *InmemoryContainer) PlayerBansService() *playerbans.Service {
+	return c.playerBans
+}
+
+func (c *InmemoryContainer) MinecraftAccessService() *minecraftaccess.Service {
+	return c.minecraftAccess
+}
+
+func (c *InmemoryContainer) GameAdminsService() *gameadmins.Service {
+	return c.gameAdmins
+}
+
+func (c *InmemoryContainer) ServerMapsService() *servermaps.Service {
+	return c.serverMaps
+}
+
+func (c *InmemoryContainer) ServerResourcesMonitor() *serverresources.Monitor {
+	return c.serverResources
+}
+
+func (c *InmemoryContainer) NodeHealthChecker() *nodehealth.Checker {
+	return c.nodeHealthChecker
+}
+func (c *InmemoryContainer) DaemonBreakers() *daemon.Breakers       { return c.daemonBreakers }
+func (c *InmemoryContainer) DaemonPools() *daemon.PoolRegistry      { return c.daemonPools }
+func (c *InmemoryContainer) DaemonStatus() *daemon.StatusService    { return c.daemonStatusService }
+func (c *InmemoryContainer) DaemonFiles() *daemon.FileService       { return c.daemonFilesService }
+func (c *InmemoryContainer) DaemonCommands() *daemon.CommandService { return c.daemonCommandsService }
 
 func LoadInmemoryContainer() (*InmemoryContainer, error) {
 	c := buildInmemoryTestContainer()
@@ -107,9 +171,15 @@ func buildInmemoryTestContainer() *InmemoryContainer {
 	userRepo := inmemory.NewUserRepository()
 	rbacRepo := inmemory.NewRBACRepository()
 	serverRepo := inmemory.NewServerRepository()
+	gameRepo := inmemory.NewGameRepository()
+	gameModRepo := inmemory.NewGameModRepository()
+	nodeRepo := inmemory.NewNodeRepository()
 
 	daemonTaskRepo := inmemory.NewDaemonTaskRepository()
 	serverSettingRepo := inmemory.NewServerSettingRepository()
+	serverQueryStore := serverquery.NewStore(0)
+	nodeHealthRepo := inmemory.NewNodeHealthRepository()
+	daemonBreakers := daemon.NewBreakers(0, 0)
 	tm := services.NewNilTransactionManager()
 
 	c := &InmemoryContainer{
@@ -118,8 +188,8 @@ func buildInmemoryTestContainer() *InmemoryContainer {
 			EncryptionKey: "test-encryption-key-testing",
 		},
 		responder:             pkgapi.NewResponder(),
-		gameRepo:              inmemory.NewGameRepository(),
-		gameModRepo:           inmemory.NewGameModRepository(),
+		gameRepo:              gameRepo,
+		gameModRepo:           gameModRepo,
 		serverRepo:            serverRepo,
 		userRepo:              userRepo,
 		authService:           auth.NewJWTService([]byte("test-secret-key-for-testing")),
@@ -130,8 +200,11 @@ func buildInmemoryTestContainer() *InmemoryContainer {
 		serverTaskRepo:        inmemory.NewServerTaskRepository(serverRepo),
 		serverTaskFailRepo:    inmemory.NewServerTaskFailRepository(),
 		serverSettingRepo:     serverSettingRepo,
-		nodeRepo:              inmemory.NewNodeRepository(),
+		nodeRepo:              nodeRepo,
 		clientCertificateRepo: inmemory.NewClientCertificateRepository(),
+		nodeStatRepo:          inmemory.NewNodeStatRepository(),
+		commandPolicyRepo:     inmemory.NewCommandPolicyRepository(),
+		nodeHealthRepo:        nodeHealthRepo,
 		rbacService:           rbac.NewRBAC(tm, rbacRepo, time.Minute),
 		serverControlService:  servercontrol.NewService(daemonTaskRepo, serverSettingRepo, tm),
 		gameUpgradeService:    nil,
@@ -139,6 +212,40 @@ func buildInmemoryTestContainer() *InmemoryContainer {
 		cacheService:          nil,
 		certificatesService:   nil,
 		globalAPIService:      nil,
+		serverQueryStore:      serverQueryStore,
+		serverStatsService:    serverstats.NewService(inmemory.NewServerStatRepository()),
+		playerSessions:        playersessions.NewService(inmemory.NewPlayerSessionRepository()),
+		playerBans: playerbans.NewService(
+			inmemory.NewPlayerBanRepository(),
+			serverRepo,
+			nodeRepo,
+			serversbase.NewGameFinder(gameRepo, gameModRepo),
+			rconexec.NewExecutor(serversbase.NewGameFinder(gameRepo, gameModRepo)),
+			nil,
+			0,
+		),
+		minecraftAccess: minecraftaccess.NewService(
+			nodeRepo,
+			serversbase.NewGameFinder(gameRepo, gameModRepo),
+			rconexec.NewExecutor(serversbase.NewGameFinder(gameRepo, gameModRepo)),
+			nil,
+			minecraftaccess.NewOfflineProfileResolver(),
+		),
+		gameAdmins: gameadmins.NewService(
+			nodeRepo,
+			rconexec.NewExecutor(serversbase.NewGameFinder(gameRepo, gameModRepo)),
+			nil,
+		),
+		serverMaps: servermaps.NewService(
+			nodeRepo,
+			serversbase.NewGameFinder(gameRepo, gameModRepo),
+			nil,
+			serverQueryStore,
+		),
+		serverResources:       serverresources.NewMonitor(serverRepo, nil, nil, 0),
+		nodeHealthChecker:     nodehealth.NewChecker(nodeRepo, nodeHealthRepo, nil, 0),
+		daemonBreakers:        daemonBreakers,
+		daemonPools:           daemon.NewPoolRegistry(daemonBreakers, 0),
 		daemonStatusService:   nil,
 		daemonFilesService:    nil,
 		daemonCommandsService: nil,